@@ -6,11 +6,13 @@ package main
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log"
 	"os"
 
 	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
 	"github.com/spf13/pflag"
 
 	"github.com/shipwright-io/build/pkg/bundle"
@@ -24,6 +26,14 @@ type settings struct {
 	target                string
 	secretPath            string
 	resultFileImageDigest string
+
+	verifySignatureKeys    string
+	resultFileErrorMessage string
+	resultFileErrorReason  string
+
+	maxTotalSize int64
+	maxFileCount int64
+	maxFileSize  int64
 }
 
 var flagValues settings
@@ -39,6 +49,14 @@ func init() {
 
 	pflag.StringVar(&flagValues.secretPath, "secret-path", "", "A directory that contains access credentials (optional)")
 	pflag.BoolVar(&flagValues.prune, "prune", false, "Delete bundle image from registry after it was pulled")
+
+	pflag.StringVar(&flagValues.verifySignatureKeys, "verify-signature-keys", "", "A directory that contains the trusted public keys to verify the bundle image signature (optional)")
+	pflag.StringVar(&flagValues.resultFileErrorMessage, "result-file-error-message", "", "A file to write the error message to")
+	pflag.StringVar(&flagValues.resultFileErrorReason, "result-file-error-reason", "", "A file to write the error reason to")
+
+	pflag.Int64Var(&flagValues.maxTotalSize, "max-total-size", 0, "The maximum number of bytes of all unpacked files, zero means no limit")
+	pflag.Int64Var(&flagValues.maxFileCount, "max-file-count", 0, "The maximum number of unpacked files, directories, and links, zero means no limit")
+	pflag.Int64Var(&flagValues.maxFileSize, "max-file-size", 0, "The maximum number of bytes of a single unpacked file, zero means no limit")
 }
 
 func main() {
@@ -71,12 +89,38 @@ func Do(ctx context.Context) error {
 		return err
 	}
 
+	if flagValues.verifySignatureKeys != "" {
+		// resolve the digest first, so that the verified image is the one that gets unpacked
+		ref, err = verifySignature(ref, options)
+		if err != nil {
+			if errors.Is(err, bundle.ErrSignatureVerificationFailed) {
+				if writeErr := writeErrorResults(bundle.SignatureVerificationFailedReason, err.Error()); writeErr != nil {
+					log.Printf("Could not write error results: %s", writeErr.Error())
+				}
+			}
+
+			return err
+		}
+	}
+
 	log.Printf("Pulling image %q", ref)
-	img, err := bundle.PullAndUnpack(
+	img, err := bundle.PullAndUnpackWithOptions(
 		ref,
 		flagValues.target,
+		bundle.UnpackOptions{
+			MaxTotalSize: flagValues.maxTotalSize,
+			MaxFileCount: flagValues.maxFileCount,
+			MaxFileSize:  flagValues.maxFileSize,
+		},
 		options...)
 	if err != nil {
+		var unpackErr *bundle.UnpackError
+		if errors.As(err, &unpackErr) {
+			if writeErr := writeErrorResults(string(unpackErr.Reason), unpackErr.Message); writeErr != nil {
+				log.Printf("Could not write error results: %s", writeErr.Error())
+			}
+		}
+
 		return err
 	}
 
@@ -110,3 +154,36 @@ func Do(ctx context.Context) error {
 
 	return nil
 }
+
+func verifySignature(ref name.Reference, options []remote.Option) (name.Digest, error) {
+	desc, err := remote.Head(ref, options...)
+	if err != nil {
+		return name.Digest{}, err
+	}
+
+	digest := ref.Context().Digest(desc.Digest.String())
+
+	keys, err := bundle.LoadPublicKeys(flagValues.verifySignatureKeys)
+	if err != nil {
+		return name.Digest{}, err
+	}
+
+	log.Printf("Verifying the signature of image %q", digest)
+	if err := bundle.VerifySignature(digest, keys, options...); err != nil {
+		return name.Digest{}, err
+	}
+
+	return digest, nil
+}
+
+func writeErrorResults(reason string, message string) error {
+	if flagValues.resultFileErrorReason == "" || flagValues.resultFileErrorMessage == "" {
+		return nil
+	}
+
+	if err := os.WriteFile(flagValues.resultFileErrorMessage, []byte(message), 0666); err != nil {
+		return err
+	}
+
+	return os.WriteFile(flagValues.resultFileErrorReason, []byte(reason), 0666)
+}
@@ -6,9 +6,17 @@ package main_test
 
 import (
 	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	cryptorand "crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
 	"fmt"
 	"io"
 	"log"
+	"net/http/httptest"
+	"net/url"
 	"os"
 	"path/filepath"
 
@@ -16,9 +24,11 @@ import (
 	. "github.com/onsi/gomega"
 
 	. "github.com/shipwright-io/build/cmd/bundle"
+	"github.com/shipwright-io/build/pkg/bundle"
 	"github.com/shipwright-io/build/pkg/image"
 
 	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/registry"
 	containerreg "github.com/google/go-containerregistry/pkg/v1"
 	"github.com/google/go-containerregistry/pkg/v1/remote"
 	"k8s.io/apimachinery/pkg/util/rand"
@@ -232,4 +242,136 @@ var _ = Describe("Bundle Loader", func() {
 			})
 		})
 	})
+
+	Context("Verifying the image signature", func() {
+		var testImage string
+		var key *ecdsa.PrivateKey
+
+		var writePublicKey = func(dir string, publicKey crypto.PublicKey) {
+			data, err := x509.MarshalPKIXPublicKey(publicKey)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(os.WriteFile(filepath.Join(dir, "cosign.pub"), pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: data}), 0644)).To(Succeed())
+		}
+
+		BeforeEach(func() {
+			logLogger := log.Logger{}
+			logLogger.SetOutput(GinkgoWriter)
+
+			s := httptest.NewServer(registry.New(registry.Logger(&logLogger)))
+			DeferCleanup(s.Close)
+
+			u, err := url.Parse(s.URL)
+			Expect(err).ToNot(HaveOccurred())
+
+			testImage = fmt.Sprintf("%s/namespace/%s:source", u.Host, rand.String(5))
+
+			ref, err := name.ParseReference(testImage)
+			Expect(err).ToNot(HaveOccurred())
+
+			digest, err := bundle.PackAndPush(ref, filepath.Join("..", "..", "test", "bundle"))
+			Expect(err).ToNot(HaveOccurred())
+
+			key, err = ecdsa.GenerateKey(elliptic.P256(), cryptorand.Reader)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(bundle.Sign(digest, key)).To(Succeed())
+		})
+
+		It("should pull and unpack an image signed by a trusted key", func() {
+			withTempDir(func(keys string) {
+				writePublicKey(keys, key.Public())
+
+				withTempDir(func(target string) {
+					Expect(run(
+						"--image", testImage,
+						"--target", target,
+						"--verify-signature-keys", keys,
+					)).To(Succeed())
+
+					Expect(filepath.Join(target, "README.md")).To(BeAnExistingFile())
+				})
+			})
+		})
+
+		It("should fail with a dedicated reason for an image that is not signed by a trusted key", func() {
+			untrusted, err := ecdsa.GenerateKey(elliptic.P256(), cryptorand.Reader)
+			Expect(err).ToNot(HaveOccurred())
+
+			withTempDir(func(keys string) {
+				writePublicKey(keys, untrusted.Public())
+
+				withTempDir(func(target string) {
+					withTempFile("error-reason", func(reasonFile string) {
+						withTempFile("error-message", func(messageFile string) {
+							Expect(run(
+								"--image", testImage,
+								"--target", target,
+								"--verify-signature-keys", keys,
+								"--result-file-error-reason", reasonFile,
+								"--result-file-error-message", messageFile,
+							)).To(MatchError(bundle.ErrSignatureVerificationFailed))
+
+							Expect(filecontent(reasonFile)).To(Equal(bundle.SignatureVerificationFailedReason))
+							Expect(filecontent(messageFile)).To(ContainSubstring("not signed by a trusted key"))
+							Expect(filepath.Join(target, "README.md")).ToNot(BeAnExistingFile())
+						})
+					})
+				})
+			})
+		})
+	})
+
+	Context("Unpacking with limits", func() {
+		var testImage string
+
+		BeforeEach(func() {
+			logLogger := log.Logger{}
+			logLogger.SetOutput(GinkgoWriter)
+
+			s := httptest.NewServer(registry.New(registry.Logger(&logLogger)))
+			DeferCleanup(s.Close)
+
+			u, err := url.Parse(s.URL)
+			Expect(err).ToNot(HaveOccurred())
+
+			testImage = fmt.Sprintf("%s/namespace/%s:source", u.Host, rand.String(5))
+
+			ref, err := name.ParseReference(testImage)
+			Expect(err).ToNot(HaveOccurred())
+
+			_, err = bundle.PackAndPush(ref, filepath.Join("..", "..", "test", "bundle"))
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("should unpack an image within the limits", func() {
+			withTempDir(func(target string) {
+				Expect(run(
+					"--image", testImage,
+					"--target", target,
+					"--max-total-size", "1048576",
+					"--max-file-count", "100",
+				)).To(Succeed())
+
+				Expect(filepath.Join(target, "README.md")).To(BeAnExistingFile())
+			})
+		})
+
+		It("should fail with a dedicated reason for an image that exceeds a limit", func() {
+			withTempDir(func(target string) {
+				withTempFile("error-reason", func(reasonFile string) {
+					withTempFile("error-message", func(messageFile string) {
+						Expect(run(
+							"--image", testImage,
+							"--target", target,
+							"--max-file-count", "1",
+							"--result-file-error-reason", reasonFile,
+							"--result-file-error-message", messageFile,
+						)).To(HaveOccurred())
+
+						Expect(filecontent(reasonFile)).To(Equal(string(bundle.FileCountExceeded)))
+						Expect(filecontent(messageFile)).To(ContainSubstring("more than the maximum of 1 files"))
+					})
+				})
+			})
+		})
+	})
 })
@@ -0,0 +1,210 @@
+// Copyright The Shipwright Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"io/fs"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"syscall"
+	"time"
+)
+
+// mirrorSuffix is the suffix of the bare mirror directories in the cache,
+// every mirror has a lock file next to it which is never removed, so that
+// concurrent clones always lock the same file
+const (
+	mirrorSuffix     = ".git"
+	mirrorLockSuffix = ".lock"
+)
+
+// cacheMirror is a bare mirror of the Git repository in the cache directory,
+// the clone holds a shared lock on it until the objects are copied
+type cacheMirror struct {
+	path string
+	lock *os.File
+}
+
+// mirrorPath derives the directory of the mirror from the repository URL
+func mirrorPath(repoURL string) string {
+	hash := sha256.Sum256([]byte(repoURL))
+	return filepath.Join(flagValues.cacheDir, hex.EncodeToString(hash[:16])+mirrorSuffix)
+}
+
+// updateMirror creates or updates the mirror of the repository while holding
+// an exclusive lock, and then downgrades the lock to a shared lock so that
+// concurrent clones can read from the mirror while it cannot be evicted
+func updateMirror(ctx context.Context, addtlGitArgs []string) (*cacheMirror, error) {
+	if err := os.MkdirAll(flagValues.cacheDir, 0755); err != nil {
+		return nil, err
+	}
+
+	path := mirrorPath(displayURL)
+
+	lock, err := os.OpenFile(path+mirrorLockSuffix, os.O_CREATE|os.O_RDWR, 0644)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := syscall.Flock(int(lock.Fd()), syscall.LOCK_EX); err != nil {
+		lock.Close()
+		return nil, err
+	}
+
+	mirror := &cacheMirror{path: path, lock: lock}
+
+	// the credentials are passed as global configuration options, so that
+	// they are not stored in the configuration of the mirror
+	var gitArgs []string
+	if hasFile(path, "HEAD") {
+		gitArgs = append([]string{"-C", path}, addtlGitArgs...)
+		gitArgs = append(gitArgs, "fetch", "--quiet", "--prune", "origin")
+	} else {
+		if err := os.RemoveAll(path); err != nil {
+			mirror.release()
+			return nil, err
+		}
+
+		gitArgs = append(gitArgs, addtlGitArgs...)
+		gitArgs = append(gitArgs, "clone", "--quiet", "--mirror", "--", flagValues.url, path)
+	}
+
+	if _, err := git(ctx, gitArgs...); err != nil {
+		mirror.release()
+		return nil, err
+	}
+
+	// the modification time of the lock file tells when the mirror was used last
+	now := time.Now()
+	if err := os.Chtimes(lock.Name(), now, now); err != nil {
+		mirror.release()
+		return nil, err
+	}
+
+	if err := syscall.Flock(int(lock.Fd()), syscall.LOCK_SH); err != nil {
+		mirror.release()
+		return nil, err
+	}
+
+	return mirror, nil
+}
+
+// release unlocks the mirror
+func (m *cacheMirror) release() {
+	_ = syscall.Flock(int(m.lock.Fd()), syscall.LOCK_UN)
+	_ = m.lock.Close()
+}
+
+// evictMirrors removes the least recently used mirrors until the size of the
+// cache does not exceed the maximum size, mirrors that are locked by other
+// clones are skipped
+func evictMirrors() error {
+	if flagValues.cacheMaxSize <= 0 {
+		return nil
+	}
+
+	entries, err := os.ReadDir(flagValues.cacheDir)
+	if err != nil {
+		return err
+	}
+
+	type mirrorUsage struct {
+		path     string
+		size     int64
+		lastUsed time.Time
+	}
+
+	var mirrors []mirrorUsage
+	var totalSize int64
+	for _, entry := range entries {
+		if !entry.IsDir() || !strings.HasSuffix(entry.Name(), mirrorSuffix) {
+			continue
+		}
+
+		path := filepath.Join(flagValues.cacheDir, entry.Name())
+
+		size, err := directorySize(path)
+		if err != nil {
+			return err
+		}
+
+		var lastUsed time.Time
+		if info, err := os.Stat(path + mirrorLockSuffix); err == nil {
+			lastUsed = info.ModTime()
+		}
+
+		mirrors = append(mirrors, mirrorUsage{path: path, size: size, lastUsed: lastUsed})
+		totalSize += size
+	}
+
+	sort.Slice(mirrors, func(i, j int) bool {
+		return mirrors[i].lastUsed.Before(mirrors[j].lastUsed)
+	})
+
+	for _, mirror := range mirrors {
+		if totalSize <= flagValues.cacheMaxSize {
+			break
+		}
+
+		evicted, err := evictMirror(mirror.path)
+		if err != nil {
+			return err
+		}
+
+		if evicted {
+			log.Printf("Evicted %s (%d bytes) from the Git cache\n", filepath.Base(mirror.path), mirror.size)
+			totalSize -= mirror.size
+		}
+	}
+
+	return nil
+}
+
+// evictMirror removes the mirror in case it is not locked by another clone
+func evictMirror(path string) (bool, error) {
+	lock, err := os.OpenFile(path+mirrorLockSuffix, os.O_CREATE|os.O_RDWR, 0644)
+	if err != nil {
+		return false, err
+	}
+	defer lock.Close()
+
+	if err := syscall.Flock(int(lock.Fd()), syscall.LOCK_EX|syscall.LOCK_NB); err != nil {
+		if err == syscall.EWOULDBLOCK {
+			return false, nil
+		}
+		return false, err
+	}
+	defer func() { _ = syscall.Flock(int(lock.Fd()), syscall.LOCK_UN) }()
+
+	return true, os.RemoveAll(path)
+}
+
+// directorySize sums up the size of the files in the directory
+func directorySize(path string) (int64, error) {
+	var size int64
+	err := filepath.WalkDir(path, func(_ string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if d.Type().IsRegular() {
+			info, err := d.Info()
+			if err != nil {
+				return err
+			}
+			size += info.Size()
+		}
+
+		return nil
+	})
+
+	return size, err
+}
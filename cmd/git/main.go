@@ -6,6 +6,7 @@ package main
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"log"
 	"net/url"
@@ -14,6 +15,7 @@ import (
 	"path/filepath"
 	"regexp"
 	"strings"
+	"unicode/utf8"
 
 	shpgit "github.com/shipwright-io/build/pkg/git"
 	"github.com/spf13/pflag"
@@ -25,6 +27,15 @@ const (
 	typeUndef credentialType = iota
 	typePrivateKey
 	typeUsernamePassword
+	typeGitHubApp
+)
+
+// maxSubjectLength and maxTags limit the size of the commit subject and the
+// tags in the commit details result, as the results of all steps share a small
+// size limit
+const (
+	maxSubjectLength = 256
+	maxTags          = 16
 )
 
 var useNoTagsFlag = false
@@ -32,6 +43,14 @@ var useDepthForSubmodule = false
 
 var displayURL string
 
+// revisionCommit and mergeTargetCommit are the commits that were merged in
+// case a merge target is defined, otherwise the revision commit is HEAD
+var revisionCommit, mergeTargetCommit string
+
+// signer and signerKey are the identity and fingerprint of the trusted key
+// that signed the revision in case the signature is verified
+var signer, signerKey string
+
 // ExitError is an error which has an exit code to be used in os.Exit() to
 // return both an exit code and an error message
 type ExitError struct {
@@ -50,11 +69,27 @@ type settings struct {
 	url                    string
 	revision               string
 	depth                  uint
+	sparseCheckout         []string
+	filter                 string
+	tags                   bool
+	lfs                    bool
+	lfsInclude             []string
+	lfsExclude             []string
+	resultFileShallow      string
+	mergeTarget            string
+	resultFileMergeTarget  string
+	resultFileDetails      string
+	envFile                string
+	verifySignatureKeys    string
+	resultFileSigner       string
+	resultFileSignerKey    string
 	target                 string
 	resultFileCommitSha    string
 	resultFileCommitAuthor string
 	resultFileBranchName   string
 	secretPath             string
+	cacheDir               string
+	cacheMaxSize           int64
 	skipValidation         bool
 	gitURLRewrite          bool
 	resultFileErrorMessage string
@@ -67,6 +102,10 @@ var flagValues settings
 var (
 	sshGitURLRegEx = regexp.MustCompile(`^(git@|ssh:\/\/).+$`)
 	commitShaRegEx = regexp.MustCompile(`^[0-9a-f]{7,40}$`)
+
+	sshPublicKeyRegEx     = regexp.MustCompile(`^(ssh-|ecdsa-|sk-)\S+\s+\S+`)
+	sshPrincipalRegEx     = regexp.MustCompile(`^[A-Za-z0-9._@+-]+$`)
+	sshGoodSignatureRegEx = regexp.MustCompile(`Good "git" signature for (\S+) with \S+ key (\S+)`)
 )
 
 func init() {
@@ -77,12 +116,20 @@ func init() {
 	// the flags for `url`, and `target` will always be used, but `revision`
 	// depends on the respective use case.
 	pflag.StringVar(&flagValues.url, "url", "", "The URL of the Git repository")
-	pflag.StringVar(&flagValues.revision, "revision", "", "The revision of the Git repository to be cloned. Optional, defaults to the default branch.")
+	pflag.StringVar(&flagValues.revision, "revision", "", "The revision of the Git repository to be cloned, a branch, tag, commit SHA, or reference like refs/pull/123/head. Optional, defaults to the default branch.")
+	pflag.StringVar(&flagValues.mergeTarget, "merge-target", "", "The branch into which the revision is merged, for example the target branch of a pull request. Optional.")
 	pflag.StringVar(&flagValues.target, "target", "", "The target directory of the clone operation")
 	pflag.StringVar(&flagValues.resultFileCommitSha, "result-file-commit-sha", "", "A file to write the commit sha to.")
 	pflag.StringVar(&flagValues.resultFileCommitAuthor, "result-file-commit-author", "", "A file to write the commit author to.")
 	pflag.StringVar(&flagValues.resultFileBranchName, "result-file-branch-name", "", "A file to write the branch name to.")
-	pflag.StringVar(&flagValues.secretPath, "secret-path", "", "A directory that contains a secret. Either username and password for basic authentication. Or a SSH private key and optionally a known hosts file. Optional.")
+	pflag.StringVar(&flagValues.resultFileShallow, "result-file-shallow", "", "A file to write whether the clone is shallow to.")
+	pflag.StringVar(&flagValues.resultFileMergeTarget, "result-file-merge-target-commit-sha", "", "A file to write the commit sha of the merge target to.")
+	pflag.StringVar(&flagValues.resultFileDetails, "result-file-commit-details", "", "A file to write the committer timestamp, the subject, the tags, and the description of the commit to as JSON.")
+	pflag.StringVar(&flagValues.envFile, "env-file", "", "A file to write the details of the commit to as shell variable assignments, for example SOURCE_DATE_EPOCH, so that build strategy steps can source it.")
+	pflag.StringVar(&flagValues.verifySignatureKeys, "verify-signature-keys", "", "A directory that contains the GPG or SSH public keys that are trusted to sign the commit or the tag of the revision. Optional, the signature is not verified if not set.")
+	pflag.StringVar(&flagValues.resultFileSigner, "result-file-signer", "", "A file to write the identity of the key that signed the revision to.")
+	pflag.StringVar(&flagValues.resultFileSignerKey, "result-file-signer-key", "", "A file to write the fingerprint of the key that signed the revision to.")
+	pflag.StringVar(&flagValues.secretPath, "secret-path", "", "A directory that contains a secret. Either username and password for basic authentication. Or a SSH private key and optionally a known hosts file. Or the app ID, installation ID, and private key of a GitHub App. Optional.")
 
 	// Flags with paths for writing error related information
 	pflag.StringVar(&flagValues.resultFileErrorMessage, "result-file-error-message", "", "A file to write the error message to.")
@@ -93,6 +140,23 @@ func init() {
 	// for (in the context of Shipwright build).
 	pflag.UintVar(&flagValues.depth, "depth", 1, "Create a shallow clone based on the given depth")
 
+	// Optional flags to reduce the clone size of large repositories, for
+	// example monorepos, where only a part of the repository is needed.
+	pflag.StringArrayVar(&flagValues.sparseCheckout, "sparse-checkout", nil, "A directory to check out, can be specified multiple times. Optional, defaults to checking out the complete repository.")
+	pflag.StringVar(&flagValues.filter, "filter", "", "The partial clone filter, for example blob:none. Optional.")
+
+	// Optional flags to control which tags and Git Large File Storage (LFS)
+	// files are fetched, by default no tags but all LFS files are fetched.
+	pflag.BoolVar(&flagValues.tags, "tags", false, "Fetch the tags of the Git repository")
+	pflag.BoolVar(&flagValues.lfs, "lfs", true, "Download Git Large File Storage (LFS) files, otherwise the pointer files are checked out")
+	pflag.StringArrayVar(&flagValues.lfsInclude, "lfs-include", nil, "A pattern of LFS files to download, can be specified multiple times. Optional, defaults to all LFS files.")
+	pflag.StringArrayVar(&flagValues.lfsExclude, "lfs-exclude", nil, "A pattern of LFS files not to download, can be specified multiple times. Optional.")
+
+	// Optional flags to clone from a cache of bare mirrors, which is updated
+	// from the remote repository before the clone.
+	pflag.StringVar(&flagValues.cacheDir, "cache-dir", "", "A directory that caches bare mirrors of Git repositories, for example a persistent volume shared by several builds. Optional, the repository is cloned without cache if not set.")
+	pflag.Int64Var(&flagValues.cacheMaxSize, "cache-max-size", 0, "The maximum size in bytes of the mirrors in the cache directory, the least recently used mirrors are evicted once it is exceeded. Optional, defaults to no limit.")
+
 	// Mostly internal flag
 	pflag.BoolVar(&flagValues.skipValidation, "skip-validation", false, "skip pre-requisite validation")
 	pflag.BoolVar(&flagValues.gitURLRewrite, "git-url-rewrite", false, "set Git config to use url-insteadOf setting based on Git repository URL")
@@ -102,12 +166,18 @@ func init() {
 func main() {
 	if err := Execute(context.Background()); err != nil {
 		var exitcode = 1
+		var failure = shpgit.NewErrorResultFromMessage(err.Error())
 		switch err := err.(type) {
 		case *ExitError:
 			exitcode = err.Code
+
+			// Errors with a dedicated reason do not need to be classified
+			if err.Reason != shpgit.Unknown {
+				failure = &shpgit.ErrorResult{Message: err.Message, Reason: err.Reason}
+			}
 		}
 
-		if writeErr := writeErrorResults(shpgit.NewErrorResultFromMessage(err.Error())); writeErr != nil {
+		if writeErr := writeErrorResults(failure); writeErr != nil {
 			log.Printf("Could not write error results: %s", writeErr.Error())
 		}
 
@@ -118,7 +188,7 @@ func main() {
 
 // Execute performs flag parsing, input validation and the Git clone
 func Execute(ctx context.Context) error {
-	flagValues = settings{depth: 1}
+	flagValues = settings{depth: 1, lfs: true}
 	pflag.Parse()
 
 	if flagValues.help {
@@ -142,6 +212,9 @@ func Execute(ctx context.Context) error {
 	// Create clean version of the URL that should be safe to be displayed in logs
 	displayURL = cleanURL()
 
+	revisionCommit, mergeTargetCommit = "HEAD", ""
+	signer, signerKey = "", ""
+
 	return runGitClone(ctx)
 }
 
@@ -154,12 +227,39 @@ func runGitClone(ctx context.Context) error {
 		return &ExitError{Code: 101, Message: "the 'target' argument must not be empty"}
 	}
 
+	if flagValues.mergeTarget != "" && flagValues.revision == "" {
+		return &ExitError{Code: 102, Message: "the 'revision' argument must not be empty when a 'merge-target' is defined"}
+	}
+
+	// Merging requires the common history of the revision and the merge target
+	if flagValues.mergeTarget != "" {
+		flagValues.depth = 0
+	}
+
 	if err := clone(ctx); err != nil {
 		return err
 	}
 
+	if flagValues.resultFileMergeTarget != "" && mergeTargetCommit != "" {
+		if err := os.WriteFile(flagValues.resultFileMergeTarget, []byte(mergeTargetCommit), 0644); err != nil {
+			return err
+		}
+	}
+
+	if flagValues.resultFileSigner != "" && signer != "" {
+		if err := os.WriteFile(flagValues.resultFileSigner, []byte(signer), 0644); err != nil {
+			return err
+		}
+	}
+
+	if flagValues.resultFileSignerKey != "" && signerKey != "" {
+		if err := os.WriteFile(flagValues.resultFileSignerKey, []byte(signerKey), 0644); err != nil {
+			return err
+		}
+	}
+
 	if flagValues.resultFileCommitSha != "" {
-		output, err := git(ctx, "-C", flagValues.target, "rev-parse", "--verify", "HEAD")
+		output, err := git(ctx, "-C", flagValues.target, "rev-parse", "--verify", revisionCommit)
 		if err != nil {
 			return err
 		}
@@ -170,7 +270,7 @@ func runGitClone(ctx context.Context) error {
 	}
 
 	if flagValues.resultFileCommitAuthor != "" {
-		output, err := git(ctx, "-C", flagValues.target, "log", "-1", "--pretty=format:%an")
+		output, err := git(ctx, "-C", flagValues.target, "log", "-1", "--pretty=format:%an", revisionCommit)
 		if err != nil {
 			return err
 		}
@@ -191,9 +291,156 @@ func runGitClone(ctx context.Context) error {
 		}
 	}
 
+	if flagValues.resultFileShallow != "" {
+		output, err := git(ctx, "-C", flagValues.target, "rev-parse", "--is-shallow-repository")
+		if err != nil {
+			return err
+		}
+
+		if err := os.WriteFile(flagValues.resultFileShallow, []byte(output), 0644); err != nil {
+			return err
+		}
+	}
+
+	if flagValues.resultFileDetails != "" || flagValues.envFile != "" {
+		details, err := getCommitDetails(ctx)
+		if err != nil {
+			return err
+		}
+
+		if flagValues.resultFileDetails != "" {
+			if err := writeCommitDetails(details); err != nil {
+				return err
+			}
+		}
+
+		if flagValues.envFile != "" {
+			if err := writeEnvFile(details); err != nil {
+				return err
+			}
+		}
+	}
+
 	return nil
 }
 
+// commitDetails are the details of the revision commit, they are written as a
+// single result to keep the number and the size of the results small, and as
+// env file for the build strategy steps
+type commitDetails struct {
+	SHA       string   `json:"-"`
+	Timestamp string   `json:"timestamp"`
+	Subject   string   `json:"subject,omitempty"`
+	Tags      []string `json:"tags,omitempty"`
+	Describe  string   `json:"describe,omitempty"`
+}
+
+// getCommitDetails reads the SHA, the committer timestamp, the subject, the
+// tags, and the description of the revision commit
+func getCommitDetails(ctx context.Context) (*commitDetails, error) {
+	output, err := git(ctx, "-C", flagValues.target, "log", "-1", "--pretty=format:%H%n%ct%n%s", revisionCommit)
+	if err != nil {
+		return nil, err
+	}
+
+	// the subject is the last line, and empty for commits with an empty message
+	lines := strings.SplitN(output, "\n", 3)
+	for len(lines) < 3 {
+		lines = append(lines, "")
+	}
+
+	tags, err := pointingTags(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	describe, err := git(ctx, "-C", flagValues.target, "describe", "--tags", "--always", revisionCommit)
+	if err != nil {
+		return nil, err
+	}
+
+	return &commitDetails{
+		SHA:       lines[0],
+		Timestamp: lines[1],
+		Subject:   lines[2],
+		Tags:      tags,
+		Describe:  describe,
+	}, nil
+}
+
+// writeCommitDetails writes the commit details as JSON to the result file,
+// the subject is truncated to keep the result small
+func writeCommitDetails(details *commitDetails) error {
+	result := *details
+	result.Subject = truncate(result.Subject, maxSubjectLength)
+
+	data, err := json.Marshal(result)
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(flagValues.resultFileDetails, data, 0644)
+}
+
+// pointingTags lists the tags that point at the revision commit, the highest
+// versions first, limited to keep the results small
+func pointingTags(ctx context.Context) ([]string, error) {
+	output, err := git(ctx, "-C", flagValues.target, "tag", "--points-at", revisionCommit, "--sort=-version:refname")
+	if err != nil {
+		return nil, err
+	}
+
+	var tags []string
+	for _, tag := range strings.Split(output, "\n") {
+		if tag = strings.TrimSpace(tag); tag != "" && len(tags) < maxTags {
+			tags = append(tags, tag)
+		}
+	}
+
+	return tags, nil
+}
+
+// writeEnvFile writes the commit details as shell variable assignments, which
+// build strategy steps can source to build reproducibly and to stamp versions
+// without running git themselves
+func writeEnvFile(details *commitDetails) error {
+	var content strings.Builder
+	for _, variable := range []struct {
+		name  string
+		value string
+	}{
+		{name: "SOURCE_DATE_EPOCH", value: details.Timestamp},
+		{name: "SHP_SOURCE_COMMIT_SHA", value: details.SHA},
+		{name: "SHP_SOURCE_COMMIT_SUBJECT", value: details.Subject},
+		{name: "SHP_SOURCE_DESCRIBE", value: details.Describe},
+		{name: "SHP_SOURCE_TAGS", value: strings.Join(details.Tags, " ")},
+	} {
+		fmt.Fprintf(&content, "%s=%s\n", variable.name, shellQuote(variable.value))
+	}
+
+	return os.WriteFile(flagValues.envFile, []byte(content.String()), 0644)
+}
+
+// shellQuote quotes the value so that it is assigned as-is when the file is
+// sourced by a shell
+func shellQuote(value string) string {
+	return "'" + strings.ReplaceAll(value, "'", `'\''`) + "'"
+}
+
+// truncate shortens the value to at most the given number of bytes without
+// splitting a multi-byte character
+func truncate(value string, length int) string {
+	if len(value) <= length {
+		return value
+	}
+
+	for length > 0 && !utf8.RuneStart(value[length]) {
+		length--
+	}
+
+	return value[:length]
+}
+
 func checkEnvironment(ctx context.Context) error {
 	if flagValues.skipValidation {
 		return nil
@@ -236,16 +483,32 @@ func clone(ctx context.Context) error {
 		"--quiet",
 	}
 
-	if useNoTagsFlag {
+	if useNoTagsFlag && !flagValues.tags {
 		cloneArgs = append(cloneArgs, "--no-tags")
 	}
 
-	var commitSha string
+	if flagValues.filter != "" {
+		cloneArgs = append(cloneArgs, "--filter", flagValues.filter)
+	}
+
+	// Only check out the files in the repository root initially, the
+	// directories are added once the sparse checkout is configured
+	if len(flagValues.sparseCheckout) > 0 {
+		cloneArgs = append(cloneArgs, "--sparse")
+	}
+
+	var commitSha, fetchRef string
 	switch {
 	case commitShaRegEx.MatchString(flagValues.revision):
 		commitSha = flagValues.revision
 		cloneArgs = append(cloneArgs, "--no-checkout")
 
+	case strings.HasPrefix(flagValues.revision, "refs/"):
+		// References like refs/pull/123/head cannot be cloned using --branch,
+		// they are fetched using the reference as refspec instead
+		fetchRef = flagValues.revision
+		commitSha = "FETCH_HEAD"
+
 	default:
 		cloneArgs = append(cloneArgs, "--single-branch")
 
@@ -342,23 +605,18 @@ func clone(ctx context.Context) error {
 				}
 			}
 
-		case typeUsernamePassword:
+		case typeUsernamePassword, typeGitHubApp:
 			repoURL, err := url.Parse(flagValues.url)
 			if err != nil {
 				return err
 			}
 
-			username, err := os.ReadFile(filepath.Join(flagValues.secretPath, "username"))
+			username, password, err := basicAuthCredentials(ctx, credType, repoURL.Host)
 			if err != nil {
 				return err
 			}
 
-			password, err := os.ReadFile(filepath.Join(flagValues.secretPath, "password"))
-			if err != nil {
-				return err
-			}
-
-			repoURL.User = url.UserPassword(string(username), string(password))
+			repoURL.User = url.UserPassword(username, password)
 
 			credHelperFile, err := os.CreateTemp(os.TempDir(), "cred-helper-file")
 			if err != nil {
@@ -378,14 +636,85 @@ func clone(ctx context.Context) error {
 		}
 	}
 
-	cloneArgs = append(cloneArgs, addtlGitArgs...)
-	cloneArgs = append(cloneArgs, "--", flagValues.url, flagValues.target)
-	if _, err := git(ctx, cloneArgs...); err != nil {
-		return err
+	// Configure the LFS filter so that the pointer files of LFS files that are
+	// not downloaded are checked out instead
+	if !flagValues.lfs {
+		addtlGitArgs = append(addtlGitArgs,
+			"-c", "filter.lfs.smudge=git-lfs smudge --skip -- %f",
+			"-c", "filter.lfs.process=git-lfs filter-process --skip",
+		)
+	}
+
+	if len(flagValues.lfsInclude) > 0 {
+		addtlGitArgs = append(addtlGitArgs, "-c", fmt.Sprintf("lfs.fetchinclude=%s", strings.Join(flagValues.lfsInclude, ",")))
+	}
+
+	if len(flagValues.lfsExclude) > 0 {
+		addtlGitArgs = append(addtlGitArgs, "-c", fmt.Sprintf("lfs.fetchexclude=%s", strings.Join(flagValues.lfsExclude, ",")))
+	}
+
+	// The objects of the mirror are copied into the clone, so that the clone
+	// does not depend on the mirror, which can be evicted later
+	var mirror *cacheMirror
+	if flagValues.cacheDir != "" {
+		var err error
+		if mirror, err = updateMirror(ctx, addtlGitArgs); err != nil {
+			log.Printf("Failed to update the Git cache, cloning without it: %v\n", err)
+		} else {
+			defer func() {
+				mirror.release()
+				if err := evictMirrors(); err != nil {
+					log.Printf("Failed to evict mirrors from the Git cache: %v\n", err)
+				}
+			}()
+
+			cloneArgs = append(cloneArgs, "--reference", mirror.path, "--dissociate")
+		}
+	}
+
+	if fetchRef != "" {
+		if err := fetch(ctx, fetchRef, addtlGitArgs, mirror); err != nil {
+			return err
+		}
+	} else {
+		cloneArgs = append(cloneArgs, addtlGitArgs...)
+		cloneArgs = append(cloneArgs, "--", flagValues.url, flagValues.target)
+		if _, err := git(ctx, cloneArgs...); err != nil {
+			return err
+		}
+	}
+
+	// Objects that were filtered out by a partial clone are fetched from the
+	// remote during the checkout, therefore the credentials are required
+	if len(flagValues.sparseCheckout) > 0 {
+		sparseCheckoutArgs := []string{"-C", flagValues.target}
+		sparseCheckoutArgs = append(sparseCheckoutArgs, addtlGitArgs...)
+		sparseCheckoutArgs = append(sparseCheckoutArgs, "sparse-checkout", "set", "--cone")
+		sparseCheckoutArgs = append(sparseCheckoutArgs, flagValues.sparseCheckout...)
+		if _, err := git(ctx, sparseCheckoutArgs...); err != nil {
+			return err
+		}
 	}
 
 	if commitSha != "" {
-		if _, err := git(ctx, "-C", flagValues.target, "checkout", commitSha); err != nil {
+		checkoutArgs := []string{"-C", flagValues.target}
+		checkoutArgs = append(checkoutArgs, addtlGitArgs...)
+		checkoutArgs = append(checkoutArgs, "checkout", commitSha)
+		if _, err := git(ctx, checkoutArgs...); err != nil {
+			return err
+		}
+	}
+
+	// The signature of the revision is verified before it is merged, the merge
+	// commit itself is not signed
+	if flagValues.verifySignatureKeys != "" {
+		if err := verifySignature(ctx); err != nil {
+			return err
+		}
+	}
+
+	if flagValues.mergeTarget != "" {
+		if err := merge(ctx, addtlGitArgs); err != nil {
 			return err
 		}
 	}
@@ -421,6 +750,301 @@ func clone(ctx context.Context) error {
 	return nil
 }
 
+// fetch initializes an empty repository in the target directory and fetches
+// the reference from the remote repository, using the objects of the mirror
+// in case one is provided
+func fetch(ctx context.Context, ref string, addtlGitArgs []string, mirror *cacheMirror) error {
+	if _, err := git(ctx, "init", "--quiet", flagValues.target); err != nil {
+		return err
+	}
+
+	var alternates = filepath.Join(flagValues.target, ".git", "objects", "info", "alternates")
+	if mirror != nil {
+		if err := os.WriteFile(alternates, []byte(filepath.Join(mirror.path, "objects")+"\n"), 0644); err != nil {
+			return err
+		}
+	}
+
+	if _, err := git(ctx, "-C", flagValues.target, "remote", "add", "origin", flagValues.url); err != nil {
+		return err
+	}
+
+	fetchArgs := []string{"-C", flagValues.target}
+	fetchArgs = append(fetchArgs, addtlGitArgs...)
+	fetchArgs = append(fetchArgs, "fetch", "--quiet")
+
+	if !flagValues.tags {
+		fetchArgs = append(fetchArgs, "--no-tags")
+	}
+
+	if flagValues.depth > 0 {
+		fetchArgs = append(fetchArgs, "--depth", fmt.Sprintf("%d", flagValues.depth))
+	}
+
+	if flagValues.filter != "" {
+		fetchArgs = append(fetchArgs, "--filter", flagValues.filter)
+	}
+
+	fetchArgs = append(fetchArgs, "origin", ref)
+	if _, err := git(ctx, fetchArgs...); err != nil {
+		return err
+	}
+
+	// copy the objects of the mirror like git clone --dissociate does
+	if mirror != nil {
+		if _, err := git(ctx, "-C", flagValues.target, "repack", "-a", "-d", "--quiet"); err != nil {
+			return err
+		}
+
+		return os.Remove(alternates)
+	}
+
+	return nil
+}
+
+// merge fetches the merge target branch, checks it out, and merges the
+// checked out revision into it
+func merge(ctx context.Context, addtlGitArgs []string) error {
+	revisionSha, err := git(ctx, "-C", flagValues.target, "rev-parse", "--verify", "HEAD")
+	if err != nil {
+		return err
+	}
+
+	fetchArgs := []string{"-C", flagValues.target}
+	fetchArgs = append(fetchArgs, addtlGitArgs...)
+	fetchArgs = append(fetchArgs, "fetch", "--quiet", "--no-tags", "origin", flagValues.mergeTarget)
+	if _, err := git(ctx, fetchArgs...); err != nil {
+		return err
+	}
+
+	targetSha, err := git(ctx, "-C", flagValues.target, "rev-parse", "--verify", "FETCH_HEAD")
+	if err != nil {
+		return err
+	}
+
+	checkoutArgs := []string{"-C", flagValues.target}
+	checkoutArgs = append(checkoutArgs, addtlGitArgs...)
+	checkoutArgs = append(checkoutArgs, "checkout", "--quiet", "--detach", targetSha)
+	if _, err := git(ctx, checkoutArgs...); err != nil {
+		return err
+	}
+
+	mergeArgs := []string{"-C", flagValues.target}
+	mergeArgs = append(mergeArgs, addtlGitArgs...)
+	mergeArgs = append(mergeArgs,
+		"-c", "user.name=Shipwright",
+		"-c", "user.email=shipwright@localhost",
+		"merge", "--quiet", "--no-ff", "--no-edit", revisionSha,
+	)
+	if output, err := git(ctx, mergeArgs...); err != nil {
+		return &ExitError{
+			Code:    130,
+			Message: fmt.Sprintf("the revision cannot be merged into %s: %s", flagValues.mergeTarget, output),
+			Cause:   err,
+		}
+	}
+
+	revisionCommit, mergeTargetCommit = revisionSha, targetSha
+	return nil
+}
+
+// verifySignature verifies that the tag the revision resolves from, or the
+// checked out commit, is signed by one of the trusted keys
+func verifySignature(ctx context.Context) error {
+	tmpDir, err := os.MkdirTemp(os.TempDir(), "signature-keys")
+	if err != nil {
+		return err
+	}
+
+	defer os.RemoveAll(tmpDir)
+
+	gnupgHome, allowedSignersFile, err := importTrustedKeys(ctx, flagValues.verifySignatureKeys, tmpDir)
+	if err != nil {
+		return err
+	}
+
+	// Git runs gpg to verify GPG signatures, which uses the keyring of the
+	// trusted keys through the environment
+	if previous, ok := os.LookupEnv("GNUPGHOME"); ok {
+		defer os.Setenv("GNUPGHOME", previous)
+	} else {
+		defer os.Unsetenv("GNUPGHOME")
+	}
+
+	os.Setenv("GNUPGHOME", gnupgHome)
+
+	var tagRef string
+	switch {
+	case strings.HasPrefix(flagValues.revision, "refs/tags/"):
+		tagRef = "FETCH_HEAD"
+
+	case flagValues.revision != "" && !commitShaRegEx.MatchString(flagValues.revision) && !strings.HasPrefix(flagValues.revision, "refs/"):
+		if _, err := git(ctx, "-C", flagValues.target, "rev-parse", "--verify", "--quiet", "refs/tags/"+flagValues.revision); err == nil {
+			tagRef = "refs/tags/" + flagValues.revision
+		}
+	}
+
+	if tagRef != "" {
+		output, err := git(ctx, "-C", flagValues.target, "-c", "gpg.ssh.allowedSignersFile="+allowedSignersFile, "verify-tag", "--raw", tagRef)
+		if err == nil {
+			if signer, signerKey = parseTagSigner(output); signerKey != "" {
+				return nil
+			}
+		}
+	}
+
+	output, err := git(ctx, "-C", flagValues.target, "-c", "gpg.ssh.allowedSignersFile="+allowedSignersFile, "log", "-1", "--format=signature%x09%G?%x09%GF%x09%GS", "HEAD")
+	if err != nil {
+		return err
+	}
+
+	for _, line := range strings.Split(output, "\n") {
+		fields := strings.SplitN(line, "\t", 4)
+		if len(fields) != 4 || fields[0] != "signature" {
+			continue
+		}
+
+		// G is a good and valid signature, U is a good signature of a key
+		// with unknown validity, for example an SSH key for which no
+		// principal matched, it is not trusted therefore
+		if fields[1] == "G" && fields[2] != "" {
+			signer, signerKey = signerIdentity(fields[3], fields[2]), fields[2]
+			return nil
+		}
+	}
+
+	return &ExitError{
+		Code:    140,
+		Message: shpgit.SignatureVerificationFailed.ToMessage(),
+		Reason:  shpgit.SignatureVerificationFailed,
+	}
+}
+
+// importTrustedKeys imports the GPG public keys in the directory into a new
+// keyring and writes the SSH public keys into an allowed signers file
+func importTrustedKeys(ctx context.Context, dir string, tmpDir string) (string, string, error) {
+	gnupgHome := filepath.Join(tmpDir, "gnupg")
+	if err := os.Mkdir(gnupgHome, 0700); err != nil {
+		return "", "", err
+	}
+
+	// The keyring only contains trusted keys, therefore all keys are valid
+	if err := os.WriteFile(filepath.Join(gnupgHome, "gpg.conf"), []byte("trust-model always\n"), 0600); err != nil {
+		return "", "", err
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return "", "", err
+	}
+
+	var keys int
+	var allowedSigners strings.Builder
+	for _, entry := range entries {
+		// Skip the hidden files and directories of mounted volumes
+		if entry.IsDir() || strings.HasPrefix(entry.Name(), ".") {
+			continue
+		}
+
+		data, err := os.ReadFile(filepath.Join(dir, entry.Name()))
+		if err != nil {
+			return "", "", err
+		}
+
+		if strings.Contains(string(data), "-----BEGIN PGP PUBLIC KEY BLOCK-----") {
+			cmd := exec.CommandContext(ctx, "gpg", "--batch", "--quiet", "--import", filepath.Join(dir, entry.Name()))
+			cmd.Env = append(os.Environ(), "GNUPGHOME="+gnupgHome)
+			if out, err := cmd.CombinedOutput(); err != nil {
+				return "", "", &ExitError{
+					Code:    141,
+					Message: fmt.Sprintf("failed to import the GPG public key %s: %s", entry.Name(), strings.TrimSpace(string(out))),
+					Cause:   err,
+				}
+			}
+
+			keys++
+			continue
+		}
+
+		for _, line := range strings.Split(string(data), "\n") {
+			line = strings.TrimSpace(line)
+			if line == "" || strings.HasPrefix(line, "#") {
+				continue
+			}
+
+			// Public keys in the authorized keys format are trusted for the
+			// principal in their comment, or any principal
+			if sshPublicKeyRegEx.MatchString(line) {
+				fields := strings.Fields(line)
+				principal := "*"
+				if len(fields) > 2 && sshPrincipalRegEx.MatchString(strings.Join(fields[2:], " ")) {
+					principal = fields[2]
+				}
+
+				line = fmt.Sprintf("%s %s %s", principal, fields[0], fields[1])
+			}
+
+			allowedSigners.WriteString(line + "\n")
+			keys++
+		}
+	}
+
+	if keys == 0 {
+		return "", "", &ExitError{
+			Code:    141,
+			Message: fmt.Sprintf("no trusted GPG or SSH public keys found in %s", dir),
+			Reason:  shpgit.SignatureVerificationFailed,
+		}
+	}
+
+	allowedSignersFile := filepath.Join(tmpDir, "allowed_signers")
+	if err := os.WriteFile(allowedSignersFile, []byte(allowedSigners.String()), 0400); err != nil {
+		return "", "", err
+	}
+
+	return gnupgHome, allowedSignersFile, nil
+}
+
+// parseTagSigner returns the signer and key fingerprint of a good signature in
+// the output of git verify-tag --raw
+func parseTagSigner(output string) (string, string) {
+	if match := sshGoodSignatureRegEx.FindStringSubmatch(output); match != nil {
+		return signerIdentity(match[1], match[2]), match[2]
+	}
+
+	var uid, fingerprint string
+	for _, line := range strings.Split(output, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) < 3 || fields[0] != "[GNUPG:]" {
+			continue
+		}
+
+		switch fields[1] {
+		case "GOODSIG":
+			uid = strings.Join(fields[3:], " ")
+
+		case "VALIDSIG":
+			fingerprint = fields[2]
+		}
+	}
+
+	if uid == "" || fingerprint == "" {
+		return "", ""
+	}
+
+	return uid, fingerprint
+}
+
+// signerIdentity returns the identity of the signer, which is the key
+// fingerprint for SSH keys that are trusted for any principal
+func signerIdentity(identity string, fingerprint string) string {
+	if identity == "" || identity == "*" {
+		return fingerprint
+	}
+
+	return identity
+}
+
 func git(ctx context.Context, args ...string) (string, error) {
 	fullArgs := []string{
 		"-c",
@@ -494,6 +1118,30 @@ func checkCredentials() (credentialType, error) {
 		}
 	}
 
+	// Checking whether mounted secret contains the credentials of a GitHub App,
+	// which are exchanged for an installation token
+	hasGitHubAppID := hasFile(flagValues.secretPath, shpgit.GitHubAppIDKey)
+	hasGitHubAppInstallationID := hasFile(flagValues.secretPath, shpgit.GitHubAppInstallationIDKey)
+	hasGitHubAppPrivateKey := hasFile(flagValues.secretPath, shpgit.GitHubAppPrivateKeyKey)
+	switch {
+	case hasGitHubAppID && hasGitHubAppInstallationID && hasGitHubAppPrivateKey && strings.HasPrefix(flagValues.url, "https://"):
+		return typeGitHubApp, nil
+
+	case hasGitHubAppID && hasGitHubAppInstallationID && hasGitHubAppPrivateKey:
+		return typeUndef, &ExitError{
+			Code:    110,
+			Message: shpgit.AuthUnexpectedHTTP.ToMessage(),
+			Reason:  shpgit.AuthUnexpectedHTTP,
+		}
+
+	case hasGitHubAppID || hasGitHubAppInstallationID || hasGitHubAppPrivateKey:
+		return typeUndef, &ExitError{
+			Code:    110,
+			Message: shpgit.AuthGitHubAppIncomplete.ToMessage(),
+			Reason:  shpgit.AuthGitHubAppIncomplete,
+		}
+	}
+
 	// Checking whether mounted secret is of type `kubernetes.io/basic-auth`
 	// in which case there need to be the files username and password
 	hasUsername := hasFile(flagValues.secretPath, "username")
@@ -524,6 +1172,47 @@ func checkCredentials() (credentialType, error) {
 	}
 }
 
+// basicAuthCredentials returns the username and password to use for HTTPS, for
+// a GitHub App the password is a short-lived installation token
+func basicAuthCredentials(ctx context.Context, credType credentialType, host string) (string, string, error) {
+	if credType == typeGitHubApp {
+		data := map[string][]byte{}
+		for _, key := range []string{shpgit.GitHubAppIDKey, shpgit.GitHubAppInstallationIDKey, shpgit.GitHubAppPrivateKeyKey} {
+			value, err := os.ReadFile(filepath.Join(flagValues.secretPath, key))
+			if err != nil {
+				return "", "", err
+			}
+
+			data[key] = value
+		}
+
+		app, err := shpgit.NewGitHubApp(data, shpgit.GitHubAPIURL(host))
+		if err != nil {
+			return "", "", &ExitError{Code: 110, Message: err.Error(), Cause: err}
+		}
+
+		token, err := app.InstallationToken(ctx)
+		if err != nil {
+			return "", "", &ExitError{Code: 110, Message: err.Error(), Cause: err}
+		}
+
+		log.Printf("Created an installation token for GitHub App %s\n", app.AppID)
+		return shpgit.GitHubAppTokenUsername, token, nil
+	}
+
+	username, err := os.ReadFile(filepath.Join(flagValues.secretPath, "username"))
+	if err != nil {
+		return "", "", err
+	}
+
+	password, err := os.ReadFile(filepath.Join(flagValues.secretPath, "password"))
+	if err != nil {
+		return "", "", err
+	}
+
+	return string(username), string(password), nil
+}
+
 func writeErrorResults(failure *shpgit.ErrorResult) (err error) {
 	if flagValues.resultFileErrorReason == "" || flagValues.resultFileErrorMessage == "" {
 		return nil
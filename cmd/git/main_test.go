@@ -205,6 +205,367 @@ var _ = Describe("Git Resource", func() {
 		})
 	})
 
+	Context("cloning parts of repositories", func() {
+		const exampleRepo = "https://github.com/shipwright-io/sample-go"
+
+		It("should Git clone only the sparse checkout directories and the files in the repository root", func() {
+			withTempDir(func(target string) {
+				Expect(run(withArgs(
+					"--url", exampleRepo,
+					"--target", target,
+					"--sparse-checkout", "docker-build",
+				))).ToNot(HaveOccurred())
+
+				Expect(filepath.Join(target, "README.md")).To(BeAnExistingFile())
+				Expect(filepath.Join(target, "docker-build", "Dockerfile")).To(BeAnExistingFile())
+				Expect(filepath.Join(target, "source-build")).ToNot(BeADirectory())
+			})
+		})
+
+		It("should Git clone a commit-sha using a sparse checkout and a partial clone filter", func() {
+			withTempFile("commit-sha", func(filename string) {
+				withTempDir(func(target string) {
+					Expect(run(withArgs(
+						"--url", exampleRepo,
+						"--target", target,
+						"--revision", "0e0583421a5e4bf562ffe33f3651e16ba0c78591",
+						"--sparse-checkout", "docker-build",
+						"--filter", "blob:none",
+						"--result-file-commit-sha", filename,
+					))).ToNot(HaveOccurred())
+
+					Expect(filecontent(filename)).To(Equal("0e0583421a5e4bf562ffe33f3651e16ba0c78591"))
+					Expect(filepath.Join(target, "docker-build", "Dockerfile")).To(BeAnExistingFile())
+					Expect(filepath.Join(target, "source-build")).ToNot(BeADirectory())
+
+					out, err := exec.Command("git", "-C", target, "config", "remote.origin.partialclonefilter").CombinedOutput()
+					Expect(err).ToNot(HaveOccurred())
+					Expect(strings.TrimSpace(string(out))).To(Equal("blob:none"))
+				})
+			})
+		})
+	})
+
+	Context("cloning references and merging revisions", func() {
+		const exampleRepo = "https://github.com/shipwright-io/sample-go"
+
+		It("should Git clone a repository using a reference", func() {
+			withTempDir(func(target string) {
+				Expect(run(withArgs(
+					"--url", exampleRepo,
+					"--target", target,
+					"--revision", "refs/heads/main",
+				))).ToNot(HaveOccurred())
+
+				Expect(filepath.Join(target, "README.md")).To(BeAnExistingFile())
+			})
+		})
+
+		It("should fail in case the reference does not exist", func() {
+			withTempDir(func(target string) {
+				Expect(run(withArgs(
+					"--url", exampleRepo,
+					"--target", target,
+					"--revision", "refs/heads/feqlQoDIHc",
+				))).To(HaveOccurred())
+			})
+		})
+
+		It("should fail in case a merge target is defined without revision", func() {
+			withTempDir(func(target string) {
+				Expect(run(withArgs(
+					"--url", exampleRepo,
+					"--target", target,
+					"--merge-target", "main",
+				))).To(HaveOccurred())
+			})
+		})
+
+		It("should store the commit-sha of the revision and the merge target when merging a revision", func() {
+			withTempFile("commit-sha", func(commitSha string) {
+				withTempFile("merge-target-commit-sha", func(mergeTargetCommitSha string) {
+					withTempDir(func(target string) {
+						Expect(run(withArgs(
+							"--url", exampleRepo,
+							"--target", target,
+							"--revision", "v0.1.0",
+							"--merge-target", "main",
+							"--result-file-commit-sha", commitSha,
+							"--result-file-merge-target-commit-sha", mergeTargetCommitSha,
+						))).ToNot(HaveOccurred())
+
+						Expect(filecontent(commitSha)).To(Equal("8016b0437a7a09079f961e5003e81e5ad54e6c26"))
+						Expect(filecontent(mergeTargetCommitSha)).To(MatchRegexp("^[0-9a-f]{40}$"))
+					})
+				})
+			})
+		})
+	})
+
+	Context("verifying signatures of commits and tags", func() {
+		var (
+			repo      string
+			signedSha string
+			keys      string
+		)
+
+		var gitIn = func(dir string, args ...string) string {
+			args = append([]string{"-C", dir, "-c", "user.name=Shipwright", "-c", "user.email=shipwright@localhost", "-c", "gpg.format=ssh"}, args...)
+			out, err := exec.Command("git", args...).CombinedOutput()
+			Expect(err).ToNot(HaveOccurred(), string(out))
+			return strings.TrimSpace(string(out))
+		}
+
+		// sshKey creates a key pair in the directory and returns the private
+		// key, the public key is trusted in the keys directory
+		var sshKey = func(dir string, keysDir string, comment string) string {
+			out, err := exec.Command("ssh-keygen", "-q", "-t", "ed25519", "-N", "", "-C", comment, "-f", filepath.Join(dir, "key")).CombinedOutput()
+			Expect(err).ToNot(HaveOccurred(), string(out))
+			Expect(os.Rename(filepath.Join(dir, "key.pub"), filepath.Join(keysDir, "key.pub"))).To(Succeed())
+			return filepath.Join(dir, "key")
+		}
+
+		BeforeEach(func() {
+			var err error
+			repo, err = os.MkdirTemp(os.TempDir(), "repo")
+			Expect(err).ToNot(HaveOccurred())
+
+			keys, err = os.MkdirTemp(os.TempDir(), "keys")
+			Expect(err).ToNot(HaveOccurred())
+
+			gitIn(repo, "init", "--quiet", "--initial-branch", "main")
+			signingKey := sshKey(filepath.Join(repo, ".git"), keys, "signer@example.com")
+
+			file(filepath.Join(repo, "README.md"), 0644, []byte("signed"))
+			gitIn(repo, "add", "README.md")
+			gitIn(repo, "-c", "user.signingkey="+signingKey, "commit", "--quiet", "--gpg-sign", "--message", "signed")
+			signedSha = gitIn(repo, "rev-parse", "HEAD")
+
+			file(filepath.Join(repo, "README.md"), 0644, []byte("unsigned"))
+			gitIn(repo, "commit", "--quiet", "--all", "--message", "unsigned")
+			gitIn(repo, "-c", "user.signingkey="+signingKey, "tag", "--sign", "--message", "signed", "v1.0.0")
+		})
+
+		AfterEach(func() {
+			os.RemoveAll(repo)
+			os.RemoveAll(keys)
+		})
+
+		It("should store the signer of a commit signed by a trusted key", func() {
+			withTempFile("signer", func(signer string) {
+				withTempFile("signer-key", func(signerKey string) {
+					withTempDir(func(target string) {
+						Expect(run(withArgs(
+							"--url", "file://"+repo,
+							"--target", target,
+							"--revision", signedSha,
+							"--verify-signature-keys", keys,
+							"--result-file-signer", signer,
+							"--result-file-signer-key", signerKey,
+						))).ToNot(HaveOccurred())
+
+						Expect(filecontent(signer)).To(Equal("signer@example.com"))
+						Expect(filecontent(signerKey)).To(HavePrefix("SHA256:"))
+					})
+				})
+			})
+		})
+
+		It("should accept an unsigned commit that is resolved from a signed tag", func() {
+			withTempFile("signer", func(signer string) {
+				withTempDir(func(target string) {
+					Expect(run(withArgs(
+						"--url", "file://"+repo,
+						"--target", target,
+						"--revision", "v1.0.0",
+						"--verify-signature-keys", keys,
+						"--result-file-signer", signer,
+					))).ToNot(HaveOccurred())
+
+					Expect(filecontent(signer)).To(Equal("signer@example.com"))
+				})
+			})
+		})
+
+		It("should fail in case the commit is not signed", func() {
+			withTempDir(func(target string) {
+				err := run(withArgs(
+					"--url", "file://"+repo,
+					"--target", target,
+					"--revision", "main",
+					"--verify-signature-keys", keys,
+				))
+
+				Expect(err).To(HaveOccurred())
+				Expect(err.(*ExitError).Reason).To(Equal(shpgit.SignatureVerificationFailed))
+			})
+		})
+
+		It("should fail in case the commit is signed by a key that is not trusted", func() {
+			withTempDir(func(otherKeys string) {
+				sshKey(repo, otherKeys, "other@example.com")
+
+				withTempDir(func(target string) {
+					err := run(withArgs(
+						"--url", "file://"+repo,
+						"--target", target,
+						"--revision", signedSha,
+						"--verify-signature-keys", otherKeys,
+					))
+
+					Expect(err).To(HaveOccurred())
+					Expect(err.(*ExitError).Reason).To(Equal(shpgit.SignatureVerificationFailed))
+				})
+			})
+		})
+	})
+
+	Context("cloning from a cache of mirrors", func() {
+		var (
+			repo  string
+			cache string
+		)
+
+		var gitIn = func(dir string, args ...string) string {
+			args = append([]string{"-C", dir, "-c", "user.name=Shipwright", "-c", "user.email=shipwright@localhost"}, args...)
+			out, err := exec.Command("git", args...).CombinedOutput()
+			Expect(err).ToNot(HaveOccurred(), string(out))
+			return strings.TrimSpace(string(out))
+		}
+
+		var mirrors = func() []string {
+			matches, err := filepath.Glob(filepath.Join(cache, "*.git"))
+			Expect(err).ToNot(HaveOccurred())
+			return matches
+		}
+
+		BeforeEach(func() {
+			var err error
+			repo, err = os.MkdirTemp(os.TempDir(), "repo")
+			Expect(err).ToNot(HaveOccurred())
+
+			cache, err = os.MkdirTemp(os.TempDir(), "cache")
+			Expect(err).ToNot(HaveOccurred())
+
+			gitIn(repo, "init", "--quiet", "--initial-branch", "main")
+			file(filepath.Join(repo, "README.md"), 0644, []byte("first"))
+			gitIn(repo, "add", "README.md")
+			gitIn(repo, "commit", "--quiet", "--message", "first")
+		})
+
+		AfterEach(func() {
+			os.RemoveAll(repo)
+			os.RemoveAll(cache)
+		})
+
+		It("should clone through a mirror that is updated for every clone", func() {
+			withTempDir(func(target string) {
+				Expect(run(withArgs(
+					"--url", "file://"+repo,
+					"--target", target,
+					"--cache-dir", cache,
+				))).ToNot(HaveOccurred())
+
+				Expect(mirrors()).To(HaveLen(1))
+				Expect(filecontent(filepath.Join(target, "README.md"))).To(Equal("first"))
+				Expect(filepath.Join(target, ".git", "objects", "info", "alternates")).ToNot(BeAnExistingFile())
+			})
+
+			file(filepath.Join(repo, "README.md"), 0644, []byte("second"))
+			gitIn(repo, "commit", "--quiet", "--all", "--message", "second")
+
+			withTempDir(func(target string) {
+				Expect(run(withArgs(
+					"--url", "file://"+repo,
+					"--target", target,
+					"--revision", "refs/heads/main",
+					"--cache-dir", cache,
+				))).ToNot(HaveOccurred())
+
+				Expect(mirrors()).To(HaveLen(1))
+				Expect(filecontent(filepath.Join(target, "README.md"))).To(Equal("second"))
+				Expect(filepath.Join(target, ".git", "objects", "info", "alternates")).ToNot(BeAnExistingFile())
+			})
+		})
+
+		It("should evict the mirrors once the cache exceeds its maximum size", func() {
+			withTempDir(func(target string) {
+				Expect(run(withArgs(
+					"--url", "file://"+repo,
+					"--target", target,
+					"--cache-dir", cache,
+					"--cache-max-size", "1",
+				))).ToNot(HaveOccurred())
+
+				Expect(mirrors()).To(BeEmpty())
+				Expect(filecontent(filepath.Join(target, "README.md"))).To(Equal("first"))
+			})
+		})
+	})
+
+	Context("store the inputs for reproducible builds", func() {
+		var repo string
+
+		var gitIn = func(args ...string) {
+			args = append([]string{"-C", repo, "-c", "user.name=Shipwright", "-c", "user.email=shipwright@localhost"}, args...)
+			cmd := exec.Command("git", args...)
+			cmd.Env = append(os.Environ(), "GIT_COMMITTER_DATE=@1700000000 +0000")
+			out, err := cmd.CombinedOutput()
+			Expect(err).ToNot(HaveOccurred(), string(out))
+		}
+
+		BeforeEach(func() {
+			var err error
+			repo, err = os.MkdirTemp(os.TempDir(), "repo")
+			Expect(err).ToNot(HaveOccurred())
+			DeferCleanup(os.RemoveAll, repo)
+
+			gitIn("init", "--quiet", "--initial-branch", "main")
+			file(filepath.Join(repo, "README.md"), 0644, []byte("hello"))
+			gitIn("add", "README.md")
+			gitIn("commit", "--quiet", "--message", "Let's add a README")
+			gitIn("tag", "v1.9.0")
+			gitIn("tag", "v1.10.0")
+		})
+
+		It("should store the commit timestamp, subject, tags, and description into the details result file", func() {
+			withTempFile("commit-details", func(details string) {
+				withTempDir(func(target string) {
+					Expect(run(withArgs(
+						"--url", "file://"+repo,
+						"--target", target,
+						"--tags",
+						"--result-file-commit-details", details,
+					))).ToNot(HaveOccurred())
+
+					Expect(filecontent(details)).To(MatchJSON(`{
+						"timestamp": "1700000000",
+						"subject": "Let's add a README",
+						"tags": ["v1.10.0", "v1.9.0"],
+						"describe": "v1.10.0"
+					}`))
+				})
+			})
+		})
+
+		It("should write an env file that can be sourced by a shell", func() {
+			withTempFile("env", func(envFile string) {
+				withTempDir(func(target string) {
+					Expect(run(withArgs(
+						"--url", "file://"+repo,
+						"--target", target,
+						"--tags",
+						"--env-file", envFile,
+					))).ToNot(HaveOccurred())
+
+					out, err := exec.Command("sh", "-c", `. "$0" && echo "$SOURCE_DATE_EPOCH|$SHP_SOURCE_COMMIT_SUBJECT|$SHP_SOURCE_DESCRIBE|$SHP_SOURCE_TAGS"`, envFile).CombinedOutput()
+					Expect(err).ToNot(HaveOccurred(), string(out))
+					Expect(strings.TrimSpace(string(out))).To(Equal("1700000000|Let's add a README|v1.10.0|v1.10.0 v1.9.0"))
+				})
+			})
+		})
+	})
+
 	Context("cloning private repositories using SSH keys", func() {
 		const exampleRepo = "git@github.com:shipwright-io/sample-nodejs-private.git"
 
@@ -409,6 +770,78 @@ var _ = Describe("Git Resource", func() {
 		})
 	})
 
+	Context("cloning private repositories using GitHub App credentials", func() {
+		const exampleRepo = "https://github.com/shipwright-io/sample-nodejs-private"
+
+		var withGitHubApp = func(f func(appID, installationID, privateKey string)) {
+			var appID = os.Getenv("TEST_GIT_GITHUB_APP_ID")
+			var installationID = os.Getenv("TEST_GIT_GITHUB_APP_INSTALLATION_ID")
+			var privateKey = os.Getenv("TEST_GIT_GITHUB_APP_PRIVATE_KEY")
+			if appID == "" || installationID == "" || privateKey == "" {
+				Skip("Skipping GitHub App tests since TEST_GIT_GITHUB_APP_ID, TEST_GIT_GITHUB_APP_INSTALLATION_ID, or TEST_GIT_GITHUB_APP_PRIVATE_KEY environment variables are not set")
+			}
+
+			f(appID, installationID, privateKey)
+		}
+
+		It("should fail in case the GitHub App credentials are incomplete", func() {
+			withTempDir(func(secret string) {
+				file(filepath.Join(secret, "github-app-id"), 0400, []byte("12345"))
+				file(filepath.Join(secret, "github-app-installation-id"), 0400, []byte("67890"))
+
+				withTempDir(func(target string) {
+					err := run(withArgs(
+						"--url", exampleRepo,
+						"--secret-path", secret,
+						"--target", target,
+					))
+
+					Expect(err).To(HaveOccurred())
+					Expect(err.Error()).To(ContainSubstring(shpgit.AuthGitHubAppIncomplete.ToMessage()))
+				})
+			})
+		})
+
+		It("should fail in case GitHub App credentials are used in conjunction with HTTP URI", func() {
+			withTempDir(func(secret string) {
+				file(filepath.Join(secret, "github-app-id"), 0400, []byte("12345"))
+				file(filepath.Join(secret, "github-app-installation-id"), 0400, []byte("67890"))
+				file(filepath.Join(secret, "github-app-private-key"), 0400, []byte("private key"))
+
+				withTempDir(func(target string) {
+					err := run(withArgs(
+						"--url", "http://github.com/shipwright-io/sample-nodejs-private",
+						"--secret-path", secret,
+						"--target", target,
+					))
+
+					Expect(err).To(HaveOccurred())
+					Expect(err.Error()).To(ContainSubstring(shpgit.AuthUnexpectedHTTP.ToMessage()))
+				})
+			})
+		})
+
+		It("should Git clone a private repository using an installation token of a GitHub App provided via a secret", func() {
+			withTempDir(func(secret string) {
+				withGitHubApp(func(appID, installationID, privateKey string) {
+					file(filepath.Join(secret, "github-app-id"), 0400, []byte(appID))
+					file(filepath.Join(secret, "github-app-installation-id"), 0400, []byte(installationID))
+					file(filepath.Join(secret, "github-app-private-key"), 0400, []byte(privateKey))
+
+					withTempDir(func(target string) {
+						Expect(run(withArgs(
+							"--url", exampleRepo,
+							"--secret-path", secret,
+							"--target", target,
+						))).ToNot(HaveOccurred())
+
+						Expect(filepath.Join(target, "README.md")).To(BeAnExistingFile())
+					})
+				})
+			})
+		})
+	})
+
 	Context("cloning repositories with submodules", func() {
 		const exampleRepo = "https://github.com/shipwright-io/website"
 
@@ -471,6 +904,41 @@ var _ = Describe("Git Resource", func() {
 				})
 			})
 		})
+
+		It("should store whether the clone is shallow into file specified in --result-file-shallow flag", func() {
+			withTempFile("shallow", func(filename string) {
+				withTempDir(func(target string) {
+					Expect(run(withArgs(
+						"--url", exampleRepo,
+						"--target", target,
+						"--result-file-shallow", filename,
+					))).ToNot(HaveOccurred())
+
+					Expect(filecontent(filename)).To(Equal("true"))
+				})
+			})
+		})
+
+		It("should store the commit description into file specified in --result-file-commit-details flag when cloning the full history with tags", func() {
+			withTempFile("shallow", func(shallow string) {
+				withTempFile("commit-details", func(details string) {
+					withTempDir(func(target string) {
+						Expect(run(withArgs(
+							"--url", exampleRepo,
+							"--target", target,
+							"--revision", "v0.1.0",
+							"--depth", "0",
+							"--tags",
+							"--result-file-shallow", shallow,
+							"--result-file-commit-details", details,
+						))).ToNot(HaveOccurred())
+
+						Expect(filecontent(shallow)).To(Equal("false"))
+						Expect(filecontent(details)).To(ContainSubstring(`"describe":"v0.1.0"`))
+					})
+				})
+			})
+		})
 	})
 
 	Context("Some tests mutate or depend on git configurations. They must run sequentially to avoid race-conditions.", Ordered, func() {
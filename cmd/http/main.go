@@ -0,0 +1,136 @@
+// Copyright The Shipwright Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/spf13/pflag"
+
+	"github.com/shipwright-io/build/pkg/bundle"
+	"github.com/shipwright-io/build/pkg/download"
+)
+
+type settings struct {
+	help             bool
+	url              string
+	target           string
+	sha256           string
+	secretPath       string
+	extract          bool
+	stripComponents  uint
+	maxTotalSize     int64
+	maxFileCount     int64
+	maxFileSize      int64
+	retries          uint
+	retryDelay       time.Duration
+	resultFileDigest string
+}
+
+var flagValues settings
+
+func init() {
+	// Explicitly define the help flag so that --help can be invoked and returns status code 0
+	pflag.BoolVar(&flagValues.help, "help", false, "Print the help")
+
+	// Main flags of the HTTP step
+	pflag.StringVar(&flagValues.url, "url", "", "The URL of the remote artifact (mandatory)")
+	pflag.StringVar(&flagValues.target, "target", "/workspace/source", "The target directory to place the remote artifact")
+	pflag.StringVar(&flagValues.resultFileDigest, "result-file-digest", "", "A file to write the digest of the remote artifact")
+
+	pflag.StringVar(&flagValues.sha256, "sha256", "", "The expected SHA-256 digest of the remote artifact (optional)")
+	pflag.StringVar(&flagValues.secretPath, "secret-path", "", "A directory that contains the name and value of the HTTP header to authenticate the download (optional)")
+	pflag.BoolVar(&flagValues.extract, "extract", false, "Extract the remote artifact, which is a tar, gzip compressed tar, or zip archive")
+	pflag.UintVar(&flagValues.stripComponents, "strip-components", 0, "The number of leading path elements to remove from the file names of the archive")
+	pflag.Int64Var(&flagValues.maxTotalSize, "max-total-size", 0, "The maximum number of bytes of all extracted files, zero means no limit")
+	pflag.Int64Var(&flagValues.maxFileCount, "max-file-count", 0, "The maximum number of extracted files, directories, and links, zero means no limit")
+	pflag.Int64Var(&flagValues.maxFileSize, "max-file-size", 0, "The maximum number of bytes of a single extracted file, zero means no limit")
+	pflag.UintVar(&flagValues.retries, "retries", 3, "The number of times the download is retried on network or server errors")
+	pflag.DurationVar(&flagValues.retryDelay, "retry-delay", time.Second, "The delay before the first retry, it doubles with every further retry")
+}
+
+func main() {
+	if err := Do(context.Background()); err != nil {
+		log.Fatal(err.Error())
+	}
+}
+
+// Do is the main entry point of the HTTP command
+func Do(ctx context.Context) error {
+	flagValues = settings{retries: 3, retryDelay: time.Second}
+	pflag.Parse()
+
+	if flagValues.help {
+		pflag.Usage()
+		return nil
+	}
+
+	if flagValues.url == "" {
+		return fmt.Errorf("mandatory flag --url is not set")
+	}
+
+	header, err := authHeader()
+	if err != nil {
+		return err
+	}
+
+	log.Printf("Downloading %s\n", flagValues.url)
+	digest, err := download.Download(ctx, flagValues.url, flagValues.target, download.Options{
+		Header:          header,
+		SHA256:          flagValues.sha256,
+		Extract:         flagValues.extract,
+		StripComponents: int(flagValues.stripComponents),
+		Limits: bundle.UnpackOptions{
+			MaxTotalSize: flagValues.maxTotalSize,
+			MaxFileCount: flagValues.maxFileCount,
+			MaxFileSize:  flagValues.maxFileSize,
+		},
+		Retries:    int(flagValues.retries),
+		RetryDelay: flagValues.retryDelay,
+		Client:     &http.Client{Timeout: 30 * time.Minute},
+	})
+	if err != nil {
+		return err
+	}
+
+	log.Printf("Remote artifact %s (%s) was placed in %s\n", flagValues.url, digest, flagValues.target)
+
+	if flagValues.resultFileDigest != "" {
+		if err := os.WriteFile(flagValues.resultFileDigest, []byte(digest), 0644); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// authHeader returns the HTTP header to authenticate the download, which is
+// read from the secret
+func authHeader() (http.Header, error) {
+	if flagValues.secretPath == "" {
+		return nil, nil
+	}
+
+	value, err := os.ReadFile(filepath.Join(flagValues.secretPath, download.AuthHeaderValueKey))
+	if err != nil {
+		return nil, fmt.Errorf("the secret does not contain the %s key: %w", download.AuthHeaderValueKey, err)
+	}
+
+	name := "Authorization"
+	if data, err := os.ReadFile(filepath.Join(flagValues.secretPath, download.AuthHeaderNameKey)); err == nil && strings.TrimSpace(string(data)) != "" {
+		name = strings.TrimSpace(string(data))
+	}
+
+	header := http.Header{}
+	header.Set(name, strings.TrimSpace(string(value)))
+	return header, nil
+}
@@ -0,0 +1,115 @@
+// Copyright The Shipwright Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package main_test
+
+import (
+	"context"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	. "github.com/shipwright-io/build/cmd/http"
+)
+
+var _ = Describe("HTTP Source", func() {
+	var run = func(args ...string) error {
+		// discard log output
+		log.SetOutput(io.Discard)
+
+		// discard stderr output
+		var tmp = os.Stderr
+		os.Stderr = nil
+		defer func() { os.Stderr = tmp }()
+
+		os.Args = append([]string{"tool"}, args...)
+		return Do(context.Background())
+	}
+
+	var withTempDir = func(f func(target string)) {
+		path, err := os.MkdirTemp(os.TempDir(), "http")
+		Expect(err).ToNot(HaveOccurred())
+		defer os.RemoveAll(path)
+
+		f(path)
+	}
+
+	var filecontent = func(path string) string {
+		data, err := os.ReadFile(path)
+		Expect(err).ToNot(HaveOccurred())
+		return string(data)
+	}
+
+	var server *httptest.Server
+
+	BeforeEach(func() {
+		server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Header.Get("Authorization") != "Bearer token" {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+
+			_, _ = w.Write([]byte("remote artifact"))
+		}))
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	Context("validations and error cases", func() {
+		It("should succeed in case the help is requested", func() {
+			Expect(run("--help")).To(Succeed())
+		})
+
+		It("should fail in case the URL is not set", func() {
+			Expect(run()).To(HaveOccurred())
+		})
+
+		It("should fail in case the download is not authenticated", func() {
+			withTempDir(func(target string) {
+				Expect(run(
+					"--url", server.URL+"/artifact.txt",
+					"--target", target,
+				)).To(HaveOccurred())
+			})
+		})
+	})
+
+	Context("downloading a remote artifact", func() {
+		It("should download the remote artifact using the header of the secret and store its digest", func() {
+			withTempDir(func(secret string) {
+				Expect(os.WriteFile(filepath.Join(secret, "header-value"), []byte("Bearer token\n"), 0400)).To(Succeed())
+
+				withTempDir(func(target string) {
+					digestFile := filepath.Join(secret, "digest")
+
+					Expect(run(
+						"--url", server.URL+"/artifact.txt",
+						"--target", target,
+						"--secret-path", secret,
+						"--sha256", "a0c5e4e3ed8f5c6d1ee1b6a9a4a9b7cb0f5ab23e4b0c3e8a1df0a4d97df0d3c5",
+						"--result-file-digest", digestFile,
+					)).To(HaveOccurred())
+
+					Expect(run(
+						"--url", server.URL+"/artifact.txt",
+						"--target", target,
+						"--secret-path", secret,
+						"--result-file-digest", digestFile,
+					)).To(Succeed())
+
+					Expect(filecontent(filepath.Join(target, "artifact.txt"))).To(Equal("remote artifact"))
+					Expect(filecontent(digestFile)).To(MatchRegexp("^sha256:[0-9a-f]{64}$"))
+				})
+			})
+		})
+	})
+})
@@ -8,15 +8,20 @@
 package main
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"fmt"
 	"log"
 	"os"
+	"path/filepath"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/google/go-containerregistry/pkg/name"
 	containerreg "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
 	"github.com/shipwright-io/build/pkg/image"
 	"github.com/spf13/pflag"
 )
@@ -37,12 +42,24 @@ type settings struct {
 	help bool
 	push string
 	annotation,
-	label *[]string
+	label,
+	additionalTag,
+	mirror,
+	mirrorInsecure,
+	mirrorSecretPath *[]string
 	insecure bool
 	image,
+	buildRunName,
+	commitSHAFile,
 	resultFileImageDigest,
 	resultFileImageSize,
-	secretPath string
+	resultFileImageTags,
+	resultFileImageMirrors,
+	resultFileSignatureDigest,
+	resultFileSBOMDigest,
+	sbomFormat,
+	secretPath,
+	signingSecretPath string
 }
 
 func getAnnotation() []string {
@@ -65,6 +82,46 @@ func getLabel() []string {
 	return label
 }
 
+func getAdditionalTag() []string {
+	var additionalTag []string
+
+	if flagValues.additionalTag != nil {
+		return append(additionalTag, *flagValues.additionalTag...)
+	}
+
+	return additionalTag
+}
+
+func getMirror() []string {
+	var mirror []string
+
+	if flagValues.mirror != nil {
+		return append(mirror, *flagValues.mirror...)
+	}
+
+	return mirror
+}
+
+func getMirrorInsecure() []string {
+	var mirrorInsecure []string
+
+	if flagValues.mirrorInsecure != nil {
+		return append(mirrorInsecure, *flagValues.mirrorInsecure...)
+	}
+
+	return mirrorInsecure
+}
+
+func getMirrorSecretPath() []string {
+	var mirrorSecretPath []string
+
+	if flagValues.mirrorSecretPath != nil {
+		return append(mirrorSecretPath, *flagValues.mirrorSecretPath...)
+	}
+
+	return mirrorSecretPath
+}
+
 var flagValues settings
 
 func initializeFlag() {
@@ -83,6 +140,22 @@ func initializeFlag() {
 	flagValues.label = pflag.StringArray("label", nil, "New labels to add")
 	pflag.StringVar(&flagValues.resultFileImageDigest, "result-file-image-digest", "", "A file to write the image digest to")
 	pflag.StringVar(&flagValues.resultFileImageSize, "result-file-image-size", "", "A file to write the image size to")
+
+	flagValues.additionalTag = pflag.StringArray("additional-tag", nil, "An additional tag to push for the image, it can contain the placeholders $(buildrun-name), $(commit-sha), $(commit-sha-short), and $(timestamp)")
+	pflag.StringVar(&flagValues.buildRunName, "buildrun-name", "", "The value of the $(buildrun-name) placeholder of the additional tags")
+	pflag.StringVar(&flagValues.commitSHAFile, "commit-sha-file", "", "A file that contains the value of the $(commit-sha) placeholder of the additional tags")
+	pflag.StringVar(&flagValues.resultFileImageTags, "result-file-image-tags", "", "A file to write the pushed tags to, one per line")
+
+	flagValues.mirror = pflag.StringArray("mirror", nil, "An image name to copy the pushed image to")
+	flagValues.mirrorInsecure = pflag.StringArray("mirror-insecure", nil, "An image name of a mirror whose container registry is insecure")
+	flagValues.mirrorSecretPath = pflag.StringArray("mirror-secret-path", nil, "An image name of a mirror and the directory that contains its access credentials, in the form image=path")
+	pflag.StringVar(&flagValues.resultFileImageMirrors, "result-file-image-mirrors", "", "A file to write the digests of the mirrors and their errors to, in JSON")
+
+	pflag.StringVar(&flagValues.signingSecretPath, "signing-secret-path", "", "A directory that contains the private key to sign the image in the cosign.key file, and its password in the cosign.password file (optional)")
+	pflag.StringVar(&flagValues.resultFileSignatureDigest, "result-file-signature-digest", "", "A file to write the digest of the image signature to")
+
+	pflag.StringVar(&flagValues.sbomFormat, "sbom-format", "", "Generate a software bill of materials in this format, either spdx or cyclonedx, and attach it to the image (optional)")
+	pflag.StringVar(&flagValues.resultFileSBOMDigest, "result-file-sbom-digest", "", "A file to write the digest of the software bill of materials to")
 }
 
 func main() {
@@ -122,6 +195,34 @@ func runImageProcessing(ctx context.Context) error {
 		return fmt.Errorf("failed to parse image name: %w", err)
 	}
 
+	// validate the SBOM format before the image is pushed
+	sbomFormat := image.SBOMFormat(flagValues.sbomFormat)
+	if sbomFormat != "" && sbomFormat != image.SBOMFormatSPDX && sbomFormat != image.SBOMFormatCycloneDX {
+		return &ExitError{Code: 100, Message: fmt.Sprintf("the 'sbom-format' argument must be %s or %s", image.SBOMFormatSPDX, image.SBOMFormatCycloneDX)}
+	}
+
+	// render the additional tags before the image is pushed, so that all tags
+	// use the same timestamp
+	additionalTags, err := renderAdditionalTags(imageName, time.Now())
+	if err != nil {
+		return &ExitError{Code: 100, Message: err.Error(), Cause: err}
+	}
+
+	// parse the mirrors before the image is pushed
+	mirrorNames := make([]name.Reference, 0, len(getMirror()))
+	for _, mirror := range getMirror() {
+		mirrorName, err := name.ParseReference(mirror)
+		if err != nil {
+			return &ExitError{Code: 100, Message: fmt.Sprintf("the mirror %q is invalid: %v", mirror, err), Cause: err}
+		}
+		mirrorNames = append(mirrorNames, mirrorName)
+	}
+
+	mirrorSecretPaths, err := splitKeyVals(getMirrorSecretPath())
+	if err != nil {
+		return err
+	}
+
 	// parse annotations
 	annotations, err := splitKeyVals(getAnnotation())
 	if err != nil {
@@ -179,6 +280,28 @@ func runImageProcessing(ctx context.Context) error {
 		return err
 	}
 
+	// push the additional tags, they point at the digest of the pushed image
+	if len(additionalTags) > 0 {
+		log.Printf("Pushing the additional tags %s\n", strings.Join(additionalTags, ", "))
+		if err := image.TagImageOrImageIndex(imageName, img, imageIndex, additionalTags, options); err != nil {
+			log.Printf("Failed to push the additional tags: %v\n", err)
+			return err
+		}
+	}
+
+	// Writing the pushed tags to file
+	if flagValues.resultFileImageTags != "" {
+		var tags []string
+		if tag, ok := imageName.(name.Tag); ok {
+			tags = append(tags, tag.TagStr())
+		}
+		tags = append(tags, additionalTags...)
+
+		if err := os.WriteFile(flagValues.resultFileImageTags, []byte(strings.Join(tags, "\n")), 0400); err != nil {
+			return err
+		}
+	}
+
 	// Writing image digest to file
 	if digest != "" && flagValues.resultFileImageDigest != "" {
 		if err := os.WriteFile(flagValues.resultFileImageDigest, []byte(digest), 0400); err != nil {
@@ -193,9 +316,186 @@ func runImageProcessing(ctx context.Context) error {
 		}
 	}
 
+	// sign the pushed image digest
+	if flagValues.signingSecretPath != "" {
+		log.Printf("Signing the image %q\n", imageName.Context().Digest(digest).String())
+		signatureDigest, err := signImage(imageName.Context().Digest(digest), options)
+		if err != nil {
+			log.Printf("Failed to sign the image: %v\n", err)
+			return err
+		}
+
+		if flagValues.resultFileSignatureDigest != "" {
+			if err := os.WriteFile(flagValues.resultFileSignatureDigest, []byte(signatureDigest.String()), 0400); err != nil {
+				return err
+			}
+		}
+	}
+
+	// generate the SBOM of the pushed image digest and attach it
+	if sbomFormat != "" {
+		log.Printf("Attaching a software bill of materials to the image %q\n", imageName.Context().Digest(digest).String())
+		sbomDigest, err := attachSBOM(imageName.Context().Digest(digest), img, imageIndex, sbomFormat, options)
+		if err != nil {
+			log.Printf("Failed to attach the software bill of materials: %v\n", err)
+			return err
+		}
+
+		if flagValues.resultFileSBOMDigest != "" {
+			if err := os.WriteFile(flagValues.resultFileSBOMDigest, []byte(sbomDigest.String()), 0400); err != nil {
+				return err
+			}
+		}
+	}
+
+	// copy the pushed image digest to the mirrors, a mirror that fails does
+	// not fail the push of the image, it is reported in the result instead
+	if len(mirrorNames) > 0 {
+		mirrorInsecure := map[string]bool{}
+		for _, mirror := range getMirrorInsecure() {
+			mirrorInsecure[mirror] = true
+		}
+
+		results := make([]mirrorResult, 0, len(mirrorNames))
+		for i, mirror := range getMirror() {
+			mirrorName := mirrorNames[i]
+			log.Printf("Copying the image to the mirror %q\n", mirrorName.String())
+			result := mirrorResult{Image: mirror}
+
+			mirrorDigest, err := mirrorImage(ctx, imageName.Context().Digest(digest), options, mirrorName, mirrorInsecure[mirror], mirrorSecretPaths[mirror], img, imageIndex, additionalTags)
+			if err != nil {
+				log.Printf("Failed to copy the image to the mirror %q: %v\n", mirrorName.String(), err)
+				result.Error = err.Error()
+			} else {
+				result.Digest = mirrorDigest
+			}
+
+			results = append(results, result)
+		}
+
+		if flagValues.resultFileImageMirrors != "" {
+			data, err := json.Marshal(results)
+			if err != nil {
+				return err
+			}
+
+			if err := os.WriteFile(flagValues.resultFileImageMirrors, data, 0400); err != nil {
+				return err
+			}
+		}
+	}
+
 	return nil
 }
 
+// mirrorResult is the result of copying the image to a mirror
+type mirrorResult struct {
+	Image  string `json:"image"`
+	Digest string `json:"digest,omitempty"`
+	Error  string `json:"error,omitempty"`
+}
+
+// mirrorImage copies the image digest to the mirror, including its additional
+// tags, and returns the digest of the copy
+func mirrorImage(ctx context.Context, digest name.Digest, options []remote.Option, mirrorName name.Reference, insecure bool, secretPath string, img containerreg.Image, imageIndex containerreg.ImageIndex, additionalTags []string) (string, error) {
+	mirrorOptions, _, err := image.GetOptions(ctx, mirrorName, insecure, secretPath, "Shipwright Build")
+	if err != nil {
+		return "", err
+	}
+
+	mirrorDigest, err := image.MirrorImageOrImageIndex(digest, options, mirrorName, mirrorOptions)
+	if err != nil {
+		return "", err
+	}
+
+	if len(additionalTags) > 0 {
+		if err := image.TagImageOrImageIndex(mirrorName, img, imageIndex, additionalTags, mirrorOptions); err != nil {
+			return "", err
+		}
+	}
+
+	return mirrorDigest, nil
+}
+
+// attachSBOM lists the packages of the image or image index, and attaches a
+// software bill of materials to the image digest. It returns the digest of the
+// SBOM artifact.
+func attachSBOM(digest name.Digest, img containerreg.Image, imageIndex containerreg.ImageIndex, format image.SBOMFormat, options []remote.Option) (containerreg.Hash, error) {
+	packages, err := image.ListPackages(img, imageIndex)
+	if err != nil {
+		return containerreg.Hash{}, err
+	}
+
+	log.Printf("Found %d packages\n", len(packages))
+
+	sbom, mediaType, err := image.GenerateSBOM(digest, packages, format, time.Now())
+	if err != nil {
+		return containerreg.Hash{}, err
+	}
+
+	return image.AttachSBOM(digest, sbom, mediaType, options...)
+}
+
+// renderAdditionalTags replaces the placeholders of the additional tags, tags
+// that are the same as the tag of the image are skipped
+func renderAdditionalTags(imageName name.Reference, timestamp time.Time) ([]string, error) {
+	templates := getAdditionalTag()
+	if len(templates) == 0 {
+		return nil, nil
+	}
+
+	values := image.TagValues{
+		BuildRunName: flagValues.buildRunName,
+		Timestamp:    timestamp,
+	}
+
+	if flagValues.commitSHAFile != "" {
+		data, err := os.ReadFile(flagValues.commitSHAFile)
+		if err != nil && !os.IsNotExist(err) {
+			return nil, err
+		}
+		values.CommitSHA = strings.TrimSpace(string(data))
+	}
+
+	var tags []string
+	seen := map[string]struct{}{}
+	if tag, ok := imageName.(name.Tag); ok {
+		seen[tag.TagStr()] = struct{}{}
+	}
+
+	for _, template := range templates {
+		tag, err := image.RenderTag(template, values)
+		if err != nil {
+			return nil, err
+		}
+
+		if _, ok := seen[tag]; ok {
+			continue
+		}
+
+		seen[tag] = struct{}{}
+		tags = append(tags, tag)
+	}
+
+	return tags, nil
+}
+
+// signImage signs the image digest using the private key of the signing
+// secret and returns the digest of the signature
+func signImage(digest name.Digest, options []remote.Option) (containerreg.Hash, error) {
+	password, err := os.ReadFile(filepath.Join(flagValues.signingSecretPath, "cosign.password"))
+	if err != nil && !os.IsNotExist(err) {
+		return containerreg.Hash{}, err
+	}
+
+	key, err := image.LoadPrivateKey(filepath.Join(flagValues.signingSecretPath, "cosign.key"), bytes.TrimRight(password, "\r\n"))
+	if err != nil {
+		return containerreg.Hash{}, err
+	}
+
+	return image.Sign(digest, key, options...)
+}
+
 // splitKeyVals splits key value pairs which is in form hello=world
 func splitKeyVals(kvPairs []string) (map[string]string, error) {
 	m := map[string]string{}
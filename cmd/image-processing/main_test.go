@@ -6,16 +6,26 @@ package main_test
 
 import (
 	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	cryptorand "crypto/rand"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
 	"fmt"
 	"log"
 	"net/http/httptest"
 	"net/url"
 	"os"
+	"path/filepath"
 	"strconv"
+	"strings"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	. "github.com/shipwright-io/build/cmd/image-processing"
+	"github.com/shipwright-io/build/pkg/image"
 
 	"github.com/google/go-containerregistry/pkg/name"
 	"github.com/google/go-containerregistry/pkg/registry"
@@ -300,4 +310,176 @@ var _ = Describe("Image Processing Resource", func() {
 			})
 		})
 	})
+
+	Context("signing the image", func() {
+		var (
+			key        *ecdsa.PrivateKey
+			signingDir string
+		)
+
+		BeforeEach(func() {
+			var err error
+			key, err = ecdsa.GenerateKey(elliptic.P256(), cryptorand.Reader)
+			Expect(err).ToNot(HaveOccurred())
+
+			data, err := x509.MarshalPKCS8PrivateKey(key)
+			Expect(err).ToNot(HaveOccurred())
+
+			signingDir, err = os.MkdirTemp("", "signing")
+			Expect(err).ToNot(HaveOccurred())
+			DeferCleanup(os.RemoveAll, signingDir)
+
+			Expect(os.WriteFile(filepath.Join(signingDir, "cosign.key"), pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: data}), 0400)).To(Succeed())
+		})
+
+		It("should sign the pushed image and store the signature digest into the file specified in --result-file-signature-digest flag", func() {
+			withTestImage(func(tag name.Tag) {
+				withTempFile("signature-digest", func(filename string) {
+					Expect(run(
+						"--insecure",
+						"--image", tag.String(),
+						"--signing-secret-path", signingDir,
+						"--result-file-signature-digest", filename,
+					)).ToNot(HaveOccurred())
+
+					digest := tag.Context().Digest(getImageDigest(tag).String())
+					Expect(image.VerifySignature(digest, []crypto.PublicKey{key.Public()})).To(Succeed())
+
+					signatureTag, err := name.NewTag(fmt.Sprintf("%s:%s.sig", tag.Context().Name(), strings.Replace(digest.DigestStr(), ":", "-", 1)))
+					Expect(err).ToNot(HaveOccurred())
+					Expect(filecontent(filename)).To(Equal(getImageDigest(signatureTag).String()))
+				})
+			})
+		})
+
+		It("should fail in case the signing secret contains no key", func() {
+			withTestImage(func(tag name.Tag) {
+				Expect(os.Remove(filepath.Join(signingDir, "cosign.key"))).To(Succeed())
+
+				Expect(run(
+					"--insecure",
+					"--image", tag.String(),
+					"--signing-secret-path", signingDir,
+				)).To(HaveOccurred())
+			})
+		})
+	})
+
+	Context("attaching an SBOM", func() {
+		It("should attach an SBOM to the pushed image and store its digest into the file specified in --result-file-sbom-digest flag", func() {
+			withTestImage(func(tag name.Tag) {
+				withTempFile("sbom-digest", func(filename string) {
+					Expect(run(
+						"--insecure",
+						"--image", tag.String(),
+						"--sbom-format", "cyclonedx",
+						"--result-file-sbom-digest", filename,
+					)).ToNot(HaveOccurred())
+
+					referrers, err := remote.Referrers(tag.Context().Digest(getImageDigest(tag).String()))
+					Expect(err).ToNot(HaveOccurred())
+
+					indexManifest, err := referrers.IndexManifest()
+					Expect(err).ToNot(HaveOccurred())
+					Expect(indexManifest.Manifests).To(HaveLen(1))
+					Expect(indexManifest.Manifests[0].ArtifactType).To(Equal("application/vnd.cyclonedx+json"))
+					Expect(filecontent(filename)).To(Equal(indexManifest.Manifests[0].Digest.String()))
+				})
+			})
+		})
+
+		It("should fail in case the SBOM format is not supported", func() {
+			withTestImage(func(tag name.Tag) {
+				Expect(run(
+					"--insecure",
+					"--image", tag.String(),
+					"--sbom-format", "swid",
+				)).To(HaveOccurred())
+			})
+		})
+	})
+
+	Context("pushing additional tags", func() {
+		It("should push the rendered additional tags for the same digest and store all tags into the file specified in --result-file-image-tags flag", func() {
+			withTestImage(func(tag name.Tag) {
+				withTempFile("image-tags", func(filename string) {
+					withTempFile("commit-sha", func(commitFile string) {
+						Expect(os.WriteFile(commitFile, []byte("0e0583421a5e4bf562ffe33f3651e16ba0c78591"), 0644)).To(Succeed())
+
+						Expect(run(
+							"--insecure",
+							"--image", tag.String(),
+							"--additional-tag", "$(buildrun-name)",
+							"--additional-tag", "sha-$(commit-sha-short)",
+							"--buildrun-name", "sample-go-run",
+							"--commit-sha-file", commitFile,
+							"--result-file-image-tags", filename,
+						)).ToNot(HaveOccurred())
+
+						digest := getImageDigest(tag)
+						for _, additionalTag := range []string{"sample-go-run", "sha-0e05834"} {
+							Expect(getImageDigest(tag.Context().Tag(additionalTag))).To(Equal(digest))
+						}
+
+						Expect(filecontent(filename)).To(Equal(tag.TagStr() + "\nsample-go-run\nsha-0e05834"))
+					})
+				})
+			})
+		})
+
+		It("should fail in case an additional tag contains an unknown placeholder", func() {
+			withTestImage(func(tag name.Tag) {
+				Expect(run(
+					"--insecure",
+					"--image", tag.String(),
+					"--additional-tag", "$(branch)",
+				)).To(HaveOccurred())
+			})
+		})
+	})
+
+	Context("copying the image to mirrors", func() {
+		It("should copy the pushed image to the mirrors and store their digests and errors into the file specified in --result-file-image-mirrors flag", func() {
+			withTestImage(func(tag name.Tag) {
+				withTempRegistry(func(mirrorEndpoint string) {
+					withTempFile("image-mirrors", func(filename string) {
+						mirror := fmt.Sprintf("%s/dr-namespace/temp-image:latest", mirrorEndpoint)
+						failingMirror := fmt.Sprintf("%s/other-namespace/temp-image:latest", mirrorEndpoint)
+
+						Expect(run(
+							"--insecure",
+							"--image", tag.String(),
+							"--mirror", mirror,
+							"--mirror", failingMirror,
+							"--mirror-insecure", mirror,
+							"--mirror-secret-path", failingMirror+"=/does/not/exist",
+							"--result-file-image-mirrors", filename,
+						)).ToNot(HaveOccurred())
+
+						digest := getImageDigest(tag)
+						mirrorTag, err := name.NewTag(mirror)
+						Expect(err).ToNot(HaveOccurred())
+						Expect(getImageDigest(mirrorTag)).To(Equal(digest))
+
+						var results []map[string]string
+						Expect(json.Unmarshal([]byte(filecontent(filename)), &results)).To(Succeed())
+						Expect(results).To(HaveLen(2))
+						Expect(results[0]).To(Equal(map[string]string{"image": mirror, "digest": digest.String()}))
+						Expect(results[1]["image"]).To(Equal(failingMirror))
+						Expect(results[1]["error"]).To(ContainSubstring("failed to open the config json"))
+					})
+				})
+			})
+		})
+
+		It("should fail in case a mirror is invalid", func() {
+			withTestImage(func(tag name.Tag) {
+				Expect(run(
+					"--insecure",
+					"--image", tag.String(),
+					"--mirror", "INVALID//image",
+				)).To(HaveOccurred())
+			})
+		})
+	})
 })
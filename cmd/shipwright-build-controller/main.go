@@ -11,6 +11,9 @@ import (
 	"os"
 	"runtime"
 
+	// Embed the time zone database, it is used to evaluate the time zones of Schedule triggers
+	_ "time/tzdata"
+
 	// Import all Kubernetes client auth plugins (e.g. Azure, GCP, OIDC, etc.)
 
 	"github.com/spf13/pflag"
@@ -0,0 +1,136 @@
+// Copyright The Shipwright Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"net/http"
+	"os"
+	"runtime"
+	"time"
+
+	"github.com/spf13/pflag"
+	corev1 "k8s.io/api/core/v1"
+	k8sruntime "k8s.io/apimachinery/pkg/runtime"
+	_ "k8s.io/client-go/plugin/pkg/client/auth"
+	"knative.dev/pkg/signals"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/config"
+
+	"github.com/shipwright-io/build/pkg/apis"
+	"github.com/shipwright-io/build/pkg/ctxlog"
+	"github.com/shipwright-io/build/pkg/trigger/webhook"
+	"github.com/shipwright-io/build/version"
+)
+
+var (
+	versionGiven  = flag.String("version", "devel", "Version of Shipwright trigger running")
+	listenAddress = flag.String("listen-address", ":8080", "Address the webhook receiver listens on")
+)
+
+func printVersion(ctx context.Context) {
+	ctxlog.Info(ctx, fmt.Sprintf("Shipwright Build Trigger Version: %s", version.Version))
+	ctxlog.Info(ctx, fmt.Sprintf("Go Version: %s", runtime.Version()))
+	ctxlog.Info(ctx, fmt.Sprintf("Go OS/Arch: %s/%s", runtime.GOOS, runtime.GOARCH))
+}
+
+func main() {
+	// Add the zap logger flag set to the CLI. The flag set must
+	// be added before calling pflag.Parse().
+	pflag.CommandLine.AddGoFlagSet(ctxlog.CustomZapFlagSet())
+
+	// Add flags registered by imported packages (e.g. glog and
+	// controller-runtime)
+	pflag.CommandLine.AddGoFlagSet(flag.CommandLine)
+
+	pflag.Parse()
+
+	if err := Execute(); err != nil {
+		os.Exit(1)
+	}
+}
+
+func Execute() error {
+	l := ctxlog.NewLogger("shp-build-trigger")
+
+	ctx := ctxlog.NewParentContext(l)
+
+	version.SetVersion(*versionGiven)
+	printVersion(ctx)
+
+	// Get a config to talk to the apiserver
+	cfg, err := config.GetConfig()
+	if err != nil {
+		ctxlog.Error(ctx, err, "failed to get the cluster configuration")
+		return err
+	}
+
+	scheme := k8sruntime.NewScheme()
+	if err := corev1.AddToScheme(scheme); err != nil {
+		ctxlog.Error(ctx, err, "failed to register core types")
+		return err
+	}
+
+	if err := apis.AddToScheme(scheme); err != nil {
+		ctxlog.Error(ctx, err, "failed to register Shipwright types")
+		return err
+	}
+
+	c, err := client.New(cfg, client.Options{Scheme: scheme})
+	if err != nil {
+		ctxlog.Error(ctx, err, "failed to create the cluster client")
+		return err
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/health", health)
+	ctxlog.Info(ctx, "adding handlefunc() /health")
+
+	// github endpoint handles push and pull_request events of GitHub webhooks
+	mux.Handle("/github", webhook.NewHandler(ctx, c, webhook.GitHub{}))
+	ctxlog.Info(ctx, "adding handle() /github")
+
+	// gitlab endpoint handles push, tag push and merge request events of GitLab webhooks
+	mux.Handle("/gitlab", webhook.NewHandler(ctx, c, webhook.GitLab{}))
+	ctxlog.Info(ctx, "adding handle() /gitlab")
+
+	// gitea endpoint handles push and pull_request events of Gitea webhooks
+	mux.Handle("/gitea", webhook.NewHandler(ctx, c, webhook.Gitea{}))
+	ctxlog.Info(ctx, "adding handle() /gitea")
+
+	// bitbucket endpoint handles repo:push and pull request events of Bitbucket Cloud webhooks
+	mux.Handle("/bitbucket", webhook.NewHandler(ctx, c, webhook.Bitbucket{}))
+	ctxlog.Info(ctx, "adding handle() /bitbucket")
+
+	server := &http.Server{
+		Addr:              *listenAddress,
+		Handler:           mux,
+		ReadHeaderTimeout: 32 * time.Second,
+	}
+
+	go func() {
+		ctxlog.Info(ctx, "starting trigger server", "address", *listenAddress)
+		// blocking call, returns on error
+		if err := server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			ctxlog.Error(ctx, err, "trigger server failed to start")
+		}
+	}()
+
+	stopCh := signals.SetupSignalHandler()
+	sig := <-stopCh
+
+	ctxlog.Info(ctx, "shutting down trigger server,", "signal:", sig)
+	if err := server.Shutdown(context.Background()); err != nil {
+		l.Error(err, "Failed to gracefully shutdown the server.")
+		return err
+	}
+	return nil
+}
+
+func health(resp http.ResponseWriter, _ *http.Request) {
+	resp.WriteHeader(http.StatusNoContent)
+}
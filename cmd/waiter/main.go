@@ -13,8 +13,15 @@ import (
 
 // settings composed by command-line flag values.
 type settings struct {
-	lockFile string        // path to lock file
-	timeout  time.Duration // how long wait for 'done'
+	lockFile        string        // path to lock file
+	timeout         time.Duration // how long wait for 'done'
+	uploadAddress   string        // address of the upload endpoint, disabled when empty
+	uploadTokenFile string        // path to the file holding the upload bearer token
+	uploadTarget    string        // directory the uploaded source code is extracted into
+	uploadMaxSize   int64         // maximum number of bytes of an upload, zero means no limit
+	maxTotalSize    int64         // maximum number of bytes of all extracted files, zero means no limit
+	maxFileCount    int64         // maximum number of extracted files, zero means no limit
+	maxFileSize     int64         // maximum number of bytes of a single extracted file, zero means no limit
 }
 
 const longDesc = `
@@ -38,6 +45,16 @@ Or, alternatively:
 
 	$ rm -f <lock-file>
 
+## Upload Endpoint
+
+Use --upload-address to serve an endpoint that accepts the source code as a tar
+or zstd stream. The upload is authenticated with the bearer token read from
+--upload-token-file, or from the WAITER_UPLOAD_TOKEN environment variable. After
+a successful upload, the source code is extracted into --upload-target and the
+waiting ends:
+
+	$ waiter start --upload-address :8080 --upload-token-file /etc/waiter/token
+
 ## Return-Code
 
 In the case of timeout, the waiter will return error, it only exits gracefully via
@@ -56,6 +73,12 @@ var defaultTimeout = 60 * time.Second
 // defaultLockFile default location of the lock-file.
 var defaultLockFile = "/tmp/waiter.lock"
 
+// defaultUploadTarget default directory the uploaded source code is extracted into.
+var defaultUploadTarget = "/workspace/source"
+
+// uploadTokenEnvVar environment variable holding the upload bearer token.
+const uploadTokenEnvVar = "WAITER_UPLOAD_TOKEN"
+
 // flagValues receives the command-line flag values.
 var flagValues = settings{}
 
@@ -66,6 +89,16 @@ func init() {
 	flags.StringVar(&flagValues.lockFile, "lock-file", defaultLockFile, "lock file full path")
 	flags.DurationVar(&flagValues.timeout, "timeout", defaultTimeout, "how long to wait until 'done'")
 
+	startFlags := startCmd.Flags()
+
+	startFlags.StringVar(&flagValues.uploadAddress, "upload-address", "", "address to serve the upload endpoint on, disabled when empty")
+	startFlags.StringVar(&flagValues.uploadTokenFile, "upload-token-file", "", "file holding the bearer token of the upload endpoint, defaults to the "+uploadTokenEnvVar+" environment variable")
+	startFlags.StringVar(&flagValues.uploadTarget, "upload-target", defaultUploadTarget, "directory the uploaded source code is extracted into")
+	startFlags.Int64Var(&flagValues.uploadMaxSize, "upload-max-size", 0, "maximum number of bytes of an upload, zero means no limit")
+	startFlags.Int64Var(&flagValues.maxTotalSize, "max-total-size", 0, "maximum number of bytes of all extracted files, zero means no limit")
+	startFlags.Int64Var(&flagValues.maxFileCount, "max-file-count", 0, "maximum number of extracted files, directories, and links, zero means no limit")
+	startFlags.Int64Var(&flagValues.maxFileSize, "max-file-size", 0, "maximum number of bytes of a single extracted file, zero means no limit")
+
 	rootCmd.AddCommand(startCmd)
 	rootCmd.AddCommand(doneCmd)
 }
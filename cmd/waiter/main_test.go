@@ -5,8 +5,12 @@ package main
 
 import (
 	"bytes"
+	"context"
+	"fmt"
+	"net"
 	"os"
 	"os/exec"
+	"path/filepath"
 	"time"
 
 	"github.com/onsi/gomega/gbytes"
@@ -15,6 +19,8 @@ import (
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+
+	"github.com/shipwright-io/build/pkg/upload"
 )
 
 // executable path to the waiter executable file.
@@ -126,6 +132,53 @@ var _ = Describe("Waiter", func() {
 			Eventually(startCh, defaultTimeout).Should(BeClosed())
 		})
 	})
+
+	Describe("expect to succeed when the source code is uploaded before timeout", func() {
+		var (
+			startCh = make(chan interface{})
+			address string
+			tempDir string
+		)
+
+		BeforeEach(func() {
+			var err error
+			tempDir, err = os.MkdirTemp("", "waiter")
+			Expect(err).ToNot(HaveOccurred())
+			DeferCleanup(os.RemoveAll, tempDir)
+
+			Expect(os.WriteFile(filepath.Join(tempDir, "token"), []byte("secret-token\n"), 0600)).To(Succeed())
+			Expect(os.MkdirAll(filepath.Join(tempDir, "source"), 0755)).To(Succeed())
+			Expect(os.WriteFile(filepath.Join(tempDir, "source", "main.go"), []byte("package main"), 0644)).To(Succeed())
+
+			// find a free port for the upload endpoint
+			listener, err := net.Listen("tcp", "127.0.0.1:0")
+			Expect(err).ToNot(HaveOccurred())
+			address = listener.Addr().String()
+			Expect(listener.Close()).To(Succeed())
+
+			cmd := exec.Command(executable, "start",
+				"--upload-address", address,
+				"--upload-token-file", filepath.Join(tempDir, "token"),
+				"--upload-target", filepath.Join(tempDir, "target"),
+			)
+			session, err := gexec.Start(cmd, GinkgoWriter, GinkgoWriter)
+			Expect(err).ToNot(HaveOccurred())
+
+			go inspectSession(session, startCh, gexec.Exit(0))
+		})
+
+		It("extracts the upload and stops", func() {
+			client := &upload.Client{Endpoint: fmt.Sprintf("http://%s", address), Token: "secret-token"}
+
+			Eventually(func() error {
+				_, err := client.UploadDirectory(context.Background(), filepath.Join(tempDir, "source"))
+				return err
+			}, defaultTimeout).Should(Succeed())
+
+			Eventually(startCh, defaultTimeout).Should(BeClosed())
+			Expect(filepath.Join(tempDir, "target", "main.go")).To(BeAnExistingFile())
+		})
+	})
 })
 
 var _ = AfterSuite(func() {
@@ -4,12 +4,19 @@
 package main
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"log"
+	"net"
+	"net/http"
 	"os"
 	"strconv"
+	"strings"
 	"time"
+
+	"github.com/shipwright-io/build/pkg/bundle"
+	"github.com/shipwright-io/build/pkg/upload"
 )
 
 // Waiter represents the actor that will wait for timeout, using a lock-file to keep it actively
@@ -62,6 +69,61 @@ func (w *Waiter) retry() error {
 
 }
 
+// token reads the bearer token of the upload endpoint from the token file, or
+// from the environment.
+func (w *Waiter) token() (string, error) {
+	if w.flagValues.uploadTokenFile == "" {
+		if token := os.Getenv(uploadTokenEnvVar); token != "" {
+			return token, nil
+		}
+		return "", fmt.Errorf("the upload endpoint requires a token, use --upload-token-file or %s", uploadTokenEnvVar)
+	}
+
+	data, err := os.ReadFile(w.flagValues.uploadTokenFile)
+	if err != nil {
+		return "", err
+	}
+
+	token := strings.TrimSpace(string(data))
+	if token == "" {
+		return "", fmt.Errorf("the upload token file '%s' is empty", w.flagValues.uploadTokenFile)
+	}
+	return token, nil
+}
+
+// serve starts the upload endpoint, a successful upload removes the lock-file.
+func (w *Waiter) serve() (*http.Server, error) {
+	token, err := w.token()
+	if err != nil {
+		return nil, err
+	}
+
+	listener, err := net.Listen("tcp", w.flagValues.uploadAddress)
+	if err != nil {
+		return nil, err
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle(upload.Path, upload.NewHandler(w.flagValues.uploadTarget, token, upload.HandlerOptions{
+		MaxSize: w.flagValues.uploadMaxSize,
+		Limits: bundle.UnpackOptions{
+			MaxTotalSize: w.flagValues.maxTotalSize,
+			MaxFileCount: w.flagValues.maxFileCount,
+			MaxFileSize:  w.flagValues.maxFileSize,
+		},
+	}, w.Done))
+
+	server := &http.Server{Handler: mux, ReadHeaderTimeout: 10 * time.Second}
+	go func() {
+		if err := server.Serve(listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			log.Printf("Upload endpoint failed: %v\n", err)
+		}
+	}()
+
+	log.Printf("Serving the upload endpoint on %s\n", listener.Addr())
+	return server, nil
+}
+
 // Wait wait for the lock-file to be removed, or timeout.
 func (w *Waiter) Wait() error {
 	pid := os.Getpid()
@@ -69,6 +131,21 @@ func (w *Waiter) Wait() error {
 		return err
 	}
 
+	if w.flagValues.uploadAddress != "" {
+		server, err := w.serve()
+		if err != nil {
+			_ = os.RemoveAll(w.flagValues.lockFile)
+			return err
+		}
+
+		// the shutdown waits for the response of an upload that released the lock
+		defer func() {
+			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+			defer cancel()
+			_ = server.Shutdown(ctx)
+		}()
+	}
+
 	// waiting for the lock-file removal...
 	err := w.retry()
 	if err != nil {
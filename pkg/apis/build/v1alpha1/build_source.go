@@ -5,6 +5,7 @@
 package v1alpha1
 
 import (
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -17,12 +18,23 @@ const LocalCopy BuildSourceType = "LocalCopy"
 
 // HTTP defines a (HTTP) remote artifact, which will be downloaded into the build POD, right before
 // the build process starts. Represents a remote dependency.
-//
-// NOTICE: HTTP artifact downloads are deprecated. This feature will be removed in a future release.
 const HTTP BuildSourceType = "HTTP"
 
-// BuildSource remote artifact definition, also known as "sources". Simple "name" and "url" pairs,
-// initially without "credentials" (authentication) support yet.
+// Git defines a Git repository, which is cloned into a subdirectory of the source directory that
+// is named after the source.
+const Git BuildSourceType = "Git"
+
+// Bundle defines a source bundle image, which is pulled into a subdirectory of the source directory
+// that is named after the source.
+const Bundle BuildSourceType = "Bundle"
+
+// DefaultSourceName is the name of the source that is placed in the source directory itself, all
+// other sources are placed in a subdirectory of the source directory that is named after them.
+const DefaultSourceName = "default"
+
+// BuildSource remote artifact definition, also known as "sources". The "name" and "url" pairs of
+// the HTTP type can be complemented with a checksum, authentication, and the extraction of archives.
+// Sources of the Git and Bundle types define the repository or image in "source".
 type BuildSource struct {
 	// Name instance entry.
 	Name string `json:"name"`
@@ -37,8 +49,60 @@ type BuildSource struct {
 	// +optional
 	Timeout *metav1.Duration `json:"timeout,omitempty"`
 
+	// UploadSecretRef references a Secret that contains the bearer token of
+	// the upload endpoint in the `token` key. If defined for a LocalCopy
+	// source, the waiter accepts the source code on the upload endpoint in
+	// addition to the lock file.
+	//
+	// +optional
+	UploadSecretRef *corev1.LocalObjectReference `json:"uploadSecretRef,omitempty"`
+
 	// URL remote artifact location.
 	//
 	// +optional
 	URL string `json:"url,omitempty"`
+
+	// SHA256 is the expected SHA-256 digest of the remote artifact as hex
+	// encoded string, the download fails if the digest does not match.
+	//
+	// +optional
+	SHA256 *string `json:"sha256,omitempty"`
+
+	// AuthSecretRef references a Secret that contains the value of the HTTP
+	// header to authenticate the download in the `header-value` key, and
+	// optionally the name of the header in the `header-name` key, which
+	// defaults to `Authorization`.
+	//
+	// +optional
+	AuthSecretRef *corev1.LocalObjectReference `json:"authSecretRef,omitempty"`
+
+	// Extract defines whether the remote artifact is a tar, gzip compressed
+	// tar, or zip archive that is extracted, otherwise it is stored as file.
+	//
+	// If not defined, it defaults to false.
+	//
+	// +optional
+	Extract *bool `json:"extract,omitempty"`
+
+	// StripComponents is the number of leading path elements that are
+	// removed from the file names of the archive when it is extracted.
+	//
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	StripComponents *int32 `json:"stripComponents,omitempty"`
+
+	// Retries is the number of times the download is retried when it fails
+	// due to network errors or server errors.
+	//
+	// If not defined, it defaults to 3.
+	//
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	Retries *int32 `json:"retries,omitempty"`
+
+	// Source holds the Git repository of a source of the Git type, or the
+	// bundle image of a source of the Bundle type.
+	//
+	// +optional
+	Source *Source `json:"source,omitempty"`
 }
@@ -70,6 +70,20 @@ const (
 	TriggerInvalidImage BuildReason = "TriggerInvalidImage"
 	// TriggerInvalidPipeline indicates the trigger type Pipeline is invalid
 	TriggerInvalidPipeline BuildReason = "TriggerInvalidPipeline"
+	// TriggerInvalidGitLabWebHook indicates the trigger type GitLab is invalid
+	TriggerInvalidGitLabWebHook BuildReason = "TriggerInvalidGitLabWebHook"
+	// TriggerInvalidGiteaWebHook indicates the trigger type Gitea is invalid
+	TriggerInvalidGiteaWebHook BuildReason = "TriggerInvalidGiteaWebHook"
+	// TriggerInvalidBitbucketWebHook indicates the trigger type Bitbucket is invalid
+	TriggerInvalidBitbucketWebHook BuildReason = "TriggerInvalidBitbucketWebHook"
+	// TriggerInvalidSchedule indicates the trigger type Schedule is invalid
+	TriggerInvalidSchedule BuildReason = "TriggerInvalidSchedule"
+	// SpecSourceGitOptionsInvalid indicates the clone options of the Git source are invalid
+	SpecSourceGitOptionsInvalid BuildReason = "SpecSourceGitOptionsInvalid"
+	// SpecSourceHTTPOptionsInvalid indicates the download options of a HTTP source are invalid
+	SpecSourceHTTPOptionsInvalid BuildReason = "SpecSourceHTTPOptionsInvalid"
+	// SpecSourcesInvalid indicates a named source in spec.sources is invalid
+	SpecSourcesInvalid BuildReason = "SpecSourcesInvalid"
 
 	// AllValidationsSucceeded indicates a Build was successfully validated
 	AllValidationsSucceeded = "all validations succeeded"
@@ -106,6 +120,10 @@ const (
 	// or has a value of 'true', the controller triggers the validation. A value of 'false' means the controller
 	// will bypass checking the remote repository.
 	AnnotationBuildVerifyRepository = BuildDomain + "/verify.repository"
+
+	// AnnotationPipelineRunTriggeredBuilds is an annotation key for Tekton PipelineRuns, it holds
+	// the comma-separated names of the Builds that a Pipeline trigger created a BuildRun for
+	AnnotationPipelineRunTriggeredBuilds = BuildDomain + "/triggered-builds"
 )
 
 // BuildSpec defines the desired state of Build
@@ -233,6 +251,87 @@ type Image struct {
 	//
 	// +optional
 	Labels map[string]string `json:"labels,omitempty"`
+
+	// AdditionalTags are pushed to the repository of the image, and point at
+	// the same digest. A tag can contain the placeholders $(buildrun-name),
+	// $(commit-sha), $(commit-sha-short), and $(timestamp).
+	//
+	// +optional
+	AdditionalTags []string `json:"additionalTags,omitempty"`
+
+	// Signing signs the digest of the image after it was pushed. The signature
+	// is stored next to the image in the format of cosign.
+	//
+	// +optional
+	Signing *ImageSigning `json:"signing,omitempty"`
+
+	// SBOM generates a software bill of materials of the packages in the image
+	// after it was pushed. The SBOM is attached to the image as an OCI
+	// referrer.
+	//
+	// +optional
+	SBOM *ImageSBOM `json:"sbom,omitempty"`
+
+	// Mirrors are additional destinations the image is copied to after it was
+	// pushed. The copies have the same digest as the image.
+	//
+	// +optional
+	Mirrors []ImageMirror `json:"mirrors,omitempty"`
+}
+
+// ImageMirror refers to a destination that the image is copied to
+type ImageMirror struct {
+	// Image is the reference of the copy of the image.
+	Image string `json:"image"`
+
+	// Insecure defines whether the registry of the mirror is not secure
+	//
+	// +optional
+	Insecure *bool `json:"insecure,omitempty"`
+
+	// Credentials references a Secret that contains credentials to push to
+	// the registry of the mirror.
+	//
+	// +optional
+	Credentials *corev1.LocalObjectReference `json:"credentials,omitempty"`
+}
+
+// ImageSigning references the private key to sign the image with. The Secret
+// contains the PEM encoded ECDSA, RSA, or Ed25519 private key in the cosign.key
+// entry. If the key is encrypted by cosign, for example because it was created
+// using cosign generate-key-pair k8s://<namespace>/<name>, the cosign.password
+// entry contains its password.
+type ImageSigning struct {
+	// SecretRef references a Secret that contains the private key.
+	SecretRef corev1.LocalObjectReference `json:"secretRef"`
+
+	// Provenance attaches an SLSA provenance attestation of the image that is
+	// signed using the same key. It records the sources, the strategy, and the
+	// parameters of the BuildRun.
+	//
+	// +optional
+	Provenance bool `json:"provenance,omitempty"`
+}
+
+// SBOMFormat is the document format of a software bill of materials
+type SBOMFormat string
+
+const (
+	// SBOMFormatSPDX is the SPDX 2.3 JSON format
+	SBOMFormatSPDX SBOMFormat = "spdx"
+
+	// SBOMFormatCycloneDX is the CycloneDX 1.5 JSON format
+	SBOMFormatCycloneDX SBOMFormat = "cyclonedx"
+)
+
+// ImageSBOM defines the software bill of materials of the image. It lists the
+// packages of the OS package databases, and of the lockfiles of common
+// languages in the image.
+type ImageSBOM struct {
+	// Format is the document format of the SBOM, either spdx or cyclonedx.
+	//
+	// +kubebuilder:validation:Enum=spdx;cyclonedx
+	Format SBOMFormat `json:"format"`
 }
 
 // BuildStatus defines the observed state of Build
@@ -250,6 +349,10 @@ type BuildStatus struct {
 	// The message of the registered Build, either an error or succeed message
 	// +optional
 	Message *string `json:"message,omitempty"`
+
+	// Trigger contains the observed state of the Build triggers
+	// +optional
+	Trigger *TriggerStatus `json:"trigger,omitempty"`
 }
 
 // +genclient
@@ -18,6 +18,31 @@ const (
 
 	// LabelBuildRunGeneration is a label key for BuildRuns to define the generation
 	LabelBuildRunGeneration = BuildRunDomain + "/generation"
+
+	// AnnotationBuildRunTriggerName is an annotation key for BuildRuns created by a Build trigger, it
+	// holds the name of the `.spec.trigger.when[]` entry that matched
+	AnnotationBuildRunTriggerName = BuildRunDomain + "/trigger.name"
+
+	// AnnotationBuildRunTriggerType is an annotation key for BuildRuns created by a Build trigger, it
+	// holds the type of the `.spec.trigger.when[]` entry that matched
+	AnnotationBuildRunTriggerType = BuildRunDomain + "/trigger.type"
+
+	// AnnotationBuildRunTriggerEvent is an annotation key for BuildRuns created by a Build trigger, it
+	// holds the name of the event that caused the BuildRun to be created
+	AnnotationBuildRunTriggerEvent = BuildRunDomain + "/trigger.event"
+
+	// AnnotationBuildRunCommitStatus is an annotation key for BuildRuns created by a Git trigger, it
+	// holds the last commit status state that was reported to the Git provider
+	AnnotationBuildRunCommitStatus = BuildRunDomain + "/commit-status"
+
+	// LabelBuildRunTriggerPipelineRun is a label key for BuildRuns created by a Pipeline trigger, it
+	// holds a hash of the name of the Tekton PipelineRun that caused the BuildRun to be created,
+	// as the name can be longer than a label value
+	LabelBuildRunTriggerPipelineRun = BuildRunDomain + "/trigger.pipelinerun"
+
+	// AnnotationBuildRunTriggerPipelineRun is an annotation key for BuildRuns created by a Pipeline
+	// trigger, it holds the name of the Tekton PipelineRun that caused the BuildRun to be created
+	AnnotationBuildRunTriggerPipelineRun = BuildRunDomain + "/trigger.pipelinerun"
 )
 
 // BuildRunSpec defines the desired state of BuildRun
@@ -38,6 +63,12 @@ type BuildRunSpec struct {
 	// +optional
 	Sources []BuildSource `json:"sources,omitempty"`
 
+	// Revision overrides the Git revision (e.g., branch, tag, commit SHA, etc.)
+	// defined in the referenced Build source for this BuildRun only.
+	//
+	// +optional
+	Revision *string `json:"revision,omitempty"`
+
 	// ServiceAccount refers to the kubernetes serviceaccount
 	// which is used for resource control.
 	// Default serviceaccount will be set if it is empty
@@ -117,6 +148,18 @@ type SourceResult struct {
 	//
 	// +optional
 	Bundle *BundleSourceResult `json:"bundle,omitempty"`
+
+	// HTTP holds the results emitted from the
+	// source step of type HTTP
+	//
+	// +optional
+	HTTP *HTTPSourceResult `json:"http,omitempty"`
+}
+
+// HTTPSourceResult holds the results emitted from the HTTP source
+type HTTPSourceResult struct {
+	// Digest holds the SHA-256 digest of the downloaded remote artifact
+	Digest string `json:"digest,omitempty"`
 }
 
 // BundleSourceResult holds the results emitted from the bundle source
@@ -136,6 +179,57 @@ type GitSourceResult struct {
 	// BranchName holds the default branch name of the git source
 	// this will be set only when revision is not specified in Build object
 	BranchName string `json:"branchName,omitempty"`
+
+	// Shallow indicates that the clone does not contain the full history of
+	// the git source, it will be set only when the depth is specified in the
+	// Build object
+	//
+	// +optional
+	Shallow bool `json:"shallow,omitempty"`
+
+	// CommitTimestamp holds the committer timestamp of the commit of the
+	// git source
+	//
+	// +optional
+	CommitTimestamp *metav1.Time `json:"commitTimestamp,omitempty"`
+
+	// CommitSubject holds the first line of the commit message of the git
+	// source
+	//
+	// +optional
+	CommitSubject string `json:"commitSubject,omitempty"`
+
+	// Tags holds the tags that point at the commit of the git source
+	//
+	// +optional
+	Tags []string `json:"tags,omitempty"`
+
+	// Describe holds the output of `git describe --tags --always` for the
+	// commit of the git source, it falls back to the abbreviated commit sha
+	// when no tags are fetched
+	//
+	// +optional
+	Describe string `json:"describe,omitempty"`
+
+	// MergeTargetCommitSha holds the commit sha of the branch the revision
+	// was merged into, it will be set only when a merge target is specified
+	// in Build object
+	//
+	// +optional
+	MergeTargetCommitSha string `json:"mergeTargetCommitSha,omitempty"`
+
+	// Signer holds the identity of the trusted key that signed the commit,
+	// or the tag it was resolved from, of the git source, it will be set
+	// only when the signature verification is specified in Build object
+	//
+	// +optional
+	Signer string `json:"signer,omitempty"`
+
+	// SignerKey holds the fingerprint of the trusted key that signed the
+	// commit, or the tag it was resolved from, of the git source
+	//
+	// +optional
+	SignerKey string `json:"signerKey,omitempty"`
 }
 
 // Output holds the results emitted from the output step (build-and-push)
@@ -145,6 +239,37 @@ type Output struct {
 
 	// Size holds the compressed size of output image
 	Size int64 `json:"size,omitempty"`
+
+	// SignatureDigest holds the digest of the signature of the output image,
+	// if the image is signed
+	SignatureDigest string `json:"signatureDigest,omitempty"`
+
+	// SBOMDigest holds the digest of the software bill of materials that is
+	// attached to the output image, if an SBOM is generated
+	SBOMDigest string `json:"sbomDigest,omitempty"`
+
+	// ProvenanceDigest holds the digest of the signed SLSA provenance
+	// attestation of the output image, if provenance is requested
+	ProvenanceDigest string `json:"provenanceDigest,omitempty"`
+
+	// Tags holds the tags that were pushed for the output image
+	Tags []string `json:"tags,omitempty"`
+
+	// Mirrors holds the results of copying the output image to its mirrors
+	Mirrors []MirrorResult `json:"mirrors,omitempty"`
+}
+
+// MirrorResult holds the result of copying the output image to a mirror
+type MirrorResult struct {
+	// Image is the reference of the mirror
+	Image string `json:"image"`
+
+	// Digest holds the digest of the copy of the output image
+	Digest string `json:"digest,omitempty"`
+
+	// Error holds the reason why the output image could not be copied to the
+	// mirror
+	Error string `json:"error,omitempty"`
 }
 
 // BuildRunStatus defines the observed state of BuildRun
@@ -6,6 +6,7 @@ package v1alpha1
 
 import (
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 )
 
 // PruneOption defines the supported options for image pruning
@@ -32,6 +33,20 @@ type BundleContainer struct {
 	//
 	// +optional
 	Prune *PruneOption `json:"prune,omitempty"`
+
+	// VerifySignature requires the bundle image to be signed by one of the
+	// trusted public keys, otherwise the bundle is not unpacked.
+	//
+	// +optional
+	VerifySignature *BundleSignatureVerification `json:"verifySignature,omitempty"`
+}
+
+// BundleSignatureVerification references the public keys that are trusted to
+// sign the bundle image. Each entry of the Secret is a PEM encoded ECDSA, RSA,
+// or Ed25519 public key.
+type BundleSignatureVerification struct {
+	// SecretRef references a Secret that contains the trusted public keys.
+	SecretRef corev1.LocalObjectReference `json:"secretRef"`
 }
 
 // Source describes the Git source repository to fetch.
@@ -47,7 +62,7 @@ type Source struct {
 	BundleContainer *BundleContainer `json:"bundleContainer,omitempty"`
 
 	// Revision describes the Git revision (e.g., branch, tag, commit SHA,
-	// etc.) to fetch.
+	// or a reference like `refs/pull/123/head`) to fetch.
 	//
 	// If not defined, it will fallback to the repository's default branch.
 	//
@@ -64,6 +79,128 @@ type Source struct {
 	//
 	// +optional
 	Credentials *corev1.LocalObjectReference `json:"credentials,omitempty"`
+
+	// MergeTarget is the branch into which the revision is merged before the
+	// build, for example the target branch of a pull request. The full
+	// history of the revision and the branch is fetched to merge them.
+	//
+	// +optional
+	MergeTarget *string `json:"mergeTarget,omitempty"`
+
+	// SparseCheckout restricts the checkout of the Git repository to a set of
+	// directories, which reduces the size of the workspace for large repositories.
+	//
+	// +optional
+	SparseCheckout *SparseCheckout `json:"sparseCheckout,omitempty"`
+
+	// CloneFilter is the partial clone filter used to clone the Git repository,
+	// for example `blob:none`. Objects that are filtered out are only fetched
+	// when they are needed for the checkout.
+	//
+	// +optional
+	CloneFilter *string `json:"cloneFilter,omitempty"`
+
+	// Depth is the number of commits to fetch from the history of the Git
+	// repository. Use 0 to fetch the full history, which is required by tools
+	// like `git describe`.
+	//
+	// If not defined, it defaults to 1.
+	//
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	Depth *int32 `json:"depth,omitempty"`
+
+	// FetchTags defines whether the tags of the Git repository are fetched.
+	//
+	// If not defined, it defaults to false.
+	//
+	// +optional
+	FetchTags *bool `json:"fetchTags,omitempty"`
+
+	// LFS configures the download of Git Large File Storage (LFS) files.
+	//
+	// +optional
+	LFS *GitLFS `json:"lfs,omitempty"`
+
+	// VerifySignature requires the checked out commit, or the tag that the
+	// revision resolves from, to be signed by one of the trusted GPG or SSH
+	// keys, otherwise the clone fails.
+	//
+	// +optional
+	VerifySignature *GitSignatureVerification `json:"verifySignature,omitempty"`
+
+	// Cache configures a volume with bare mirrors of Git repositories that
+	// are updated and cloned from instead of cloning the repository over the
+	// network for every BuildRun.
+	//
+	// +optional
+	Cache *GitCache `json:"cache,omitempty"`
+}
+
+// GitLFS describes which Git Large File Storage (LFS) files to download
+type GitLFS struct {
+	// Enabled defines whether LFS files are downloaded, otherwise the LFS
+	// pointer files are checked out.
+	//
+	// If not defined, it defaults to true.
+	//
+	// +optional
+	Enabled *bool `json:"enabled,omitempty"`
+
+	// Include are the patterns of the LFS files to download. If not defined,
+	// all LFS files are downloaded.
+	//
+	// +optional
+	Include []string `json:"include,omitempty"`
+
+	// Exclude are the patterns of the LFS files not to download.
+	//
+	// +optional
+	Exclude []string `json:"exclude,omitempty"`
+}
+
+// GitSignatureVerification references the keys that are trusted to sign the
+// commits and tags of the Git repository. Each entry of the Secret or ConfigMap
+// is either an ASCII armored GPG public key, or SSH public keys in the format
+// of an allowed signers or authorized keys file.
+type GitSignatureVerification struct {
+	// SecretRef references a Secret that contains the trusted keys.
+	//
+	// +optional
+	SecretRef *corev1.LocalObjectReference `json:"secretRef,omitempty"`
+
+	// ConfigMapRef references a ConfigMap that contains the trusted keys.
+	//
+	// +optional
+	ConfigMapRef *corev1.LocalObjectReference `json:"configMapRef,omitempty"`
+}
+
+// GitCache references the PersistentVolumeClaim that caches the Git
+// repositories. The volume can be shared by several Builds, concurrent
+// BuildRuns lock the mirror of a repository while it is updated.
+type GitCache struct {
+	// PersistentVolumeClaimRef references the PersistentVolumeClaim that
+	// holds the mirrors.
+	PersistentVolumeClaimRef corev1.LocalObjectReference `json:"persistentVolumeClaimRef"`
+
+	// MaxSize is the maximum size of the mirrors in the volume, the least
+	// recently used mirrors are evicted once it is exceeded.
+	//
+	// If not defined, the size is not limited.
+	//
+	// +optional
+	MaxSize *resource.Quantity `json:"maxSize,omitempty"`
+}
+
+// SparseCheckout describes the directories of the Git repository to check out
+type SparseCheckout struct {
+	// Paths are the directories to check out, relative to the repository root.
+	// Files in the repository root are always checked out.
+	//
+	// If not defined, it defaults to the context directory.
+	//
+	// +optional
+	Paths []string `json:"paths,omitempty"`
 }
 
 // IsLocalCopyType tells if we have an entry of the type local
@@ -75,3 +212,14 @@ func IsLocalCopyType(sources []BuildSource) (int, bool) {
 	}
 	return -1, false
 }
+
+// IsDefaultHTTPType tells if we have an entry of the type HTTP that is the
+// default source of the Build
+func IsDefaultHTTPType(sources []BuildSource) (int, bool) {
+	for i, bs := range sources {
+		if bs.Type == HTTP && bs.Name == DefaultSourceName {
+			return i, true
+		}
+	}
+	return -1, false
+}
@@ -16,4 +16,27 @@ type Trigger struct {
 	//
 	// +optional
 	SecretRef *corev1.LocalObjectReference `json:"secretRef,omitempty"`
+
+	// CommitStatus configures reporting the state of BuildRuns triggered by
+	// GitHub or GitLab events as commit status to the Git provider.
+	//
+	// +optional
+	CommitStatus *CommitStatus `json:"commitStatus,omitempty"`
+}
+
+// CommitStatus configures the commit status reporting of triggered BuildRuns.
+type CommitStatus struct {
+	// SecretRef points to a local object carrying the Git provider API token in the `token` key.
+	SecretRef corev1.LocalObjectReference `json:"secretRef"`
+
+	// APIURL the base URL of the Git provider API. Defaults to the API of the
+	// repository host, for example `https://api.github.com` for `github.com`.
+	//
+	// +optional
+	APIURL *string `json:"apiURL,omitempty"`
+
+	// Context the name of the commit status. Defaults to `shipwright/<build name>`.
+	//
+	// +optional
+	Context *string `json:"context,omitempty"`
 }
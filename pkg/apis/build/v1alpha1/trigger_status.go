@@ -0,0 +1,82 @@
+// Copyright The Shipwright Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// TriggerStatus describes the observed state of the Build triggers.
+type TriggerStatus struct {
+	// Images the last observed state of the images watched by Image triggers.
+	//
+	// +optional
+	Images []ImageTriggerStatus `json:"images,omitempty"`
+
+	// Schedules the state of the Schedule triggers.
+	//
+	// +optional
+	Schedules []ScheduleTriggerStatus `json:"schedules,omitempty"`
+}
+
+// ImageTriggerStatus describes the last observed state of a watched image.
+type ImageTriggerStatus struct {
+	// Name the image name as listed in the trigger condition.
+	Name string `json:"name"`
+
+	// Digest the last observed digest of the image.
+	Digest string `json:"digest"`
+
+	// LastChangeTime the time the digest change was observed.
+	//
+	// +optional
+	LastChangeTime *metav1.Time `json:"lastChangeTime,omitempty"`
+}
+
+// ScheduleTriggerStatus describes the state of a Schedule trigger.
+type ScheduleTriggerStatus struct {
+	// Name the name of the trigger condition.
+	Name string `json:"name"`
+
+	// LastScheduleTime the last time a BuildRun was created by the schedule.
+	//
+	// +optional
+	LastScheduleTime *metav1.Time `json:"lastScheduleTime,omitempty"`
+
+	// NextScheduleTime the next time the schedule is due.
+	//
+	// +optional
+	NextScheduleTime *metav1.Time `json:"nextScheduleTime,omitempty"`
+}
+
+// GetImage returns the status of the given image, or nil if it was not observed yet.
+func (s *TriggerStatus) GetImage(name string) *ImageTriggerStatus {
+	if s == nil {
+		return nil
+	}
+
+	for i := range s.Images {
+		if s.Images[i].Name == name {
+			return &s.Images[i]
+		}
+	}
+
+	return nil
+}
+
+// GetSchedule returns the status of the given Schedule trigger, or nil if it was not scheduled yet.
+func (s *TriggerStatus) GetSchedule(name string) *ScheduleTriggerStatus {
+	if s == nil {
+		return nil
+	}
+
+	for i := range s.Schedules {
+		if s.Schedules[i].Name == name {
+			return &s.Schedules[i]
+		}
+	}
+
+	return nil
+}
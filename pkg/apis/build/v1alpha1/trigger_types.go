@@ -3,6 +3,10 @@
 // SPDX-License-Identifier: Apache-2.0
 package v1alpha1
 
+import (
+	corev1 "k8s.io/api/core/v1"
+)
+
 // TriggerType set of TriggerWhen valid names.
 type TriggerType string
 
@@ -15,6 +19,31 @@ const (
 
 	// PipelineTrigger Tekton Pipeline trigger type name.
 	PipelineTrigger TriggerType = "Pipeline"
+
+	// GitLabWebHookTrigger GitLab webhook trigger type name.
+	GitLabWebHookTrigger TriggerType = "GitLab"
+
+	// GiteaWebHookTrigger Gitea webhook trigger type name.
+	GiteaWebHookTrigger TriggerType = "Gitea"
+
+	// BitbucketWebHookTrigger Bitbucket webhook trigger type name.
+	BitbucketWebHookTrigger TriggerType = "Bitbucket"
+
+	// ScheduleTrigger Schedule (cron) trigger type name.
+	ScheduleTrigger TriggerType = "Schedule"
+)
+
+// ScheduleConcurrencyPolicy set of WhenSchedule valid concurrency policies.
+type ScheduleConcurrencyPolicy string
+
+const (
+	// SkipConcurrencyPolicy does not create a BuildRun while a BuildRun created
+	// by the same schedule is still running.
+	SkipConcurrencyPolicy ScheduleConcurrencyPolicy = "Skip"
+
+	// ReplaceConcurrencyPolicy cancels the BuildRuns created by the same schedule
+	// that are still running before it creates a new BuildRun.
+	ReplaceConcurrencyPolicy ScheduleConcurrencyPolicy = "Replace"
 )
 
 // GitHubEventName set of WhenGitHub valid event names.
@@ -28,12 +57,60 @@ const (
 	GitHubPushEvent GitHubEventName = "Push"
 )
 
+// GitLabEventName set of WhenGitLab valid event names.
+type GitLabEventName string
+
+const (
+	// GitLabPushEvent gitlab push event name.
+	GitLabPushEvent GitLabEventName = "Push"
+
+	// GitLabMergeRequestEvent gitlab merge-request event name.
+	GitLabMergeRequestEvent GitLabEventName = "MergeRequest"
+
+	// GitLabTagEvent gitlab tag push event name.
+	GitLabTagEvent GitLabEventName = "Tag"
+)
+
+// GiteaEventName set of WhenGitea valid event names.
+type GiteaEventName string
+
+const (
+	// GiteaPushEvent gitea push event name.
+	GiteaPushEvent GiteaEventName = "Push"
+
+	// GiteaPullRequestEvent gitea pull-request event name.
+	GiteaPullRequestEvent GiteaEventName = "PullRequest"
+
+	// GiteaTagEvent gitea tag push event name.
+	GiteaTagEvent GiteaEventName = "Tag"
+)
+
+// BitbucketEventName set of WhenBitbucket valid event names.
+type BitbucketEventName string
+
+const (
+	// BitbucketPushEvent bitbucket push event name.
+	BitbucketPushEvent BitbucketEventName = "Push"
+
+	// BitbucketPullRequestEvent bitbucket pull-request event name.
+	BitbucketPullRequestEvent BitbucketEventName = "PullRequest"
+
+	// BitbucketTagEvent bitbucket tag push event name.
+	BitbucketTagEvent BitbucketEventName = "Tag"
+)
+
 // WhenImage attributes to match Image events.
 type WhenImage struct {
 	// Names fully qualified image names.
 	//
 	// +optional
 	Names []string `json:"names,omitempty"`
+
+	// SecretRef points to a local secret of type kubernetes.io/dockerconfigjson
+	// carrying the credentials to resolve the images.
+	//
+	// +optional
+	SecretRef *corev1.LocalObjectReference `json:"secretRef,omitempty"`
 }
 
 // WhenGitHub attributes to match GitHub events.
@@ -47,6 +124,102 @@ type WhenGitHub struct {
 	//
 	// +optional
 	Branches []string `json:"branches,omitempty"`
+
+	// Paths filters the events by the files they change.
+	//
+	// +optional
+	Paths *WhenPaths `json:"paths,omitempty"`
+}
+
+// WhenGitLab attributes to match GitLab events.
+type WhenGitLab struct {
+	// Events GitLab event names.
+	//
+	// +kubebuilder:validation:MinItems=1
+	Events []GitLabEventName `json:"events,omitempty"`
+
+	// Branches slice of branch names where the event applies.
+	//
+	// +optional
+	Branches []string `json:"branches,omitempty"`
+
+	// Paths filters the events by the files they change.
+	//
+	// +optional
+	Paths *WhenPaths `json:"paths,omitempty"`
+}
+
+// WhenGitea attributes to match Gitea events.
+type WhenGitea struct {
+	// Events Gitea event names.
+	//
+	// +kubebuilder:validation:MinItems=1
+	Events []GiteaEventName `json:"events,omitempty"`
+
+	// Branches slice of branch names where the event applies.
+	//
+	// +optional
+	Branches []string `json:"branches,omitempty"`
+
+	// Paths filters the events by the files they change.
+	//
+	// +optional
+	Paths *WhenPaths `json:"paths,omitempty"`
+}
+
+// WhenBitbucket attributes to match Bitbucket events.
+type WhenBitbucket struct {
+	// Events Bitbucket event names.
+	//
+	// +kubebuilder:validation:MinItems=1
+	Events []BitbucketEventName `json:"events,omitempty"`
+
+	// Branches slice of branch names where the event applies.
+	//
+	// +optional
+	Branches []string `json:"branches,omitempty"`
+
+	// Paths filters the events by the files they change.
+	//
+	// +optional
+	Paths *WhenPaths `json:"paths,omitempty"`
+}
+
+// WhenPaths attributes to filter Git events by the files they change. The globs
+// are matched against the file paths relative to the repository root, `**` matches
+// any number of directories, and a glob matching a directory matches all files in it.
+type WhenPaths struct {
+	// Include globs of the files that trigger a build when changed. When the source
+	// defines a context directory, changes in it always trigger a build.
+	//
+	// +optional
+	Include []string `json:"include,omitempty"`
+
+	// Exclude globs of the files that never trigger a build, even when included.
+	//
+	// +optional
+	Exclude []string `json:"exclude,omitempty"`
+}
+
+// WhenSchedule attributes to trigger builds periodically.
+type WhenSchedule struct {
+	// Cron the schedule in the cron format, for example `0 2 * * *`. Besides the
+	// five fields for minute, hour, day of month, month, and day of week, the
+	// descriptors `@yearly`, `@monthly`, `@weekly`, `@daily`, and `@hourly` are supported.
+	Cron string `json:"cron"`
+
+	// TimeZone the name of the time zone the schedule is evaluated in, for example
+	// `Europe/Berlin`. Defaults to UTC.
+	//
+	// +optional
+	TimeZone *string `json:"timeZone,omitempty"`
+
+	// ConcurrencyPolicy how to treat a scheduled BuildRun while the previous one
+	// is still running. Defaults to Skip.
+	//
+	// +optional
+	// +kubebuilder:validation:Enum=Skip;Replace
+	ConcurrencyPolicy ScheduleConcurrencyPolicy `json:"concurrencyPolicy,omitempty"`
 }
 
 // WhenObjectRef attributes to reference local Kubernetes objects.
@@ -16,11 +16,31 @@ type TriggerWhen struct {
 	// +optional
 	GitHub *WhenGitHub `json:"github,omitempty"`
 
+	// GitLab describes how to trigger builds based on GitLab (SCM) events.
+	//
+	// +optional
+	GitLab *WhenGitLab `json:"gitlab,omitempty"`
+
+	// Gitea describes how to trigger builds based on Gitea (SCM) events.
+	//
+	// +optional
+	Gitea *WhenGitea `json:"gitea,omitempty"`
+
+	// Bitbucket describes how to trigger builds based on Bitbucket (SCM) events.
+	//
+	// +optional
+	Bitbucket *WhenBitbucket `json:"bitbucket,omitempty"`
+
 	// Image slice of image names where the event applies.
 	//
 	// +optional
 	Image *WhenImage `json:"image,omitempty"`
 
+	// Schedule describes when to trigger builds periodically.
+	//
+	// +optional
+	Schedule *WhenSchedule `json:"schedule,omitempty"`
+
 	// ObjectRef describes how to match a foreign resource, either using the name or the label
 	// selector, plus the current resource status.
 	//
@@ -36,6 +56,48 @@ func (w *TriggerWhen) GetBranches(whenType TriggerType) []string {
 			return nil
 		}
 		return w.GitHub.Branches
+	case GitLabWebHookTrigger:
+		if w.GitLab == nil {
+			return nil
+		}
+		return w.GitLab.Branches
+	case GiteaWebHookTrigger:
+		if w.Gitea == nil {
+			return nil
+		}
+		return w.Gitea.Branches
+	case BitbucketWebHookTrigger:
+		if w.Bitbucket == nil {
+			return nil
+		}
+		return w.Bitbucket.Branches
+	}
+	return nil
+}
+
+// GetPaths return the changed-path filters based on the WhenTypeName informed.
+func (w *TriggerWhen) GetPaths(whenType TriggerType) *WhenPaths {
+	switch whenType {
+	case GitHubWebHookTrigger:
+		if w.GitHub == nil {
+			return nil
+		}
+		return w.GitHub.Paths
+	case GitLabWebHookTrigger:
+		if w.GitLab == nil {
+			return nil
+		}
+		return w.GitLab.Paths
+	case GiteaWebHookTrigger:
+		if w.Gitea == nil {
+			return nil
+		}
+		return w.Gitea.Paths
+	case BitbucketWebHookTrigger:
+		if w.Bitbucket == nil {
+			return nil
+		}
+		return w.Bitbucket.Paths
 	}
 	return nil
 }
@@ -240,6 +240,11 @@ func (in *BuildRunSpec) DeepCopyInto(out *BuildRunSpec) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Revision != nil {
+		in, out := &in.Revision, &out.Revision
+		*out = new(string)
+		**out = **in
+	}
 	if in.ServiceAccount != nil {
 		in, out := &in.ServiceAccount, &out.ServiceAccount
 		*out = new(ServiceAccount)
@@ -312,7 +317,7 @@ func (in *BuildRunStatus) DeepCopyInto(out *BuildRunStatus) {
 	if in.Output != nil {
 		in, out := &in.Output, &out.Output
 		*out = new(Output)
-		**out = **in
+		(*in).DeepCopyInto(*out)
 	}
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
@@ -370,6 +375,41 @@ func (in *BuildSource) DeepCopyInto(out *BuildSource) {
 		*out = new(v1.Duration)
 		**out = **in
 	}
+	if in.UploadSecretRef != nil {
+		in, out := &in.UploadSecretRef, &out.UploadSecretRef
+		*out = new(corev1.LocalObjectReference)
+		**out = **in
+	}
+	if in.SHA256 != nil {
+		in, out := &in.SHA256, &out.SHA256
+		*out = new(string)
+		**out = **in
+	}
+	if in.AuthSecretRef != nil {
+		in, out := &in.AuthSecretRef, &out.AuthSecretRef
+		*out = new(corev1.LocalObjectReference)
+		**out = **in
+	}
+	if in.Extract != nil {
+		in, out := &in.Extract, &out.Extract
+		*out = new(bool)
+		**out = **in
+	}
+	if in.StripComponents != nil {
+		in, out := &in.StripComponents, &out.StripComponents
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Retries != nil {
+		in, out := &in.Retries, &out.Retries
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Source != nil {
+		in, out := &in.Source, &out.Source
+		*out = new(Source)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -473,6 +513,11 @@ func (in *BuildStatus) DeepCopyInto(out *BuildStatus) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.Trigger != nil {
+		in, out := &in.Trigger, &out.Trigger
+		*out = new(TriggerStatus)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -695,6 +740,11 @@ func (in *BundleContainer) DeepCopyInto(out *BundleContainer) {
 		*out = new(PruneOption)
 		**out = **in
 	}
+	if in.VerifySignature != nil {
+		in, out := &in.VerifySignature, &out.VerifySignature
+		*out = new(BundleSignatureVerification)
+		**out = **in
+	}
 	return
 }
 
@@ -708,6 +758,23 @@ func (in *BundleContainer) DeepCopy() *BundleContainer {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BundleSignatureVerification) DeepCopyInto(out *BundleSignatureVerification) {
+	*out = *in
+	out.SecretRef = in.SecretRef
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BundleSignatureVerification.
+func (in *BundleSignatureVerification) DeepCopy() *BundleSignatureVerification {
+	if in == nil {
+		return nil
+	}
+	out := new(BundleSignatureVerification)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *BundleSourceResult) DeepCopyInto(out *BundleSourceResult) {
 	*out = *in
@@ -785,6 +852,33 @@ func (in *ClusterBuildStrategyList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CommitStatus) DeepCopyInto(out *CommitStatus) {
+	*out = *in
+	out.SecretRef = in.SecretRef
+	if in.APIURL != nil {
+		in, out := &in.APIURL, &out.APIURL
+		*out = new(string)
+		**out = **in
+	}
+	if in.Context != nil {
+		in, out := &in.Context, &out.Context
+		*out = new(string)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CommitStatus.
+func (in *CommitStatus) DeepCopy() *CommitStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(CommitStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Condition) DeepCopyInto(out *Condition) {
 	*out = *in
@@ -861,9 +955,97 @@ func (in *FailureDetails) DeepCopy() *FailureDetails {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GitCache) DeepCopyInto(out *GitCache) {
+	*out = *in
+	out.PersistentVolumeClaimRef = in.PersistentVolumeClaimRef
+	if in.MaxSize != nil {
+		in, out := &in.MaxSize, &out.MaxSize
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GitCache.
+func (in *GitCache) DeepCopy() *GitCache {
+	if in == nil {
+		return nil
+	}
+	out := new(GitCache)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GitLFS) DeepCopyInto(out *GitLFS) {
+	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Include != nil {
+		in, out := &in.Include, &out.Include
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Exclude != nil {
+		in, out := &in.Exclude, &out.Exclude
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GitLFS.
+func (in *GitLFS) DeepCopy() *GitLFS {
+	if in == nil {
+		return nil
+	}
+	out := new(GitLFS)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GitSignatureVerification) DeepCopyInto(out *GitSignatureVerification) {
+	*out = *in
+	if in.SecretRef != nil {
+		in, out := &in.SecretRef, &out.SecretRef
+		*out = new(corev1.LocalObjectReference)
+		**out = **in
+	}
+	if in.ConfigMapRef != nil {
+		in, out := &in.ConfigMapRef, &out.ConfigMapRef
+		*out = new(corev1.LocalObjectReference)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GitSignatureVerification.
+func (in *GitSignatureVerification) DeepCopy() *GitSignatureVerification {
+	if in == nil {
+		return nil
+	}
+	out := new(GitSignatureVerification)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *GitSourceResult) DeepCopyInto(out *GitSourceResult) {
 	*out = *in
+	if in.CommitTimestamp != nil {
+		in, out := &in.CommitTimestamp, &out.CommitTimestamp
+		*out = (*in).DeepCopy()
+	}
+	if in.Tags != nil {
+		in, out := &in.Tags, &out.Tags
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -877,6 +1059,22 @@ func (in *GitSourceResult) DeepCopy() *GitSourceResult {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HTTPSourceResult) DeepCopyInto(out *HTTPSourceResult) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HTTPSourceResult.
+func (in *HTTPSourceResult) DeepCopy() *HTTPSourceResult {
+	if in == nil {
+		return nil
+	}
+	out := new(HTTPSourceResult)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Image) DeepCopyInto(out *Image) {
 	*out = *in
@@ -904,6 +1102,28 @@ func (in *Image) DeepCopyInto(out *Image) {
 			(*out)[key] = val
 		}
 	}
+	if in.AdditionalTags != nil {
+		in, out := &in.AdditionalTags, &out.AdditionalTags
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Signing != nil {
+		in, out := &in.Signing, &out.Signing
+		*out = new(ImageSigning)
+		**out = **in
+	}
+	if in.SBOM != nil {
+		in, out := &in.SBOM, &out.SBOM
+		*out = new(ImageSBOM)
+		**out = **in
+	}
+	if in.Mirrors != nil {
+		in, out := &in.Mirrors, &out.Mirrors
+		*out = make([]ImageMirror, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	return
 }
 
@@ -917,6 +1137,101 @@ func (in *Image) DeepCopy() *Image {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ImageMirror) DeepCopyInto(out *ImageMirror) {
+	*out = *in
+	if in.Insecure != nil {
+		in, out := &in.Insecure, &out.Insecure
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Credentials != nil {
+		in, out := &in.Credentials, &out.Credentials
+		*out = new(corev1.LocalObjectReference)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ImageMirror.
+func (in *ImageMirror) DeepCopy() *ImageMirror {
+	if in == nil {
+		return nil
+	}
+	out := new(ImageMirror)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ImageSBOM) DeepCopyInto(out *ImageSBOM) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ImageSBOM.
+func (in *ImageSBOM) DeepCopy() *ImageSBOM {
+	if in == nil {
+		return nil
+	}
+	out := new(ImageSBOM)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ImageSigning) DeepCopyInto(out *ImageSigning) {
+	*out = *in
+	out.SecretRef = in.SecretRef
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ImageSigning.
+func (in *ImageSigning) DeepCopy() *ImageSigning {
+	if in == nil {
+		return nil
+	}
+	out := new(ImageSigning)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ImageTriggerStatus) DeepCopyInto(out *ImageTriggerStatus) {
+	*out = *in
+	if in.LastChangeTime != nil {
+		in, out := &in.LastChangeTime, &out.LastChangeTime
+		*out = (*in).DeepCopy()
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ImageTriggerStatus.
+func (in *ImageTriggerStatus) DeepCopy() *ImageTriggerStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ImageTriggerStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MirrorResult) DeepCopyInto(out *MirrorResult) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MirrorResult.
+func (in *MirrorResult) DeepCopy() *MirrorResult {
+	if in == nil {
+		return nil
+	}
+	out := new(MirrorResult)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ObjectKeyRef) DeepCopyInto(out *ObjectKeyRef) {
 	*out = *in
@@ -941,6 +1256,16 @@ func (in *ObjectKeyRef) DeepCopy() *ObjectKeyRef {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Output) DeepCopyInto(out *Output) {
 	*out = *in
+	if in.Tags != nil {
+		in, out := &in.Tags, &out.Tags
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Mirrors != nil {
+		in, out := &in.Mirrors, &out.Mirrors
+		*out = make([]MirrorResult, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -1012,6 +1337,30 @@ func (in *Parameter) DeepCopy() *Parameter {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ScheduleTriggerStatus) DeepCopyInto(out *ScheduleTriggerStatus) {
+	*out = *in
+	if in.LastScheduleTime != nil {
+		in, out := &in.LastScheduleTime, &out.LastScheduleTime
+		*out = (*in).DeepCopy()
+	}
+	if in.NextScheduleTime != nil {
+		in, out := &in.NextScheduleTime, &out.NextScheduleTime
+		*out = (*in).DeepCopy()
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScheduleTriggerStatus.
+func (in *ScheduleTriggerStatus) DeepCopy() *ScheduleTriggerStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ScheduleTriggerStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ServiceAccount) DeepCopyInto(out *ServiceAccount) {
 	*out = *in
@@ -1097,6 +1446,46 @@ func (in *Source) DeepCopyInto(out *Source) {
 		*out = new(corev1.LocalObjectReference)
 		**out = **in
 	}
+	if in.MergeTarget != nil {
+		in, out := &in.MergeTarget, &out.MergeTarget
+		*out = new(string)
+		**out = **in
+	}
+	if in.SparseCheckout != nil {
+		in, out := &in.SparseCheckout, &out.SparseCheckout
+		*out = new(SparseCheckout)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.CloneFilter != nil {
+		in, out := &in.CloneFilter, &out.CloneFilter
+		*out = new(string)
+		**out = **in
+	}
+	if in.Depth != nil {
+		in, out := &in.Depth, &out.Depth
+		*out = new(int32)
+		**out = **in
+	}
+	if in.FetchTags != nil {
+		in, out := &in.FetchTags, &out.FetchTags
+		*out = new(bool)
+		**out = **in
+	}
+	if in.LFS != nil {
+		in, out := &in.LFS, &out.LFS
+		*out = new(GitLFS)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.VerifySignature != nil {
+		in, out := &in.VerifySignature, &out.VerifySignature
+		*out = new(GitSignatureVerification)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Cache != nil {
+		in, out := &in.Cache, &out.Cache
+		*out = new(GitCache)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -1116,13 +1505,18 @@ func (in *SourceResult) DeepCopyInto(out *SourceResult) {
 	if in.Git != nil {
 		in, out := &in.Git, &out.Git
 		*out = new(GitSourceResult)
-		**out = **in
+		(*in).DeepCopyInto(*out)
 	}
 	if in.Bundle != nil {
 		in, out := &in.Bundle, &out.Bundle
 		*out = new(BundleSourceResult)
 		**out = **in
 	}
+	if in.HTTP != nil {
+		in, out := &in.HTTP, &out.HTTP
+		*out = new(HTTPSourceResult)
+		**out = **in
+	}
 	return
 }
 
@@ -1136,6 +1530,27 @@ func (in *SourceResult) DeepCopy() *SourceResult {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SparseCheckout) DeepCopyInto(out *SparseCheckout) {
+	*out = *in
+	if in.Paths != nil {
+		in, out := &in.Paths, &out.Paths
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SparseCheckout.
+func (in *SparseCheckout) DeepCopy() *SparseCheckout {
+	if in == nil {
+		return nil
+	}
+	out := new(SparseCheckout)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Strategy) DeepCopyInto(out *Strategy) {
 	*out = *in
@@ -1177,6 +1592,11 @@ func (in *Trigger) DeepCopyInto(out *Trigger) {
 		*out = new(corev1.LocalObjectReference)
 		**out = **in
 	}
+	if in.CommitStatus != nil {
+		in, out := &in.CommitStatus, &out.CommitStatus
+		*out = new(CommitStatus)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -1190,6 +1610,36 @@ func (in *Trigger) DeepCopy() *Trigger {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TriggerStatus) DeepCopyInto(out *TriggerStatus) {
+	*out = *in
+	if in.Images != nil {
+		in, out := &in.Images, &out.Images
+		*out = make([]ImageTriggerStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Schedules != nil {
+		in, out := &in.Schedules, &out.Schedules
+		*out = make([]ScheduleTriggerStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TriggerStatus.
+func (in *TriggerStatus) DeepCopy() *TriggerStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(TriggerStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TriggerWhen) DeepCopyInto(out *TriggerWhen) {
 	*out = *in
@@ -1198,11 +1648,31 @@ func (in *TriggerWhen) DeepCopyInto(out *TriggerWhen) {
 		*out = new(WhenGitHub)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.GitLab != nil {
+		in, out := &in.GitLab, &out.GitLab
+		*out = new(WhenGitLab)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Gitea != nil {
+		in, out := &in.Gitea, &out.Gitea
+		*out = new(WhenGitea)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Bitbucket != nil {
+		in, out := &in.Bitbucket, &out.Bitbucket
+		*out = new(WhenBitbucket)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Image != nil {
 		in, out := &in.Image, &out.Image
 		*out = new(WhenImage)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Schedule != nil {
+		in, out := &in.Schedule, &out.Schedule
+		*out = new(WhenSchedule)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.ObjectRef != nil {
 		in, out := &in.ObjectRef, &out.ObjectRef
 		*out = new(WhenObjectRef)
@@ -1221,6 +1691,37 @@ func (in *TriggerWhen) DeepCopy() *TriggerWhen {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WhenBitbucket) DeepCopyInto(out *WhenBitbucket) {
+	*out = *in
+	if in.Events != nil {
+		in, out := &in.Events, &out.Events
+		*out = make([]BitbucketEventName, len(*in))
+		copy(*out, *in)
+	}
+	if in.Branches != nil {
+		in, out := &in.Branches, &out.Branches
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Paths != nil {
+		in, out := &in.Paths, &out.Paths
+		*out = new(WhenPaths)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WhenBitbucket.
+func (in *WhenBitbucket) DeepCopy() *WhenBitbucket {
+	if in == nil {
+		return nil
+	}
+	out := new(WhenBitbucket)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *WhenGitHub) DeepCopyInto(out *WhenGitHub) {
 	*out = *in
@@ -1234,6 +1735,11 @@ func (in *WhenGitHub) DeepCopyInto(out *WhenGitHub) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.Paths != nil {
+		in, out := &in.Paths, &out.Paths
+		*out = new(WhenPaths)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -1247,6 +1753,68 @@ func (in *WhenGitHub) DeepCopy() *WhenGitHub {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WhenGitLab) DeepCopyInto(out *WhenGitLab) {
+	*out = *in
+	if in.Events != nil {
+		in, out := &in.Events, &out.Events
+		*out = make([]GitLabEventName, len(*in))
+		copy(*out, *in)
+	}
+	if in.Branches != nil {
+		in, out := &in.Branches, &out.Branches
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Paths != nil {
+		in, out := &in.Paths, &out.Paths
+		*out = new(WhenPaths)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WhenGitLab.
+func (in *WhenGitLab) DeepCopy() *WhenGitLab {
+	if in == nil {
+		return nil
+	}
+	out := new(WhenGitLab)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WhenGitea) DeepCopyInto(out *WhenGitea) {
+	*out = *in
+	if in.Events != nil {
+		in, out := &in.Events, &out.Events
+		*out = make([]GiteaEventName, len(*in))
+		copy(*out, *in)
+	}
+	if in.Branches != nil {
+		in, out := &in.Branches, &out.Branches
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Paths != nil {
+		in, out := &in.Paths, &out.Paths
+		*out = new(WhenPaths)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WhenGitea.
+func (in *WhenGitea) DeepCopy() *WhenGitea {
+	if in == nil {
+		return nil
+	}
+	out := new(WhenGitea)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *WhenImage) DeepCopyInto(out *WhenImage) {
 	*out = *in
@@ -1255,6 +1823,11 @@ func (in *WhenImage) DeepCopyInto(out *WhenImage) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.SecretRef != nil {
+		in, out := &in.SecretRef, &out.SecretRef
+		*out = new(corev1.LocalObjectReference)
+		**out = **in
+	}
 	return
 }
 
@@ -1295,3 +1868,50 @@ func (in *WhenObjectRef) DeepCopy() *WhenObjectRef {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WhenPaths) DeepCopyInto(out *WhenPaths) {
+	*out = *in
+	if in.Include != nil {
+		in, out := &in.Include, &out.Include
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Exclude != nil {
+		in, out := &in.Exclude, &out.Exclude
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WhenPaths.
+func (in *WhenPaths) DeepCopy() *WhenPaths {
+	if in == nil {
+		return nil
+	}
+	out := new(WhenPaths)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WhenSchedule) DeepCopyInto(out *WhenSchedule) {
+	*out = *in
+	if in.TimeZone != nil {
+		in, out := &in.TimeZone, &out.TimeZone
+		*out = new(string)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WhenSchedule.
+func (in *WhenSchedule) DeepCopy() *WhenSchedule {
+	if in == nil {
+		return nil
+	}
+	out := new(WhenSchedule)
+	in.DeepCopyInto(out)
+	return out
+}
@@ -51,8 +51,9 @@ func (src *Build) ConvertTo(ctx context.Context, obj *unstructured.Unstructured)
 	// convert OCIArtifact to Bundle
 	if src.Spec.Source.OCIArtifact != nil {
 		alphaBuild.Spec.Source.BundleContainer = &v1alpha1.BundleContainer{
-			Image: src.Spec.Source.OCIArtifact.Image,
-			Prune: (*v1alpha1.PruneOption)(src.Spec.Source.OCIArtifact.Prune),
+			Image:           src.Spec.Source.OCIArtifact.Image,
+			Prune:           (*v1alpha1.PruneOption)(src.Spec.Source.OCIArtifact.Prune),
+			VerifySignature: getAlphaBundleSignatureVerification(src.Spec.Source.OCIArtifact.VerifySignature),
 		}
 	}
 
@@ -100,6 +101,16 @@ func (src *Build) ConvertFrom(ctx context.Context, obj *unstructured.Unstructure
 		Message:    alphaBuild.Status.Message,
 	}
 
+	if alphaBuild.Status.Trigger != nil {
+		src.Status.Trigger = &TriggerStatus{}
+		for _, image := range alphaBuild.Status.Trigger.Images {
+			src.Status.Trigger.Images = append(src.Status.Trigger.Images, ImageTriggerStatus(image))
+		}
+		for _, schedule := range alphaBuild.Status.Trigger.Schedules {
+			src.Status.Trigger.Schedules = append(src.Status.Trigger.Schedules, ScheduleTriggerStatus(schedule))
+		}
+	}
+
 	return nil
 }
 
@@ -108,38 +119,52 @@ func (dest *BuildSpec) ConvertFrom(orig *v1alpha1.BuildSpec) error {
 	specSource := Source{}
 
 	// only interested on spec.sources as long as an item of the list
-	// is of the type LocalCopy. Otherwise, we move into bundle or git types.
+	// is of the type LocalCopy, or is the default source of the type HTTP.
+	// Otherwise, we move into bundle or git types.
 	index, isLocal := v1alpha1.IsLocalCopyType(orig.Sources)
+	httpIndex, isHTTP := v1alpha1.IsDefaultHTTPType(orig.Sources)
 	if isLocal {
 		specSource.Type = LocalType
-		specSource.LocalSource = &Local{
-			Name:    orig.Sources[index].Name,
-			Timeout: orig.Sources[index].Timeout,
-		}
+		specSource.LocalSource = getBetaLocalSource(orig.Sources[index])
+	} else if isHTTP && orig.Source.URL == nil && orig.Source.BundleContainer == nil {
+		specSource.Type = HTTPType
+		specSource.HTTPSource = getBetaHTTPSource(orig.Sources[httpIndex])
 	} else {
-		if orig.Source.BundleContainer != nil {
-			specSource.Type = OCIArtifactType
-			specSource.OCIArtifact = &OCIArtifact{
-				Image: orig.Source.BundleContainer.Image,
-				Prune: (*PruneOption)(orig.Source.BundleContainer.Prune),
-			}
-			if orig.Source.Credentials != nil {
-				specSource.OCIArtifact.PullSecret = &orig.Source.Credentials.Name
-			}
-		} else if orig.Source.URL != nil {
-			specSource.Type = GitType
-			specSource.GitSource = &Git{
-				URL:      *orig.Source.URL,
-				Revision: orig.Source.Revision,
-			}
-			if orig.Source.Credentials != nil {
-				specSource.GitSource.CloneSecret = &orig.Source.Credentials.Name
-			}
-		}
+		specSource = getBetaBuildSource(orig.Source)
 	}
 	specSource.ContextDir = orig.Source.ContextDir
 	dest.Source = specSource
 
+	// Handle BuildSpec Sources, which are all sources besides the default one
+	dest.Sources = nil
+	for i, source := range orig.Sources {
+		if (isLocal && i == index) || (specSource.Type == HTTPType && i == httpIndex) {
+			continue
+		}
+
+		switch source.Type {
+		case v1alpha1.HTTP:
+			dest.Sources = append(dest.Sources, NamedSource{
+				Name:       source.Name,
+				Type:       HTTPType,
+				HTTPSource: getBetaHTTPSource(source),
+			})
+
+		case v1alpha1.Git, v1alpha1.Bundle:
+			if source.Source == nil {
+				continue
+			}
+
+			betaSource := getBetaBuildSource(*source.Source)
+			dest.Sources = append(dest.Sources, NamedSource{
+				Name:        source.Name,
+				Type:        betaSource.Type,
+				GitSource:   betaSource.GitSource,
+				OCIArtifact: betaSource.OCIArtifact,
+			})
+		}
+	}
+
 	// Handle BuildSpec Triggers
 	if orig.Trigger != nil {
 		dest.Trigger = &Trigger{}
@@ -149,6 +174,13 @@ func (dest *BuildSpec) ConvertFrom(orig *v1alpha1.BuildSpec) error {
 		if orig.Trigger.SecretRef != nil {
 			dest.Trigger.TriggerSecret = &orig.Trigger.SecretRef.Name
 		}
+		if orig.Trigger.CommitStatus != nil {
+			dest.Trigger.CommitStatus = &CommitStatus{
+				Secret:  orig.Trigger.CommitStatus.SecretRef.Name,
+				APIURL:  orig.Trigger.CommitStatus.APIURL,
+				Context: orig.Trigger.CommitStatus.Context,
+			}
+		}
 	}
 
 	// Handle BuildSpec Strategy
@@ -194,6 +226,10 @@ func (dest *BuildSpec) ConvertFrom(orig *v1alpha1.BuildSpec) error {
 
 	dest.Output.Annotations = orig.Output.Annotations
 	dest.Output.Labels = orig.Output.Labels
+	dest.Output.AdditionalTags = orig.Output.AdditionalTags
+	dest.Output.Signing = getBetaImageSigning(orig.Output.Signing)
+	dest.Output.SBOM = getBetaImageSBOM(orig.Output.SBOM)
+	dest.Output.Mirrors = getBetaImageMirrors(orig.Output.Mirrors)
 
 	// Handle BuildSpec Timeout
 	dest.Timeout = orig.Timeout
@@ -227,13 +263,44 @@ func (dest *BuildSpec) ConvertFrom(orig *v1alpha1.BuildSpec) error {
 func (dest *BuildSpec) ConvertTo(bs *v1alpha1.BuildSpec) error {
 	// Handle BuildSpec Sources or Source
 	if dest.Source.Type == LocalType && dest.Source.LocalSource != nil {
-		bs.Sources = append(bs.Sources, v1alpha1.BuildSource{
-			Name:    dest.Source.LocalSource.Name,
-			Type:    v1alpha1.LocalCopy,
-			Timeout: dest.Source.LocalSource.Timeout,
-		})
+		bs.Sources = append(bs.Sources, getAlphaLocalSource(*dest.Source.LocalSource))
+	} else if dest.Source.Type == HTTPType && dest.Source.HTTPSource != nil {
+		bs.Sources = append(bs.Sources, getAlphaHTTPSource(v1alpha1.DefaultSourceName, *dest.Source.HTTPSource))
+		bs.Source.ContextDir = dest.Source.ContextDir
 	} else {
-		bs.Source = getAlphaBuildSource(*dest)
+		bs.Source = getAlphaBuildSource(dest.Source)
+	}
+
+	// Handle BuildSpec named Sources
+	for _, namedSource := range dest.Sources {
+		switch namedSource.Type {
+		case HTTPType:
+			if namedSource.HTTPSource != nil {
+				bs.Sources = append(bs.Sources, getAlphaHTTPSource(namedSource.Name, *namedSource.HTTPSource))
+			}
+
+		case GitType, OCIArtifactType:
+			if (namedSource.Type == GitType && namedSource.GitSource == nil) || (namedSource.Type == OCIArtifactType && namedSource.OCIArtifact == nil) {
+				continue
+			}
+
+			source := getAlphaBuildSource(Source{
+				Type:        namedSource.Type,
+				GitSource:   namedSource.GitSource,
+				OCIArtifact: namedSource.OCIArtifact,
+			})
+
+			sourceType := v1alpha1.Git
+			if namedSource.Type == OCIArtifactType {
+				sourceType = v1alpha1.Bundle
+			}
+
+			bs.Sources = append(bs.Sources, v1alpha1.BuildSource{
+				Name:   namedSource.Name,
+				Type:   sourceType,
+				Source: &source,
+			})
+		}
 	}
 
 	// Handle BuildSpec Trigger
@@ -247,6 +314,13 @@ func (dest *BuildSpec) ConvertTo(bs *v1alpha1.BuildSpec) error {
 		if dest.Trigger.TriggerSecret != nil {
 			bs.Trigger.SecretRef = &corev1.LocalObjectReference{Name: *dest.Trigger.TriggerSecret}
 		}
+		if dest.Trigger.CommitStatus != nil {
+			bs.Trigger.CommitStatus = &v1alpha1.CommitStatus{
+				SecretRef: corev1.LocalObjectReference{Name: dest.Trigger.CommitStatus.Secret},
+				APIURL:    dest.Trigger.CommitStatus.APIURL,
+				Context:   dest.Trigger.CommitStatus.Context,
+			}
+		}
 	}
 
 	// Handle BuildSpec Strategy
@@ -286,6 +360,10 @@ func (dest *BuildSpec) ConvertTo(bs *v1alpha1.BuildSpec) error {
 	}
 	bs.Output.Annotations = dest.Output.Annotations
 	bs.Output.Labels = dest.Output.Labels
+	bs.Output.AdditionalTags = dest.Output.AdditionalTags
+	bs.Output.Signing = getAlphaImageSigning(dest.Output.Signing)
+	bs.Output.SBOM = getAlphaImageSBOM(dest.Output.SBOM)
+	bs.Output.Mirrors = getAlphaImageMirrors(dest.Output.Mirrors)
 
 	// Handle BuildSpec Timeout
 	bs.Timeout = dest.Timeout
@@ -354,13 +432,56 @@ func (p TriggerWhen) convertToAlpha(dest *v1alpha1.TriggerWhen) {
 	dest.Name = p.Name
 	dest.Type = v1alpha1.TriggerType(p.Type)
 
-	dest.GitHub = &v1alpha1.WhenGitHub{}
-	for _, e := range p.GitHub.Events {
-		dest.GitHub.Events = append(dest.GitHub.Events, v1alpha1.GitHubEventName(e))
+	if p.GitHub != nil {
+		dest.GitHub = &v1alpha1.WhenGitHub{}
+		for _, e := range p.GitHub.Events {
+			dest.GitHub.Events = append(dest.GitHub.Events, v1alpha1.GitHubEventName(e))
+		}
+		dest.GitHub.Branches = p.GetBranches(GitHubWebHookTrigger)
+		dest.GitHub.Paths = (*v1alpha1.WhenPaths)(p.GetPaths(GitHubWebHookTrigger))
+	}
+
+	if p.GitLab != nil {
+		dest.GitLab = &v1alpha1.WhenGitLab{}
+		for _, e := range p.GitLab.Events {
+			dest.GitLab.Events = append(dest.GitLab.Events, v1alpha1.GitLabEventName(e))
+		}
+		dest.GitLab.Branches = p.GetBranches(GitLabWebHookTrigger)
+		dest.GitLab.Paths = (*v1alpha1.WhenPaths)(p.GetPaths(GitLabWebHookTrigger))
+	}
+
+	if p.Gitea != nil {
+		dest.Gitea = &v1alpha1.WhenGitea{}
+		for _, e := range p.Gitea.Events {
+			dest.Gitea.Events = append(dest.Gitea.Events, v1alpha1.GiteaEventName(e))
+		}
+		dest.Gitea.Branches = p.GetBranches(GiteaWebHookTrigger)
+		dest.Gitea.Paths = (*v1alpha1.WhenPaths)(p.GetPaths(GiteaWebHookTrigger))
+	}
+
+	if p.Bitbucket != nil {
+		dest.Bitbucket = &v1alpha1.WhenBitbucket{}
+		for _, e := range p.Bitbucket.Events {
+			dest.Bitbucket.Events = append(dest.Bitbucket.Events, v1alpha1.BitbucketEventName(e))
+		}
+		dest.Bitbucket.Branches = p.GetBranches(BitbucketWebHookTrigger)
+		dest.Bitbucket.Paths = (*v1alpha1.WhenPaths)(p.GetPaths(BitbucketWebHookTrigger))
+	}
+
+	if p.Image != nil {
+		dest.Image = &v1alpha1.WhenImage{Names: p.Image.Names}
+		if p.Image.PullSecret != nil {
+			dest.Image.SecretRef = &corev1.LocalObjectReference{Name: *p.Image.PullSecret}
+		}
 	}
-	dest.GitHub.Branches = p.GetBranches(GitHubWebHookTrigger)
 
-	dest.Image = (*v1alpha1.WhenImage)(p.Image)
+	if p.Schedule != nil {
+		dest.Schedule = &v1alpha1.WhenSchedule{
+			Cron:              p.Schedule.Cron,
+			TimeZone:          p.Schedule.TimeZone,
+			ConcurrencyPolicy: v1alpha1.ScheduleConcurrencyPolicy(p.Schedule.ConcurrencyPolicy),
+		}
+	}
 	dest.ObjectRef = (*v1alpha1.WhenObjectRef)(p.ObjectRef)
 
 }
@@ -399,43 +520,334 @@ func convertToBetaTriggers(orig *v1alpha1.TriggerWhen) TriggerWhen {
 		Type: TriggerType(orig.Type),
 	}
 
-	dest.GitHub = &WhenGitHub{}
-	for _, e := range orig.GitHub.Events {
-		dest.GitHub.Events = append(dest.GitHub.Events, GitHubEventName(e))
+	if orig.GitHub != nil {
+		dest.GitHub = &WhenGitHub{}
+		for _, e := range orig.GitHub.Events {
+			dest.GitHub.Events = append(dest.GitHub.Events, GitHubEventName(e))
+		}
+		dest.GitHub.Branches = orig.GetBranches(v1alpha1.GitHubWebHookTrigger)
+		dest.GitHub.Paths = (*WhenPaths)(orig.GetPaths(v1alpha1.GitHubWebHookTrigger))
+	}
+
+	if orig.GitLab != nil {
+		dest.GitLab = &WhenGitLab{}
+		for _, e := range orig.GitLab.Events {
+			dest.GitLab.Events = append(dest.GitLab.Events, GitLabEventName(e))
+		}
+		dest.GitLab.Branches = orig.GetBranches(v1alpha1.GitLabWebHookTrigger)
+		dest.GitLab.Paths = (*WhenPaths)(orig.GetPaths(v1alpha1.GitLabWebHookTrigger))
+	}
+
+	if orig.Gitea != nil {
+		dest.Gitea = &WhenGitea{}
+		for _, e := range orig.Gitea.Events {
+			dest.Gitea.Events = append(dest.Gitea.Events, GiteaEventName(e))
+		}
+		dest.Gitea.Branches = orig.GetBranches(v1alpha1.GiteaWebHookTrigger)
+		dest.Gitea.Paths = (*WhenPaths)(orig.GetPaths(v1alpha1.GiteaWebHookTrigger))
+	}
+
+	if orig.Bitbucket != nil {
+		dest.Bitbucket = &WhenBitbucket{}
+		for _, e := range orig.Bitbucket.Events {
+			dest.Bitbucket.Events = append(dest.Bitbucket.Events, BitbucketEventName(e))
+		}
+		dest.Bitbucket.Branches = orig.GetBranches(v1alpha1.BitbucketWebHookTrigger)
+		dest.Bitbucket.Paths = (*WhenPaths)(orig.GetPaths(v1alpha1.BitbucketWebHookTrigger))
 	}
 
-	dest.GitHub.Branches = orig.GetBranches(v1alpha1.GitHubWebHookTrigger)
-	dest.Image = (*WhenImage)(orig.Image)
+	if orig.Image != nil {
+		dest.Image = &WhenImage{Names: orig.Image.Names}
+		if orig.Image.SecretRef != nil {
+			dest.Image.PullSecret = &orig.Image.SecretRef.Name
+		}
+	}
+
+	if orig.Schedule != nil {
+		dest.Schedule = &WhenSchedule{
+			Cron:              orig.Schedule.Cron,
+			TimeZone:          orig.Schedule.TimeZone,
+			ConcurrencyPolicy: ScheduleConcurrencyPolicy(orig.Schedule.ConcurrencyPolicy),
+		}
+	}
 	dest.ObjectRef = (*WhenObjectRef)(orig.ObjectRef)
 
 	return dest
 }
 
-func getAlphaBuildSource(src BuildSpec) v1alpha1.Source {
+// getBetaBuildSource converts the Git repository or bundle image of the alpha source
+func getBetaBuildSource(source v1alpha1.Source) Source {
+	betaSource := Source{}
+	if source.BundleContainer != nil {
+		betaSource.Type = OCIArtifactType
+		betaSource.OCIArtifact = &OCIArtifact{
+			Image: source.BundleContainer.Image,
+			Prune: (*PruneOption)(source.BundleContainer.Prune),
+		}
+		if source.BundleContainer.VerifySignature != nil {
+			betaSource.OCIArtifact.VerifySignature = &OCIArtifactSignatureVerification{
+				Secret: source.BundleContainer.VerifySignature.SecretRef.Name,
+			}
+		}
+		if source.Credentials != nil {
+			betaSource.OCIArtifact.PullSecret = &source.Credentials.Name
+		}
+	} else if source.URL != nil {
+		betaSource.Type = GitType
+		betaSource.GitSource = &Git{
+			URL:         *source.URL,
+			Revision:    source.Revision,
+			CloneFilter: source.CloneFilter,
+			MergeTarget: source.MergeTarget,
+			Depth:       source.Depth,
+			FetchTags:   source.FetchTags,
+		}
+		if source.SparseCheckout != nil {
+			betaSource.GitSource.SparseCheckout = &SparseCheckout{Paths: source.SparseCheckout.Paths}
+		}
+		if source.LFS != nil {
+			betaSource.GitSource.LFS = &GitLFS{
+				Enabled: source.LFS.Enabled,
+				Include: source.LFS.Include,
+				Exclude: source.LFS.Exclude,
+			}
+		}
+		if source.VerifySignature != nil {
+			betaSource.GitSource.VerifySignature = &GitSignatureVerification{}
+			if source.VerifySignature.SecretRef != nil {
+				betaSource.GitSource.VerifySignature.Secret = &source.VerifySignature.SecretRef.Name
+			}
+			if source.VerifySignature.ConfigMapRef != nil {
+				betaSource.GitSource.VerifySignature.ConfigMap = &source.VerifySignature.ConfigMapRef.Name
+			}
+		}
+		if source.Cache != nil {
+			betaSource.GitSource.Cache = &GitCache{
+				PersistentVolumeClaim: source.Cache.PersistentVolumeClaimRef.Name,
+				MaxSize:               source.Cache.MaxSize,
+			}
+		}
+		if source.Credentials != nil {
+			betaSource.GitSource.CloneSecret = &source.Credentials.Name
+		}
+	}
+
+	return betaSource
+}
+
+// getBetaHTTPSource converts the remote artifact of the alpha HTTP source
+func getBetaHTTPSource(source v1alpha1.BuildSource) *HTTP {
+	httpSource := &HTTP{
+		URL:             source.URL,
+		SHA256:          source.SHA256,
+		Extract:         source.Extract,
+		StripComponents: source.StripComponents,
+		Retries:         source.Retries,
+	}
+	if source.AuthSecretRef != nil {
+		httpSource.AuthSecret = &source.AuthSecretRef.Name
+	}
+
+	return httpSource
+}
+
+// getAlphaHTTPSource converts the remote artifact of the HTTP source
+func getAlphaHTTPSource(name string, httpSource HTTP) v1alpha1.BuildSource {
+	source := v1alpha1.BuildSource{
+		Name:            name,
+		Type:            v1alpha1.HTTP,
+		URL:             httpSource.URL,
+		SHA256:          httpSource.SHA256,
+		Extract:         httpSource.Extract,
+		StripComponents: httpSource.StripComponents,
+		Retries:         httpSource.Retries,
+	}
+	if httpSource.AuthSecret != nil {
+		source.AuthSecretRef = &corev1.LocalObjectReference{Name: *httpSource.AuthSecret}
+	}
+
+	return source
+}
+
+// getBetaLocalSource converts the alpha LocalCopy source
+func getBetaLocalSource(source v1alpha1.BuildSource) *Local {
+	localSource := &Local{
+		Name:    source.Name,
+		Timeout: source.Timeout,
+	}
+	if source.UploadSecretRef != nil {
+		localSource.UploadSecret = &source.UploadSecretRef.Name
+	}
+
+	return localSource
+}
+
+// getAlphaLocalSource converts the Local source
+func getAlphaLocalSource(localSource Local) v1alpha1.BuildSource {
+	source := v1alpha1.BuildSource{
+		Name:    localSource.Name,
+		Type:    v1alpha1.LocalCopy,
+		Timeout: localSource.Timeout,
+	}
+	if localSource.UploadSecret != nil {
+		source.UploadSecretRef = &corev1.LocalObjectReference{Name: *localSource.UploadSecret}
+	}
+
+	return source
+}
+
+// getAlphaBundleSignatureVerification converts the signature verification of the OCI artifact
+func getAlphaBundleSignatureVerification(verifySignature *OCIArtifactSignatureVerification) *v1alpha1.BundleSignatureVerification {
+	if verifySignature == nil {
+		return nil
+	}
+
+	return &v1alpha1.BundleSignatureVerification{
+		SecretRef: corev1.LocalObjectReference{Name: verifySignature.Secret},
+	}
+}
+
+func getAlphaImageSigning(signing *ImageSigning) *v1alpha1.ImageSigning {
+	if signing == nil {
+		return nil
+	}
+
+	return &v1alpha1.ImageSigning{
+		SecretRef:  corev1.LocalObjectReference{Name: signing.Secret},
+		Provenance: signing.Provenance,
+	}
+}
+
+func getBetaImageSigning(signing *v1alpha1.ImageSigning) *ImageSigning {
+	if signing == nil {
+		return nil
+	}
+
+	return &ImageSigning{
+		Secret:     signing.SecretRef.Name,
+		Provenance: signing.Provenance,
+	}
+}
+
+func getAlphaImageSBOM(sbom *ImageSBOM) *v1alpha1.ImageSBOM {
+	if sbom == nil {
+		return nil
+	}
+
+	return &v1alpha1.ImageSBOM{
+		Format: v1alpha1.SBOMFormat(sbom.Format),
+	}
+}
+
+func getBetaImageSBOM(sbom *v1alpha1.ImageSBOM) *ImageSBOM {
+	if sbom == nil {
+		return nil
+	}
+
+	return &ImageSBOM{
+		Format: SBOMFormat(sbom.Format),
+	}
+}
+
+func getAlphaImageMirrors(mirrors []ImageMirror) []v1alpha1.ImageMirror {
+	if mirrors == nil {
+		return nil
+	}
+
+	alphaMirrors := make([]v1alpha1.ImageMirror, 0, len(mirrors))
+	for _, mirror := range mirrors {
+		alphaMirror := v1alpha1.ImageMirror{
+			Image:    mirror.Image,
+			Insecure: mirror.Insecure,
+		}
+		if mirror.PushSecret != nil {
+			alphaMirror.Credentials = &corev1.LocalObjectReference{
+				Name: *mirror.PushSecret,
+			}
+		}
+
+		alphaMirrors = append(alphaMirrors, alphaMirror)
+	}
+
+	return alphaMirrors
+}
+
+func getBetaImageMirrors(mirrors []v1alpha1.ImageMirror) []ImageMirror {
+	if mirrors == nil {
+		return nil
+	}
+
+	betaMirrors := make([]ImageMirror, 0, len(mirrors))
+	for _, mirror := range mirrors {
+		betaMirror := ImageMirror{
+			Image:    mirror.Image,
+			Insecure: mirror.Insecure,
+		}
+		if mirror.Credentials != nil {
+			betaMirror.PushSecret = &mirror.Credentials.Name
+		}
+
+		betaMirrors = append(betaMirrors, betaMirror)
+	}
+
+	return betaMirrors
+}
+
+func getAlphaBuildSource(src Source) v1alpha1.Source {
 	source := v1alpha1.Source{}
 	var credentials corev1.LocalObjectReference
 	var revision *string
 
-	switch src.Source.Type {
+	switch src.Type {
 	case OCIArtifactType:
-		if src.Source.OCIArtifact != nil && src.Source.OCIArtifact.PullSecret != nil {
+		if src.OCIArtifact != nil && src.OCIArtifact.PullSecret != nil {
 			credentials = corev1.LocalObjectReference{
-				Name: *src.Source.OCIArtifact.PullSecret,
+				Name: *src.OCIArtifact.PullSecret,
 			}
 		}
 		source.BundleContainer = &v1alpha1.BundleContainer{
-			Image: src.Source.OCIArtifact.Image,
-			Prune: (*v1alpha1.PruneOption)(src.Source.OCIArtifact.Prune),
+			Image:           src.OCIArtifact.Image,
+			Prune:           (*v1alpha1.PruneOption)(src.OCIArtifact.Prune),
+			VerifySignature: getAlphaBundleSignatureVerification(src.OCIArtifact.VerifySignature),
 		}
 	default:
-		if src.Source.GitSource != nil && src.Source.GitSource.CloneSecret != nil {
+		if src.GitSource != nil && src.GitSource.CloneSecret != nil {
 			credentials = corev1.LocalObjectReference{
-				Name: *src.Source.GitSource.CloneSecret,
+				Name: *src.GitSource.CloneSecret,
 			}
 		}
-		if src.Source.GitSource != nil {
-			source.URL = &src.Source.GitSource.URL
-			revision = src.Source.GitSource.Revision
+		if src.GitSource != nil {
+			source.URL = &src.GitSource.URL
+			revision = src.GitSource.Revision
+			source.CloneFilter = src.GitSource.CloneFilter
+			source.MergeTarget = src.GitSource.MergeTarget
+			source.Depth = src.GitSource.Depth
+			source.FetchTags = src.GitSource.FetchTags
+			if src.GitSource.LFS != nil {
+				source.LFS = &v1alpha1.GitLFS{
+					Enabled: src.GitSource.LFS.Enabled,
+					Include: src.GitSource.LFS.Include,
+					Exclude: src.GitSource.LFS.Exclude,
+				}
+			}
+			if src.GitSource.SparseCheckout != nil {
+				source.SparseCheckout = &v1alpha1.SparseCheckout{Paths: src.GitSource.SparseCheckout.Paths}
+			}
+			if src.GitSource.VerifySignature != nil {
+				source.VerifySignature = &v1alpha1.GitSignatureVerification{}
+				if src.GitSource.VerifySignature.Secret != nil {
+					source.VerifySignature.SecretRef = &corev1.LocalObjectReference{Name: *src.GitSource.VerifySignature.Secret}
+				}
+				if src.GitSource.VerifySignature.ConfigMap != nil {
+					source.VerifySignature.ConfigMapRef = &corev1.LocalObjectReference{Name: *src.GitSource.VerifySignature.ConfigMap}
+				}
+			}
+			if src.GitSource.Cache != nil {
+				source.Cache = &v1alpha1.GitCache{
+					PersistentVolumeClaimRef: corev1.LocalObjectReference{Name: src.GitSource.Cache.PersistentVolumeClaim},
+					MaxSize:                  src.GitSource.Cache.MaxSize,
+				}
+			}
 		}
 
 	}
@@ -445,7 +857,7 @@ func getAlphaBuildSource(src BuildSpec) v1alpha1.Source {
 	}
 
 	source.Revision = revision
-	source.ContextDir = src.Source.ContextDir
+	source.ContextDir = src.ContextDir
 
 	return source
 }
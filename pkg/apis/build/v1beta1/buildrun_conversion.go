@@ -44,13 +44,12 @@ func (src *BuildRun) ConvertTo(ctx context.Context, obj *unstructured.Unstructur
 
 	// BuildRunSpec Sources
 	if src.Spec.Source != nil && src.Spec.Source.Type == LocalType && src.Spec.Source.LocalSource != nil {
-		alphaBuildRun.Spec.Sources = append(alphaBuildRun.Spec.Sources, v1alpha1.BuildSource{
-			Name:    src.Spec.Source.LocalSource.Name,
-			Type:    v1alpha1.LocalCopy,
-			Timeout: src.Spec.Source.LocalSource.Timeout,
-		})
+		alphaBuildRun.Spec.Sources = append(alphaBuildRun.Spec.Sources, getAlphaLocalSource(*src.Spec.Source.LocalSource))
 	}
 
+	// BuildRunSpec Revision
+	alphaBuildRun.Spec.Revision = src.Spec.Revision
+
 	// BuildRunSpec ServiceAccount
 	// With the deprecation of serviceAccount.Generate, serviceAccount is set to ".generate" to have the SA created on fly.
 	if src.Spec.ServiceAccount != nil && *src.Spec.ServiceAccount == ".generate" {
@@ -79,9 +78,13 @@ func (src *BuildRun) ConvertTo(ctx context.Context, obj *unstructured.Unstructur
 
 	if src.Spec.Output != nil {
 		alphaBuildRun.Spec.Output = &v1alpha1.Image{
-			Image:       src.Spec.Output.Image,
-			Annotations: src.Spec.Output.Annotations,
-			Labels:      src.Spec.Output.Labels,
+			Image:          src.Spec.Output.Image,
+			Annotations:    src.Spec.Output.Annotations,
+			Labels:         src.Spec.Output.Labels,
+			AdditionalTags: src.Spec.Output.AdditionalTags,
+			Signing:        getAlphaImageSigning(src.Spec.Output.Signing),
+			SBOM:           getAlphaImageSBOM(src.Spec.Output.SBOM),
+			Mirrors:        getAlphaImageMirrors(src.Spec.Output.Mirrors),
 		}
 		if src.Spec.Output.PushSecret != nil {
 			alphaBuildRun.Spec.Output.Credentials = &corev1.LocalObjectReference{
@@ -138,11 +141,28 @@ func (src *BuildRun) ConvertFrom(ctx context.Context, obj *unstructured.Unstruct
 	src.Spec.ConvertFrom(&alphaBuildRun.Spec)
 
 	var sourceStatus *SourceResult
+	var namedSourceStatus []SourceResult
 	for _, s := range alphaBuildRun.Status.Sources {
-		sourceStatus = &SourceResult{
-			Git:         (*GitSourceResult)(s.Git),
-			OciArtifact: (*OciArtifactSourceResult)(s.Bundle),
+		result := SourceResult{}
+		if s.Git != nil {
+			result.Git = (*GitSourceResult)(s.Git)
+		}
+		if s.Bundle != nil {
+			result.OciArtifact = (*OciArtifactSourceResult)(s.Bundle)
+		}
+		if s.HTTP != nil {
+			result.HTTP = (*HTTPSourceResult)(s.HTTP)
 		}
+
+		// the results of the named sources are listed separately from the
+		// results of the default source
+		if s.Name != "" && s.Name != v1alpha1.DefaultSourceName {
+			result.Name = s.Name
+			namedSourceStatus = append(namedSourceStatus, result)
+			continue
+		}
+
+		sourceStatus = &result
 	}
 
 	conditions := []Condition{}
@@ -168,7 +188,8 @@ func (src *BuildRun) ConvertFrom(ctx context.Context, obj *unstructured.Unstruct
 
 	src.Status = BuildRunStatus{
 		Source:         sourceStatus,
-		Output:         (*Output)(alphaBuildRun.Status.Output),
+		Sources:        namedSourceStatus,
+		Output:         getBetaOutput(alphaBuildRun.Status.Output),
 		Conditions:     conditions,
 		TaskRunName:    alphaBuildRun.Status.LatestTaskRunRef,
 		StartTime:      alphaBuildRun.Status.StartTime,
@@ -201,14 +222,13 @@ func (dest *BuildRunSpec) ConvertFrom(orig *v1alpha1.BuildRunSpec) error {
 	index, isLocal := v1alpha1.IsLocalCopyType(orig.Sources)
 	if isLocal {
 		dest.Source = &BuildRunSource{
-			Type: LocalType,
-			LocalSource: &Local{
-				Name:    orig.Sources[index].Name,
-				Timeout: orig.Sources[index].Timeout,
-			},
+			Type:        LocalType,
+			LocalSource: getBetaLocalSource(orig.Sources[index]),
 		}
 	}
 
+	dest.Revision = orig.Revision
+
 	if orig.ServiceAccount != nil {
 		dest.ServiceAccount = orig.ServiceAccount.Name
 	}
@@ -225,9 +245,13 @@ func (dest *BuildRunSpec) ConvertFrom(orig *v1alpha1.BuildRunSpec) error {
 	// Handle BuildRunSpec Output
 	if orig.Output != nil {
 		dest.Output = &Image{
-			Image:       orig.Output.Image,
-			Annotations: orig.Output.Annotations,
-			Labels:      orig.Output.Labels,
+			Image:          orig.Output.Image,
+			Annotations:    orig.Output.Annotations,
+			Labels:         orig.Output.Labels,
+			AdditionalTags: orig.Output.AdditionalTags,
+			Signing:        getBetaImageSigning(orig.Output.Signing),
+			SBOM:           getBetaImageSBOM(orig.Output.SBOM),
+			Mirrors:        getBetaImageMirrors(orig.Output.Mirrors),
 		}
 
 		if orig.Output.Credentials != nil {
@@ -254,3 +278,23 @@ func (dest *BuildRunSpec) ConvertFrom(orig *v1alpha1.BuildRunSpec) error {
 	}
 	return nil
 }
+
+func getBetaOutput(output *v1alpha1.Output) *Output {
+	if output == nil {
+		return nil
+	}
+
+	betaOutput := &Output{
+		Digest:           output.Digest,
+		Size:             output.Size,
+		SignatureDigest:  output.SignatureDigest,
+		SBOMDigest:       output.SBOMDigest,
+		ProvenanceDigest: output.ProvenanceDigest,
+		Tags:             output.Tags,
+	}
+	for _, mirror := range output.Mirrors {
+		betaOutput.Mirrors = append(betaOutput.Mirrors, MirrorResult(mirror))
+	}
+
+	return betaOutput
+}
@@ -4,7 +4,10 @@
 
 package v1beta1
 
-import metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+import (
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
 
 // PruneOption defines the supported options for image pruning
 type PruneOption string
@@ -23,6 +26,9 @@ const GitType BuildSourceType = "Git"
 // OCIArtifactType represents a source code bundle container image to pull. This is where the source code resides.
 const OCIArtifactType BuildSourceType = "OCI"
 
+// HTTPType represents a remote artifact that is downloaded using HTTP, and optionally extracted when it is an archive.
+const HTTPType BuildSourceType = "HTTP"
+
 const (
 	// Do not delete image after it was pulled
 	PruneNever PruneOption = "Never"
@@ -39,6 +45,13 @@ type Local struct {
 
 	// Name of the local step
 	Name string `json:"name,omitempty"`
+
+	// UploadSecret references a Secret that contains the bearer token of the
+	// upload endpoint in the `token` key. If defined, the waiter accepts the
+	// source code on the upload endpoint in addition to the lock file.
+	//
+	// +optional
+	UploadSecret *string `json:"uploadSecret,omitempty"`
 }
 
 // Git describes the git repository to pull
@@ -47,7 +60,7 @@ type Git struct {
 	URL string `json:"url"`
 
 	// Revision describes the Git revision (e.g., branch, tag, commit SHA,
-	// etc.) to fetch.
+	// or a reference like `refs/pull/123/head`) to fetch.
 	//
 	// If not defined, it will fallback to the repository's default branch.
 	//
@@ -59,6 +72,172 @@ type Git struct {
 	//
 	// +optional
 	CloneSecret *string `json:"cloneSecret,omitempty"`
+
+	// MergeTarget is the branch into which the revision is merged before the
+	// build, for example the target branch of a pull request. The full
+	// history of the revision and the branch is fetched to merge them.
+	//
+	// +optional
+	MergeTarget *string `json:"mergeTarget,omitempty"`
+
+	// SparseCheckout restricts the checkout of the Git repository to a set of
+	// directories, which reduces the size of the workspace for large repositories.
+	//
+	// +optional
+	SparseCheckout *SparseCheckout `json:"sparseCheckout,omitempty"`
+
+	// CloneFilter is the partial clone filter used to clone the Git repository,
+	// for example `blob:none`. Objects that are filtered out are only fetched
+	// when they are needed for the checkout.
+	//
+	// +optional
+	CloneFilter *string `json:"cloneFilter,omitempty"`
+
+	// Depth is the number of commits to fetch from the history of the Git
+	// repository. Use 0 to fetch the full history, which is required by tools
+	// like `git describe`.
+	//
+	// If not defined, it defaults to 1.
+	//
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	Depth *int32 `json:"depth,omitempty"`
+
+	// FetchTags defines whether the tags of the Git repository are fetched.
+	//
+	// If not defined, it defaults to false.
+	//
+	// +optional
+	FetchTags *bool `json:"fetchTags,omitempty"`
+
+	// LFS configures the download of Git Large File Storage (LFS) files.
+	//
+	// +optional
+	LFS *GitLFS `json:"lfs,omitempty"`
+
+	// VerifySignature requires the checked out commit, or the tag that the
+	// revision resolves from, to be signed by one of the trusted GPG or SSH
+	// keys, otherwise the clone fails.
+	//
+	// +optional
+	VerifySignature *GitSignatureVerification `json:"verifySignature,omitempty"`
+
+	// Cache configures a volume with bare mirrors of Git repositories that
+	// are updated and cloned from instead of cloning the repository over the
+	// network for every BuildRun.
+	//
+	// +optional
+	Cache *GitCache `json:"cache,omitempty"`
+}
+
+// GitLFS describes which Git Large File Storage (LFS) files to download
+type GitLFS struct {
+	// Enabled defines whether LFS files are downloaded, otherwise the LFS
+	// pointer files are checked out.
+	//
+	// If not defined, it defaults to true.
+	//
+	// +optional
+	Enabled *bool `json:"enabled,omitempty"`
+
+	// Include are the patterns of the LFS files to download. If not defined,
+	// all LFS files are downloaded.
+	//
+	// +optional
+	Include []string `json:"include,omitempty"`
+
+	// Exclude are the patterns of the LFS files not to download.
+	//
+	// +optional
+	Exclude []string `json:"exclude,omitempty"`
+}
+
+// GitSignatureVerification references the keys that are trusted to sign the
+// commits and tags of the Git repository. Each entry of the Secret or ConfigMap
+// is either an ASCII armored GPG public key, or SSH public keys in the format
+// of an allowed signers or authorized keys file.
+type GitSignatureVerification struct {
+	// Secret is the name of a Secret that contains the trusted keys.
+	//
+	// +optional
+	Secret *string `json:"secret,omitempty"`
+
+	// ConfigMap is the name of a ConfigMap that contains the trusted keys.
+	//
+	// +optional
+	ConfigMap *string `json:"configMap,omitempty"`
+}
+
+// GitCache references the PersistentVolumeClaim that caches the Git
+// repositories. The volume can be shared by several Builds, concurrent
+// BuildRuns lock the mirror of a repository while it is updated.
+type GitCache struct {
+	// PersistentVolumeClaim is the name of the PersistentVolumeClaim that
+	// holds the mirrors.
+	PersistentVolumeClaim string `json:"persistentVolumeClaim"`
+
+	// MaxSize is the maximum size of the mirrors in the volume, the least
+	// recently used mirrors are evicted once it is exceeded.
+	//
+	// If not defined, the size is not limited.
+	//
+	// +optional
+	MaxSize *resource.Quantity `json:"maxSize,omitempty"`
+}
+
+// SparseCheckout describes the directories of the Git repository to check out
+type SparseCheckout struct {
+	// Paths are the directories to check out, relative to the repository root.
+	// Files in the repository root are always checked out.
+	//
+	// If not defined, it defaults to the context directory.
+	//
+	// +optional
+	Paths []string `json:"paths,omitempty"`
+}
+
+// HTTP describes the remote artifact to download
+type HTTP struct {
+	// URL describes the URL of the remote artifact.
+	URL string `json:"url"`
+
+	// SHA256 is the expected SHA-256 digest of the remote artifact as hex
+	// encoded string, the download fails if the digest does not match.
+	//
+	// +optional
+	SHA256 *string `json:"sha256,omitempty"`
+
+	// AuthSecret references a Secret that contains the value of the HTTP
+	// header to authenticate the download in the `header-value` key, and
+	// optionally the name of the header in the `header-name` key, which
+	// defaults to `Authorization`.
+	//
+	// +optional
+	AuthSecret *string `json:"authSecret,omitempty"`
+
+	// Extract defines whether the remote artifact is a tar, gzip compressed
+	// tar, or zip archive that is extracted, otherwise it is stored as file.
+	//
+	// If not defined, it defaults to false.
+	//
+	// +optional
+	Extract *bool `json:"extract,omitempty"`
+
+	// StripComponents is the number of leading path elements that are
+	// removed from the file names of the archive when it is extracted.
+	//
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	StripComponents *int32 `json:"stripComponents,omitempty"`
+
+	// Retries is the number of times the download is retried when it fails
+	// due to network errors or server errors.
+	//
+	// If not defined, it defaults to 3.
+	//
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	Retries *int32 `json:"retries,omitempty"`
 }
 
 // OCIArtifact describes the source code bundle container to pull
@@ -79,6 +258,20 @@ type OCIArtifact struct {
 	//
 	// +optional
 	PullSecret *string `json:"pullSecret,omitempty"`
+
+	// VerifySignature requires the image to be signed by one of the trusted
+	// public keys, otherwise the image is not unpacked.
+	//
+	// +optional
+	VerifySignature *OCIArtifactSignatureVerification `json:"verifySignature,omitempty"`
+}
+
+// OCIArtifactSignatureVerification references the public keys that are trusted
+// to sign the image. Each entry of the Secret is a PEM encoded ECDSA, RSA, or
+// Ed25519 public key.
+type OCIArtifactSignatureVerification struct {
+	// Secret is the name of a Secret that contains the trusted public keys.
+	Secret string `json:"secret"`
 }
 
 // Source describes the Git source repository to fetch.
@@ -107,6 +300,38 @@ type Source struct {
 	//
 	// +optional
 	LocalSource *Local `json:"local,omitempty"`
+
+	// HTTPSource
+	//
+	// +optional
+	HTTPSource *HTTP `json:"http,omitempty"`
+}
+
+// NamedSource describes an additional source of the Build, which is fetched
+// into a subdirectory of the source directory that is named after the source
+type NamedSource struct {
+	// Name of the source, it is used as name of the subdirectory. The path of
+	// the subdirectory is available in the `shp-source-<name>-path` parameter.
+	Name string `json:"name"`
+
+	// Type is the NamedSource qualifier, the type of the data-source. The
+	// supported types are Git, OCI, and HTTP.
+	Type BuildSourceType `json:"type"`
+
+	// OCIArtifact
+	//
+	// +optional
+	OCIArtifact *OCIArtifact `json:"ociArtifact,omitempty"`
+
+	// GitSource
+	//
+	// +optional
+	GitSource *Git `json:"git,omitempty"`
+
+	// HTTPSource
+	//
+	// +optional
+	HTTPSource *HTTP `json:"http,omitempty"`
 }
 
 // BuildRunSource describes the local source to use
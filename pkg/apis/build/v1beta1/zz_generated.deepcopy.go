@@ -234,6 +234,11 @@ func (in *BuildRunSpec) DeepCopyInto(out *BuildRunSpec) {
 		*out = new(BuildRunSource)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Revision != nil {
+		in, out := &in.Revision, &out.Revision
+		*out = new(string)
+		**out = **in
+	}
 	if in.ServiceAccount != nil {
 		in, out := &in.ServiceAccount, &out.ServiceAccount
 		*out = new(string)
@@ -301,10 +306,17 @@ func (in *BuildRunStatus) DeepCopyInto(out *BuildRunStatus) {
 		*out = new(SourceResult)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Sources != nil {
+		in, out := &in.Sources, &out.Sources
+		*out = make([]SourceResult, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	if in.Output != nil {
 		in, out := &in.Output, &out.Output
 		*out = new(Output)
-		**out = **in
+		(*in).DeepCopyInto(*out)
 	}
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
@@ -353,6 +365,13 @@ func (in *BuildRunStatus) DeepCopy() *BuildRunStatus {
 func (in *BuildSpec) DeepCopyInto(out *BuildSpec) {
 	*out = *in
 	in.Source.DeepCopyInto(&out.Source)
+	if in.Sources != nil {
+		in, out := &in.Sources, &out.Sources
+		*out = make([]NamedSource, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	if in.Trigger != nil {
 		in, out := &in.Trigger, &out.Trigger
 		*out = new(Trigger)
@@ -422,6 +441,11 @@ func (in *BuildStatus) DeepCopyInto(out *BuildStatus) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.Trigger != nil {
+		in, out := &in.Trigger, &out.Trigger
+		*out = new(TriggerStatus)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -675,6 +699,32 @@ func (in *ClusterBuildStrategyList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CommitStatus) DeepCopyInto(out *CommitStatus) {
+	*out = *in
+	if in.APIURL != nil {
+		in, out := &in.APIURL, &out.APIURL
+		*out = new(string)
+		**out = **in
+	}
+	if in.Context != nil {
+		in, out := &in.Context, &out.Context
+		*out = new(string)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CommitStatus.
+func (in *CommitStatus) DeepCopy() *CommitStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(CommitStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Condition) DeepCopyInto(out *Condition) {
 	*out = *in
@@ -748,6 +798,46 @@ func (in *Git) DeepCopyInto(out *Git) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.MergeTarget != nil {
+		in, out := &in.MergeTarget, &out.MergeTarget
+		*out = new(string)
+		**out = **in
+	}
+	if in.SparseCheckout != nil {
+		in, out := &in.SparseCheckout, &out.SparseCheckout
+		*out = new(SparseCheckout)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.CloneFilter != nil {
+		in, out := &in.CloneFilter, &out.CloneFilter
+		*out = new(string)
+		**out = **in
+	}
+	if in.Depth != nil {
+		in, out := &in.Depth, &out.Depth
+		*out = new(int32)
+		**out = **in
+	}
+	if in.FetchTags != nil {
+		in, out := &in.FetchTags, &out.FetchTags
+		*out = new(bool)
+		**out = **in
+	}
+	if in.LFS != nil {
+		in, out := &in.LFS, &out.LFS
+		*out = new(GitLFS)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.VerifySignature != nil {
+		in, out := &in.VerifySignature, &out.VerifySignature
+		*out = new(GitSignatureVerification)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Cache != nil {
+		in, out := &in.Cache, &out.Cache
+		*out = new(GitCache)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -761,9 +851,96 @@ func (in *Git) DeepCopy() *Git {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GitCache) DeepCopyInto(out *GitCache) {
+	*out = *in
+	if in.MaxSize != nil {
+		in, out := &in.MaxSize, &out.MaxSize
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GitCache.
+func (in *GitCache) DeepCopy() *GitCache {
+	if in == nil {
+		return nil
+	}
+	out := new(GitCache)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GitLFS) DeepCopyInto(out *GitLFS) {
+	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Include != nil {
+		in, out := &in.Include, &out.Include
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Exclude != nil {
+		in, out := &in.Exclude, &out.Exclude
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GitLFS.
+func (in *GitLFS) DeepCopy() *GitLFS {
+	if in == nil {
+		return nil
+	}
+	out := new(GitLFS)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GitSignatureVerification) DeepCopyInto(out *GitSignatureVerification) {
+	*out = *in
+	if in.Secret != nil {
+		in, out := &in.Secret, &out.Secret
+		*out = new(string)
+		**out = **in
+	}
+	if in.ConfigMap != nil {
+		in, out := &in.ConfigMap, &out.ConfigMap
+		*out = new(string)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GitSignatureVerification.
+func (in *GitSignatureVerification) DeepCopy() *GitSignatureVerification {
+	if in == nil {
+		return nil
+	}
+	out := new(GitSignatureVerification)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *GitSourceResult) DeepCopyInto(out *GitSourceResult) {
 	*out = *in
+	if in.CommitTimestamp != nil {
+		in, out := &in.CommitTimestamp, &out.CommitTimestamp
+		*out = (*in).DeepCopy()
+	}
+	if in.Tags != nil {
+		in, out := &in.Tags, &out.Tags
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -777,6 +954,63 @@ func (in *GitSourceResult) DeepCopy() *GitSourceResult {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HTTP) DeepCopyInto(out *HTTP) {
+	*out = *in
+	if in.SHA256 != nil {
+		in, out := &in.SHA256, &out.SHA256
+		*out = new(string)
+		**out = **in
+	}
+	if in.AuthSecret != nil {
+		in, out := &in.AuthSecret, &out.AuthSecret
+		*out = new(string)
+		**out = **in
+	}
+	if in.Extract != nil {
+		in, out := &in.Extract, &out.Extract
+		*out = new(bool)
+		**out = **in
+	}
+	if in.StripComponents != nil {
+		in, out := &in.StripComponents, &out.StripComponents
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Retries != nil {
+		in, out := &in.Retries, &out.Retries
+		*out = new(int32)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HTTP.
+func (in *HTTP) DeepCopy() *HTTP {
+	if in == nil {
+		return nil
+	}
+	out := new(HTTP)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HTTPSourceResult) DeepCopyInto(out *HTTPSourceResult) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HTTPSourceResult.
+func (in *HTTPSourceResult) DeepCopy() *HTTPSourceResult {
+	if in == nil {
+		return nil
+	}
+	out := new(HTTPSourceResult)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Image) DeepCopyInto(out *Image) {
 	*out = *in
@@ -804,6 +1038,28 @@ func (in *Image) DeepCopyInto(out *Image) {
 			(*out)[key] = val
 		}
 	}
+	if in.AdditionalTags != nil {
+		in, out := &in.AdditionalTags, &out.AdditionalTags
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Signing != nil {
+		in, out := &in.Signing, &out.Signing
+		*out = new(ImageSigning)
+		**out = **in
+	}
+	if in.SBOM != nil {
+		in, out := &in.SBOM, &out.SBOM
+		*out = new(ImageSBOM)
+		**out = **in
+	}
+	if in.Mirrors != nil {
+		in, out := &in.Mirrors, &out.Mirrors
+		*out = make([]ImageMirror, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	return
 }
 
@@ -817,6 +1073,84 @@ func (in *Image) DeepCopy() *Image {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ImageMirror) DeepCopyInto(out *ImageMirror) {
+	*out = *in
+	if in.Insecure != nil {
+		in, out := &in.Insecure, &out.Insecure
+		*out = new(bool)
+		**out = **in
+	}
+	if in.PushSecret != nil {
+		in, out := &in.PushSecret, &out.PushSecret
+		*out = new(string)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ImageMirror.
+func (in *ImageMirror) DeepCopy() *ImageMirror {
+	if in == nil {
+		return nil
+	}
+	out := new(ImageMirror)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ImageSBOM) DeepCopyInto(out *ImageSBOM) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ImageSBOM.
+func (in *ImageSBOM) DeepCopy() *ImageSBOM {
+	if in == nil {
+		return nil
+	}
+	out := new(ImageSBOM)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ImageSigning) DeepCopyInto(out *ImageSigning) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ImageSigning.
+func (in *ImageSigning) DeepCopy() *ImageSigning {
+	if in == nil {
+		return nil
+	}
+	out := new(ImageSigning)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ImageTriggerStatus) DeepCopyInto(out *ImageTriggerStatus) {
+	*out = *in
+	if in.LastChangeTime != nil {
+		in, out := &in.LastChangeTime, &out.LastChangeTime
+		*out = (*in).DeepCopy()
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ImageTriggerStatus.
+func (in *ImageTriggerStatus) DeepCopy() *ImageTriggerStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ImageTriggerStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Local) DeepCopyInto(out *Local) {
 	*out = *in
@@ -825,6 +1159,11 @@ func (in *Local) DeepCopyInto(out *Local) {
 		*out = new(v1.Duration)
 		**out = **in
 	}
+	if in.UploadSecret != nil {
+		in, out := &in.UploadSecret, &out.UploadSecret
+		*out = new(string)
+		**out = **in
+	}
 	return
 }
 
@@ -854,6 +1193,53 @@ func (in *Location) DeepCopy() *Location {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MirrorResult) DeepCopyInto(out *MirrorResult) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MirrorResult.
+func (in *MirrorResult) DeepCopy() *MirrorResult {
+	if in == nil {
+		return nil
+	}
+	out := new(MirrorResult)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NamedSource) DeepCopyInto(out *NamedSource) {
+	*out = *in
+	if in.OCIArtifact != nil {
+		in, out := &in.OCIArtifact, &out.OCIArtifact
+		*out = new(OCIArtifact)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.GitSource != nil {
+		in, out := &in.GitSource, &out.GitSource
+		*out = new(Git)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.HTTPSource != nil {
+		in, out := &in.HTTPSource, &out.HTTPSource
+		*out = new(HTTP)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NamedSource.
+func (in *NamedSource) DeepCopy() *NamedSource {
+	if in == nil {
+		return nil
+	}
+	out := new(NamedSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *OCIArtifact) DeepCopyInto(out *OCIArtifact) {
 	*out = *in
@@ -867,6 +1253,11 @@ func (in *OCIArtifact) DeepCopyInto(out *OCIArtifact) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.VerifySignature != nil {
+		in, out := &in.VerifySignature, &out.VerifySignature
+		*out = new(OCIArtifactSignatureVerification)
+		**out = **in
+	}
 	return
 }
 
@@ -880,6 +1271,22 @@ func (in *OCIArtifact) DeepCopy() *OCIArtifact {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OCIArtifactSignatureVerification) DeepCopyInto(out *OCIArtifactSignatureVerification) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OCIArtifactSignatureVerification.
+func (in *OCIArtifactSignatureVerification) DeepCopy() *OCIArtifactSignatureVerification {
+	if in == nil {
+		return nil
+	}
+	out := new(OCIArtifactSignatureVerification)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ObjectKeyRef) DeepCopyInto(out *ObjectKeyRef) {
 	*out = *in
@@ -920,6 +1327,16 @@ func (in *OciArtifactSourceResult) DeepCopy() *OciArtifactSourceResult {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Output) DeepCopyInto(out *Output) {
 	*out = *in
+	if in.Tags != nil {
+		in, out := &in.Tags, &out.Tags
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Mirrors != nil {
+		in, out := &in.Mirrors, &out.Mirrors
+		*out = make([]MirrorResult, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -1017,6 +1434,30 @@ func (in *ReferencedBuild) DeepCopy() *ReferencedBuild {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ScheduleTriggerStatus) DeepCopyInto(out *ScheduleTriggerStatus) {
+	*out = *in
+	if in.LastScheduleTime != nil {
+		in, out := &in.LastScheduleTime, &out.LastScheduleTime
+		*out = (*in).DeepCopy()
+	}
+	if in.NextScheduleTime != nil {
+		in, out := &in.NextScheduleTime, &out.NextScheduleTime
+		*out = (*in).DeepCopy()
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScheduleTriggerStatus.
+func (in *ScheduleTriggerStatus) DeepCopy() *ScheduleTriggerStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ScheduleTriggerStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SingleValue) DeepCopyInto(out *SingleValue) {
 	*out = *in
@@ -1071,6 +1512,11 @@ func (in *Source) DeepCopyInto(out *Source) {
 		*out = new(Local)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.HTTPSource != nil {
+		in, out := &in.HTTPSource, &out.HTTPSource
+		*out = new(HTTP)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -1090,13 +1536,18 @@ func (in *SourceResult) DeepCopyInto(out *SourceResult) {
 	if in.Git != nil {
 		in, out := &in.Git, &out.Git
 		*out = new(GitSourceResult)
-		**out = **in
+		(*in).DeepCopyInto(*out)
 	}
 	if in.OciArtifact != nil {
 		in, out := &in.OciArtifact, &out.OciArtifact
 		*out = new(OciArtifactSourceResult)
 		**out = **in
 	}
+	if in.HTTP != nil {
+		in, out := &in.HTTP, &out.HTTP
+		*out = new(HTTPSourceResult)
+		**out = **in
+	}
 	return
 }
 
@@ -1110,6 +1561,27 @@ func (in *SourceResult) DeepCopy() *SourceResult {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SparseCheckout) DeepCopyInto(out *SparseCheckout) {
+	*out = *in
+	if in.Paths != nil {
+		in, out := &in.Paths, &out.Paths
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SparseCheckout.
+func (in *SparseCheckout) DeepCopy() *SparseCheckout {
+	if in == nil {
+		return nil
+	}
+	out := new(SparseCheckout)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Step) DeepCopyInto(out *Step) {
 	*out = *in
@@ -1192,6 +1664,11 @@ func (in *Trigger) DeepCopyInto(out *Trigger) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.CommitStatus != nil {
+		in, out := &in.CommitStatus, &out.CommitStatus
+		*out = new(CommitStatus)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -1205,6 +1682,36 @@ func (in *Trigger) DeepCopy() *Trigger {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TriggerStatus) DeepCopyInto(out *TriggerStatus) {
+	*out = *in
+	if in.Images != nil {
+		in, out := &in.Images, &out.Images
+		*out = make([]ImageTriggerStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Schedules != nil {
+		in, out := &in.Schedules, &out.Schedules
+		*out = make([]ScheduleTriggerStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TriggerStatus.
+func (in *TriggerStatus) DeepCopy() *TriggerStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(TriggerStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TriggerWhen) DeepCopyInto(out *TriggerWhen) {
 	*out = *in
@@ -1213,11 +1720,31 @@ func (in *TriggerWhen) DeepCopyInto(out *TriggerWhen) {
 		*out = new(WhenGitHub)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.GitLab != nil {
+		in, out := &in.GitLab, &out.GitLab
+		*out = new(WhenGitLab)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Gitea != nil {
+		in, out := &in.Gitea, &out.Gitea
+		*out = new(WhenGitea)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Bitbucket != nil {
+		in, out := &in.Bitbucket, &out.Bitbucket
+		*out = new(WhenBitbucket)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Image != nil {
 		in, out := &in.Image, &out.Image
 		*out = new(WhenImage)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Schedule != nil {
+		in, out := &in.Schedule, &out.Schedule
+		*out = new(WhenSchedule)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.ObjectRef != nil {
 		in, out := &in.ObjectRef, &out.ObjectRef
 		*out = new(WhenObjectRef)
@@ -1236,6 +1763,37 @@ func (in *TriggerWhen) DeepCopy() *TriggerWhen {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WhenBitbucket) DeepCopyInto(out *WhenBitbucket) {
+	*out = *in
+	if in.Events != nil {
+		in, out := &in.Events, &out.Events
+		*out = make([]BitbucketEventName, len(*in))
+		copy(*out, *in)
+	}
+	if in.Branches != nil {
+		in, out := &in.Branches, &out.Branches
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Paths != nil {
+		in, out := &in.Paths, &out.Paths
+		*out = new(WhenPaths)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WhenBitbucket.
+func (in *WhenBitbucket) DeepCopy() *WhenBitbucket {
+	if in == nil {
+		return nil
+	}
+	out := new(WhenBitbucket)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *WhenGitHub) DeepCopyInto(out *WhenGitHub) {
 	*out = *in
@@ -1249,6 +1807,11 @@ func (in *WhenGitHub) DeepCopyInto(out *WhenGitHub) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.Paths != nil {
+		in, out := &in.Paths, &out.Paths
+		*out = new(WhenPaths)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -1262,6 +1825,68 @@ func (in *WhenGitHub) DeepCopy() *WhenGitHub {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WhenGitLab) DeepCopyInto(out *WhenGitLab) {
+	*out = *in
+	if in.Events != nil {
+		in, out := &in.Events, &out.Events
+		*out = make([]GitLabEventName, len(*in))
+		copy(*out, *in)
+	}
+	if in.Branches != nil {
+		in, out := &in.Branches, &out.Branches
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Paths != nil {
+		in, out := &in.Paths, &out.Paths
+		*out = new(WhenPaths)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WhenGitLab.
+func (in *WhenGitLab) DeepCopy() *WhenGitLab {
+	if in == nil {
+		return nil
+	}
+	out := new(WhenGitLab)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WhenGitea) DeepCopyInto(out *WhenGitea) {
+	*out = *in
+	if in.Events != nil {
+		in, out := &in.Events, &out.Events
+		*out = make([]GiteaEventName, len(*in))
+		copy(*out, *in)
+	}
+	if in.Branches != nil {
+		in, out := &in.Branches, &out.Branches
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Paths != nil {
+		in, out := &in.Paths, &out.Paths
+		*out = new(WhenPaths)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WhenGitea.
+func (in *WhenGitea) DeepCopy() *WhenGitea {
+	if in == nil {
+		return nil
+	}
+	out := new(WhenGitea)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *WhenImage) DeepCopyInto(out *WhenImage) {
 	*out = *in
@@ -1270,6 +1895,11 @@ func (in *WhenImage) DeepCopyInto(out *WhenImage) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.PullSecret != nil {
+		in, out := &in.PullSecret, &out.PullSecret
+		*out = new(string)
+		**out = **in
+	}
 	return
 }
 
@@ -1310,3 +1940,50 @@ func (in *WhenObjectRef) DeepCopy() *WhenObjectRef {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WhenPaths) DeepCopyInto(out *WhenPaths) {
+	*out = *in
+	if in.Include != nil {
+		in, out := &in.Include, &out.Include
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Exclude != nil {
+		in, out := &in.Exclude, &out.Exclude
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WhenPaths.
+func (in *WhenPaths) DeepCopy() *WhenPaths {
+	if in == nil {
+		return nil
+	}
+	out := new(WhenPaths)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WhenSchedule) DeepCopyInto(out *WhenSchedule) {
+	*out = *in
+	if in.TimeZone != nil {
+		in, out := &in.TimeZone, &out.TimeZone
+		*out = new(string)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WhenSchedule.
+func (in *WhenSchedule) DeepCopy() *WhenSchedule {
+	if in == nil {
+		return nil
+	}
+	out := new(WhenSchedule)
+	in.DeepCopyInto(out)
+	return out
+}
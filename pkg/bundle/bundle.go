@@ -8,6 +8,7 @@ import (
 	"archive/tar"
 	"bufio"
 	"fmt"
+	"hash/fnv"
 	"io"
 	"io/fs"
 	"os"
@@ -16,31 +17,53 @@ import (
 	"time"
 
 	"github.com/go-git/go-git/v5/plumbing/format/gitignore"
+	"github.com/google/go-containerregistry/pkg/compression"
 	"github.com/google/go-containerregistry/pkg/name"
 	containerreg "github.com/google/go-containerregistry/pkg/v1"
 	"github.com/google/go-containerregistry/pkg/v1/empty"
 	"github.com/google/go-containerregistry/pkg/v1/mutate"
 	"github.com/google/go-containerregistry/pkg/v1/remote"
 	"github.com/google/go-containerregistry/pkg/v1/tarball"
+	"github.com/google/go-containerregistry/pkg/v1/types"
 )
 
 const shpIgnoreFilename = ".shpignore"
 
+// chunkBoundaryModulus defines how often a file path ends a chunk of the
+// chunked layout, on average every 64th file ends a chunk
+const chunkBoundaryModulus = 64
+
+// PackOptions configures how a directory is packed into a bundle image
+type PackOptions struct {
+	// Compression of the image layers, either gzip (the default) or zstd
+	Compression compression.Compression
+
+	// ChunkSize enables the chunked layout, in which the directory content is
+	// split into multiple layers, so that only the layers with changed files
+	// have to be pushed again. A chunk ends at a content-defined boundary, or
+	// once its file content reaches the size in bytes. Zero uses one layer.
+	ChunkSize int64
+}
+
+// entry is a file or directory that is packed into the tar stream
+type entry struct {
+	name string
+	path string
+	info fs.FileInfo
+}
+
 // PackAndPush a local directory as-is into a container image. See
 // remote.Option for optional options to the image push to the registry, for
 // example to provide the appropriate access credentials.
 func PackAndPush(ref name.Reference, directory string, options ...remote.Option) (name.Digest, error) {
-	bundleLayer, err := tarball.LayerFromOpener(func() (io.ReadCloser, error) { return Pack(directory) })
-	if err != nil {
-		return name.Digest{}, err
-	}
-
-	image, err := mutate.AppendLayers(empty.Image, bundleLayer)
-	if err != nil {
-		return name.Digest{}, err
-	}
+	return PackAndPushWithOptions(ref, directory, PackOptions{}, options...)
+}
 
-	image, err = mutate.Time(image, time.Unix(0, 0))
+// PackAndPushWithOptions packs a local directory into a container image like
+// PackAndPush, using the compression and layout of the provided PackOptions.
+// Identical directory trees result in the same image digest.
+func PackAndPushWithOptions(ref name.Reference, directory string, packOptions PackOptions, options ...remote.Option) (name.Digest, error) {
+	image, err := packImage(directory, packOptions)
 	if err != nil {
 		return name.Digest{}, err
 	}
@@ -60,10 +83,64 @@ func PackAndPush(ref name.Reference, directory string, options ...remote.Option)
 	))
 }
 
+func packImage(directory string, packOptions PackOptions) (containerreg.Image, error) {
+	var layerOptions []tarball.LayerOption
+	switch packOptions.Compression {
+	case "", compression.GZip:
+		// gzip is the default compression of tarball layers
+
+	case compression.ZStd:
+		layerOptions = append(layerOptions, tarball.WithCompression(compression.ZStd), tarball.WithMediaType(types.OCILayerZStd))
+
+	default:
+		return nil, fmt.Errorf("unsupported compression %q, supported are %q and %q", packOptions.Compression, compression.GZip, compression.ZStd)
+	}
+
+	entries, err := walk(directory)
+	if err != nil {
+		return nil, err
+	}
+
+	var layers []containerreg.Layer
+	for _, chunk := range chunk(entries, packOptions.ChunkSize) {
+		chunk := chunk
+		layer, err := tarball.LayerFromOpener(func() (io.ReadCloser, error) { return pack(chunk), nil }, layerOptions...)
+		if err != nil {
+			return nil, err
+		}
+
+		layers = append(layers, layer)
+	}
+
+	image := empty.Image
+	if packOptions.Compression == compression.ZStd {
+		// zstd compressed layers are only defined for OCI images
+		image = mutate.MediaType(image, types.OCIManifestSchema1)
+		image = mutate.ConfigMediaType(image, types.OCIConfigJSON)
+	}
+
+	image, err = mutate.AppendLayers(image, layers...)
+	if err != nil {
+		return nil, err
+	}
+
+	// the timestamps of the layer content are already normalized, therefore
+	// only the creation time of the image is set, mutate.Time would recompress
+	// all layers using gzip
+	return mutate.CreatedAt(image, containerreg.Time{Time: time.Unix(0, 0)})
+}
+
 // PullAndUnpack a container image layer content into a local directory. Analog
 // to the bundle.PackAndPush function, optional remote.Option can be used to
 // configure settings for the image pull, i.e. access credentials.
 func PullAndUnpack(ref name.Reference, targetPath string, options ...remote.Option) (containerreg.Image, error) {
+	return PullAndUnpackWithOptions(ref, targetPath, UnpackOptions{}, options...)
+}
+
+// PullAndUnpackWithOptions pulls a container image and unpacks its layer content
+// into a local directory like PullAndUnpack, enforcing the limits of the
+// provided UnpackOptions.
+func PullAndUnpackWithOptions(ref name.Reference, targetPath string, unpackOptions UnpackOptions, options ...remote.Option) (containerreg.Image, error) {
 	desc, err := remote.Get(ref, options...)
 	if err != nil {
 		return nil, err
@@ -77,7 +154,7 @@ func PullAndUnpack(ref name.Reference, targetPath string, options ...remote.Opti
 	rc := mutate.Extract(image)
 	defer rc.Close()
 
-	if err = Unpack(rc, targetPath); err != nil {
+	if err = UnpackWithOptions(rc, targetPath, unpackOptions); err != nil {
 		return nil, err
 	}
 
@@ -88,20 +165,22 @@ func PullAndUnpack(ref name.Reference, targetPath string, options ...remote.Opti
 // - storing all directories and regular files as-is,
 // - dereferencing all symlinks and storing the respective target,
 // - ignoring all files configured in .shpignore
+//
+// The tar stream is reproducible, the entries are sorted by name, and their
+// timestamps and ownership are normalized.
 func Pack(directory string) (io.ReadCloser, error) {
-	var split = func(path string) []string { return strings.Split(path, string(filepath.Separator)) }
-
-	var write = func(w io.Writer, path string) error {
-		file, err := os.Open(path)
-		if err != nil {
-			return err
-		}
+	entries, err := walk(directory)
+	if err != nil {
+		return nil, err
+	}
 
-		defer file.Close()
+	return pack(entries), nil
+}
 
-		_, err = io.Copy(w, file)
-		return err
-	}
+// walk lists the entries of the directory in lexical order, skipping the
+// files on the ignore list and dereferencing symlinks
+func walk(directory string) ([]entry, error) {
+	var split = func(path string) []string { return strings.Split(path, string(filepath.Separator)) }
 
 	var followSymLink = func(path string) (string, os.FileInfo, error) {
 		deref, err := os.Readlink(path)
@@ -140,18 +219,8 @@ func Pack(directory string) (io.ReadCloser, error) {
 
 	matcher := gitignore.NewMatcher(patterns)
 
-	r, w, err := os.Pipe()
-	if err != nil {
-		return nil, err
-	}
-
-	var tw = tar.NewWriter(w)
-	defer func() {
-		_ = tw.Close()
-		_ = w.Close()
-	}()
-
-	err = filepath.WalkDir(directory, func(path string, d fs.DirEntry, err error) error {
+	var entries []entry
+	err := filepath.WalkDir(directory, func(path string, d fs.DirEntry, err error) error {
 		// Bail out on path errors
 		if err != nil {
 			return err
@@ -171,26 +240,14 @@ func Pack(directory string) (io.ReadCloser, error) {
 			return err
 		}
 
-		header, err := tar.FileInfoHeader(info, path)
-		if err != nil {
-			return err
-		}
-
-		header.Name, err = filepath.Rel(directory, path)
+		name, err := filepath.Rel(directory, path)
 		if err != nil {
 			return err
 		}
 
 		switch {
-		case info.Mode().IsDir():
-			return tw.WriteHeader(header)
-
-		case info.Mode().IsRegular():
-			if err := tw.WriteHeader(header); err != nil {
-				return err
-			}
-
-			return write(tw, path)
+		case info.Mode().IsDir(), info.Mode().IsRegular():
+			entries = append(entries, entry{name: name, path: path, info: info})
 
 		case info.Mode()&os.ModeSymlink == os.ModeSymlink:
 			deref, info, err := followSymLink(path)
@@ -198,80 +255,110 @@ func Pack(directory string) (io.ReadCloser, error) {
 				return err
 			}
 
-			header, err = tar.FileInfoHeader(info, deref)
-			if err != nil {
-				return err
-			}
-
-			header.Name, err = filepath.Rel(directory, path)
-			if err != nil {
-				return err
-			}
-
-			if err := tw.WriteHeader(header); err != nil {
-				return err
+			if !info.Mode().IsRegular() && !info.Mode().IsDir() {
+				return fmt.Errorf("unsupported file type: %s", path)
 			}
 
-			return write(tw, deref)
+			entries = append(entries, entry{name: name, path: deref, info: info})
 
 		default:
 			return fmt.Errorf("unsupported file type: %s", path)
 		}
+
+		return nil
 	})
 
-	return r, err
+	return entries, err
 }
 
-// Unpack reads a tar stream and writes the content into the local file system
-// with all files and directories.
-func Unpack(in io.Reader, targetPath string) error {
-	var tr = tar.NewReader(in)
-	for {
-		header, err := tr.Next()
-		switch {
-		case err == io.EOF:
-			return nil
+// chunk splits the entries into chunks, a chunk ends after an entry whose
+// name hash is a boundary, or once the file content reaches the chunk size.
+// As the boundaries depend on the names, changing a file only changes the
+// chunk that contains the file, and adding or removing files only changes
+// the chunks up to the next boundary.
+func chunk(entries []entry, size int64) [][]entry {
+	if size <= 0 {
+		return [][]entry{entries}
+	}
 
-		case err != nil:
-			return err
+	var (
+		chunks  [][]entry
+		current []entry
+		total   int64
+	)
 
-		case header == nil:
-			continue
+	for _, e := range entries {
+		current = append(current, e)
+		if e.info.Mode().IsRegular() {
+			total += e.info.Size()
 		}
 
-		var target = filepath.Join(targetPath, header.Name)
-		if strings.Contains(target, "/../") {
-			return fmt.Errorf("targetPath validation failed, path contains unexpected special elements")
+		hash := fnv.New32a()
+		_, _ = hash.Write([]byte(e.name))
+
+		if total >= size || hash.Sum32()%chunkBoundaryModulus == 0 {
+			chunks = append(chunks, current)
+			current, total = nil, 0
 		}
+	}
 
-		switch header.Typeflag {
-		case tar.TypeDir:
-			if err := os.MkdirAll(target, os.FileMode(header.Mode)); err != nil {
-				return err
-			}
+	if len(current) > 0 {
+		chunks = append(chunks, current)
+	}
 
-		case tar.TypeReg:
-			// Edge case in which that tarball did not have a directory entry
-			dir, _ := filepath.Split(target)
-			if err := os.MkdirAll(dir, os.FileMode(0755)); err != nil {
-				return err
-			}
+	return chunks
+}
 
-			file, err := os.OpenFile(target, os.O_CREATE|os.O_RDWR, os.FileMode(header.Mode))
+// pack writes the entries into a tar stream with normalized timestamps and
+// ownership, so that the same entries always result in the same tar stream
+func pack(entries []entry) io.ReadCloser {
+	var write = func(w io.Writer, path string) error {
+		file, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+
+		defer file.Close()
+
+		_, err = io.Copy(w, file)
+		return err
+	}
+
+	r, w := io.Pipe()
+
+	go func() {
+		tw := tar.NewWriter(w)
+
+		for _, e := range entries {
+			header, err := tar.FileInfoHeader(e.info, "")
 			if err != nil {
-				return err
+				_ = w.CloseWithError(err)
+				return
 			}
 
-			if _, err := io.Copy(file, tr); err != nil {
-				file.Close()
-				return err
-			}
+			header.Name = filepath.ToSlash(e.name)
+			header.ModTime = time.Unix(0, 0)
+			header.AccessTime = time.Time{}
+			header.ChangeTime = time.Time{}
+			header.Uid, header.Gid = 0, 0
+			header.Uname, header.Gname = "", ""
+			header.Format = tar.FormatPAX
 
-			file.Close()
-			os.Chtimes(target, header.AccessTime, header.ModTime)
+			if err := tw.WriteHeader(header); err != nil {
+				_ = w.CloseWithError(err)
+				return
+			}
 
-		default:
-			return fmt.Errorf("provided tarball contains unsupported file type, only directories and regular files are supported")
+			if e.info.Mode().IsRegular() {
+				if err := write(tw, e.path); err != nil {
+					_ = w.CloseWithError(err)
+					return
+				}
+			}
 		}
-	}
+
+		_ = w.CloseWithError(tw.Close())
+	}()
+
+	return r
 }
@@ -11,13 +11,17 @@ import (
 	"net/url"
 	"os"
 	"path/filepath"
+	"time"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	. "github.com/shipwright-io/build/pkg/bundle"
 
+	"github.com/google/go-containerregistry/pkg/compression"
 	"github.com/google/go-containerregistry/pkg/name"
 	"github.com/google/go-containerregistry/pkg/registry"
+	containerreg "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
 	"k8s.io/apimachinery/pkg/util/rand"
 )
 
@@ -89,4 +93,133 @@ var _ = Describe("Bundle", func() {
 			})
 		})
 	})
+
+	Context("reproducible bundles", func() {
+		writeFiles := func(dir string, files map[string]string) {
+			for name, content := range files {
+				path := filepath.Join(dir, name)
+				Expect(os.MkdirAll(filepath.Dir(path), 0755)).To(Succeed())
+				Expect(os.WriteFile(path, []byte(content), 0644)).To(Succeed())
+			}
+		}
+
+		testFiles := func(count int) map[string]string {
+			files := map[string]string{}
+			for i := 0; i < count; i++ {
+				files[fmt.Sprintf("dir-%d/file-%d.txt", i%7, i)] = fmt.Sprintf("content of file %d", i)
+			}
+			return files
+		}
+
+		pushAndGet := func(endpoint string, dir string, options PackOptions) (name.Digest, containerreg.Image) {
+			ref, err := name.ParseReference(fmt.Sprintf("%s/namespace/unit-test-pkg-bundle-%s:latest", endpoint, rand.String(5)))
+			Expect(err).ToNot(HaveOccurred())
+
+			digest, err := PackAndPushWithOptions(ref, dir, options)
+			Expect(err).ToNot(HaveOccurred())
+
+			image, err := remote.Image(digest)
+			Expect(err).ToNot(HaveOccurred())
+
+			return digest, image
+		}
+
+		layerDigests := func(image containerreg.Image) []string {
+			layers, err := image.Layers()
+			Expect(err).ToNot(HaveOccurred())
+
+			var digests []string
+			for _, layer := range layers {
+				digest, err := layer.Digest()
+				Expect(err).ToNot(HaveOccurred())
+				digests = append(digests, digest.String())
+			}
+			return digests
+		}
+
+		It("should create the same digest for identical directory trees", func() {
+			withTempRegistry(func(endpoint string) {
+				withTempDir(func(first string) {
+					withTempDir(func(second string) {
+						writeFiles(first, testFiles(10))
+						writeFiles(second, testFiles(10))
+						Expect(os.Chtimes(filepath.Join(second, "dir-1", "file-1.txt"), time.Now(), time.Now().Add(-time.Hour))).To(Succeed())
+
+						firstDigest, _ := pushAndGet(endpoint, first, PackOptions{})
+						secondDigest, _ := pushAndGet(endpoint, second, PackOptions{})
+						Expect(firstDigest.DigestStr()).To(Equal(secondDigest.DigestStr()))
+					})
+				})
+			})
+		})
+
+		It("should pack and unpack a zstd compressed image", func() {
+			withTempRegistry(func(endpoint string) {
+				digest, image := pushAndGet(endpoint, filepath.Join("..", "..", "test", "bundle"), PackOptions{Compression: compression.ZStd})
+
+				layers, err := image.Layers()
+				Expect(err).ToNot(HaveOccurred())
+				Expect(layers).To(HaveLen(1))
+
+				mediaType, err := layers[0].MediaType()
+				Expect(err).ToNot(HaveOccurred())
+				Expect(string(mediaType)).To(ContainSubstring("zstd"))
+
+				withTempDir(func(tempDir string) {
+					_, err := PullAndUnpack(digest, tempDir)
+					Expect(err).ToNot(HaveOccurred())
+					Expect(filepath.Join(tempDir, "README.md")).To(BeAnExistingFile())
+					Expect(filepath.Join(tempDir, "linktofile")).To(BeAnExistingFile())
+				})
+			})
+		})
+
+		It("should fail for an unsupported compression", func() {
+			ref, err := name.ParseReference("registry.example.com/namespace/bundle:latest")
+			Expect(err).ToNot(HaveOccurred())
+
+			_, err = PackAndPushWithOptions(ref, filepath.Join("..", "..", "test", "bundle"), PackOptions{Compression: compression.None})
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("should only change the chunk of a changed file", func() {
+			withTempRegistry(func(endpoint string) {
+				withTempDir(func(dir string) {
+					files := testFiles(500)
+					writeFiles(dir, files)
+
+					options := PackOptions{ChunkSize: 1024}
+					_, before := pushAndGet(endpoint, dir, options)
+					beforeLayers := layerDigests(before)
+					Expect(len(beforeLayers)).To(BeNumerically(">", 1))
+
+					writeFiles(dir, map[string]string{"dir-3/file-250.txt": "changed content"})
+					digest, after := pushAndGet(endpoint, dir, options)
+					afterLayers := layerDigests(after)
+					Expect(afterLayers).To(HaveLen(len(beforeLayers)))
+
+					var changed int
+					for i := range beforeLayers {
+						if beforeLayers[i] != afterLayers[i] {
+							changed++
+						}
+					}
+					Expect(changed).To(Equal(1))
+
+					withTempDir(func(tempDir string) {
+						_, err := PullAndUnpack(digest, tempDir)
+						Expect(err).ToNot(HaveOccurred())
+
+						for name := range files {
+							Expect(filepath.Join(tempDir, name)).To(BeAnExistingFile())
+						}
+
+						content, err := os.ReadFile(filepath.Join(tempDir, "dir-3", "file-250.txt"))
+						Expect(err).ToNot(HaveOccurred())
+						Expect(string(content)).To(Equal("changed content"))
+					})
+				})
+			})
+		})
+	})
 })
@@ -0,0 +1,42 @@
+// Copyright The Shipwright Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package bundle
+
+import (
+	"crypto"
+
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+
+	"github.com/shipwright-io/build/pkg/image"
+)
+
+// ErrSignatureVerificationFailed is returned when the bundle image is not
+// signed by any of the trusted public keys
+var ErrSignatureVerificationFailed = image.ErrSignatureVerificationFailed
+
+// SignatureVerificationFailedReason is the failure reason of a bundle image
+// that is not signed by any of the trusted public keys
+const SignatureVerificationFailedReason = "BundleSignatureVerificationFailed"
+
+// Sign creates a signature of the bundle image digest using the private key,
+// and pushes it into the repository of the bundle image. See remote.Option for
+// optional options to the image push to the registry.
+func Sign(digest name.Digest, key crypto.Signer, options ...remote.Option) error {
+	_, err := image.Sign(digest, key, options...)
+	return err
+}
+
+// VerifySignature checks that the bundle image digest is signed by one of the
+// trusted public keys, see image.VerifySignature
+func VerifySignature(digest name.Digest, keys []crypto.PublicKey, options ...remote.Option) error {
+	return image.VerifySignature(digest, keys, options...)
+}
+
+// LoadPublicKeys reads the PEM encoded public keys of all files in the
+// directory, for example the mount directory of a Secret
+func LoadPublicKeys(directory string) ([]crypto.PublicKey, error) {
+	return image.LoadPublicKeys(directory)
+}
@@ -0,0 +1,134 @@
+// Copyright The Shipwright Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package bundle_test
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"log"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/shipwright-io/build/pkg/bundle"
+
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/registry"
+	k8srand "k8s.io/apimachinery/pkg/util/rand"
+)
+
+var _ = Describe("Signature", func() {
+	var endpoint string
+
+	BeforeEach(func() {
+		logLogger := log.Logger{}
+		logLogger.SetOutput(GinkgoWriter)
+
+		s := httptest.NewServer(registry.New(registry.Logger(&logLogger)))
+		DeferCleanup(s.Close)
+
+		u, err := url.Parse(s.URL)
+		Expect(err).ToNot(HaveOccurred())
+		endpoint = u.Host
+	})
+
+	pushBundle := func(directory string) name.Digest {
+		ref, err := name.ParseReference(fmt.Sprintf("%s/namespace/unit-test-pkg-bundle-%s:latest", endpoint, k8srand.String(5)))
+		Expect(err).ToNot(HaveOccurred())
+
+		digest, err := PackAndPush(ref, directory)
+		Expect(err).ToNot(HaveOccurred())
+
+		return digest
+	}
+
+	newECDSAKey := func() *ecdsa.PrivateKey {
+		key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		Expect(err).ToNot(HaveOccurred())
+		return key
+	}
+
+	DescribeTable("should verify a bundle signed by a trusted key",
+		func(newKey func() crypto.Signer) {
+			key := newKey()
+			digest := pushBundle(filepath.Join("..", "..", "test", "bundle"))
+
+			Expect(Sign(digest, key)).To(Succeed())
+			Expect(VerifySignature(digest, []crypto.PublicKey{key.Public()})).To(Succeed())
+		},
+		Entry("ECDSA", func() crypto.Signer { return newECDSAKey() }),
+		Entry("RSA", func() crypto.Signer {
+			key, err := rsa.GenerateKey(rand.Reader, 2048)
+			Expect(err).ToNot(HaveOccurred())
+			return key
+		}),
+		Entry("Ed25519", func() crypto.Signer {
+			_, key, err := ed25519.GenerateKey(rand.Reader)
+			Expect(err).ToNot(HaveOccurred())
+			return key
+		}),
+	)
+
+	It("should reject an unsigned bundle", func() {
+		digest := pushBundle(filepath.Join("..", "..", "test", "bundle"))
+
+		err := VerifySignature(digest, []crypto.PublicKey{newECDSAKey().Public()})
+		Expect(err).To(MatchError(ErrSignatureVerificationFailed))
+	})
+
+	It("should reject a bundle signed by an untrusted key", func() {
+		digest := pushBundle(filepath.Join("..", "..", "test", "bundle"))
+		Expect(Sign(digest, newECDSAKey())).To(Succeed())
+
+		err := VerifySignature(digest, []crypto.PublicKey{newECDSAKey().Public()})
+		Expect(err).To(MatchError(ErrSignatureVerificationFailed))
+	})
+
+	It("should reject a bundle that was changed after signing", func() {
+		key := newECDSAKey()
+
+		tempDir, err := os.MkdirTemp("", "bundle")
+		Expect(err).ToNot(HaveOccurred())
+		defer os.RemoveAll(tempDir)
+
+		Expect(os.WriteFile(filepath.Join(tempDir, "file"), []byte("original"), 0644)).To(Succeed())
+		signed := pushBundle(tempDir)
+		Expect(Sign(signed, key)).To(Succeed())
+
+		// push a changed bundle into the same repository
+		Expect(os.WriteFile(filepath.Join(tempDir, "file"), []byte("tampered"), 0644)).To(Succeed())
+		tampered, err := PackAndPush(signed.Context().Tag("latest"), tempDir)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(tampered.DigestStr()).ToNot(Equal(signed.DigestStr()))
+
+		err = VerifySignature(tampered, []crypto.PublicKey{key.Public()})
+		Expect(err).To(MatchError(ErrSignatureVerificationFailed))
+	})
+
+	It("should load the PEM encoded public keys of a directory", func() {
+		tempDir, err := os.MkdirTemp("", "keys")
+		Expect(err).ToNot(HaveOccurred())
+		defer os.RemoveAll(tempDir)
+
+		data, err := x509.MarshalPKIXPublicKey(newECDSAKey().Public())
+		Expect(err).ToNot(HaveOccurred())
+		Expect(os.WriteFile(filepath.Join(tempDir, "cosign.pub"), pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: data}), 0644)).To(Succeed())
+		Expect(os.Mkdir(filepath.Join(tempDir, "..data"), 0755)).To(Succeed())
+
+		keys, err := LoadPublicKeys(tempDir)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(keys).To(HaveLen(1))
+	})
+})
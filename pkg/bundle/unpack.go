@@ -0,0 +1,274 @@
+// Copyright The Shipwright Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package bundle
+
+import (
+	"archive/tar"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// UnpackErrorReason classifies why a tar stream was refused during unpacking
+type UnpackErrorReason string
+
+const (
+	// TotalSizeExceeded indicates that the content is larger than the total size limit
+	TotalSizeExceeded UnpackErrorReason = "BundleTotalSizeExceeded"
+
+	// FileCountExceeded indicates that the content has more entries than the file count limit
+	FileCountExceeded UnpackErrorReason = "BundleFileCountExceeded"
+
+	// FileSizeExceeded indicates that a file is larger than the file size limit
+	FileSizeExceeded UnpackErrorReason = "BundleFileSizeExceeded"
+
+	// PathTraversal indicates that an entry would be written outside of the target path
+	PathTraversal UnpackErrorReason = "BundlePathTraversal"
+
+	// SymlinkEscape indicates that a symbolic link points outside of the target path
+	SymlinkEscape UnpackErrorReason = "BundleSymlinkEscape"
+
+	// DeviceFile indicates that the content contains a device file or named pipe
+	DeviceFile UnpackErrorReason = "BundleDeviceFile"
+
+	// UnsupportedFileType indicates that the content contains an entry of an unsupported type
+	UnsupportedFileType UnpackErrorReason = "BundleUnsupportedFileType"
+)
+
+// UnpackError is returned when a tar stream violates the limits or the safety
+// rules of unpacking, the reason classifies the violation
+type UnpackError struct {
+	Reason  UnpackErrorReason
+	Message string
+}
+
+func (e *UnpackError) Error() string {
+	return e.Message
+}
+
+func unpackError(reason UnpackErrorReason, format string, args ...interface{}) error {
+	return &UnpackError{Reason: reason, Message: fmt.Sprintf(format, args...)}
+}
+
+// UnpackOptions configures the limits of unpacking a tar stream, a limit of
+// zero means that the respective property is not limited
+type UnpackOptions struct {
+	// MaxTotalSize is the maximum number of bytes of all files
+	MaxTotalSize int64
+
+	// MaxFileCount is the maximum number of files, directories, and links
+	MaxFileCount int64
+
+	// MaxFileSize is the maximum number of bytes of a single file
+	MaxFileSize int64
+}
+
+// Unpack reads a tar stream and writes the content into the local file system
+// with all files and directories.
+func Unpack(in io.Reader, targetPath string) error {
+	return UnpackWithOptions(in, targetPath, UnpackOptions{})
+}
+
+// UnpackWithOptions reads a tar stream and writes the content into the local
+// file system like Unpack, enforcing the limits of the provided UnpackOptions.
+// Entries that would be written outside of the target path, symbolic links
+// that point outside of it, and device files are refused. Violations result
+// in an UnpackError.
+func UnpackWithOptions(in io.Reader, targetPath string, options UnpackOptions) error {
+	root, err := filepath.Abs(targetPath)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(root, os.FileMode(0755)); err != nil {
+		return err
+	}
+
+	// the target path itself may be a symlink, i.e. a mounted volume
+	if root, err = filepath.EvalSymlinks(root); err != nil {
+		return err
+	}
+
+	var (
+		tr         = tar.NewReader(in)
+		fileCount  int64
+		totalBytes int64
+	)
+
+	for {
+		header, err := tr.Next()
+		switch {
+		case err == io.EOF:
+			return nil
+
+		case err != nil:
+			return err
+
+		case header == nil:
+			continue
+		}
+
+		fileCount++
+		if options.MaxFileCount > 0 && fileCount > options.MaxFileCount {
+			return unpackError(FileCountExceeded, "bundle contains more than the maximum of %d files", options.MaxFileCount)
+		}
+
+		name := filepath.Clean(filepath.FromSlash(header.Name))
+		if filepath.IsAbs(name) || name == ".." || strings.HasPrefix(name, ".."+string(filepath.Separator)) {
+			return unpackError(PathTraversal, "bundle entry %q would be written outside of the target path", header.Name)
+		}
+
+		if name == "." {
+			continue
+		}
+
+		// resolve the parent directory, following the links that were already unpacked
+		dir, err := resolveWithin(root, filepath.Dir(name))
+		if err != nil {
+			return unpackError(PathTraversal, "bundle entry %q would be written outside of the target path", header.Name)
+		}
+
+		var target = filepath.Join(dir, filepath.Base(name))
+
+		switch header.Typeflag {
+		case tar.TypeDir:
+			if err := os.MkdirAll(target, os.FileMode(header.Mode)); err != nil {
+				return err
+			}
+
+		case tar.TypeReg:
+			if options.MaxFileSize > 0 && header.Size > options.MaxFileSize {
+				return unpackError(FileSizeExceeded, "bundle file %q is larger than the maximum of %d bytes", header.Name, options.MaxFileSize)
+			}
+
+			if options.MaxTotalSize > 0 && totalBytes+header.Size > options.MaxTotalSize {
+				return unpackError(TotalSizeExceeded, "bundle content is larger than the maximum of %d bytes", options.MaxTotalSize)
+			}
+
+			// Edge case in which that tarball did not have a directory entry
+			if err := os.MkdirAll(dir, os.FileMode(0755)); err != nil {
+				return err
+			}
+
+			// an existing link is replaced by the file instead of writing through it
+			if info, err := os.Lstat(target); err == nil && info.Mode()&os.ModeSymlink != 0 {
+				if err := os.Remove(target); err != nil {
+					return err
+				}
+			}
+
+			file, err := os.OpenFile(target, os.O_CREATE|os.O_RDWR|os.O_TRUNC, os.FileMode(header.Mode))
+			if err != nil {
+				return err
+			}
+
+			written, err := io.CopyN(file, tr, header.Size)
+			file.Close()
+			if err != nil {
+				return err
+			}
+
+			totalBytes += written
+			os.Chtimes(target, header.AccessTime, header.ModTime)
+
+		case tar.TypeSymlink:
+			if filepath.IsAbs(header.Linkname) {
+				return unpackError(SymlinkEscape, "bundle symlink %q points to the absolute path %q", header.Name, header.Linkname)
+			}
+
+			// the link target is not cleaned, so that ".." elements are resolved like the file system does
+			if _, err := resolveWithin(root, filepath.Dir(name)+string(filepath.Separator)+header.Linkname); err != nil {
+				return unpackError(SymlinkEscape, "bundle symlink %q points outside of the target path", header.Name)
+			}
+
+			if err := os.MkdirAll(dir, os.FileMode(0755)); err != nil {
+				return err
+			}
+
+			if err := os.Symlink(header.Linkname, target); err != nil {
+				return err
+			}
+
+		case tar.TypeChar, tar.TypeBlock, tar.TypeFifo:
+			return unpackError(DeviceFile, "bundle entry %q is a device file or named pipe, which is not supported", header.Name)
+
+		default:
+			return unpackError(UnsupportedFileType, "provided tarball contains unsupported file type, only directories, regular files, and symbolic links are supported")
+		}
+	}
+}
+
+// resolveWithin resolves the relative path inside of the root directory,
+// following the symbolic links that exist on disk. It fails if the path, or
+// any of the links it passes, leads outside of the root directory. Components
+// that do not exist yet cannot be followed by "..", as they could become a
+// link later.
+func resolveWithin(root string, path string) (string, error) {
+	var (
+		current   = root
+		remaining = strings.Split(filepath.ToSlash(path), "/")
+		missing   = false
+		links     = 0
+	)
+
+	for len(remaining) > 0 {
+		component := remaining[0]
+		remaining = remaining[1:]
+
+		switch component {
+		case "", ".":
+			continue
+
+		case "..":
+			if missing || current == root {
+				return "", fmt.Errorf("path %q leads outside of %s", path, root)
+			}
+
+			current = filepath.Dir(current)
+			continue
+		}
+
+		next := filepath.Join(current, component)
+		if missing {
+			current = next
+			continue
+		}
+
+		info, err := os.Lstat(next)
+		switch {
+		case os.IsNotExist(err):
+			missing = true
+			current = next
+
+		case err != nil:
+			return "", err
+
+		case info.Mode()&os.ModeSymlink != 0:
+			links++
+			if links > 255 {
+				return "", fmt.Errorf("path %q contains too many links", path)
+			}
+
+			linkname, err := os.Readlink(next)
+			if err != nil {
+				return "", err
+			}
+
+			if filepath.IsAbs(linkname) {
+				return "", fmt.Errorf("path %q contains a link to the absolute path %q", path, linkname)
+			}
+
+			// continue with the link target, relative to the directory of the link
+			remaining = append(strings.Split(filepath.ToSlash(linkname), "/"), remaining...)
+
+		default:
+			current = next
+		}
+	}
+
+	return current, nil
+}
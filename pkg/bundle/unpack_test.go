@@ -0,0 +1,131 @@
+// Copyright The Shipwright Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package bundle_test
+
+import (
+	"archive/tar"
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/shipwright-io/build/pkg/bundle"
+)
+
+var _ = Describe("Unpack", func() {
+	type entry struct {
+		header  tar.Header
+		content string
+	}
+
+	file := func(name string, content string) entry {
+		return entry{header: tar.Header{Typeflag: tar.TypeReg, Name: name, Mode: 0644, Size: int64(len(content))}, content: content}
+	}
+
+	dir := func(name string) entry {
+		return entry{header: tar.Header{Typeflag: tar.TypeDir, Name: name, Mode: 0755}}
+	}
+
+	symlink := func(name string, linkname string) entry {
+		return entry{header: tar.Header{Typeflag: tar.TypeSymlink, Name: name, Linkname: linkname}}
+	}
+
+	tarball := func(entries ...entry) *bytes.Buffer {
+		var buf bytes.Buffer
+		tw := tar.NewWriter(&buf)
+		for _, e := range entries {
+			header := e.header
+			Expect(tw.WriteHeader(&header)).To(Succeed())
+			_, err := tw.Write([]byte(e.content))
+			Expect(err).ToNot(HaveOccurred())
+		}
+		Expect(tw.Close()).To(Succeed())
+		return &buf
+	}
+
+	var targetPath string
+
+	BeforeEach(func() {
+		tempDir, err := os.MkdirTemp("", "unpack")
+		Expect(err).ToNot(HaveOccurred())
+		DeferCleanup(os.RemoveAll, tempDir)
+
+		targetPath = filepath.Join(tempDir, "target")
+	})
+
+	unpack := func(options UnpackOptions, entries ...entry) error {
+		return UnpackWithOptions(tarball(entries...), targetPath, options)
+	}
+
+	reasonOf := func(err error) UnpackErrorReason {
+		var unpackErr *UnpackError
+		Expect(errors.As(err, &unpackErr)).To(BeTrue(), "expected an UnpackError, got %v", err)
+		return unpackErr.Reason
+	}
+
+	It("should unpack directories, files, and symbolic links inside of the target path", func() {
+		Expect(unpack(UnpackOptions{MaxTotalSize: 10, MaxFileCount: 4, MaxFileSize: 5},
+			dir("src"),
+			file("src/main.go", "hello"),
+			file("README.md", "world"),
+			symlink("src/readme", "../README.md"),
+		)).To(Succeed())
+
+		content, err := os.ReadFile(filepath.Join(targetPath, "src", "readme"))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(string(content)).To(Equal("world"))
+	})
+
+	It("should refuse more files than the file count limit", func() {
+		err := unpack(UnpackOptions{MaxFileCount: 1}, file("a", "a"), file("b", "b"))
+		Expect(reasonOf(err)).To(Equal(FileCountExceeded))
+	})
+
+	It("should refuse a file larger than the file size limit", func() {
+		err := unpack(UnpackOptions{MaxFileSize: 4}, file("a", "hello"))
+		Expect(reasonOf(err)).To(Equal(FileSizeExceeded))
+	})
+
+	It("should refuse content larger than the total size limit", func() {
+		err := unpack(UnpackOptions{MaxTotalSize: 8}, file("a", "hello"), file("b", "world"))
+		Expect(reasonOf(err)).To(Equal(TotalSizeExceeded))
+	})
+
+	It("should refuse a path that traverses out of the target path", func() {
+		err := unpack(UnpackOptions{}, file("../evil", "evil"))
+		Expect(reasonOf(err)).To(Equal(PathTraversal))
+		Expect(filepath.Join(filepath.Dir(targetPath), "evil")).ToNot(BeAnExistingFile())
+	})
+
+	It("should refuse a symbolic link that points out of the target path", func() {
+		err := unpack(UnpackOptions{}, symlink("link", "../../etc"))
+		Expect(reasonOf(err)).To(Equal(SymlinkEscape))
+	})
+
+	It("should refuse a symbolic link with an absolute target", func() {
+		err := unpack(UnpackOptions{}, symlink("link", "/etc/passwd"))
+		Expect(reasonOf(err)).To(Equal(SymlinkEscape))
+	})
+
+	It("should refuse a symbolic link that could escape once another link is unpacked", func() {
+		err := unpack(UnpackOptions{}, symlink("escape", "later/.."), symlink("later", "."))
+		Expect(reasonOf(err)).To(Equal(SymlinkEscape))
+	})
+
+	It("should refuse to write through a symbolic link out of the target path", func() {
+		Expect(os.MkdirAll(targetPath, 0755)).To(Succeed())
+		Expect(os.Symlink(filepath.Dir(targetPath), filepath.Join(targetPath, "outside"))).To(Succeed())
+
+		err := unpack(UnpackOptions{}, file("outside/evil", "evil"))
+		Expect(reasonOf(err)).To(Equal(PathTraversal))
+	})
+
+	It("should refuse device files", func() {
+		err := unpack(UnpackOptions{}, entry{header: tar.Header{Typeflag: tar.TypeChar, Name: "null", Devmajor: 1, Devminor: 3}})
+		Expect(reasonOf(err)).To(Equal(DeviceFile))
+	})
+})
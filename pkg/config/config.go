@@ -13,6 +13,7 @@ import (
 
 	"github.com/prometheus/client_golang/prometheus"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	"k8s.io/utils/pointer"
 )
 
@@ -22,9 +23,6 @@ const (
 	// E.g. if 5 seconds is wanted, the CTX_TIMEOUT=5
 	contextTimeoutEnvVar = "CTX_TIMEOUT"
 
-	remoteArtifactsDefaultImage = "quay.io/quay/busybox:latest"
-	remoteArtifactsEnvVar       = "REMOTE_ARTIFACTS_CONTAINER_IMAGE"
-
 	// the Git image is built using ko which can replace environment variable values in the deployment, so once we decide to move
 	// from environment variables to a ConfigMap, then we should move the container template, but retain the environment variable
 	// (or make it an argument like Tekton)
@@ -41,6 +39,22 @@ const (
 	bundleImageEnvVar             = "BUNDLE_CONTAINER_IMAGE"
 	bundleContainerTemplateEnvVar = "BUNDLE_CONTAINER_TEMPLATE"
 
+	// environment variables and defaults for the limits of unpacking bundle images
+	bundleMaxTotalSizeDefault = 10 * 1024 * 1024 * 1024
+	bundleMaxFileCountDefault = 1000000
+	bundleMaxTotalSizeEnvVar  = "BUNDLE_MAX_TOTAL_SIZE"
+	bundleMaxFileCountEnvVar  = "BUNDLE_MAX_FILE_COUNT"
+	bundleMaxFileSizeEnvVar   = "BUNDLE_MAX_FILE_SIZE"
+
+	// Analog to the bundle image, the HTTP image that downloads remote artifacts is also created by ko
+	httpDefaultImage            = "ghcr.io/shipwright-io/build/http:latest"
+	httpImageEnvVar             = "HTTP_CONTAINER_IMAGE"
+	httpContainerTemplateEnvVar = "HTTP_CONTAINER_TEMPLATE"
+
+	// Deprecated: the environment variable of the image that downloaded remote artifacts before the
+	// HTTP image, it is used as HTTP image if HTTP_CONTAINER_IMAGE is not defined
+	remoteArtifactsEnvVar = "REMOTE_ARTIFACTS_CONTAINER_IMAGE"
+
 	// environment variable to hold waiter's container image, created by ko
 	waiterDefaultImage            = "ghcr.io/shipwright-io/build/waiter:latest"
 	waiterImageEnvVar             = "WAITER_CONTAINER_IMAGE"
@@ -76,6 +90,13 @@ const (
 
 	// environment variable for the Git rewrite setting
 	useGitRewriteRule = "GIT_ENABLE_REWRITE_RULE"
+
+	// environment variable for the interval in which images of Image triggers are resolved
+	imageTriggerPollIntervalDefault = 5 * time.Minute
+	imageTriggerPollIntervalEnvVar  = "IMAGE_TRIGGER_POLL_INTERVAL"
+
+	// environment variable for the link to a BuildRun that is used in reported commit statuses
+	commitStatusTargetURLEnvVar = "COMMIT_STATUS_TARGET_URL"
 )
 
 var (
@@ -95,14 +116,16 @@ type Config struct {
 	GitContainerTemplate             Step
 	ImageProcessingContainerTemplate Step
 	BundleContainerTemplate          Step
+	BundleUnpackLimits               BundleUnpackLimits
 	WaiterContainerTemplate          Step
-	RemoteArtifactsContainerImage    string
+	HTTPContainerTemplate            Step
 	TerminationLogPath               string
 	Prometheus                       PrometheusConfig
 	ManagerOptions                   ManagerOptions
 	Controllers                      Controllers
 	KubeAPIOptions                   KubeAPIOptions
 	GitRewriteRule                   bool
+	Triggers                         TriggersConfig
 }
 
 // PrometheusConfig contains the specific configuration for the
@@ -134,6 +157,23 @@ type ControllerOptions struct {
 	MaxConcurrentReconciles int
 }
 
+// TriggersConfig contains the options for the Build triggers handled by the controller
+type TriggersConfig struct {
+	ImagePollInterval time.Duration
+
+	// CommitStatusTargetURL is the link to a BuildRun used in commit statuses, the
+	// placeholders {namespace} and {name} are replaced with the ones of the BuildRun
+	CommitStatusTargetURL string
+}
+
+// BundleUnpackLimits contains the limits of unpacking bundle images, zero means
+// that the respective property is not limited
+type BundleUnpackLimits struct {
+	MaxTotalSize int64
+	MaxFileCount int64
+	MaxFileSize  int64
+}
+
 // KubeAPIOptions contains configurable options for the kube API client
 type KubeAPIOptions struct {
 	QPS   int
@@ -154,10 +194,9 @@ type Step struct {
 // NewDefaultConfig returns a new Config, with context timeout and default Kaniko image.
 func NewDefaultConfig() *Config {
 	return &Config{
-		CtxTimeOut:                    contextTimeout,
-		RemoteArtifactsContainerImage: remoteArtifactsDefaultImage,
-		TerminationLogPath:            terminationLogPathDefault,
-		GitRewriteRule:                false,
+		CtxTimeOut:         contextTimeout,
+		TerminationLogPath: terminationLogPathDefault,
+		GitRewriteRule:     false,
 
 		GitContainerTemplate: Step{
 			Image: gitDefaultImage,
@@ -207,6 +246,30 @@ func NewDefaultConfig() *Config {
 			},
 		},
 
+		HTTPContainerTemplate: Step{
+			Image: httpDefaultImage,
+			Command: []string{
+				"/ko-app/http",
+			},
+			// This directory is created in the base image as writable for everybody
+			Env: []corev1.EnvVar{
+				{
+					Name:  "HOME",
+					Value: "/shared-home",
+				},
+			},
+			SecurityContext: &corev1.SecurityContext{
+				AllowPrivilegeEscalation: pointer.Bool(false),
+				Capabilities: &corev1.Capabilities{
+					Drop: []corev1.Capability{
+						"ALL",
+					},
+				},
+				RunAsUser:  nonRoot,
+				RunAsGroup: nonRoot,
+			},
+		},
+
 		ImageProcessingContainerTemplate: Step{
 			Image: imageProcessingDefaultImage,
 			Command: []string{
@@ -295,6 +358,15 @@ func NewDefaultConfig() *Config {
 			QPS:   0,
 			Burst: 0,
 		},
+
+		Triggers: TriggersConfig{
+			ImagePollInterval: imageTriggerPollIntervalDefault,
+		},
+
+		BundleUnpackLimits: BundleUnpackLimits{
+			MaxTotalSize: bundleMaxTotalSizeDefault,
+			MaxFileCount: bundleMaxFileCountDefault,
+		},
 	}
 }
 
@@ -373,8 +445,22 @@ func (c *Config) SetConfigFromEnv() error {
 		c.WaiterContainerTemplate.Image = waiterImage
 	}
 
-	if remoteArtifactsImage := os.Getenv(remoteArtifactsEnvVar); remoteArtifactsImage != "" {
-		c.RemoteArtifactsContainerImage = remoteArtifactsImage
+	if httpContainerTemplate := os.Getenv(httpContainerTemplateEnvVar); httpContainerTemplate != "" {
+		c.HTTPContainerTemplate = Step{}
+		if err := json.Unmarshal([]byte(httpContainerTemplate), &c.HTTPContainerTemplate); err != nil {
+			return err
+		}
+		if c.HTTPContainerTemplate.Image == "" {
+			c.HTTPContainerTemplate.Image = httpDefaultImage
+		}
+	}
+
+	// the dedicated environment variable for the image overwrites what is defined in the HTTP container template,
+	// the deprecated environment variable of the remote artifacts image is honored if it is not defined
+	if httpImage := os.Getenv(httpImageEnvVar); httpImage != "" {
+		c.HTTPContainerTemplate.Image = httpImage
+	} else if remoteArtifactsImage := os.Getenv(remoteArtifactsEnvVar); remoteArtifactsImage != "" {
+		c.HTTPContainerTemplate.Image = remoteArtifactsImage
 	}
 
 	if err := updateBucketsConfig(&c.Prometheus.BuildRunCompletionDurationBuckets, metricBuildRunCompletionDurationBucketsEnvVar); err != nil {
@@ -433,6 +519,31 @@ func (c *Config) SetConfigFromEnv() error {
 		c.TerminationLogPath = terminationLogPath
 	}
 
+	// trigger settings
+	if value := os.Getenv(imageTriggerPollIntervalEnvVar); value != "" {
+		interval, err := time.ParseDuration(value)
+		if err != nil {
+			return err
+		}
+		c.Triggers.ImagePollInterval = interval
+	}
+
+	if value := os.Getenv(commitStatusTargetURLEnvVar); value != "" {
+		c.Triggers.CommitStatusTargetURL = value
+	}
+
+	if err := updateQuantityOption(&c.BundleUnpackLimits.MaxTotalSize, bundleMaxTotalSizeEnvVar); err != nil {
+		return err
+	}
+
+	if err := updateInt64Option(&c.BundleUnpackLimits.MaxFileCount, bundleMaxFileCountEnvVar); err != nil {
+		return err
+	}
+
+	if err := updateQuantityOption(&c.BundleUnpackLimits.MaxFileSize, bundleMaxFileSizeEnvVar); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -487,3 +598,28 @@ func updateIntOption(i *int, envVarName string) error {
 
 	return nil
 }
+
+func updateInt64Option(i *int64, envVarName string) error {
+	if value := os.Getenv(envVarName); value != "" {
+		intValue, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return err
+		}
+		*i = intValue
+	}
+
+	return nil
+}
+
+// updateQuantityOption parses a size like 10Gi or 500M
+func updateQuantityOption(i *int64, envVarName string) error {
+	if value := os.Getenv(envVarName); value != "" {
+		quantity, err := resource.ParseQuantity(value)
+		if err != nil {
+			return err
+		}
+		*i = quantity.Value()
+	}
+
+	return nil
+}
@@ -101,6 +101,20 @@ var _ = Describe("Config", func() {
 			})
 		})
 
+		It("should allow for an override of the image trigger poll interval", func() {
+			var overrides = map[string]string{"IMAGE_TRIGGER_POLL_INTERVAL": "90s"}
+			configWithEnvVariableOverrides(overrides, func(config *Config) {
+				Expect(config.Triggers.ImagePollInterval).To(Equal(90 * time.Second))
+			})
+		})
+
+		It("should allow for an override of the commit status target URL", func() {
+			var overrides = map[string]string{"COMMIT_STATUS_TARGET_URL": "https://console.example.com/ns/{namespace}/buildruns/{name}"}
+			configWithEnvVariableOverrides(overrides, func(config *Config) {
+				Expect(config.Triggers.CommitStatusTargetURL).To(Equal("https://console.example.com/ns/{namespace}/buildruns/{name}"))
+			})
+		})
+
 		It("should allow for an override of the Git container template", func() {
 			var overrides = map[string]string{
 				"GIT_CONTAINER_TEMPLATE": "{\"image\":\"myregistry/custom/git-image\",\"resources\":{\"requests\":{\"cpu\":\"0.5\",\"memory\":\"128Mi\"}}}",
@@ -265,6 +279,62 @@ var _ = Describe("Config", func() {
 				}))
 			})
 		})
+
+		It("should allow for an override of the HTTP container template and image", func() {
+			var overrides = map[string]string{
+				"HTTP_CONTAINER_TEMPLATE": `{"image":"myregistry/custom/http","resources":{"requests":{"cpu":"0.5","memory":"128Mi"}}}`,
+				"HTTP_CONTAINER_IMAGE":    "myregistry/custom/http:override",
+			}
+
+			configWithEnvVariableOverrides(overrides, func(config *Config) {
+				Expect(config.HTTPContainerTemplate).To(Equal(Step{
+					Image: "myregistry/custom/http:override",
+					Resources: corev1.ResourceRequirements{
+						Requests: corev1.ResourceList{
+							corev1.ResourceCPU:    resource.MustParse("0.5"),
+							corev1.ResourceMemory: resource.MustParse("128Mi"),
+						},
+					},
+				}))
+			})
+		})
+
+		It("should use the deprecated remote artifacts image as HTTP image", func() {
+			var overrides = map[string]string{
+				"REMOTE_ARTIFACTS_CONTAINER_IMAGE": "myregistry/custom/http:deprecated",
+			}
+
+			configWithEnvVariableOverrides(overrides, func(config *Config) {
+				Expect(config.HTTPContainerTemplate.Image).To(Equal("myregistry/custom/http:deprecated"))
+			})
+		})
+
+		It("should prefer the HTTP image over the deprecated remote artifacts image", func() {
+			var overrides = map[string]string{
+				"HTTP_CONTAINER_IMAGE":             "myregistry/custom/http:override",
+				"REMOTE_ARTIFACTS_CONTAINER_IMAGE": "myregistry/custom/http:deprecated",
+			}
+
+			configWithEnvVariableOverrides(overrides, func(config *Config) {
+				Expect(config.HTTPContainerTemplate.Image).To(Equal("myregistry/custom/http:override"))
+			})
+		})
+
+		It("should allow for an override of the bundle unpack limits", func() {
+			var overrides = map[string]string{
+				"BUNDLE_MAX_TOTAL_SIZE": "2Gi",
+				"BUNDLE_MAX_FILE_COUNT": "5000",
+				"BUNDLE_MAX_FILE_SIZE":  "500M",
+			}
+
+			configWithEnvVariableOverrides(overrides, func(config *Config) {
+				Expect(config.BundleUnpackLimits).To(Equal(BundleUnpackLimits{
+					MaxTotalSize: 2 * 1024 * 1024 * 1024,
+					MaxFileCount: 5000,
+					MaxFileSize:  500 * 1000 * 1000,
+				}))
+			})
+		})
 	})
 })
 
@@ -21,6 +21,10 @@ import (
 	"github.com/shipwright-io/build/pkg/reconciler/buildrunttlcleanup"
 	"github.com/shipwright-io/build/pkg/reconciler/buildstrategy"
 	"github.com/shipwright-io/build/pkg/reconciler/clusterbuildstrategy"
+	"github.com/shipwright-io/build/pkg/reconciler/commitstatus"
+	"github.com/shipwright-io/build/pkg/reconciler/imagetrigger"
+	"github.com/shipwright-io/build/pkg/reconciler/pipelinetrigger"
+	"github.com/shipwright-io/build/pkg/reconciler/scheduletrigger"
 )
 
 // NewManager add all the controllers to the manager and register the required schemes
@@ -73,5 +77,21 @@ func NewManager(ctx context.Context, config *config.Config, cfg *rest.Config, op
 		return nil, err
 	}
 
+	if err := imagetrigger.Add(ctx, config, mgr); err != nil {
+		return nil, err
+	}
+
+	if err := pipelinetrigger.Add(ctx, config, mgr); err != nil {
+		return nil, err
+	}
+
+	if err := scheduletrigger.Add(ctx, config, mgr); err != nil {
+		return nil, err
+	}
+
+	if err := commitstatus.Add(ctx, config, mgr); err != nil {
+		return nil, err
+	}
+
 	return mgr, nil
 }
@@ -0,0 +1,246 @@
+// Copyright The Shipwright Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package download
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/shipwright-io/build/pkg/bundle"
+)
+
+const (
+	// AuthHeaderNameKey is the secret key holding the name of the HTTP header
+	// used to authenticate the download
+	AuthHeaderNameKey = "header-name"
+
+	// AuthHeaderValueKey is the secret key holding the value of the HTTP header
+	// used to authenticate the download
+	AuthHeaderValueKey = "header-value"
+
+	// defaultFileName is the name of the downloaded file in case the URL path
+	// does not contain a file name
+	defaultFileName = "download"
+)
+
+// Options configures the download of a remote artifact
+type Options struct {
+	// Header are additional HTTP headers of the request, for example to
+	// authenticate the download
+	Header http.Header
+
+	// SHA256 is the expected hex encoded SHA-256 digest of the remote artifact
+	SHA256 string
+
+	// Extract defines whether the remote artifact is an archive to extract
+	Extract bool
+
+	// StripComponents is the number of leading path elements that are removed
+	// from the file names when the archive is extracted
+	StripComponents int
+
+	// Limits are the limits of the content that is extracted from the archive
+	Limits bundle.UnpackOptions
+
+	// Retries is the number of times the download is retried on network
+	// errors and server errors
+	Retries int
+
+	// RetryDelay is the delay before the first retry, it doubles with every
+	// further retry
+	RetryDelay time.Duration
+
+	Client *http.Client
+}
+
+// statusError is the error of a download that failed with an unexpected HTTP
+// status code
+type statusError struct {
+	url        string
+	statusCode int
+}
+
+func (e *statusError) Error() string {
+	return fmt.Sprintf("failed to download %s: %d %s", e.url, e.statusCode, http.StatusText(e.statusCode))
+}
+
+// Download downloads the remote artifact into the target directory, and either
+// extracts it or stores it using the file name of the URL. It returns the
+// digest of the remote artifact.
+func Download(ctx context.Context, sourceURL string, target string, options Options) (string, error) {
+	if err := os.MkdirAll(target, 0755); err != nil {
+		return "", err
+	}
+
+	file, err := os.CreateTemp(target, ".download-*")
+	if err != nil {
+		return "", err
+	}
+
+	defer os.Remove(file.Name())
+	defer file.Close()
+
+	var digest string
+	for attempt := 0; ; attempt++ {
+		digest, err = fetch(ctx, sourceURL, file, options)
+		if err == nil || attempt >= options.Retries || !isRetryable(err) {
+			break
+		}
+
+		delay := options.RetryDelay << attempt
+		log.Printf("Download failed, retrying in %s: %v\n", delay, err)
+
+		select {
+		case <-ctx.Done():
+			return "", ctx.Err()
+
+		case <-time.After(delay):
+		}
+	}
+
+	if err != nil {
+		return "", err
+	}
+
+	if options.SHA256 != "" {
+		expected := strings.ToLower(strings.TrimPrefix(options.SHA256, "sha256:"))
+		if expected != digest {
+			return "", fmt.Errorf("the SHA-256 digest %s of %s does not match the expected digest %s", digest, sourceURL, expected)
+		}
+	}
+
+	if err := file.Close(); err != nil {
+		return "", err
+	}
+
+	if options.Extract {
+		if err := Extract(file.Name(), target, options.StripComponents, options.Limits); err != nil {
+			return "", err
+		}
+	} else {
+		if err := os.Rename(file.Name(), filepath.Join(target, fileName(sourceURL))); err != nil {
+			return "", err
+		}
+	}
+
+	return "sha256:" + digest, nil
+}
+
+// fetch downloads the remote artifact into the file and returns its hex
+// encoded SHA-256 digest
+func fetch(ctx context.Context, sourceURL string, file *os.File, options Options) (string, error) {
+	if err := file.Truncate(0); err != nil {
+		return "", err
+	}
+
+	if _, err := file.Seek(0, io.SeekStart); err != nil {
+		return "", err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, sourceURL, nil)
+	if err != nil {
+		return "", err
+	}
+
+	for name, values := range options.Header {
+		for _, value := range values {
+			req.Header.Add(name, value)
+		}
+	}
+
+	client := options.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	resp, err := withoutHeaderOnRedirect(client, options.Header).Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", &statusError{url: sourceURL, statusCode: resp.StatusCode}
+	}
+
+	hash := sha256.New()
+	if _, err := io.Copy(io.MultiWriter(file, hash), resp.Body); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+// withoutHeaderOnRedirect returns a copy of the client that removes the
+// additional headers from requests that are redirected to another host. Go
+// only removes the Authorization and Cookie headers, but the secret can hold
+// a header of any name, for example PRIVATE-TOKEN.
+func withoutHeaderOnRedirect(client *http.Client, header http.Header) *http.Client {
+	if len(header) == 0 {
+		return client
+	}
+
+	checkRedirect := client.CheckRedirect
+	result := *client
+	result.CheckRedirect = func(req *http.Request, via []*http.Request) error {
+		if req.URL.Host != via[0].URL.Host {
+			for name := range header {
+				req.Header.Del(name)
+			}
+		}
+
+		if checkRedirect != nil {
+			return checkRedirect(req, via)
+		}
+
+		// the default policy of the HTTP client
+		if len(via) >= 10 {
+			return errors.New("stopped after 10 redirects")
+		}
+
+		return nil
+	}
+
+	return &result
+}
+
+// isRetryable checks whether the download error is temporary, which are
+// network errors, server errors, and rate limits
+func isRetryable(err error) bool {
+	var statusErr *statusError
+	if errors.As(err, &statusErr) {
+		return statusErr.statusCode >= http.StatusInternalServerError || statusErr.statusCode == http.StatusTooManyRequests
+	}
+
+	var urlErr *url.Error
+	return errors.As(err, &urlErr) || errors.Is(err, io.ErrUnexpectedEOF)
+}
+
+// fileName returns the file name of the URL path
+func fileName(sourceURL string) string {
+	parsed, err := url.Parse(sourceURL)
+	if err != nil {
+		return defaultFileName
+	}
+
+	name := path.Base(parsed.Path)
+	if name == "." || name == "/" || name == ".." {
+		return defaultFileName
+	}
+
+	return name
+}
@@ -0,0 +1,355 @@
+// Copyright The Shipwright Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package download_test
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/shipwright-io/build/pkg/bundle"
+	"github.com/shipwright-io/build/pkg/download"
+)
+
+var _ = Describe("Download", func() {
+	type entry struct {
+		name     string
+		content  string
+		linkname string
+	}
+
+	var tarGz = func(entries ...entry) []byte {
+		var buf bytes.Buffer
+		gw := gzip.NewWriter(&buf)
+		tw := tar.NewWriter(gw)
+		for _, e := range entries {
+			switch {
+			case e.linkname != "":
+				Expect(tw.WriteHeader(&tar.Header{Name: e.name, Typeflag: tar.TypeSymlink, Linkname: e.linkname, Mode: 0777})).To(Succeed())
+
+			default:
+				Expect(tw.WriteHeader(&tar.Header{Name: e.name, Typeflag: tar.TypeReg, Mode: 0644, Size: int64(len(e.content))})).To(Succeed())
+				_, err := tw.Write([]byte(e.content))
+				Expect(err).ToNot(HaveOccurred())
+			}
+		}
+		Expect(tw.Close()).To(Succeed())
+		Expect(gw.Close()).To(Succeed())
+		return buf.Bytes()
+	}
+
+	var zipArchive = func(entries ...entry) []byte {
+		var buf bytes.Buffer
+		zw := zip.NewWriter(&buf)
+		for _, e := range entries {
+			w, err := zw.Create(e.name)
+			Expect(err).ToNot(HaveOccurred())
+			_, err = w.Write([]byte(e.content))
+			Expect(err).ToNot(HaveOccurred())
+		}
+		Expect(zw.Close()).To(Succeed())
+		return buf.Bytes()
+	}
+
+	var digestOf = func(data []byte) string {
+		sum := sha256.Sum256(data)
+		return hex.EncodeToString(sum[:])
+	}
+
+	var (
+		target   string
+		content  []byte
+		requests []*http.Request
+		failures int
+		server   *httptest.Server
+	)
+
+	BeforeEach(func() {
+		var err error
+		target, err = os.MkdirTemp(os.TempDir(), "download")
+		Expect(err).ToNot(HaveOccurred())
+
+		content = []byte("remote artifact")
+		requests = nil
+		failures = 0
+
+		server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			requests = append(requests, r)
+
+			switch {
+			case r.URL.Path == "/missing":
+				w.WriteHeader(http.StatusNotFound)
+
+			case r.URL.Path == "/redirect":
+				http.Redirect(w, r, "/artifact", http.StatusFound)
+
+			case failures > 0:
+				failures--
+				w.WriteHeader(http.StatusServiceUnavailable)
+
+			default:
+				_, _ = w.Write(content)
+			}
+		}))
+	})
+
+	AfterEach(func() {
+		server.Close()
+		os.RemoveAll(target)
+	})
+
+	Context("storing the remote artifact as file", func() {
+		It("should store the file using the name of the URL and return its digest", func() {
+			digest, err := download.Download(context.TODO(), server.URL+"/files/artifact.jar?version=1", target, download.Options{})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(digest).To(Equal("sha256:" + digestOf(content)))
+
+			data, err := os.ReadFile(filepath.Join(target, "artifact.jar"))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(data).To(Equal(content))
+
+			entries, err := os.ReadDir(target)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(entries).To(HaveLen(1))
+		})
+
+		It("should send the authentication header", func() {
+			_, err := download.Download(context.TODO(), server.URL+"/artifact", target, download.Options{
+				Header: http.Header{"Private-Token": []string{"secret"}},
+			})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(requests).To(HaveLen(1))
+			Expect(requests[0].Header.Get("Private-Token")).To(Equal("secret"))
+		})
+
+		It("should not send the authentication header to another host after a redirect", func() {
+			var redirected []*http.Request
+			otherServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				redirected = append(redirected, r)
+				_, _ = w.Write(content)
+			}))
+			defer otherServer.Close()
+
+			redirectServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				requests = append(requests, r)
+				http.Redirect(w, r, otherServer.URL+"/artifact", http.StatusFound)
+			}))
+			defer redirectServer.Close()
+
+			_, err := download.Download(context.TODO(), redirectServer.URL+"/artifact", target, download.Options{
+				Header: http.Header{"Private-Token": []string{"secret"}},
+			})
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(requests).To(HaveLen(1))
+			Expect(requests[0].Header.Get("Private-Token")).To(Equal("secret"))
+			Expect(redirected).To(HaveLen(1))
+			Expect(redirected[0].Header.Get("Private-Token")).To(BeEmpty())
+		})
+
+		It("should send the authentication header after a redirect on the same host", func() {
+			_, err := download.Download(context.TODO(), server.URL+"/redirect", target, download.Options{
+				Header: http.Header{"Private-Token": []string{"secret"}},
+			})
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(requests).To(HaveLen(2))
+			Expect(requests[1].URL.Path).To(Equal("/artifact"))
+			Expect(requests[1].Header.Get("Private-Token")).To(Equal("secret"))
+		})
+
+		It("should accept the expected digest", func() {
+			_, err := download.Download(context.TODO(), server.URL+"/artifact", target, download.Options{
+				SHA256: "sha256:" + digestOf(content),
+			})
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("should fail in case the digest does not match", func() {
+			_, err := download.Download(context.TODO(), server.URL+"/artifact", target, download.Options{
+				SHA256: digestOf([]byte("other")),
+			})
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("does not match the expected digest"))
+			Expect(filepath.Join(target, "artifact")).ToNot(BeAnExistingFile())
+		})
+	})
+
+	Context("retrying downloads", func() {
+		It("should retry server errors", func() {
+			failures = 2
+
+			_, err := download.Download(context.TODO(), server.URL+"/artifact", target, download.Options{
+				Retries:    2,
+				RetryDelay: time.Millisecond,
+			})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(requests).To(HaveLen(3))
+		})
+
+		It("should fail once the retries are exhausted", func() {
+			failures = 2
+
+			_, err := download.Download(context.TODO(), server.URL+"/artifact", target, download.Options{
+				Retries:    1,
+				RetryDelay: time.Millisecond,
+			})
+			Expect(err).To(HaveOccurred())
+			Expect(requests).To(HaveLen(2))
+		})
+
+		It("should not retry client errors", func() {
+			_, err := download.Download(context.TODO(), server.URL+"/missing", target, download.Options{
+				Retries:    3,
+				RetryDelay: time.Millisecond,
+			})
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("404"))
+			Expect(requests).To(HaveLen(1))
+		})
+	})
+
+	Context("extracting archives", func() {
+		It("should extract a gzip compressed tar archive and strip the leading path elements", func() {
+			content = tarGz(
+				entry{name: "project-1.0/README.md", content: "readme"},
+				entry{name: "project-1.0/src/main.go", content: "package main"},
+				entry{name: "project-1.0/link", linkname: "src/main.go"},
+			)
+
+			_, err := download.Download(context.TODO(), server.URL+"/project.tar.gz", target, download.Options{
+				Extract:         true,
+				StripComponents: 1,
+			})
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(filepath.Join(target, "README.md")).To(BeAnExistingFile())
+			Expect(filepath.Join(target, "src", "main.go")).To(BeAnExistingFile())
+			Expect(os.Readlink(filepath.Join(target, "link"))).To(Equal("src/main.go"))
+			Expect(filepath.Join(target, "project.tar.gz")).ToNot(BeAnExistingFile())
+		})
+
+		It("should extract a zip archive", func() {
+			content = zipArchive(
+				entry{name: "README.md", content: "readme"},
+				entry{name: "src/main.go", content: "package main"},
+			)
+
+			_, err := download.Download(context.TODO(), server.URL+"/project.zip", target, download.Options{
+				Extract: true,
+			})
+			Expect(err).ToNot(HaveOccurred())
+
+			data, err := os.ReadFile(filepath.Join(target, "src", "main.go"))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(string(data)).To(Equal("package main"))
+		})
+
+		It("should fail in case the file is not an archive", func() {
+			_, err := download.Download(context.TODO(), server.URL+"/artifact", target, download.Options{
+				Extract: true,
+			})
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("should fail in case an entry is outside of the target directory", func() {
+			content = zipArchive(entry{name: "../evil", content: "evil"})
+
+			_, err := download.Download(context.TODO(), server.URL+"/project.zip", target, download.Options{
+				Extract: true,
+			})
+			Expect(err).To(HaveOccurred())
+			Expect(filepath.Join(filepath.Dir(target), "evil")).ToNot(BeAnExistingFile())
+		})
+
+		It("should fail in case a symbolic link points outside of the target directory", func() {
+			content = tarGz(entry{name: "project/link", linkname: "../../etc/passwd"})
+
+			_, err := download.Download(context.TODO(), server.URL+"/project.tar.gz", target, download.Options{
+				Extract: true,
+			})
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("should fail in case a chain of symbolic links leads outside of the target directory", func() {
+			content = tarGz(
+				entry{name: "a", linkname: "."},
+				entry{name: "a/b", linkname: ".."},
+				entry{name: "b/pwned", content: "pwned"},
+			)
+
+			_, err := download.Download(context.TODO(), server.URL+"/project.tar.gz", filepath.Join(target, "extracted"), download.Options{
+				Extract: true,
+			})
+			Expect(err).To(HaveOccurred())
+			Expect(filepath.Join(target, "pwned")).ToNot(BeAnExistingFile())
+		})
+
+		It("should replace a symbolic link by a file instead of writing through it", func() {
+			content = tarGz(
+				entry{name: "other", content: "other"},
+				entry{name: "link", linkname: "other"},
+				entry{name: "link", content: "inside"},
+			)
+
+			_, err := download.Download(context.TODO(), server.URL+"/project.tar.gz", target, download.Options{
+				Extract: true,
+			})
+			Expect(err).ToNot(HaveOccurred())
+
+			data, err := os.ReadFile(filepath.Join(target, "other"))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(string(data)).To(Equal("other"))
+
+			info, err := os.Lstat(filepath.Join(target, "link"))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(info.Mode().IsRegular()).To(BeTrue())
+		})
+
+		It("should fail in case the archive exceeds the limits", func() {
+			content = zipArchive(
+				entry{name: "README.md", content: "readme"},
+				entry{name: "src/main.go", content: "package main"},
+			)
+
+			_, err := download.Download(context.TODO(), server.URL+"/project.zip", target, download.Options{
+				Extract: true,
+				Limits:  bundle.UnpackOptions{MaxFileCount: 1},
+			})
+			Expect(err).To(HaveOccurred())
+
+			var unpackErr *bundle.UnpackError
+			Expect(errors.As(err, &unpackErr)).To(BeTrue())
+			Expect(unpackErr.Reason).To(Equal(bundle.FileCountExceeded))
+
+			_, err = download.Download(context.TODO(), server.URL+"/project.zip", target, download.Options{
+				Extract: true,
+				Limits:  bundle.UnpackOptions{MaxFileSize: 8},
+			})
+			Expect(errors.As(err, &unpackErr)).To(BeTrue())
+			Expect(unpackErr.Reason).To(Equal(bundle.FileSizeExceeded))
+
+			_, err = download.Download(context.TODO(), server.URL+"/project.zip", target, download.Options{
+				Extract: true,
+				Limits:  bundle.UnpackOptions{MaxTotalSize: 10},
+			})
+			Expect(errors.As(err, &unpackErr)).To(BeTrue())
+			Expect(unpackErr.Reason).To(Equal(bundle.TotalSizeExceeded))
+		})
+	})
+})
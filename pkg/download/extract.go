@@ -0,0 +1,231 @@
+// Copyright The Shipwright Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package download
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"strings"
+
+	"github.com/shipwright-io/build/pkg/bundle"
+)
+
+var (
+	gzipMagic = []byte{0x1f, 0x8b}
+	zipMagic  = []byte("PK\x03\x04")
+	tarMagic  = []byte("ustar")
+)
+
+// Extract extracts the tar, gzip compressed tar, or zip archive into the target
+// directory, the format is detected based on the content of the archive. The
+// entries are unpacked like a bundle, so that they cannot be written outside of
+// the target directory, also not through symbolic links of earlier entries, and
+// the limits apply to the extracted content.
+func Extract(archive string, target string, stripComponents int, limits bundle.UnpackOptions) error {
+	file, err := os.Open(archive)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	reader := bufio.NewReader(file)
+	header, err := reader.Peek(512)
+	if err != nil && err != io.EOF {
+		return err
+	}
+
+	var convert func(tw *tar.Writer) error
+	switch {
+	case bytes.HasPrefix(header, gzipMagic):
+		gzipReader, err := gzip.NewReader(reader)
+		if err != nil {
+			return err
+		}
+		defer gzipReader.Close()
+
+		convert = func(tw *tar.Writer) error { return convertTar(gzipReader, tw, stripComponents) }
+
+	case bytes.HasPrefix(header, zipMagic):
+		zipReader, err := zip.OpenReader(archive)
+		if err != nil {
+			return err
+		}
+		defer zipReader.Close()
+
+		convert = func(tw *tar.Writer) error { return convertZip(zipReader, tw, stripComponents) }
+
+	case len(header) > 262 && bytes.HasPrefix(header[257:], tarMagic):
+		convert = func(tw *tar.Writer) error { return convertTar(reader, tw, stripComponents) }
+
+	default:
+		return fmt.Errorf("the downloaded file is not a tar, gzip compressed tar, or zip archive")
+	}
+
+	// the archive is converted into a tar stream without the stripped path
+	// elements, which is unpacked like a bundle
+	pipeReader, pipeWriter := io.Pipe()
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+
+		tw := tar.NewWriter(pipeWriter)
+		err := convert(tw)
+		if err == nil {
+			err = tw.Close()
+		}
+		pipeWriter.CloseWithError(err)
+	}()
+
+	err = bundle.UnpackWithOptions(pipeReader, target, limits)
+
+	// stop the conversion in case the unpacking failed early
+	pipeReader.Close()
+	<-done
+
+	return err
+}
+
+// convertTar writes the entries of the tar archive without the stripped path
+// elements into the tar writer
+func convertTar(in io.Reader, tw *tar.Writer, stripComponents int) error {
+	tr := tar.NewReader(in)
+	for {
+		header, err := tr.Next()
+		switch {
+		case err == io.EOF:
+			return nil
+
+		case err != nil:
+			return err
+		}
+
+		if header.Typeflag == tar.TypeXGlobalHeader {
+			continue
+		}
+
+		name, err := entryName(header.Name, stripComponents)
+		if err != nil {
+			return err
+		}
+
+		if name == "" {
+			continue
+		}
+
+		mode := header.Mode
+		if header.Typeflag == tar.TypeDir {
+			mode |= 0700
+		}
+
+		if err := tw.WriteHeader(&tar.Header{
+			Typeflag: header.Typeflag,
+			Name:     name,
+			Linkname: header.Linkname,
+			Size:     header.Size,
+			Mode:     mode,
+			ModTime:  header.ModTime,
+		}); err != nil {
+			return err
+		}
+
+		if header.Typeflag == tar.TypeReg {
+			if _, err := io.Copy(tw, tr); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// convertZip writes the entries of the zip archive without the stripped path
+// elements into the tar writer
+func convertZip(reader *zip.ReadCloser, tw *tar.Writer, stripComponents int) error {
+	for _, file := range reader.File {
+		name, err := entryName(file.Name, stripComponents)
+		if err != nil {
+			return err
+		}
+
+		if name == "" {
+			continue
+		}
+
+		switch {
+		case file.FileInfo().IsDir():
+			if err := tw.WriteHeader(&tar.Header{
+				Typeflag: tar.TypeDir,
+				Name:     name,
+				Mode:     0755,
+				ModTime:  file.Modified,
+			}); err != nil {
+				return err
+			}
+
+		case file.Mode().IsRegular():
+			mode := file.Mode().Perm()
+			if mode == 0 {
+				mode = 0644
+			}
+
+			if err := tw.WriteHeader(&tar.Header{
+				Typeflag: tar.TypeReg,
+				Name:     name,
+				Size:     int64(file.UncompressedSize64),
+				Mode:     int64(mode),
+				ModTime:  file.Modified,
+			}); err != nil {
+				return err
+			}
+
+			content, err := file.Open()
+			if err != nil {
+				return err
+			}
+
+			_, err = io.Copy(tw, content)
+			content.Close()
+			if err != nil {
+				return err
+			}
+
+		default:
+			return fmt.Errorf("the archive contains %s of an unsupported file type, only directories and regular files are supported", file.Name)
+		}
+	}
+
+	return nil
+}
+
+// entryName returns the relative path of the archive entry after the leading
+// path elements are removed, an empty path means that the entry is skipped
+func entryName(name string, stripComponents int) (string, error) {
+	name = strings.ReplaceAll(name, "\\", "/")
+	if path.IsAbs(name) || escapes(name) {
+		return "", fmt.Errorf("the archive contains %s, which is outside of the target directory", name)
+	}
+
+	elements := strings.Split(path.Clean(name), "/")
+	if elements[0] == "." {
+		elements = elements[1:]
+	}
+
+	if len(elements) <= stripComponents {
+		return "", nil
+	}
+
+	return path.Join(elements[stripComponents:]...), nil
+}
+
+// escapes checks whether the relative path points outside of its root
+func escapes(name string) bool {
+	cleaned := path.Clean(name)
+	return cleaned == ".." || strings.HasPrefix(cleaned, "../")
+}
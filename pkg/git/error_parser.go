@@ -49,6 +49,10 @@ const (
 	RepositoryNotFound
 	// AuthPrompted is caused when a repo is not found, is private and authentication is insufficient
 	AuthPrompted
+	// AuthGitHubAppIncomplete expresses that either the app ID, installation ID or private key is missing in GitHub App credentials
+	AuthGitHubAppIncomplete
+	// SignatureVerificationFailed expresses that neither the commit nor the tag of the revision is signed by a trusted key
+	SignatureVerificationFailed
 )
 
 type rawToken struct {
@@ -100,6 +104,10 @@ func (class ErrorClass) String() string {
 		return "GitSSHAuthExpected"
 	case AuthUnexpectedHTTP:
 		return "AuthUnexpectedHTTP"
+	case AuthGitHubAppIncomplete:
+		return "GitGitHubAppAuthIncomplete"
+	case SignatureVerificationFailed:
+		return "GitSignatureVerificationFailed"
 	}
 
 	return "GitError"
@@ -126,6 +134,10 @@ func (class ErrorClass) ToMessage() string {
 		return "Basic Auth incomplete: Both username and password need to be configured."
 	case AuthUnexpectedHTTP:
 		return "Refusing to continue with basic authentication (username and password) over insecure HTTP connection"
+	case AuthGitHubAppIncomplete:
+		return "GitHub App incomplete: The app ID, installation ID, and private key need to be configured."
+	case SignatureVerificationFailed:
+		return "Signature verification failed: Neither the commit nor the tag of the revision is signed by a trusted key."
 	}
 
 	return "Git encountered an unknown error."
@@ -211,7 +223,8 @@ func isRepositoryNotFound(raw string) bool {
 }
 
 func isBranchNotFound(raw string) bool {
-	return strings.Contains(raw, "remote branch") && strings.Contains(raw, "not found")
+	return strings.Contains(raw, "remote branch") && strings.Contains(raw, "not found") ||
+		strings.Contains(raw, "couldn't find remote ref")
 }
 
 func parseErrorMessage(raw string) errorClassToken {
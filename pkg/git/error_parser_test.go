@@ -35,6 +35,10 @@ var _ = Describe("Parsing Git Error Messages", func() {
 			parsed := parseErrorMessage("Remote branch not found")
 			Expect(parsed.class).To(Equal(RevisionNotFound))
 		})
+		It("should recognize and parse unknown reference", func() {
+			parsed := parseErrorMessage("couldn't find remote ref refs/pull/123/head")
+			Expect(parsed.class).To(Equal(RevisionNotFound))
+		})
 		It("should recognize and parse invalid auth key", func() {
 			parsed := parseErrorMessage("could not read from remote.")
 			Expect(parsed.class).To(Equal(AuthInvalidKey))
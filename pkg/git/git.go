@@ -10,6 +10,7 @@ import (
 
 	"github.com/go-git/go-git/v5/config"
 	"github.com/go-git/go-git/v5/plumbing/transport"
+	"github.com/go-git/go-git/v5/plumbing/transport/http"
 	"github.com/go-git/go-git/v5/storage/memory"
 
 	gogitv5 "github.com/go-git/go-git/v5"
@@ -28,6 +29,16 @@ const (
 // intend to define a single Status.Reason in the form of 'remoteRepositoryUnreachable',
 // where the Status.Message will contain the longer text, like 'invalid source url
 func ValidateGitURLExists(ctx context.Context, urlPath string) error {
+	return validateGitURLExists(ctx, urlPath, nil)
+}
+
+// ValidateGitURLExistsWithBasicAuth validates if a source URL exists using
+// the username and password, for example a GitHub App installation token
+func ValidateGitURLExistsWithBasicAuth(ctx context.Context, urlPath string, username string, password string) error {
+	return validateGitURLExists(ctx, urlPath, &http.BasicAuth{Username: username, Password: password})
+}
+
+func validateGitURLExists(ctx context.Context, urlPath string, auth transport.AuthMethod) error {
 	endpoint, err := transport.NewEndpoint(urlPath)
 	if err != nil {
 		return err
@@ -40,7 +51,7 @@ func ValidateGitURLExists(ctx context.Context, urlPath string) error {
 			URLs: []string{urlPath},
 		})
 
-		if _, err := repo.ListContext(ctx, &gogitv5.ListOptions{}); err != nil {
+		if _, err := repo.ListContext(ctx, &gogitv5.ListOptions{Auth: auth}); err != nil {
 			// Note: When the urlPath is an valid public path, however, this
 			// path doesn't exist, func will return `authentication required`,
 			// this is maybe misleading. So convert this error message to:
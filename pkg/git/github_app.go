@@ -0,0 +1,153 @@
+// Copyright The Shipwright Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package git
+
+import (
+	"context"
+	"crypto/rsa"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+)
+
+const (
+	// GitHubAppIDKey is the secret key holding the ID of the GitHub App
+	GitHubAppIDKey = "github-app-id"
+
+	// GitHubAppInstallationIDKey is the secret key holding the ID of the GitHub App installation
+	GitHubAppInstallationIDKey = "github-app-installation-id"
+
+	// GitHubAppPrivateKeyKey is the secret key holding the PEM encoded private key of the GitHub App
+	GitHubAppPrivateKeyKey = "github-app-private-key"
+
+	// GitHubAppTokenUsername is the username to use with an installation token for Git operations over HTTPS
+	GitHubAppTokenUsername = "x-access-token"
+)
+
+// GitHubApp holds the credentials of a GitHub App installation, which are
+// exchanged for a short-lived installation token
+type GitHubApp struct {
+	AppID          string
+	InstallationID string
+	PrivateKey     *rsa.PrivateKey
+
+	// APIURL is the base URL of the GitHub API
+	APIURL string
+
+	Client *http.Client
+}
+
+// GitHubAPIURL returns the URL of the GitHub API for the host, which is
+// api.github.com for github.com, and the v3 API path of GitHub Enterprise
+// Server for any other host
+func GitHubAPIURL(host string) string {
+	if host == "github.com" {
+		return "https://api.github.com"
+	}
+
+	return fmt.Sprintf("https://%s/api/v3", host)
+}
+
+// HasGitHubAppCredentials checks whether the secret data contains any of the
+// GitHub App keys
+func HasGitHubAppCredentials(data map[string][]byte) bool {
+	for _, key := range []string{GitHubAppIDKey, GitHubAppInstallationIDKey, GitHubAppPrivateKeyKey} {
+		if _, ok := data[key]; ok {
+			return true
+		}
+	}
+
+	return false
+}
+
+// NewGitHubApp returns the GitHub App with the credentials in the secret data,
+// all GitHub App keys must be present
+func NewGitHubApp(data map[string][]byte, apiURL string) (*GitHubApp, error) {
+	appID := strings.TrimSpace(string(data[GitHubAppIDKey]))
+	installationID := strings.TrimSpace(string(data[GitHubAppInstallationIDKey]))
+	if appID == "" || installationID == "" || len(data[GitHubAppPrivateKeyKey]) == 0 {
+		return nil, fmt.Errorf("%s", AuthGitHubAppIncomplete.ToMessage())
+	}
+
+	// the private key is PKCS #1 or PKCS #8 encoded
+	privateKey, err := jwt.ParseRSAPrivateKeyFromPEM(data[GitHubAppPrivateKeyKey])
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse the GitHub App private key: %w", err)
+	}
+
+	return &GitHubApp{
+		AppID:          appID,
+		InstallationID: installationID,
+		PrivateKey:     privateKey,
+		APIURL:         apiURL,
+		Client:         &http.Client{Timeout: 30 * time.Second},
+	}, nil
+}
+
+// InstallationToken exchanges the GitHub App credentials for an installation
+// token, which expires after one hour
+func (a *GitHubApp) InstallationToken(ctx context.Context) (string, error) {
+	token, err := a.jwt(time.Now())
+	if err != nil {
+		return "", err
+	}
+
+	url := fmt.Sprintf("%s/app/installations/%s/access_tokens", strings.TrimSuffix(a.APIURL, "/"), a.InstallationID)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, nil)
+	if err != nil {
+		return "", err
+	}
+
+	req.Header.Set("Accept", "application/vnd.github+json")
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	client := a.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 64*1024))
+	if err != nil {
+		return "", err
+	}
+
+	if resp.StatusCode != http.StatusCreated {
+		return "", fmt.Errorf("failed to create a GitHub App installation token, %s returned %d: %s", url, resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+
+	var accessToken struct {
+		Token string `json:"token"`
+	}
+	if err := json.Unmarshal(body, &accessToken); err != nil {
+		return "", err
+	}
+
+	if accessToken.Token == "" {
+		return "", fmt.Errorf("failed to create a GitHub App installation token, %s returned no token", url)
+	}
+
+	return accessToken.Token, nil
+}
+
+// jwt returns the JSON Web Token authenticating as the GitHub App, the issue
+// time is backdated to allow for clock drift
+func (a *GitHubApp) jwt(now time.Time) (string, error) {
+	return jwt.NewWithClaims(jwt.SigningMethodRS256, jwt.RegisteredClaims{
+		IssuedAt:  jwt.NewNumericDate(now.Add(-time.Minute)),
+		ExpiresAt: jwt.NewNumericDate(now.Add(9 * time.Minute)),
+		Issuer:    a.AppID,
+	}).SignedString(a.PrivateKey)
+}
@@ -0,0 +1,190 @@
+// Copyright The Shipwright Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package image
+
+import (
+	"crypto"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/google/go-containerregistry/pkg/name"
+	containerreg "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/mutate"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/google/go-containerregistry/pkg/v1/remote/transport"
+	"github.com/google/go-containerregistry/pkg/v1/static"
+	"github.com/google/go-containerregistry/pkg/v1/types"
+)
+
+// The attestations use the format of cosign, the attestations of an image are
+// stored in the same repository using a tag that is derived from the image
+// digest. Each layer of the attestation image is a DSSE envelope that holds a
+// signed in-toto statement, the predicate type of the statement is an
+// annotation of the layer.
+const (
+	dsseEnvelopeMediaType   types.MediaType = "application/vnd.dsse.envelope.v1+json"
+	inTotoPayloadType                       = "application/vnd.in-toto+json"
+	predicateTypeAnnotation                 = "predicateType"
+	attestationTagSuffix                    = "att"
+)
+
+type dsseEnvelope struct {
+	PayloadType string          `json:"payloadType"`
+	Payload     string          `json:"payload"`
+	Signatures  []dsseSignature `json:"signatures"`
+}
+
+type dsseSignature struct {
+	KeyID string `json:"keyid"`
+	Sig   string `json:"sig"`
+}
+
+// inTotoSubjects is the part of an in-toto statement that names the subjects
+type inTotoSubjects struct {
+	Subject []struct {
+		Digest map[string]string `json:"digest"`
+	} `json:"subject"`
+}
+
+// Attest signs the in-toto statement about the image digest using the private
+// key, and appends it to the attestations in the repository of the image. It
+// returns the digest of the attestation image. See remote.Option for optional
+// options to the image pull and push from and to the registry.
+func Attest(digest name.Digest, statement []byte, predicateType string, key crypto.Signer, options ...remote.Option) (containerreg.Hash, error) {
+	signature, err := signPayload(key, preAuthenticationEncoding(inTotoPayloadType, statement))
+	if err != nil {
+		return containerreg.Hash{}, err
+	}
+
+	envelope, err := json.Marshal(dsseEnvelope{
+		PayloadType: inTotoPayloadType,
+		Payload:     base64.StdEncoding.EncodeToString(statement),
+		Signatures: []dsseSignature{{
+			Sig: base64.StdEncoding.EncodeToString(signature),
+		}},
+	})
+	if err != nil {
+		return containerreg.Hash{}, err
+	}
+
+	tag, err := cosignTag(digest, attestationTagSuffix)
+	if err != nil {
+		return containerreg.Hash{}, err
+	}
+
+	return appendCosignLayer(tag, mutate.Addendum{
+		Layer: static.NewLayer(envelope, dsseEnvelopeMediaType),
+		Annotations: map[string]string{
+			predicateTypeAnnotation: predicateType,
+		},
+	}, options...)
+}
+
+// VerifyAttestation returns the in-toto statement of the predicate type about
+// the image digest that is signed by one of the trusted public keys. An image
+// without such an attestation results in an error that wraps
+// ErrSignatureVerificationFailed. See remote.Option for optional options to
+// the image pull from the registry.
+func VerifyAttestation(digest name.Digest, predicateType string, keys []crypto.PublicKey, options ...remote.Option) ([]byte, error) {
+	if len(keys) == 0 {
+		return nil, fmt.Errorf("%w: no trusted public keys are configured", ErrSignatureVerificationFailed)
+	}
+
+	tag, err := cosignTag(digest, attestationTagSuffix)
+	if err != nil {
+		return nil, err
+	}
+
+	attestationImage, err := remote.Image(tag, options...)
+	if err != nil {
+		var transportErr *transport.Error
+		if errors.As(err, &transportErr) && transportErr.StatusCode == http.StatusNotFound {
+			return nil, fmt.Errorf("%w: image %s has no attestations", ErrSignatureVerificationFailed, digest.String())
+		}
+
+		return nil, err
+	}
+
+	manifest, err := attestationImage.Manifest()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, descriptor := range manifest.Layers {
+		if descriptor.MediaType != dsseEnvelopeMediaType || descriptor.Annotations[predicateTypeAnnotation] != predicateType {
+			continue
+		}
+
+		layer, err := attestationImage.LayerByDigest(descriptor.Digest)
+		if err != nil {
+			return nil, err
+		}
+
+		data, err := readPayload(layer)
+		if err != nil {
+			return nil, err
+		}
+
+		var envelope dsseEnvelope
+		if err := json.Unmarshal(data, &envelope); err != nil || envelope.PayloadType != inTotoPayloadType {
+			continue
+		}
+
+		statement, err := base64.StdEncoding.DecodeString(envelope.Payload)
+		if err != nil {
+			continue
+		}
+
+		// the statement must name the digest of the image, otherwise the
+		// attestation was created for another image
+		if !hasSubject(statement, digest) {
+			continue
+		}
+
+		for _, signature := range envelope.Signatures {
+			sig, err := base64.StdEncoding.DecodeString(signature.Sig)
+			if err != nil {
+				continue
+			}
+
+			for _, key := range keys {
+				if verifyPayload(key, preAuthenticationEncoding(envelope.PayloadType, statement), sig) == nil {
+					return statement, nil
+				}
+			}
+		}
+	}
+
+	return nil, fmt.Errorf("%w: image %s has no %s attestation signed by a trusted key", ErrSignatureVerificationFailed, digest.String(), predicateType)
+}
+
+// preAuthenticationEncoding returns the data that is signed for a DSSE
+// envelope, see https://github.com/secure-systems-lab/dsse/blob/master/protocol.md
+func preAuthenticationEncoding(payloadType string, payload []byte) []byte {
+	return []byte(fmt.Sprintf("DSSEv1 %d %s %d %s", len(payloadType), payloadType, len(payload), payload))
+}
+
+func hasSubject(statement []byte, digest name.Digest) bool {
+	var subjects inTotoSubjects
+	if err := json.Unmarshal(statement, &subjects); err != nil {
+		return false
+	}
+
+	hash, err := containerreg.NewHash(digest.DigestStr())
+	if err != nil {
+		return false
+	}
+
+	for _, subject := range subjects.Subject {
+		if subject.Digest[hash.Algorithm] == hash.Hex {
+			return true
+		}
+	}
+
+	return false
+}
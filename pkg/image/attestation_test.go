@@ -0,0 +1,117 @@
+// Copyright The Shipwright Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package image_test
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"fmt"
+	"io"
+	"log"
+	"net/http/httptest"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/registry"
+	"github.com/google/go-containerregistry/pkg/v1/random"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+
+	"github.com/shipwright-io/build/pkg/image"
+)
+
+var _ = Describe("Attestation", func() {
+	const predicateType = "https://slsa.dev/provenance/v1"
+
+	var (
+		digest name.Digest
+		key    *ecdsa.PrivateKey
+	)
+
+	BeforeEach(func() {
+		server := httptest.NewServer(registry.New(registry.Logger(log.New(io.Discard, "", 0))))
+		DeferCleanup(server.Close)
+
+		img, err := random.Image(1024, 1)
+		Expect(err).ToNot(HaveOccurred())
+
+		tag, err := name.NewTag(fmt.Sprintf("%s/test-namespace/test-image:latest", strings.TrimPrefix(server.URL, "http://")))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(remote.Write(tag, img)).To(Succeed())
+
+		hash, err := img.Digest()
+		Expect(err).ToNot(HaveOccurred())
+		digest = tag.Context().Digest(hash.String())
+
+		key, err = ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	statementFor := func(digest name.Digest) []byte {
+		return []byte(fmt.Sprintf(`{"_type":"https://in-toto.io/Statement/v1","subject":[{"name":%q,"digest":{"sha256":%q}}],"predicateType":%q,"predicate":{}}`,
+			digest.Context().Name(), strings.TrimPrefix(digest.DigestStr(), "sha256:"), predicateType))
+	}
+
+	It("should push an attestation that can be verified and return its digest", func() {
+		statement := statementFor(digest)
+
+		attestationDigest, err := image.Attest(digest, statement, predicateType, key)
+		Expect(err).ToNot(HaveOccurred())
+
+		verified, err := image.VerifyAttestation(digest, predicateType, []crypto.PublicKey{key.Public()})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(verified).To(Equal(statement))
+
+		desc, err := remote.Head(digest.Context().Tag(strings.Replace(digest.DigestStr(), ":", "-", 1) + ".att"))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(desc.Digest).To(Equal(attestationDigest))
+	})
+
+	It("should keep the existing attestations of the image", func() {
+		_, err := image.Attest(digest, statementFor(digest), predicateType, key)
+		Expect(err).ToNot(HaveOccurred())
+
+		otherKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		Expect(err).ToNot(HaveOccurred())
+
+		_, err = image.Attest(digest, statementFor(digest), predicateType, otherKey)
+		Expect(err).ToNot(HaveOccurred())
+
+		_, err = image.VerifyAttestation(digest, predicateType, []crypto.PublicKey{key.Public()})
+		Expect(err).ToNot(HaveOccurred())
+		_, err = image.VerifyAttestation(digest, predicateType, []crypto.PublicKey{otherKey.Public()})
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	It("should fail to verify an attestation that is signed by another key", func() {
+		_, err := image.Attest(digest, statementFor(digest), predicateType, key)
+		Expect(err).ToNot(HaveOccurred())
+
+		otherKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		Expect(err).ToNot(HaveOccurred())
+
+		_, err = image.VerifyAttestation(digest, predicateType, []crypto.PublicKey{otherKey.Public()})
+		Expect(err).To(MatchError(image.ErrSignatureVerificationFailed))
+	})
+
+	It("should fail to verify an attestation about another image", func() {
+		otherDigest := digest.Context().Digest("sha256:0000000000000000000000000000000000000000000000000000000000000000")
+
+		_, err := image.Attest(digest, statementFor(otherDigest), predicateType, key)
+		Expect(err).ToNot(HaveOccurred())
+
+		_, err = image.VerifyAttestation(digest, predicateType, []crypto.PublicKey{key.Public()})
+		Expect(err).To(MatchError(image.ErrSignatureVerificationFailed))
+	})
+
+	It("should fail to verify an image without attestations", func() {
+		_, err := image.VerifyAttestation(digest, predicateType, []crypto.PublicKey{key.Public()})
+		Expect(err).To(MatchError(ContainSubstring("has no attestations")))
+	})
+})
@@ -0,0 +1,24 @@
+// Copyright The Shipwright Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package image
+
+import (
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+)
+
+// MirrorImageOrImageIndex copies the image or image index of the digest to the
+// mirror and returns the digest of the copy. The blobs are read from the
+// repository of the digest. A mirror in the same registry mounts them from
+// there, a mirror in another registry receives them again.
+func MirrorImageOrImageIndex(digest name.Digest, options []remote.Option, mirrorName name.Reference, mirrorOptions []remote.Option) (string, error) {
+	image, imageIndex, err := LoadImageOrImageIndexFromRegistry(digest, options)
+	if err != nil {
+		return "", err
+	}
+
+	mirrorDigest, _, err := PushImageOrImageIndex(mirrorName, image, imageIndex, mirrorOptions)
+	return mirrorDigest, err
+}
@@ -0,0 +1,95 @@
+// Copyright The Shipwright Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package image_test
+
+import (
+	"fmt"
+	"io"
+	"log"
+	"net/http/httptest"
+	"strings"
+
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/registry"
+	"github.com/google/go-containerregistry/pkg/v1/random"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/shipwright-io/build/pkg/image"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("MirrorImageOrImageIndex", func() {
+
+	var sourceHost, mirrorHost string
+
+	BeforeEach(func() {
+		sourceServer := httptest.NewServer(registry.New(registry.Logger(log.New(io.Discard, "", 0))))
+		DeferCleanup(sourceServer.Close)
+		sourceHost = strings.TrimPrefix(sourceServer.URL, "http://")
+
+		mirrorServer := httptest.NewServer(registry.New(registry.Logger(log.New(io.Discard, "", 0))))
+		DeferCleanup(mirrorServer.Close)
+		mirrorHost = strings.TrimPrefix(mirrorServer.URL, "http://")
+	})
+
+	It("copies an image to a mirror in another registry", func() {
+		img, err := random.Image(3245, 2)
+		Expect(err).ToNot(HaveOccurred())
+
+		imageName, err := name.ParseReference(fmt.Sprintf("%s/test-namespace/test-image:latest", sourceHost))
+		Expect(err).ToNot(HaveOccurred())
+
+		digest, _, err := image.PushImageOrImageIndex(imageName, img, nil, []remote.Option{})
+		Expect(err).ToNot(HaveOccurred())
+
+		mirrorName, err := name.ParseReference(fmt.Sprintf("%s/dr-namespace/test-image:latest", mirrorHost))
+		Expect(err).ToNot(HaveOccurred())
+
+		mirrorDigest, err := image.MirrorImageOrImageIndex(imageName.Context().Digest(digest), []remote.Option{}, mirrorName, []remote.Option{})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(mirrorDigest).To(Equal(digest))
+
+		desc, err := remote.Head(mirrorName)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(desc.Digest.String()).To(Equal(digest))
+	})
+
+	It("copies an image index to a mirror in the same registry", func() {
+		index, err := random.Index(1234, 1, 2)
+		Expect(err).ToNot(HaveOccurred())
+
+		imageName, err := name.ParseReference(fmt.Sprintf("%s/test-namespace/test-index:latest", sourceHost))
+		Expect(err).ToNot(HaveOccurred())
+
+		digest, _, err := image.PushImageOrImageIndex(imageName, nil, index, []remote.Option{})
+		Expect(err).ToNot(HaveOccurred())
+
+		mirrorName, err := name.ParseReference(fmt.Sprintf("%s/other-namespace/test-index:v1", sourceHost))
+		Expect(err).ToNot(HaveOccurred())
+
+		mirrorDigest, err := image.MirrorImageOrImageIndex(imageName.Context().Digest(digest), []remote.Option{}, mirrorName, []remote.Option{})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(mirrorDigest).To(Equal(digest))
+
+		mirroredIndex, err := remote.Index(mirrorName)
+		Expect(err).ToNot(HaveOccurred())
+
+		indexManifest, err := mirroredIndex.IndexManifest()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(indexManifest.Manifests).To(HaveLen(2))
+	})
+
+	It("fails if the digest does not exist", func() {
+		mirrorName, err := name.ParseReference(fmt.Sprintf("%s/dr-namespace/test-image:latest", mirrorHost))
+		Expect(err).ToNot(HaveOccurred())
+
+		digest, err := name.NewDigest(fmt.Sprintf("%s/test-namespace/test-image@sha256:0000000000000000000000000000000000000000000000000000000000000000", sourceHost))
+		Expect(err).ToNot(HaveOccurred())
+
+		_, err = image.MirrorImageOrImageIndex(digest, []remote.Option{}, mirrorName, []remote.Option{})
+		Expect(err).To(HaveOccurred())
+	})
+})
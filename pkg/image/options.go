@@ -5,6 +5,7 @@
 package image
 
 import (
+	"bytes"
 	"context"
 	"crypto/tls"
 	"errors"
@@ -47,22 +48,6 @@ func (ort *optionsRoundTripper) RoundTrip(in *http.Request) (*http.Response, err
 
 // GetOptions constructs go-containerregistry options to access the remote registry, in addition, it returns the authentication separately which can be an empty object
 func GetOptions(ctx context.Context, imageName name.Reference, insecure bool, dockerConfigJSONPath string, userAgent string) ([]remote.Option, *authn.AuthConfig, error) {
-	var options []remote.Option
-
-	options = append(options, remote.WithContext(ctx))
-
-	transport := http.DefaultTransport.(*http.Transport).Clone()
-	transport.TLSClientConfig = &tls.Config{
-		MinVersion:         tls.VersionTLS12,
-		InsecureSkipVerify: false,
-	}
-
-	if insecure {
-		// #nosec:G402 insecure is explicitly requested by user, make sure to skip verification and reset empty defaults
-		transport.TLSClientConfig.InsecureSkipVerify = insecure
-		transport.TLSClientConfig.MinVersion = 0
-	}
-
 	// find a Docker config.json
 	if dockerConfigJSONPath != "" {
 		// if we have a value provided already, then we support a directory that contains a .dockerconfigjson file
@@ -85,6 +70,37 @@ func GetOptions(ctx context.Context, imageName name.Reference, insecure bool, do
 		}
 	}
 
+	return getOptions(ctx, imageName, insecure, dockerconfig, userAgent)
+}
+
+// GetOptionsForDockerConfigJSON constructs go-containerregistry options like GetOptions, but
+// takes the content of a Docker config.json, for example the data of a Secret, instead of a path
+func GetOptionsForDockerConfigJSON(ctx context.Context, imageName name.Reference, insecure bool, dockerConfigJSON []byte, userAgent string) ([]remote.Option, *authn.AuthConfig, error) {
+	dockerconfig, err := config.LoadFromReader(bytes.NewReader(dockerConfigJSON))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return getOptions(ctx, imageName, insecure, dockerconfig, userAgent)
+}
+
+func getOptions(ctx context.Context, imageName name.Reference, insecure bool, dockerconfig *configfile.ConfigFile, userAgent string) ([]remote.Option, *authn.AuthConfig, error) {
+	var options []remote.Option
+
+	options = append(options, remote.WithContext(ctx))
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = &tls.Config{
+		MinVersion:         tls.VersionTLS12,
+		InsecureSkipVerify: false,
+	}
+
+	if insecure {
+		// #nosec:G402 insecure is explicitly requested by user, make sure to skip verification and reset empty defaults
+		transport.TLSClientConfig.InsecureSkipVerify = insecure
+		transport.TLSClientConfig.MinVersion = 0
+	}
+
 	// Add a RoundTripper
 	rt := &optionsRoundTripper{
 		inner:     transport,
@@ -83,4 +83,22 @@ var _ = Describe("GetOptions", func() {
 			})
 		})
 	})
+
+	Context("with the content of a dockerconfigjson", func() {
+
+		It("constructs options and auth with the matching user", func() {
+			dockerConfigJSON := []byte(fmt.Sprintf("{\"auths\":{%q:{\"username\":\"aUser\",\"password\":\"aPassword\"}}}", authn.DefaultAuthKey))
+
+			options, auth, err := image.GetOptionsForDockerConfigJSON(context.TODO(), imageName, false, dockerConfigJSON, "test-agent")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(options).To(HaveLen(3))
+			Expect(auth.Username).To(Equal("aUser"))
+			Expect(auth.Password).To(Equal("aPassword"))
+		})
+
+		It("fails for content that is no dockerconfigjson", func() {
+			_, _, err := image.GetOptionsForDockerConfigJSON(context.TODO(), imageName, false, []byte("not json"), "test-agent")
+			Expect(err).To(HaveOccurred())
+		})
+	})
 })
@@ -0,0 +1,557 @@
+// Copyright The Shipwright Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package image
+
+import (
+	"archive/tar"
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/url"
+	"path"
+	"regexp"
+	"sort"
+	"strings"
+
+	containerreg "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/mutate"
+	"github.com/google/go-containerregistry/pkg/v1/types"
+)
+
+// maxCatalogFileSize limits the size of a package database or lockfile that
+// is read from an image, larger files are skipped
+const maxCatalogFileSize = 64 << 20
+
+// Package is a software package that is listed in a package database or a
+// lockfile of an image
+type Package struct {
+	// Name is the name of the package
+	Name string
+
+	// Version is the version of the package
+	Version string
+
+	// Type is the package URL type of the package, for example deb or npm
+	Type string
+
+	// PURL is the package URL of the package
+	PURL string
+
+	// Location is the path of the package database or lockfile in the image
+	Location string
+
+	// namespace, architecture and epoch are the optional parts of the
+	// package URL
+	namespace    string
+	architecture string
+	epoch        string
+}
+
+// catalogers parse the package databases and lockfiles, they are selected by
+// the path of a file in the image
+var catalogers = []struct {
+	matches func(filePath string) bool
+	parse   func(data []byte) []Package
+}{
+	{matches: isPath("var/lib/dpkg/status"), parse: parseDpkgStatus},
+	{matches: isInDirectory("var/lib/dpkg/status.d"), parse: parseDpkgStatus},
+	{matches: isPath("lib/apk/db/installed"), parse: parseApkInstalled},
+	{matches: isRpmDatabase("rpmdb.sqlite"), parse: parseRpmSqlite},
+	{matches: isRpmDatabase("Packages"), parse: parseRpmBerkeleyDB},
+	{matches: isRpmDatabase("Packages.db"), parse: parseRpmNdb},
+	{matches: isLockfile("package-lock.json"), parse: parseNpmPackageLock},
+	{matches: isLockfile("requirements.txt"), parse: parseRequirementsTxt},
+	{matches: isLockfile("go.mod"), parse: parseGoMod},
+	{matches: isLockfile("Cargo.lock"), parse: parseCargoLock},
+	{matches: isLockfile("Gemfile.lock"), parse: parseGemfileLock},
+}
+
+// ListPackages lists the packages of the OS package databases and the
+// language lockfiles of an image, or of all images of an image index. The
+// packages are sorted by their package URL.
+func ListPackages(image containerreg.Image, imageIndex containerreg.ImageIndex) ([]Package, error) {
+	var packages []Package
+	var err error
+
+	switch {
+	case imageIndex != nil:
+		packages, err = listIndexPackages(imageIndex)
+
+	case image != nil:
+		packages, err = listImagePackages(image)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	// the images of an index usually contain the same packages
+	seen := map[string]struct{}{}
+	var result []Package
+	for _, pkg := range packages {
+		key := pkg.PURL + "\x00" + pkg.Location
+		if _, ok := seen[key]; ok {
+			continue
+		}
+
+		seen[key] = struct{}{}
+		result = append(result, pkg)
+	}
+
+	sort.SliceStable(result, func(i, j int) bool {
+		if result[i].PURL != result[j].PURL {
+			return result[i].PURL < result[j].PURL
+		}
+		return result[i].Location < result[j].Location
+	})
+
+	return result, nil
+}
+
+func listIndexPackages(imageIndex containerreg.ImageIndex) ([]Package, error) {
+	indexManifest, err := imageIndex.IndexManifest()
+	if err != nil {
+		return nil, err
+	}
+
+	var packages []Package
+	for _, descriptor := range indexManifest.Manifests {
+		switch descriptor.MediaType {
+		case types.OCIImageIndex, types.DockerManifestList:
+			childImageIndex, err := imageIndex.ImageIndex(descriptor.Digest)
+			if err != nil {
+				return nil, err
+			}
+
+			childPackages, err := listIndexPackages(childImageIndex)
+			if err != nil {
+				return nil, err
+			}
+
+			packages = append(packages, childPackages...)
+
+		case types.OCIManifestSchema1, types.DockerManifestSchema2:
+			image, err := imageIndex.Image(descriptor.Digest)
+			if err != nil {
+				return nil, err
+			}
+
+			imagePackages, err := listImagePackages(image)
+			if err != nil {
+				return nil, err
+			}
+
+			packages = append(packages, imagePackages...)
+		}
+	}
+
+	return packages, nil
+}
+
+// listImagePackages reads the flattened file system of the image, so that
+// files which are deleted by a later layer are not listed
+func listImagePackages(image containerreg.Image) ([]Package, error) {
+	rc := mutate.Extract(image)
+	defer rc.Close()
+
+	var packages []Package
+	var osRelease []byte
+
+	tr := tar.NewReader(rc)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read the image file system: %w", err)
+		}
+
+		if header.Typeflag != tar.TypeReg || header.Size > maxCatalogFileSize {
+			continue
+		}
+
+		filePath := strings.TrimPrefix(path.Clean("/"+header.Name), "/")
+
+		if filePath == "etc/os-release" || (filePath == "usr/lib/os-release" && osRelease == nil) {
+			if osRelease, err = io.ReadAll(tr); err != nil {
+				return nil, err
+			}
+			continue
+		}
+
+		for _, cataloger := range catalogers {
+			if !cataloger.matches(filePath) {
+				continue
+			}
+
+			data, err := io.ReadAll(tr)
+			if err != nil {
+				return nil, err
+			}
+
+			for _, pkg := range cataloger.parse(data) {
+				pkg.Location = "/" + filePath
+				packages = append(packages, pkg)
+			}
+
+			break
+		}
+	}
+
+	// the package URLs of OS packages contain the distribution, which is only
+	// known once the whole file system was read
+	distribution := osReleaseID(osRelease)
+	for i := range packages {
+		if packages[i].namespace == "" && (packages[i].Type == "deb" || packages[i].Type == "apk" || packages[i].Type == "rpm") {
+			packages[i].namespace = distribution
+		}
+
+		packages[i].PURL = packageURL(packages[i])
+	}
+
+	return packages, nil
+}
+
+func isPath(expected string) func(string) bool {
+	return func(filePath string) bool {
+		return filePath == expected
+	}
+}
+
+func isInDirectory(directory string) func(string) bool {
+	return func(filePath string) bool {
+		return path.Dir(filePath) == directory
+	}
+}
+
+// isLockfile matches the lockfile in any directory, except for the lockfiles
+// of installed dependencies, which are listed by the lockfile of the project
+func isLockfile(fileName string) func(string) bool {
+	return func(filePath string) bool {
+		return path.Base(filePath) == fileName && !strings.Contains(filePath, "node_modules/")
+	}
+}
+
+// osReleaseID returns the ID of the distribution from the os-release file
+func osReleaseID(data []byte) string {
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		if value, ok := strings.CutPrefix(scanner.Text(), "ID="); ok {
+			return strings.Trim(value, `"'`)
+		}
+	}
+
+	return ""
+}
+
+// packageURL formats the package URL, see https://github.com/package-url/purl-spec
+func packageURL(pkg Package) string {
+	var purl strings.Builder
+	purl.WriteString("pkg:" + pkg.Type + "/")
+
+	if pkg.namespace != "" {
+		for _, segment := range strings.Split(pkg.namespace, "/") {
+			purl.WriteString(escapePURLSegment(segment) + "/")
+		}
+	}
+
+	purl.WriteString(escapePURLSegment(pkg.Name))
+
+	if pkg.Version != "" {
+		purl.WriteString("@" + escapePURLSegment(pkg.Version))
+	}
+
+	// the qualifiers are sorted by their key
+	var qualifiers []string
+	if pkg.architecture != "" {
+		qualifiers = append(qualifiers, "arch="+url.QueryEscape(pkg.architecture))
+	}
+
+	if pkg.epoch != "" {
+		qualifiers = append(qualifiers, "epoch="+url.QueryEscape(pkg.epoch))
+	}
+
+	if len(qualifiers) > 0 {
+		purl.WriteString("?" + strings.Join(qualifiers, "&"))
+	}
+
+	return purl.String()
+}
+
+// escapePURLSegment percent-encodes a segment of a package URL, the path
+// escaping keeps the plus and at signs which have a meaning in package URLs
+func escapePURLSegment(segment string) string {
+	return strings.NewReplacer("+", "%2B", "@", "%40").Replace(url.PathEscape(segment))
+}
+
+// parseDpkgStatus parses the Debian package database, the paragraphs of the
+// file describe the packages
+func parseDpkgStatus(data []byte) []Package {
+	var packages []Package
+	for _, paragraph := range strings.Split(string(data), "\n\n") {
+		fields := map[string]string{}
+		for _, line := range strings.Split(paragraph, "\n") {
+			if key, value, ok := strings.Cut(line, ":"); ok && !strings.HasPrefix(line, " ") {
+				fields[key] = strings.TrimSpace(value)
+			}
+		}
+
+		// the files of the status.d directory do not contain the status
+		if status, ok := fields["Status"]; ok && !strings.HasSuffix(status, " installed") {
+			continue
+		}
+
+		if fields["Package"] != "" && fields["Version"] != "" {
+			packages = append(packages, Package{
+				Name:         fields["Package"],
+				Version:      fields["Version"],
+				Type:         "deb",
+				architecture: fields["Architecture"],
+			})
+		}
+	}
+
+	return packages
+}
+
+// parseApkInstalled parses the Alpine package database, which lists the
+// packages in blocks of single letter keys
+func parseApkInstalled(data []byte) []Package {
+	var packages []Package
+	var current Package
+
+	flush := func() {
+		if current.Name != "" && current.Version != "" {
+			current.Type = "apk"
+			packages = append(packages, current)
+		}
+		current = Package{}
+	}
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(make([]byte, 0, 64*1024), maxCatalogFileSize)
+	for scanner.Scan() {
+		line := scanner.Text()
+		switch {
+		case line == "":
+			flush()
+		case strings.HasPrefix(line, "P:"):
+			current.Name = line[2:]
+		case strings.HasPrefix(line, "V:"):
+			current.Version = line[2:]
+		case strings.HasPrefix(line, "A:"):
+			current.architecture = line[2:]
+		}
+	}
+	flush()
+
+	return packages
+}
+
+type npmDependency struct {
+	Version      string                   `json:"version"`
+	Dependencies map[string]npmDependency `json:"dependencies"`
+}
+
+// parseNpmPackageLock parses the package-lock.json file, the packages of
+// lockfile version 2 and 3 are keyed by their installation path, version 1
+// nests the dependencies
+func parseNpmPackageLock(data []byte) []Package {
+	var lockfile struct {
+		Packages map[string]struct {
+			Name    string `json:"name"`
+			Version string `json:"version"`
+			Link    bool   `json:"link"`
+		} `json:"packages"`
+		Dependencies map[string]npmDependency `json:"dependencies"`
+	}
+
+	if err := json.Unmarshal(data, &lockfile); err != nil {
+		return nil
+	}
+
+	var packages []Package
+	if len(lockfile.Packages) > 0 {
+		for installPath, entry := range lockfile.Packages {
+			// the empty path is the project itself
+			index := strings.LastIndex(installPath, "node_modules/")
+			if installPath == "" || index < 0 || entry.Link || entry.Version == "" {
+				continue
+			}
+
+			name := entry.Name
+			if name == "" {
+				name = installPath[index+len("node_modules/"):]
+			}
+
+			packages = append(packages, npmPackage(name, entry.Version))
+		}
+
+		return packages
+	}
+
+	var walk func(dependencies map[string]npmDependency)
+	walk = func(dependencies map[string]npmDependency) {
+		for name, dependency := range dependencies {
+			if dependency.Version != "" {
+				packages = append(packages, npmPackage(name, dependency.Version))
+			}
+			walk(dependency.Dependencies)
+		}
+	}
+	walk(lockfile.Dependencies)
+
+	return packages
+}
+
+func npmPackage(name string, version string) Package {
+	pkg := Package{Name: name, Version: version, Type: "npm"}
+	if scope, scopedName, ok := strings.Cut(name, "/"); ok && strings.HasPrefix(scope, "@") {
+		pkg.namespace, pkg.Name = scope, scopedName
+	}
+
+	return pkg
+}
+
+var (
+	requirementRegexp     = regexp.MustCompile(`^([A-Za-z0-9][A-Za-z0-9._-]*)(\[[^\]]*\])?\s*===?\s*([^\s;#]+)`)
+	requirementNameRegexp = regexp.MustCompile(`[-_.]+`)
+)
+
+// parseRequirementsTxt parses the pinned requirements of a Python project,
+// requirements without an exact version are skipped
+func parseRequirementsTxt(data []byte) []Package {
+	var packages []Package
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		if match := requirementRegexp.FindStringSubmatch(strings.TrimSpace(scanner.Text())); match != nil {
+			packages = append(packages, Package{
+				// normalize the name as defined by PEP 503
+				Name:    strings.ToLower(requirementNameRegexp.ReplaceAllString(match[1], "-")),
+				Version: match[3],
+				Type:    "pypi",
+			})
+		}
+	}
+
+	return packages
+}
+
+// parseGoMod parses the required modules of a Go module
+func parseGoMod(data []byte) []Package {
+	var packages []Package
+	inRequireBlock := false
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		line, _, _ := strings.Cut(scanner.Text(), "//")
+		fields := strings.Fields(line)
+
+		switch {
+		case len(fields) == 0:
+			continue
+
+		case inRequireBlock && fields[0] == ")":
+			inRequireBlock = false
+			continue
+
+		case fields[0] == "require" && len(fields) == 2 && fields[1] == "(":
+			inRequireBlock = true
+			continue
+
+		case fields[0] == "require" && len(fields) == 3:
+			fields = fields[1:]
+
+		case !inRequireBlock || len(fields) != 2:
+			continue
+		}
+
+		modulePath := fields[0]
+		pkg := Package{Name: modulePath, Version: fields[1], Type: "golang"}
+		if index := strings.LastIndex(modulePath, "/"); index >= 0 {
+			pkg.namespace, pkg.Name = modulePath[:index], modulePath[index+1:]
+		}
+
+		packages = append(packages, pkg)
+	}
+
+	return packages
+}
+
+// parseCargoLock parses the packages of a Rust project, the packages are
+// TOML tables with a name and a version
+func parseCargoLock(data []byte) []Package {
+	var packages []Package
+	var current *Package
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if strings.HasPrefix(line, "[") {
+			if current != nil && current.Name != "" && current.Version != "" {
+				packages = append(packages, *current)
+			}
+
+			current = nil
+			if line == "[[package]]" {
+				current = &Package{Type: "cargo"}
+			}
+			continue
+		}
+
+		if current == nil {
+			continue
+		}
+
+		if key, value, ok := strings.Cut(line, "="); ok {
+			switch strings.TrimSpace(key) {
+			case "name":
+				current.Name = strings.Trim(strings.TrimSpace(value), `"`)
+			case "version":
+				current.Version = strings.Trim(strings.TrimSpace(value), `"`)
+			}
+		}
+	}
+
+	if current != nil && current.Name != "" && current.Version != "" {
+		packages = append(packages, *current)
+	}
+
+	return packages
+}
+
+var gemSpecRegexp = regexp.MustCompile(`^    ([^\s(]+) \(([^)]+)\)$`)
+
+// parseGemfileLock parses the gems of a Ruby project, which are listed with
+// their versions in the specs of the GEM section
+func parseGemfileLock(data []byte) []Package {
+	var packages []Package
+	inGemSection := false
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		line := scanner.Text()
+		if line != "" && !strings.HasPrefix(line, " ") {
+			inGemSection = line == "GEM"
+			continue
+		}
+
+		if !inGemSection {
+			continue
+		}
+
+		if match := gemSpecRegexp.FindStringSubmatch(line); match != nil {
+			// platform specific gems have a version like 1.15.5-x86_64-linux
+			version, _, _ := strings.Cut(match[2], "-")
+			packages = append(packages, Package{Name: match[1], Version: version, Type: "gem"})
+		}
+	}
+
+	return packages
+}
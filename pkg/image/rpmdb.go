@@ -0,0 +1,592 @@
+// Copyright The Shipwright Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package image
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"path"
+	"strconv"
+)
+
+// rpmDatabaseDirectories are the directories of the RPM database, newer
+// distributions move it to /usr/lib/sysimage/rpm and keep a symbolic link
+var rpmDatabaseDirectories = []string{"var/lib/rpm", "usr/lib/sysimage/rpm"}
+
+// the tags and types of the RPM header entries, see
+// https://github.com/rpm-software-management/rpm/blob/master/include/rpm/rpmtag.h
+const (
+	rpmTagName    = 1000
+	rpmTagVersion = 1001
+	rpmTagRelease = 1002
+	rpmTagEpoch   = 1003
+	rpmTagArch    = 1022
+
+	rpmTypeInt32      = 4
+	rpmTypeString     = 6
+	rpmTypeI18NString = 9
+)
+
+func isRpmDatabase(fileName string) func(string) bool {
+	return func(filePath string) bool {
+		if path.Base(filePath) != fileName {
+			return false
+		}
+
+		for _, directory := range rpmDatabaseDirectories {
+			if path.Dir(filePath) == directory {
+				return true
+			}
+		}
+
+		return false
+	}
+}
+
+// parseRpmSqlite parses the SQLite RPM database of Fedora 33 and later and
+// of RHEL 9, the headers of the packages are stored in the Packages table
+func parseRpmSqlite(data []byte) []Package {
+	database, err := openSqlite(data)
+	if err != nil {
+		return nil
+	}
+
+	var blobs [][]byte
+	if err := database.readTable("Packages", func(values []any) {
+		if len(values) >= 2 {
+			if blob, ok := values[1].([]byte); ok {
+				blobs = append(blobs, blob)
+			}
+		}
+	}); err != nil {
+		return nil
+	}
+
+	return rpmPackages(blobs)
+}
+
+// the Berkeley DB hash database, see
+// https://github.com/berkeleydb/libdb/blob/master/src/dbinc/db_page.h
+const (
+	bdbHashMagic          = 0x061561
+	bdbMetaSize           = 72
+	bdbPageHeaderSize     = 26
+	bdbPageHash           = 13
+	bdbPageHashUnsorted   = 2
+	bdbItemOffPage        = 3
+	bdbOffPageItemSize    = 12
+	bdbMinimumPageSize    = 512
+	bdbMaximumPageSize    = 64 << 10
+	bdbPageTypeOffset     = 25
+	bdbEntriesOffset      = 20
+	bdbNextPageOffset     = 16
+	bdbOverflowSizeOffset = 22
+)
+
+// parseRpmBerkeleyDB parses the Berkeley DB RPM database of RHEL 8 and
+// older, the headers of the packages are the values of a hash database that
+// are too large to be stored in the hash pages and are therefore stored in
+// chains of overflow pages
+func parseRpmBerkeleyDB(data []byte) []Package {
+	if len(data) < bdbMetaSize {
+		return nil
+	}
+
+	// the database uses the byte order of the machine that created it
+	var order binary.ByteOrder = binary.LittleEndian
+	if order.Uint32(data[12:16]) != bdbHashMagic {
+		order = binary.BigEndian
+		if order.Uint32(data[12:16]) != bdbHashMagic {
+			return nil
+		}
+	}
+
+	pageSize := int(order.Uint32(data[20:24]))
+	if pageSize < bdbMinimumPageSize || pageSize > bdbMaximumPageSize {
+		return nil
+	}
+
+	pageCount := len(data) / pageSize
+	page := func(number uint32) []byte {
+		if int64(number) >= int64(pageCount) {
+			return nil
+		}
+		return data[int(number)*pageSize : (int(number)+1)*pageSize]
+	}
+
+	// overflow reads the value from the chain of overflow pages, the number
+	// of pages is limited so that a cycle in a damaged file ends
+	overflow := func(number uint32, length int) []byte {
+		if length > len(data) {
+			return nil
+		}
+
+		value := make([]byte, 0, length)
+		for i := 0; number != 0 && i < pageCount && len(value) < length; i++ {
+			current := page(number)
+			if current == nil {
+				return nil
+			}
+
+			size := int(order.Uint16(current[bdbOverflowSizeOffset:]))
+			if size > pageSize-bdbPageHeaderSize || size > length-len(value) {
+				return nil
+			}
+
+			value = append(value, current[bdbPageHeaderSize:bdbPageHeaderSize+size]...)
+			number = order.Uint32(current[bdbNextPageOffset:])
+		}
+
+		if len(value) != length {
+			return nil
+		}
+
+		return value
+	}
+
+	var blobs [][]byte
+	for number := 0; number < pageCount; number++ {
+		current := page(uint32(number))
+		if current[bdbPageTypeOffset] != bdbPageHash && current[bdbPageTypeOffset] != bdbPageHashUnsorted {
+			continue
+		}
+
+		// the entries are pairs of a key and a value, the keys are the
+		// numbers of the packages
+		entries := int(order.Uint16(current[bdbEntriesOffset:]))
+		for entry := 1; entry < entries; entry += 2 {
+			indexOffset := bdbPageHeaderSize + 2*entry
+			if indexOffset+2 > pageSize {
+				break
+			}
+
+			offset := int(order.Uint16(current[indexOffset:]))
+			if offset+bdbOffPageItemSize > pageSize || current[offset] != bdbItemOffPage {
+				continue
+			}
+
+			item := current[offset : offset+bdbOffPageItemSize]
+			if blob := overflow(order.Uint32(item[4:8]), int(order.Uint32(item[8:12]))); blob != nil {
+				blobs = append(blobs, blob)
+			}
+		}
+	}
+
+	return rpmPackages(blobs)
+}
+
+// the ndb database of rpm, see
+// https://github.com/rpm-software-management/rpm/blob/master/lib/backend/ndb/rpmpkg.c
+const (
+	ndbHeaderMagic   = 'R' | 'p'<<8 | 'm'<<16 | 'P'<<24
+	ndbSlotMagic     = 'S' | 'l'<<8 | 'o'<<16 | 't'<<24
+	ndbBlobMagic     = 'B' | 'l'<<8 | 'b'<<16 | 'S'<<24
+	ndbHeaderSize    = 32
+	ndbSlotSize      = 16
+	ndbSlotPageSize  = 4096
+	ndbBlockSize     = 16
+	ndbBlobHeaderLen = 16
+)
+
+// parseRpmNdb parses the ndb RPM database of SUSE, the slots at the start of
+// the file point to the blocks that contain the headers of the packages
+func parseRpmNdb(data []byte) []Package {
+	order := binary.LittleEndian
+	if len(data) < ndbHeaderSize || order.Uint32(data[0:4]) != ndbHeaderMagic {
+		return nil
+	}
+
+	slotsEnd := int64(order.Uint32(data[12:16])) * ndbSlotPageSize
+	if slotsEnd > int64(len(data)) {
+		return nil
+	}
+
+	var blobs [][]byte
+
+	// the header of the file takes the place of the first two slots
+	for offset := int64(ndbHeaderSize); offset+ndbSlotSize <= slotsEnd; offset += ndbSlotSize {
+		slot := data[offset : offset+ndbSlotSize]
+		if order.Uint32(slot[0:4]) != ndbSlotMagic {
+			return nil
+		}
+
+		// empty slots have no package index
+		packageIndex := order.Uint32(slot[4:8])
+		if packageIndex == 0 {
+			continue
+		}
+
+		blobOffset := int64(order.Uint32(slot[8:12])) * ndbBlockSize
+		if blobOffset+ndbBlobHeaderLen > int64(len(data)) {
+			continue
+		}
+
+		header := data[blobOffset : blobOffset+ndbBlobHeaderLen]
+		if order.Uint32(header[0:4]) != ndbBlobMagic || order.Uint32(header[4:8]) != packageIndex {
+			continue
+		}
+
+		blobEnd := blobOffset + ndbBlobHeaderLen + int64(order.Uint32(header[12:16]))
+		if blobEnd > int64(len(data)) {
+			continue
+		}
+
+		blobs = append(blobs, data[blobOffset+ndbBlobHeaderLen:blobEnd])
+	}
+
+	return rpmPackages(blobs)
+}
+
+// rpmPackages parses the headers of the packages, the public keys that rpm
+// imports as gpg-pubkey packages are not listed
+func rpmPackages(blobs [][]byte) []Package {
+	var packages []Package
+	for _, blob := range blobs {
+		if pkg, ok := parseRpmHeader(blob); ok && pkg.Name != "gpg-pubkey" {
+			packages = append(packages, pkg)
+		}
+	}
+
+	return packages
+}
+
+// parseRpmHeader parses the header of a package as it is stored in the
+// database, which is the number of entries and the size of the data,
+// followed by the index entries and the data, see
+// https://rpm-software-management.github.io/rpm/manual/format_header.html
+func parseRpmHeader(blob []byte) (Package, bool) {
+	if len(blob) < 8 {
+		return Package{}, false
+	}
+
+	entries := int64(binary.BigEndian.Uint32(blob[0:4]))
+	dataStart := 8 + entries*16
+	dataEnd := dataStart + int64(binary.BigEndian.Uint32(blob[4:8]))
+	if dataEnd > int64(len(blob)) {
+		return Package{}, false
+	}
+
+	store := blob[dataStart:dataEnd]
+	values := map[uint32]string{}
+	for i := int64(0); i < entries; i++ {
+		entry := blob[8+i*16 : 8+(i+1)*16]
+		tag := binary.BigEndian.Uint32(entry[0:4])
+		offset := int64(binary.BigEndian.Uint32(entry[8:12]))
+		if offset >= int64(len(store)) {
+			continue
+		}
+
+		switch binary.BigEndian.Uint32(entry[4:8]) {
+		case rpmTypeString, rpmTypeI18NString:
+			// the first string is the untranslated one
+			value, _, _ := bytes.Cut(store[offset:], []byte{0})
+			values[tag] = string(value)
+
+		case rpmTypeInt32:
+			if offset+4 <= int64(len(store)) {
+				values[tag] = strconv.FormatUint(uint64(binary.BigEndian.Uint32(store[offset:])), 10)
+			}
+		}
+	}
+
+	pkg := Package{
+		Name:         values[rpmTagName],
+		Version:      values[rpmTagVersion],
+		Type:         "rpm",
+		architecture: values[rpmTagArch],
+		epoch:        values[rpmTagEpoch],
+	}
+
+	if pkg.Name == "" || pkg.Version == "" {
+		return Package{}, false
+	}
+
+	if release := values[rpmTagRelease]; release != "" {
+		pkg.Version += "-" + release
+	}
+
+	return pkg, true
+}
+
+// the SQLite database file, see https://www.sqlite.org/fileformat.html
+const (
+	sqliteMagic            = "SQLite format 3\x00"
+	sqliteFileHeaderSize   = 100
+	sqliteInteriorTable    = 0x05
+	sqliteLeafTable        = 0x0d
+	sqliteMaximumTreeDepth = 32
+)
+
+var errInvalidSqlite = errors.New("invalid SQLite database")
+
+// sqliteDatabase reads the tables of an SQLite database file, changes that
+// are only in the write-ahead log are not read
+type sqliteDatabase struct {
+	data       []byte
+	pageSize   int
+	usableSize int
+}
+
+func openSqlite(data []byte) (*sqliteDatabase, error) {
+	if len(data) < sqliteFileHeaderSize || string(data[:len(sqliteMagic)]) != sqliteMagic {
+		return nil, errInvalidSqlite
+	}
+
+	// the page size 65536 does not fit into two bytes and is stored as 1
+	pageSize := int(binary.BigEndian.Uint16(data[16:18]))
+	if pageSize == 1 {
+		pageSize = 65536
+	}
+
+	usableSize := pageSize - int(data[20])
+	if pageSize < 512 || usableSize < 480 {
+		return nil, errInvalidSqlite
+	}
+
+	return &sqliteDatabase{data: data, pageSize: pageSize, usableSize: usableSize}, nil
+}
+
+// readTable calls visit with the values of every row of the table, the
+// table is looked up in the schema table which is stored in the first page
+func (database *sqliteDatabase) readTable(table string, visit func(values []any)) error {
+	var rootPage int64
+	if err := database.walk(1, 0, func(values []any) {
+		if len(values) >= 4 && values[0] == "table" && values[1] == table {
+			rootPage, _ = values[3].(int64)
+		}
+	}); err != nil {
+		return err
+	}
+
+	if rootPage <= 1 {
+		return errInvalidSqlite
+	}
+
+	return database.walk(uint32(rootPage), 0, visit)
+}
+
+func (database *sqliteDatabase) page(number uint32) ([]byte, error) {
+	start := (int64(number) - 1) * int64(database.pageSize)
+	if number == 0 || start+int64(database.pageSize) > int64(len(database.data)) {
+		return nil, errInvalidSqlite
+	}
+
+	return database.data[start : start+int64(database.pageSize)], nil
+}
+
+// walk reads the rows of the table b-tree that starts at the page
+func (database *sqliteDatabase) walk(number uint32, depth int, visit func(values []any)) error {
+	if depth > sqliteMaximumTreeDepth {
+		return errInvalidSqlite
+	}
+
+	page, err := database.page(number)
+	if err != nil {
+		return err
+	}
+
+	// the first page starts with the header of the file
+	header := page
+	if number == 1 {
+		header = page[sqliteFileHeaderSize:]
+	}
+
+	var headerSize int
+	switch header[0] {
+	case sqliteInteriorTable:
+		headerSize = 12
+	case sqliteLeafTable:
+		headerSize = 8
+	default:
+		return errInvalidSqlite
+	}
+
+	cells := int(binary.BigEndian.Uint16(header[3:5]))
+	if len(header) < headerSize+2*cells {
+		return errInvalidSqlite
+	}
+
+	for i := 0; i < cells; i++ {
+		offset := int(binary.BigEndian.Uint16(header[headerSize+2*i:]))
+		if offset >= database.usableSize {
+			return errInvalidSqlite
+		}
+		cell := page[offset:database.usableSize]
+
+		switch header[0] {
+		case sqliteInteriorTable:
+			if len(cell) < 4 {
+				return errInvalidSqlite
+			}
+
+			if err := database.walk(binary.BigEndian.Uint32(cell), depth+1, visit); err != nil {
+				return err
+			}
+
+		case sqliteLeafTable:
+			payloadSize, n := sqliteVarint(cell)
+			_, m := sqliteVarint(cell[n:])
+			if n == 0 || m == 0 {
+				return errInvalidSqlite
+			}
+
+			payload, err := database.payload(cell[n+m:], payloadSize)
+			if err != nil {
+				return err
+			}
+
+			values, err := sqliteRecord(payload)
+			if err != nil {
+				return err
+			}
+
+			visit(values)
+		}
+	}
+
+	// the right-most child of an interior page is stored in its header
+	if header[0] == sqliteInteriorTable {
+		return database.walk(binary.BigEndian.Uint32(header[8:12]), depth+1, visit)
+	}
+
+	return nil
+}
+
+// payload reads the payload of a cell, the part of a large payload that
+// does not fit into the page is stored in a chain of overflow pages
+func (database *sqliteDatabase) payload(local []byte, size uint64) ([]byte, error) {
+	if size > uint64(len(database.data)) {
+		return nil, errInvalidSqlite
+	}
+
+	usable := uint64(database.usableSize)
+	maxLocal := usable - 35
+	if size <= maxLocal {
+		if size > uint64(len(local)) {
+			return nil, errInvalidSqlite
+		}
+		return local[:size], nil
+	}
+
+	minLocal := (usable-12)*32/255 - 23
+	localSize := minLocal + (size-minLocal)%(usable-4)
+	if localSize > maxLocal {
+		localSize = minLocal
+	}
+
+	if localSize+4 > uint64(len(local)) {
+		return nil, errInvalidSqlite
+	}
+
+	payload := make([]byte, 0, size)
+	payload = append(payload, local[:localSize]...)
+	next := binary.BigEndian.Uint32(local[localSize:])
+
+	for uint64(len(payload)) < size {
+		page, err := database.page(next)
+		if err != nil {
+			return nil, err
+		}
+
+		next = binary.BigEndian.Uint32(page)
+		content := page[4:database.usableSize]
+		if remaining := size - uint64(len(payload)); uint64(len(content)) > remaining {
+			content = content[:remaining]
+		}
+
+		payload = append(payload, content...)
+	}
+
+	return payload, nil
+}
+
+// sqliteRecord decodes the values of a record, integers are returned as
+// int64, blobs as []byte, text as string, and null and real values as nil
+func sqliteRecord(payload []byte) ([]any, error) {
+	headerSize, n := sqliteVarint(payload)
+	if n == 0 || headerSize > uint64(len(payload)) {
+		return nil, errInvalidSqlite
+	}
+
+	var serialTypes []uint64
+	for position := uint64(n); position < headerSize; {
+		serialType, m := sqliteVarint(payload[position:headerSize])
+		if m == 0 {
+			return nil, errInvalidSqlite
+		}
+
+		serialTypes = append(serialTypes, serialType)
+		position += uint64(m)
+	}
+
+	body := payload[headerSize:]
+	values := make([]any, 0, len(serialTypes))
+	for _, serialType := range serialTypes {
+		var size uint64
+		switch {
+		case serialType >= 12:
+			size = (serialType - 12) / 2
+		case serialType >= 1 && serialType <= 4:
+			size = serialType
+		case serialType == 5:
+			size = 6
+		case serialType == 6 || serialType == 7:
+			size = 8
+		}
+
+		if size > uint64(len(body)) {
+			return nil, errInvalidSqlite
+		}
+
+		value := body[:size]
+		body = body[size:]
+
+		switch {
+		case serialType >= 1 && serialType <= 6:
+			var integer int64
+			for _, b := range value {
+				integer = integer<<8 | int64(b)
+			}
+
+			// sign-extend the big-endian two's complement integer
+			shift := 64 - 8*len(value)
+			values = append(values, integer<<shift>>shift)
+
+		case serialType == 8, serialType == 9:
+			values = append(values, int64(serialType-8))
+
+		case serialType >= 12 && serialType%2 == 0:
+			values = append(values, value)
+
+		case serialType >= 13:
+			values = append(values, string(value))
+
+		default:
+			values = append(values, nil)
+		}
+	}
+
+	return values, nil
+}
+
+// sqliteVarint decodes the big-endian variable-length integer and returns
+// the number of bytes that it used, or zero if the integer is truncated
+func sqliteVarint(data []byte) (uint64, int) {
+	var value uint64
+	for i := 0; i < 9 && i < len(data); i++ {
+		// the ninth byte contributes all of its bits
+		if i == 8 {
+			return value<<8 | uint64(data[i]), 9
+		}
+
+		value = value<<7 | uint64(data[i]&0x7f)
+		if data[i] < 0x80 {
+			return value, i + 1
+		}
+	}
+
+	return 0, 0
+}
@@ -0,0 +1,246 @@
+// Copyright The Shipwright Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package image
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/go-containerregistry/pkg/name"
+	containerreg "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/empty"
+	"github.com/google/go-containerregistry/pkg/v1/mutate"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/google/go-containerregistry/pkg/v1/static"
+	"github.com/google/go-containerregistry/pkg/v1/types"
+	"github.com/google/uuid"
+)
+
+// SBOMFormat is the document format of a software bill of materials
+type SBOMFormat string
+
+const (
+	// SBOMFormatSPDX is the SPDX 2.3 JSON format
+	SBOMFormatSPDX SBOMFormat = "spdx"
+
+	// SBOMFormatCycloneDX is the CycloneDX 1.5 JSON format
+	SBOMFormatCycloneDX SBOMFormat = "cyclonedx"
+)
+
+const (
+	// SPDXMediaType is the media type of an SPDX JSON document
+	SPDXMediaType types.MediaType = "application/spdx+json"
+
+	// CycloneDXMediaType is the media type of a CycloneDX JSON document
+	CycloneDXMediaType types.MediaType = "application/vnd.cyclonedx+json"
+)
+
+const sbomCreator = "shipwright-io/build image-processing"
+
+type spdxDocument struct {
+	SPDXVersion       string             `json:"spdxVersion"`
+	DataLicense       string             `json:"dataLicense"`
+	SPDXID            string             `json:"SPDXID"`
+	Name              string             `json:"name"`
+	DocumentNamespace string             `json:"documentNamespace"`
+	CreationInfo      spdxCreationInfo   `json:"creationInfo"`
+	Packages          []spdxPackage      `json:"packages"`
+	Relationships     []spdxRelationship `json:"relationships"`
+}
+
+type spdxCreationInfo struct {
+	Created  string   `json:"created"`
+	Creators []string `json:"creators"`
+}
+
+type spdxPackage struct {
+	SPDXID           string            `json:"SPDXID"`
+	Name             string            `json:"name"`
+	VersionInfo      string            `json:"versionInfo,omitempty"`
+	DownloadLocation string            `json:"downloadLocation"`
+	FilesAnalyzed    bool              `json:"filesAnalyzed"`
+	SourceInfo       string            `json:"sourceInfo,omitempty"`
+	ExternalRefs     []spdxExternalRef `json:"externalRefs,omitempty"`
+}
+
+type spdxExternalRef struct {
+	ReferenceCategory string `json:"referenceCategory"`
+	ReferenceType     string `json:"referenceType"`
+	ReferenceLocator  string `json:"referenceLocator"`
+}
+
+type spdxRelationship struct {
+	SPDXElementID      string `json:"spdxElementId"`
+	RelationshipType   string `json:"relationshipType"`
+	RelatedSPDXElement string `json:"relatedSpdxElement"`
+}
+
+type cycloneDXDocument struct {
+	BOMFormat    string               `json:"bomFormat"`
+	SpecVersion  string               `json:"specVersion"`
+	SerialNumber string               `json:"serialNumber"`
+	Version      int                  `json:"version"`
+	Metadata     cycloneDXMetadata    `json:"metadata"`
+	Components   []cycloneDXComponent `json:"components"`
+}
+
+type cycloneDXMetadata struct {
+	Timestamp string             `json:"timestamp"`
+	Tools     []cycloneDXTool    `json:"tools"`
+	Component cycloneDXComponent `json:"component"`
+}
+
+type cycloneDXTool struct {
+	Name string `json:"name"`
+}
+
+type cycloneDXComponent struct {
+	BOMRef     string              `json:"bom-ref,omitempty"`
+	Type       string              `json:"type"`
+	Name       string              `json:"name"`
+	Version    string              `json:"version,omitempty"`
+	PURL       string              `json:"purl,omitempty"`
+	Properties []cycloneDXProperty `json:"properties,omitempty"`
+}
+
+type cycloneDXProperty struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+// GenerateSBOM creates a software bill of materials for the image digest that
+// lists the packages. It returns the document and its media type.
+func GenerateSBOM(subject name.Digest, packages []Package, format SBOMFormat, created time.Time) ([]byte, types.MediaType, error) {
+	timestamp := created.UTC().Format(time.RFC3339)
+
+	switch format {
+	case SBOMFormatSPDX:
+		document := spdxDocument{
+			SPDXVersion:       "SPDX-2.3",
+			DataLicense:       "CC0-1.0",
+			SPDXID:            "SPDXRef-DOCUMENT",
+			Name:              subject.String(),
+			DocumentNamespace: fmt.Sprintf("https://shipwright.io/spdx/%s-%s", subject.Context().RepositoryStr(), uuid.NewString()),
+			CreationInfo: spdxCreationInfo{
+				Created:  timestamp,
+				Creators: []string{"Tool: " + sbomCreator},
+			},
+			Packages: []spdxPackage{{
+				SPDXID:           "SPDXRef-Image",
+				Name:             subject.Context().Name(),
+				VersionInfo:      subject.DigestStr(),
+				DownloadLocation: "NOASSERTION",
+			}},
+			Relationships: []spdxRelationship{{
+				SPDXElementID:      "SPDXRef-DOCUMENT",
+				RelationshipType:   "DESCRIBES",
+				RelatedSPDXElement: "SPDXRef-Image",
+			}},
+		}
+
+		for i, pkg := range packages {
+			id := fmt.Sprintf("SPDXRef-Package-%d", i+1)
+			document.Packages = append(document.Packages, spdxPackage{
+				SPDXID:           id,
+				Name:             pkg.Name,
+				VersionInfo:      pkg.Version,
+				DownloadLocation: "NOASSERTION",
+				SourceInfo:       "acquired package info from " + pkg.Location,
+				ExternalRefs: []spdxExternalRef{{
+					ReferenceCategory: "PACKAGE-MANAGER",
+					ReferenceType:     "purl",
+					ReferenceLocator:  pkg.PURL,
+				}},
+			})
+			document.Relationships = append(document.Relationships, spdxRelationship{
+				SPDXElementID:      "SPDXRef-Image",
+				RelationshipType:   "CONTAINS",
+				RelatedSPDXElement: id,
+			})
+		}
+
+		data, err := json.Marshal(document)
+		return data, SPDXMediaType, err
+
+	case SBOMFormatCycloneDX:
+		document := cycloneDXDocument{
+			BOMFormat:    "CycloneDX",
+			SpecVersion:  "1.5",
+			SerialNumber: "urn:uuid:" + uuid.NewString(),
+			Version:      1,
+			Metadata: cycloneDXMetadata{
+				Timestamp: timestamp,
+				Tools:     []cycloneDXTool{{Name: sbomCreator}},
+				Component: cycloneDXComponent{
+					BOMRef:  subject.String(),
+					Type:    "container",
+					Name:    subject.Context().Name(),
+					Version: subject.DigestStr(),
+				},
+			},
+			Components: []cycloneDXComponent{},
+		}
+
+		for i, pkg := range packages {
+			document.Components = append(document.Components, cycloneDXComponent{
+				BOMRef:  fmt.Sprintf("%s#%d", pkg.PURL, i+1),
+				Type:    "library",
+				Name:    pkg.Name,
+				Version: pkg.Version,
+				PURL:    pkg.PURL,
+				Properties: []cycloneDXProperty{{
+					Name:  "shipwright:location",
+					Value: pkg.Location,
+				}},
+			})
+		}
+
+		data, err := json.Marshal(document)
+		return data, CycloneDXMediaType, err
+
+	default:
+		return nil, "", fmt.Errorf("unsupported SBOM format %q, supported formats are %s and %s", format, SBOMFormatSPDX, SBOMFormatCycloneDX)
+	}
+}
+
+// AttachSBOM pushes the software bill of materials as an OCI artifact into the
+// repository of the image digest. The artifact refers to the image using its
+// subject, so that it is listed by the referrers API of the registry, or by
+// the referrers tag for registries that do not support the API. It returns
+// the digest of the artifact. See remote.Option for optional options to the
+// push to the registry.
+func AttachSBOM(subject name.Digest, sbom []byte, mediaType types.MediaType, options ...remote.Option) (containerreg.Hash, error) {
+	descriptor, err := remote.Head(subject, options...)
+	if err != nil {
+		return containerreg.Hash{}, err
+	}
+
+	// the artifact type of the referrer is derived from the config media type
+	artifact, err := mutate.Append(
+		mutate.ConfigMediaType(mutate.MediaType(empty.Image, types.OCIManifestSchema1), mediaType),
+		mutate.Addendum{Layer: static.NewLayer(sbom, mediaType)},
+	)
+	if err != nil {
+		return containerreg.Hash{}, err
+	}
+
+	artifact = mutate.Subject(artifact, containerreg.Descriptor{
+		MediaType: descriptor.MediaType,
+		Digest:    descriptor.Digest,
+		Size:      descriptor.Size,
+	}).(containerreg.Image)
+
+	hash, err := artifact.Digest()
+	if err != nil {
+		return containerreg.Hash{}, err
+	}
+
+	if err := remote.Write(subject.Context().Digest(hash.String()), artifact, options...); err != nil {
+		return containerreg.Hash{}, err
+	}
+
+	return hash, nil
+}
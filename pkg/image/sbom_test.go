@@ -0,0 +1,317 @@
+// Copyright The Shipwright Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package image_test
+
+import (
+	"archive/tar"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http/httptest"
+	"os"
+	"path"
+	"strings"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/registry"
+	containerreg "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/empty"
+	"github.com/google/go-containerregistry/pkg/v1/mutate"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/google/go-containerregistry/pkg/v1/tarball"
+
+	"github.com/shipwright-io/build/pkg/image"
+)
+
+var _ = Describe("SBOM", func() {
+
+	// newLayer creates a layer that contains the files
+	newLayer := func(files map[string]string) containerreg.Layer {
+		var buf bytes.Buffer
+		tw := tar.NewWriter(&buf)
+		for path, content := range files {
+			Expect(tw.WriteHeader(&tar.Header{
+				Name:     path,
+				Typeflag: tar.TypeReg,
+				Mode:     0644,
+				Size:     int64(len(content)),
+			})).To(Succeed())
+			_, err := tw.Write([]byte(content))
+			Expect(err).ToNot(HaveOccurred())
+		}
+		Expect(tw.Close()).To(Succeed())
+
+		layer, err := tarball.LayerFromOpener(func() (io.ReadCloser, error) {
+			return io.NopCloser(bytes.NewReader(buf.Bytes())), nil
+		})
+		Expect(err).ToNot(HaveOccurred())
+
+		return layer
+	}
+
+	newImage := func(layers ...containerreg.Layer) containerreg.Image {
+		img, err := mutate.AppendLayers(empty.Image, layers...)
+		Expect(err).ToNot(HaveOccurred())
+		return img
+	}
+
+	osLayer := newLayer(map[string]string{
+		"etc/os-release": "PRETTY_NAME=\"Debian GNU/Linux 12 (bookworm)\"\nID=debian\n",
+		"var/lib/dpkg/status": `Package: base-files
+Status: install ok installed
+Architecture: amd64
+Version: 12.4+deb12u5
+Description: Debian base system miscellaneous files
+ This package contains the basic filesystem hierarchy.
+
+Package: removed-package
+Status: deinstall ok config-files
+Architecture: amd64
+Version: 1.0
+`,
+	})
+
+	appLayer := newLayer(map[string]string{
+		"app/package-lock.json": `{
+  "name": "app",
+  "lockfileVersion": 3,
+  "packages": {
+    "": {"name": "app", "version": "1.0.0"},
+    "node_modules/express": {"version": "4.18.2"},
+    "node_modules/@types/node": {"version": "20.10.0"}
+  }
+}`,
+		"app/node_modules/express/package-lock.json": `{"lockfileVersion": 3, "packages": {"node_modules/ignored": {"version": "1.0.0"}}}`,
+		"app/requirements.txt":                       "# pinned\nFlask_Cors==4.0.0\nrequests>=2.0\n",
+		"app/go.mod":                                 "module example.com/app\n\ngo 1.21\n\nrequire (\n\tgithub.com/spf13/pflag v1.0.5\n\tgolang.org/x/text v0.14.0 // indirect\n)\n",
+		"app/Cargo.lock":                             "version = 3\n\n[[package]]\nname = \"serde\"\nversion = \"1.0.193\"\n",
+		"app/Gemfile.lock":                           "GEM\n  remote: https://rubygems.org/\n  specs:\n    rack (3.0.8)\n    nokogiri (1.15.5-x86_64-linux)\n\nPLATFORMS\n  x86_64-linux\n",
+	})
+
+	purls := func(packages []image.Package) []string {
+		var result []string
+		for _, pkg := range packages {
+			result = append(result, pkg.PURL)
+		}
+		return result
+	}
+
+	Context("listing the packages of an image", func() {
+
+		It("lists the OS packages and the packages of the lockfiles", func() {
+			packages, err := image.ListPackages(newImage(osLayer, appLayer), nil)
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(purls(packages)).To(Equal([]string{
+				"pkg:cargo/serde@1.0.193",
+				"pkg:deb/debian/base-files@12.4%2Bdeb12u5?arch=amd64",
+				"pkg:gem/nokogiri@1.15.5",
+				"pkg:gem/rack@3.0.8",
+				"pkg:golang/github.com/spf13/pflag@v1.0.5",
+				"pkg:golang/golang.org/x/text@v0.14.0",
+				"pkg:npm/%40types/node@20.10.0",
+				"pkg:npm/express@4.18.2",
+				"pkg:pypi/flask-cors@4.0.0",
+			}))
+
+			Expect(packages[1].Name).To(Equal("base-files"))
+			Expect(packages[1].Version).To(Equal("12.4+deb12u5"))
+			Expect(packages[1].Type).To(Equal("deb"))
+			Expect(packages[1].Location).To(Equal("/var/lib/dpkg/status"))
+		})
+
+		It("does not list the packages of files that were deleted by a later layer", func() {
+			packages, err := image.ListPackages(newImage(osLayer, newLayer(map[string]string{
+				"var/lib/dpkg/.wh.status": "",
+			})), nil)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(packages).To(BeEmpty())
+		})
+
+		It("lists the Alpine packages", func() {
+			packages, err := image.ListPackages(newImage(newLayer(map[string]string{
+				"etc/os-release":        "ID=alpine\n",
+				"lib/apk/db/installed":  "C:Q1abc=\nP:musl\nV:1.2.4-r2\nA:x86_64\n\nP:busybox\nV:1.36.1-r15\nA:x86_64\n",
+				"usr/lib/os-release":    "ID=other\n",
+				"app/unrelated-file.md": "# README\n",
+			})), nil)
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(purls(packages)).To(Equal([]string{
+				"pkg:apk/alpine/busybox@1.36.1-r15?arch=x86_64",
+				"pkg:apk/alpine/musl@1.2.4-r2?arch=x86_64",
+			}))
+		})
+
+		Context("with an RPM database", func() {
+
+			// readFixture reads a database of the rpmdb test data directory
+			readFixture := func(fileName string) string {
+				cwd, err := os.Getwd()
+				Expect(err).ToNot(HaveOccurred())
+
+				data, err := os.ReadFile(path.Join(cwd, "../..", "test/data/rpmdb", fileName))
+				Expect(err).ToNot(HaveOccurred())
+
+				return string(data)
+			}
+
+			It("lists the packages of the SQLite database", func() {
+				packages, err := image.ListPackages(newImage(newLayer(map[string]string{
+					"etc/os-release":                    "ID=fedora\n",
+					"usr/lib/sysimage/rpm/rpmdb.sqlite": readFixture("rpmdb.sqlite"),
+				})), nil)
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(purls(packages)).To(Equal([]string{
+					"pkg:rpm/fedora/bash@5.2.15-3.fc38?arch=x86_64",
+					"pkg:rpm/fedora/openssl-libs@3.0.9-2.fc38?arch=x86_64&epoch=1",
+				}))
+
+				Expect(packages[0].Name).To(Equal("bash"))
+				Expect(packages[0].Version).To(Equal("5.2.15-3.fc38"))
+				Expect(packages[0].Type).To(Equal("rpm"))
+				Expect(packages[0].Location).To(Equal("/usr/lib/sysimage/rpm/rpmdb.sqlite"))
+			})
+
+			It("lists the packages of the Berkeley DB database", func() {
+				packages, err := image.ListPackages(newImage(newLayer(map[string]string{
+					"etc/os-release":       "ID=\"rhel\"\n",
+					"var/lib/rpm/Packages": readFixture("Packages"),
+				})), nil)
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(purls(packages)).To(Equal([]string{
+					"pkg:rpm/rhel/bash@4.4.20-4.el8_6?arch=x86_64",
+					"pkg:rpm/rhel/openssl-libs@1.1.1k-9.el8_7?arch=x86_64&epoch=1",
+				}))
+			})
+
+			It("lists the packages of the ndb database", func() {
+				packages, err := image.ListPackages(newImage(newLayer(map[string]string{
+					"etc/os-release":                   "ID=\"sles\"\n",
+					"usr/lib/sysimage/rpm/Packages.db": readFixture("Packages.db"),
+				})), nil)
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(purls(packages)).To(Equal([]string{
+					"pkg:rpm/sles/bash@4.4-150400.27.3.2?arch=x86_64",
+					"pkg:rpm/sles/libopenssl1_1@1.1.1l-150400.7.28.1?arch=x86_64",
+				}))
+			})
+
+			It("does not list the packages of a damaged database", func() {
+				database := readFixture("rpmdb.sqlite")
+
+				packages, err := image.ListPackages(newImage(newLayer(map[string]string{
+					"var/lib/rpm/rpmdb.sqlite": database[:len(database)/2],
+				})), nil)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(packages).To(BeEmpty())
+			})
+		})
+
+		It("lists the packages of all images of an image index once", func() {
+			imageIndex := mutate.AppendManifests(empty.Index,
+				mutate.IndexAddendum{Add: newImage(osLayer)},
+				mutate.IndexAddendum{Add: newImage(osLayer, appLayer)},
+			)
+
+			packages, err := image.ListPackages(nil, imageIndex)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(packages).To(HaveLen(9))
+		})
+	})
+
+	Context("generating an SBOM", func() {
+		var (
+			digest   name.Digest
+			packages []image.Package
+		)
+
+		BeforeEach(func() {
+			var err error
+			digest, err = name.NewDigest("registry.example.com/namespace/app@sha256:8f5d4d6c6bc5b4a1a6b2f3bf9f0a5e2c7d3c1b7a9e8d6f5c4b3a2918e7d6c5b4")
+			Expect(err).ToNot(HaveOccurred())
+
+			packages, err = image.ListPackages(newImage(osLayer), nil)
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("generates an SPDX document", func() {
+			data, mediaType, err := image.GenerateSBOM(digest, packages, image.SBOMFormatSPDX, time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(mediaType).To(Equal(image.SPDXMediaType))
+
+			var document map[string]interface{}
+			Expect(json.Unmarshal(data, &document)).To(Succeed())
+			Expect(document["spdxVersion"]).To(Equal("SPDX-2.3"))
+			Expect(document["creationInfo"]).To(HaveKeyWithValue("created", "2024-01-02T03:04:05Z"))
+			Expect(document["packages"]).To(HaveLen(2))
+			Expect(string(data)).To(ContainSubstring(`"referenceLocator":"pkg:deb/debian/base-files@12.4%2Bdeb12u5?arch=amd64"`))
+		})
+
+		It("generates a CycloneDX document", func() {
+			data, mediaType, err := image.GenerateSBOM(digest, packages, image.SBOMFormatCycloneDX, time.Now())
+			Expect(err).ToNot(HaveOccurred())
+			Expect(mediaType).To(Equal(image.CycloneDXMediaType))
+
+			var document map[string]interface{}
+			Expect(json.Unmarshal(data, &document)).To(Succeed())
+			Expect(document["bomFormat"]).To(Equal("CycloneDX"))
+			Expect(document["specVersion"]).To(Equal("1.5"))
+			Expect(document["components"]).To(HaveLen(1))
+			Expect(string(data)).To(ContainSubstring(`"version":"sha256:8f5d4d6c6bc5b4a1a6b2f3bf9f0a5e2c7d3c1b7a9e8d6f5c4b3a2918e7d6c5b4"`))
+		})
+
+		It("fails for an unsupported format", func() {
+			_, _, err := image.GenerateSBOM(digest, packages, "swid", time.Now())
+			Expect(err).To(MatchError(ContainSubstring("unsupported SBOM format")))
+		})
+	})
+
+	Context("attaching an SBOM", func() {
+		var registryHost string
+
+		BeforeEach(func() {
+			server := httptest.NewServer(registry.New(
+				registry.Logger(log.New(io.Discard, "", 0)),
+				registry.WithReferrersSupport(true),
+			))
+			DeferCleanup(server.Close)
+			registryHost = strings.TrimPrefix(server.URL, "http://")
+		})
+
+		It("pushes the SBOM as a referrer of the image", func() {
+			img := newImage(osLayer)
+
+			tag, err := name.NewTag(fmt.Sprintf("%s/test-namespace/test-image:latest", registryHost))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(remote.Write(tag, img)).To(Succeed())
+
+			hash, err := img.Digest()
+			Expect(err).ToNot(HaveOccurred())
+			digest := tag.Context().Digest(hash.String())
+
+			sbomDigest, err := image.AttachSBOM(digest, []byte(`{"spdxVersion":"SPDX-2.3"}`), image.SPDXMediaType)
+			Expect(err).ToNot(HaveOccurred())
+
+			referrers, err := remote.Referrers(digest)
+			Expect(err).ToNot(HaveOccurred())
+
+			indexManifest, err := referrers.IndexManifest()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(indexManifest.Manifests).To(HaveLen(1))
+			Expect(indexManifest.Manifests[0].Digest).To(Equal(sbomDigest))
+			Expect(indexManifest.Manifests[0].ArtifactType).To(Equal(string(image.SPDXMediaType)))
+		})
+	})
+})
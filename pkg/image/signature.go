@@ -0,0 +1,404 @@
+// Copyright The Shipwright Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package image
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/google/go-containerregistry/pkg/name"
+	containerreg "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/empty"
+	"github.com/google/go-containerregistry/pkg/v1/mutate"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/google/go-containerregistry/pkg/v1/remote/transport"
+	"github.com/google/go-containerregistry/pkg/v1/static"
+	"github.com/google/go-containerregistry/pkg/v1/types"
+	"golang.org/x/crypto/nacl/secretbox"
+	"golang.org/x/crypto/scrypt"
+)
+
+// The signatures use the format of cosign, the signature of an image is stored
+// in the same repository using a tag that is derived from the image digest.
+// Each layer of the signature image is a simple signing payload that names the
+// signed image digest, the signature of the payload is an annotation of the
+// layer.
+const (
+	signaturePayloadMediaType = "application/vnd.dev.cosign.simplesigning.v1+json"
+	signatureAnnotation       = "dev.cosignproject.cosign/signature"
+	signaturePayloadType      = "cosign container image signature"
+	signatureTagSuffix        = "sig"
+)
+
+// ErrSignatureVerificationFailed is returned when the image is not signed by
+// any of the trusted public keys
+var ErrSignatureVerificationFailed = errors.New("image signature verification failed")
+
+type signaturePayload struct {
+	Critical struct {
+		Identity struct {
+			DockerReference string `json:"docker-reference"`
+		} `json:"identity"`
+		Image struct {
+			DockerManifestDigest string `json:"docker-manifest-digest"`
+		} `json:"image"`
+		Type string `json:"type"`
+	} `json:"critical"`
+	Optional map[string]string `json:"optional"`
+}
+
+// Sign creates a signature of the image digest using the private key, and
+// appends it to the signatures in the repository of the image. It returns the
+// digest of the signature image. See remote.Option for optional options to the
+// image pull and push from and to the registry.
+func Sign(digest name.Digest, key crypto.Signer, options ...remote.Option) (containerreg.Hash, error) {
+	var payload signaturePayload
+	payload.Critical.Identity.DockerReference = digest.Context().Name()
+	payload.Critical.Image.DockerManifestDigest = digest.DigestStr()
+	payload.Critical.Type = signaturePayloadType
+
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return containerreg.Hash{}, err
+	}
+
+	signature, err := signPayload(key, data)
+	if err != nil {
+		return containerreg.Hash{}, err
+	}
+
+	tag, err := cosignTag(digest, signatureTagSuffix)
+	if err != nil {
+		return containerreg.Hash{}, err
+	}
+
+	return appendCosignLayer(tag, mutate.Addendum{
+		Layer: static.NewLayer(data, signaturePayloadMediaType),
+		Annotations: map[string]string{
+			signatureAnnotation: base64.StdEncoding.EncodeToString(signature),
+		},
+	}, options...)
+}
+
+// VerifySignature checks that the image digest is signed by one of the
+// trusted public keys. An unsigned image, or an image whose signatures do not
+// match its digest or any of the keys, results in an error that wraps
+// ErrSignatureVerificationFailed. See remote.Option for optional options to
+// the image pull from the registry.
+func VerifySignature(digest name.Digest, keys []crypto.PublicKey, options ...remote.Option) error {
+	if len(keys) == 0 {
+		return fmt.Errorf("%w: no trusted public keys are configured", ErrSignatureVerificationFailed)
+	}
+
+	tag, err := cosignTag(digest, signatureTagSuffix)
+	if err != nil {
+		return err
+	}
+
+	signatureImage, err := remote.Image(tag, options...)
+	if err != nil {
+		var transportErr *transport.Error
+		if errors.As(err, &transportErr) && transportErr.StatusCode == http.StatusNotFound {
+			return fmt.Errorf("%w: image %s is not signed", ErrSignatureVerificationFailed, digest.String())
+		}
+
+		return err
+	}
+
+	manifest, err := signatureImage.Manifest()
+	if err != nil {
+		return err
+	}
+
+	for _, descriptor := range manifest.Layers {
+		if descriptor.MediaType != signaturePayloadMediaType {
+			continue
+		}
+
+		signature, err := base64.StdEncoding.DecodeString(descriptor.Annotations[signatureAnnotation])
+		if err != nil {
+			continue
+		}
+
+		layer, err := signatureImage.LayerByDigest(descriptor.Digest)
+		if err != nil {
+			return err
+		}
+
+		data, err := readPayload(layer)
+		if err != nil {
+			return err
+		}
+
+		var payload signaturePayload
+		if err := json.Unmarshal(data, &payload); err != nil {
+			continue
+		}
+
+		// the payload must name the digest of the image, otherwise the
+		// signature was created for another image
+		if payload.Critical.Image.DockerManifestDigest != digest.DigestStr() {
+			continue
+		}
+
+		for _, key := range keys {
+			if verifyPayload(key, data, signature) == nil {
+				return nil
+			}
+		}
+	}
+
+	return fmt.Errorf("%w: image %s is not signed by a trusted key", ErrSignatureVerificationFailed, digest.String())
+}
+
+// LoadPublicKeys reads the PEM encoded public keys of all files in the
+// directory, for example the mount directory of a Secret
+func LoadPublicKeys(directory string) ([]crypto.PublicKey, error) {
+	entries, err := os.ReadDir(directory)
+	if err != nil {
+		return nil, err
+	}
+
+	var keys []crypto.PublicKey
+	for _, entry := range entries {
+		// skip the hidden files and directories of a Secret mount
+		if strings.HasPrefix(entry.Name(), ".") || entry.IsDir() {
+			continue
+		}
+
+		data, err := os.ReadFile(filepath.Join(directory, entry.Name()))
+		if err != nil {
+			return nil, err
+		}
+
+		for block, rest := pem.Decode(data); block != nil; block, rest = pem.Decode(rest) {
+			key, err := x509.ParsePKIXPublicKey(block.Bytes)
+			if err != nil {
+				return nil, fmt.Errorf("failed to parse public key in %s: %w", entry.Name(), err)
+			}
+
+			keys = append(keys, key)
+		}
+	}
+
+	return keys, nil
+}
+
+// LoadPrivateKey reads the PEM encoded private key from the file, see
+// ParsePrivateKey for the supported keys.
+func LoadPrivateKey(path string, password []byte) (crypto.Signer, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	signer, err := ParsePrivateKey(data, password)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load the private key from %s: %w", path, err)
+	}
+
+	return signer, nil
+}
+
+// ParsePrivateKey parses the PEM encoded private key. The key is either an
+// unencrypted PKCS #8, PKCS #1, or SEC 1 key, or a key that was encrypted by
+// cosign, which is decrypted using the password.
+func ParsePrivateKey(data []byte, password []byte) (crypto.Signer, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.New("no PEM encoded private key found")
+	}
+
+	var err error
+	var key interface{}
+	switch block.Type {
+	case "ENCRYPTED SIGSTORE PRIVATE KEY", "ENCRYPTED COSIGN PRIVATE KEY":
+		der, err := decryptCosignKey(block.Bytes, password)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decrypt the private key: %w", err)
+		}
+
+		key, err = x509.ParsePKCS8PrivateKey(der)
+		if err != nil {
+			return nil, err
+		}
+
+	case "PRIVATE KEY":
+		if key, err = x509.ParsePKCS8PrivateKey(block.Bytes); err != nil {
+			return nil, err
+		}
+
+	case "RSA PRIVATE KEY":
+		if key, err = x509.ParsePKCS1PrivateKey(block.Bytes); err != nil {
+			return nil, err
+		}
+
+	case "EC PRIVATE KEY":
+		if key, err = x509.ParseECPrivateKey(block.Bytes); err != nil {
+			return nil, err
+		}
+
+	default:
+		return nil, fmt.Errorf("unsupported PEM block type %q", block.Type)
+	}
+
+	signer, ok := key.(crypto.Signer)
+	if !ok {
+		return nil, fmt.Errorf("unsupported private key type %T", key)
+	}
+
+	return signer, nil
+}
+
+// encryptedCosignKey is the format of a private key that was encrypted by
+// cosign, the key is derived from the password using scrypt and decrypts the
+// ciphertext using NaCl secretbox
+type encryptedCosignKey struct {
+	KDF struct {
+		Name   string `json:"name"`
+		Params struct {
+			N int `json:"N"`
+			R int `json:"r"`
+			P int `json:"p"`
+		} `json:"params"`
+		Salt []byte `json:"salt"`
+	} `json:"kdf"`
+	Cipher struct {
+		Name  string `json:"name"`
+		Nonce []byte `json:"nonce"`
+	} `json:"cipher"`
+	Ciphertext []byte `json:"ciphertext"`
+}
+
+func decryptCosignKey(data []byte, password []byte) ([]byte, error) {
+	var encrypted encryptedCosignKey
+	if err := json.Unmarshal(data, &encrypted); err != nil {
+		return nil, err
+	}
+
+	if encrypted.KDF.Name != "scrypt" || encrypted.Cipher.Name != "nacl/secretbox" {
+		return nil, fmt.Errorf("unsupported key derivation %q or cipher %q", encrypted.KDF.Name, encrypted.Cipher.Name)
+	}
+
+	var nonce [24]byte
+	if len(encrypted.Cipher.Nonce) != len(nonce) {
+		return nil, fmt.Errorf("invalid nonce length %d", len(encrypted.Cipher.Nonce))
+	}
+	copy(nonce[:], encrypted.Cipher.Nonce)
+
+	derived, err := scrypt.Key(password, encrypted.KDF.Salt, encrypted.KDF.Params.N, encrypted.KDF.Params.R, encrypted.KDF.Params.P, 32)
+	if err != nil {
+		return nil, err
+	}
+
+	var secretKey [32]byte
+	copy(secretKey[:], derived)
+
+	plaintext, ok := secretbox.Open(nil, encrypted.Ciphertext, &nonce, &secretKey)
+	if !ok {
+		return nil, errors.New("invalid password")
+	}
+
+	return plaintext, nil
+}
+
+// appendCosignLayer appends the layer to the image of the tag, which holds the
+// signatures or the attestations of an image, so that the existing ones are
+// kept like cosign does, and pushes it. It returns the digest of the image.
+func appendCosignLayer(tag name.Tag, addendum mutate.Addendum, options ...remote.Option) (containerreg.Hash, error) {
+	base, err := remote.Image(tag, options...)
+	if err != nil {
+		var transportErr *transport.Error
+		if !errors.As(err, &transportErr) || transportErr.StatusCode != http.StatusNotFound {
+			return containerreg.Hash{}, err
+		}
+
+		base = mutate.ConfigMediaType(mutate.MediaType(empty.Image, types.OCIManifestSchema1), types.OCIConfigJSON)
+	}
+
+	cosignImage, err := mutate.Append(base, addendum)
+	if err != nil {
+		return containerreg.Hash{}, err
+	}
+
+	if err := remote.Write(tag, cosignImage, options...); err != nil {
+		return containerreg.Hash{}, err
+	}
+
+	return cosignImage.Digest()
+}
+
+// cosignTag returns the tag that holds the signature or the attestations of
+// the image digest, depending on the suffix
+func cosignTag(digest name.Digest, suffix string) (name.Tag, error) {
+	return name.NewTag(fmt.Sprintf("%s:%s.%s",
+		digest.Context().Name(),
+		strings.Replace(digest.DigestStr(), ":", "-", 1),
+		suffix,
+	))
+}
+
+func signPayload(key crypto.Signer, data []byte) ([]byte, error) {
+	switch key.Public().(type) {
+	case ed25519.PublicKey:
+		return key.Sign(rand.Reader, data, crypto.Hash(0))
+
+	case *ecdsa.PublicKey, *rsa.PublicKey:
+		hash := sha256.Sum256(data)
+		return key.Sign(rand.Reader, hash[:], crypto.SHA256)
+
+	default:
+		return nil, fmt.Errorf("unsupported private key type %T", key)
+	}
+}
+
+func verifyPayload(key crypto.PublicKey, data []byte, signature []byte) error {
+	hash := sha256.Sum256(data)
+
+	switch key := key.(type) {
+	case ed25519.PublicKey:
+		if !ed25519.Verify(key, data, signature) {
+			return ErrSignatureVerificationFailed
+		}
+		return nil
+
+	case *ecdsa.PublicKey:
+		if !ecdsa.VerifyASN1(key, hash[:], signature) {
+			return ErrSignatureVerificationFailed
+		}
+		return nil
+
+	case *rsa.PublicKey:
+		return rsa.VerifyPKCS1v15(key, crypto.SHA256, hash[:], signature)
+
+	default:
+		return fmt.Errorf("unsupported public key type %T", key)
+	}
+}
+
+func readPayload(layer containerreg.Layer) ([]byte, error) {
+	rc, err := layer.Compressed()
+	if err != nil {
+		return nil, err
+	}
+	defer rc.Close()
+
+	return io.ReadAll(rc)
+}
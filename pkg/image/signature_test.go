@@ -0,0 +1,208 @@
+// Copyright The Shipwright Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package image_test
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"io"
+	"log"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/registry"
+	"github.com/google/go-containerregistry/pkg/v1/random"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"golang.org/x/crypto/nacl/secretbox"
+	"golang.org/x/crypto/scrypt"
+
+	"github.com/shipwright-io/build/pkg/image"
+)
+
+var _ = Describe("Signature", func() {
+	var keyDir string
+
+	BeforeEach(func() {
+		var err error
+		keyDir, err = os.MkdirTemp("", "keys")
+		Expect(err).ToNot(HaveOccurred())
+		DeferCleanup(os.RemoveAll, keyDir)
+	})
+
+	newECDSAKey := func() *ecdsa.PrivateKey {
+		key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		Expect(err).ToNot(HaveOccurred())
+		return key
+	}
+
+	writeKey := func(blockType string, data []byte) string {
+		path := filepath.Join(keyDir, "cosign.key")
+		Expect(os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: blockType, Bytes: data}), 0600)).To(Succeed())
+		return path
+	}
+
+	// encryptKey encrypts the private key the same way as cosign generate-key-pair
+	encryptKey := func(key crypto.Signer, password string) []byte {
+		der, err := x509.MarshalPKCS8PrivateKey(key)
+		Expect(err).ToNot(HaveOccurred())
+
+		salt := make([]byte, 32)
+		_, err = rand.Read(salt)
+		Expect(err).ToNot(HaveOccurred())
+
+		var nonce [24]byte
+		_, err = rand.Read(nonce[:])
+		Expect(err).ToNot(HaveOccurred())
+
+		derived, err := scrypt.Key([]byte(password), salt, 32768, 8, 1, 32)
+		Expect(err).ToNot(HaveOccurred())
+
+		var secretKey [32]byte
+		copy(secretKey[:], derived)
+
+		data, err := json.Marshal(map[string]interface{}{
+			"kdf": map[string]interface{}{
+				"name":   "scrypt",
+				"params": map[string]int{"N": 32768, "r": 8, "p": 1},
+				"salt":   salt,
+			},
+			"cipher": map[string]interface{}{
+				"name":  "nacl/secretbox",
+				"nonce": nonce[:],
+			},
+			"ciphertext": secretbox.Seal(nil, der, &nonce, &secretKey),
+		})
+		Expect(err).ToNot(HaveOccurred())
+
+		return data
+	}
+
+	Context("loading a private key", func() {
+		It("should load an unencrypted PKCS #8 key", func() {
+			key := newECDSAKey()
+			der, err := x509.MarshalPKCS8PrivateKey(key)
+			Expect(err).ToNot(HaveOccurred())
+
+			signer, err := image.LoadPrivateKey(writeKey("PRIVATE KEY", der), nil)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(signer.Public()).To(Equal(key.Public()))
+		})
+
+		It("should load an unencrypted PKCS #1 RSA key", func() {
+			key, err := rsa.GenerateKey(rand.Reader, 2048)
+			Expect(err).ToNot(HaveOccurred())
+
+			signer, err := image.LoadPrivateKey(writeKey("RSA PRIVATE KEY", x509.MarshalPKCS1PrivateKey(key)), nil)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(signer.Public()).To(Equal(key.Public()))
+		})
+
+		It("should decrypt a key that was encrypted by cosign", func() {
+			key := newECDSAKey()
+
+			signer, err := image.LoadPrivateKey(writeKey("ENCRYPTED SIGSTORE PRIVATE KEY", encryptKey(key, "secret")), []byte("secret"))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(signer.Public()).To(Equal(key.Public()))
+		})
+
+		It("should fail to decrypt a key that was encrypted by cosign using a wrong password", func() {
+			_, err := image.LoadPrivateKey(writeKey("ENCRYPTED COSIGN PRIVATE KEY", encryptKey(newECDSAKey(), "secret")), []byte("wrong"))
+			Expect(err).To(MatchError(ContainSubstring("invalid password")))
+		})
+
+		It("should fail for a file without a private key", func() {
+			path := filepath.Join(keyDir, "cosign.key")
+			Expect(os.WriteFile(path, []byte("not a key"), 0600)).To(Succeed())
+
+			_, err := image.LoadPrivateKey(path, nil)
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("should parse a PEM encoded SEC 1 key", func() {
+			key := newECDSAKey()
+			der, err := x509.MarshalECPrivateKey(key)
+			Expect(err).ToNot(HaveOccurred())
+
+			signer, err := image.ParsePrivateKey(pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: der}), nil)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(signer.Public()).To(Equal(key.Public()))
+		})
+	})
+
+	Context("signing an image", func() {
+		var registryHost string
+
+		BeforeEach(func() {
+			server := httptest.NewServer(registry.New(registry.Logger(log.New(io.Discard, "", 0))))
+			DeferCleanup(server.Close)
+			registryHost = strings.TrimPrefix(server.URL, "http://")
+		})
+
+		It("should push a signature that can be verified and return its digest", func() {
+			img, err := random.Image(1024, 1)
+			Expect(err).ToNot(HaveOccurred())
+
+			tag, err := name.NewTag(fmt.Sprintf("%s/test-namespace/test-image:latest", registryHost))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(remote.Write(tag, img)).To(Succeed())
+
+			hash, err := img.Digest()
+			Expect(err).ToNot(HaveOccurred())
+			digest := tag.Context().Digest(hash.String())
+
+			key := newECDSAKey()
+			signatureDigest, err := image.Sign(digest, key)
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(image.VerifySignature(digest, []crypto.PublicKey{key.Public()})).To(Succeed())
+
+			desc, err := remote.Head(tag.Context().Tag(strings.Replace(hash.String(), ":", "-", 1) + ".sig"))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(desc.Digest).To(Equal(signatureDigest))
+		})
+
+		It("should keep the existing signatures of the image", func() {
+			img, err := random.Image(1024, 1)
+			Expect(err).ToNot(HaveOccurred())
+
+			tag, err := name.NewTag(fmt.Sprintf("%s/test-namespace/test-image:latest", registryHost))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(remote.Write(tag, img)).To(Succeed())
+
+			hash, err := img.Digest()
+			Expect(err).ToNot(HaveOccurred())
+			digest := tag.Context().Digest(hash.String())
+
+			firstKey, secondKey := newECDSAKey(), newECDSAKey()
+			_, err = image.Sign(digest, firstKey)
+			Expect(err).ToNot(HaveOccurred())
+			_, err = image.Sign(digest, secondKey)
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(image.VerifySignature(digest, []crypto.PublicKey{firstKey.Public()})).To(Succeed())
+			Expect(image.VerifySignature(digest, []crypto.PublicKey{secondKey.Public()})).To(Succeed())
+
+			signatureImage, err := remote.Image(tag.Context().Tag(strings.Replace(hash.String(), ":", "-", 1) + ".sig"))
+			Expect(err).ToNot(HaveOccurred())
+
+			layers, err := signatureImage.Layers()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(layers).To(HaveLen(2))
+		})
+	})
+})
@@ -0,0 +1,96 @@
+// Copyright The Shipwright Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package image
+
+import (
+	"fmt"
+	"regexp"
+	"time"
+
+	"github.com/google/go-containerregistry/pkg/name"
+	containerreg "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+)
+
+// TagTimestampFormat is the format of the timestamp placeholder of a tag
+const TagTimestampFormat = "20060102150405"
+
+var tagPlaceholderRegexp = regexp.MustCompile(`\$\(([a-z-]+)\)`)
+
+// TagValues holds the values of the placeholders of a tag template
+type TagValues struct {
+	// BuildRunName is the value of $(buildrun-name)
+	BuildRunName string
+
+	// CommitSHA is the value of $(commit-sha), and its first seven
+	// characters are the value of $(commit-sha-short)
+	CommitSHA string
+
+	// Timestamp is the value of $(timestamp) in the TagTimestampFormat
+	Timestamp time.Time
+}
+
+// RenderTag replaces the placeholders of the tag template with their values,
+// and validates that the result is a valid tag
+func RenderTag(template string, values TagValues) (string, error) {
+	var renderErr error
+	tag := tagPlaceholderRegexp.ReplaceAllStringFunc(template, func(placeholder string) string {
+		var value string
+		switch key := tagPlaceholderRegexp.FindStringSubmatch(placeholder)[1]; key {
+		case "buildrun-name":
+			value = values.BuildRunName
+
+		case "commit-sha":
+			value = values.CommitSHA
+
+		case "commit-sha-short":
+			value = values.CommitSHA
+			if len(value) > 7 {
+				value = value[:7]
+			}
+
+		case "timestamp":
+			value = values.Timestamp.UTC().Format(TagTimestampFormat)
+
+		default:
+			renderErr = fmt.Errorf("the tag %q contains the unknown placeholder %s", template, placeholder)
+		}
+
+		if value == "" && renderErr == nil {
+			renderErr = fmt.Errorf("the value of the placeholder %s of the tag %q is not known", placeholder, template)
+		}
+
+		return value
+	})
+
+	if renderErr != nil {
+		return "", renderErr
+	}
+
+	// the repository is only needed to validate the tag
+	if _, err := name.NewTag("registry.invalid/validation:"+tag, name.StrictValidation); err != nil {
+		return "", fmt.Errorf("the tag %q rendered from %q is invalid: %w", tag, template, err)
+	}
+
+	return tag, nil
+}
+
+// TagImageOrImageIndex points the tags in the repository of the image name at
+// the pushed image or image index. Only the manifest is uploaded again, the
+// tags therefore share the digest of the image name.
+func TagImageOrImageIndex(imageName name.Reference, image containerreg.Image, imageIndex containerreg.ImageIndex, tags []string, options []remote.Option) error {
+	var taggable remote.Taggable = image
+	if imageIndex != nil {
+		taggable = imageIndex
+	}
+
+	for _, tag := range tags {
+		if err := remote.Tag(imageName.Context().Tag(tag), taggable, options...); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
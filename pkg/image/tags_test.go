@@ -0,0 +1,104 @@
+// Copyright The Shipwright Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package image_test
+
+import (
+	"fmt"
+	"io"
+	"log"
+	"net/http/httptest"
+	"strings"
+	"time"
+
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/registry"
+	"github.com/google/go-containerregistry/pkg/v1/random"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/shipwright-io/build/pkg/image"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("RenderTag", func() {
+
+	values := image.TagValues{
+		BuildRunName: "sample-go-run-x7k2p",
+		CommitSHA:    "0e0583421a5e4bf562ffe33f3651e16ba0c78591",
+		Timestamp:    time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+	}
+
+	DescribeTable("rendering a tag",
+		func(template string, expected string) {
+			Expect(image.RenderTag(template, values)).To(Equal(expected))
+		},
+		Entry("without placeholders", "v1.2.3", "v1.2.3"),
+		Entry("with the BuildRun name", "$(buildrun-name)", "sample-go-run-x7k2p"),
+		Entry("with the commit SHA", "sha-$(commit-sha)", "sha-0e0583421a5e4bf562ffe33f3651e16ba0c78591"),
+		Entry("with the short commit SHA and the timestamp", "$(commit-sha-short)-$(timestamp)", "0e05834-20240102030405"),
+	)
+
+	It("fails for an unknown placeholder", func() {
+		_, err := image.RenderTag("$(branch)", values)
+		Expect(err).To(MatchError(ContainSubstring("unknown placeholder $(branch)")))
+	})
+
+	It("fails for a placeholder without a value", func() {
+		_, err := image.RenderTag("$(commit-sha)", image.TagValues{})
+		Expect(err).To(MatchError(ContainSubstring("is not known")))
+	})
+
+	It("fails for an invalid tag", func() {
+		_, err := image.RenderTag("feature/$(commit-sha-short)", values)
+		Expect(err).To(MatchError(ContainSubstring("is invalid")))
+	})
+})
+
+var _ = Describe("TagImageOrImageIndex", func() {
+
+	var registryHost string
+
+	BeforeEach(func() {
+		server := httptest.NewServer(registry.New(registry.Logger(log.New(io.Discard, "", 0))))
+		DeferCleanup(server.Close)
+		registryHost = strings.TrimPrefix(server.URL, "http://")
+	})
+
+	It("points all tags at the digest of the pushed image", func() {
+		img, err := random.Image(3245, 1)
+		Expect(err).ToNot(HaveOccurred())
+
+		imageName, err := name.ParseReference(fmt.Sprintf("%s/test-namespace/test-image:latest", registryHost))
+		Expect(err).ToNot(HaveOccurred())
+
+		digest, _, err := image.PushImageOrImageIndex(imageName, img, nil, []remote.Option{})
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(image.TagImageOrImageIndex(imageName, img, nil, []string{"v1", "v1.2"}, []remote.Option{})).To(Succeed())
+
+		for _, tag := range []string{"v1", "v1.2"} {
+			desc, err := remote.Head(imageName.Context().Tag(tag))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(desc.Digest.String()).To(Equal(digest))
+		}
+	})
+
+	It("points all tags at the digest of the pushed image index", func() {
+		index, err := random.Index(1234, 1, 2)
+		Expect(err).ToNot(HaveOccurred())
+
+		imageName, err := name.ParseReference(fmt.Sprintf("%s/test-namespace/test-index:latest", registryHost))
+		Expect(err).ToNot(HaveOccurred())
+
+		digest, _, err := image.PushImageOrImageIndex(imageName, nil, index, []remote.Option{})
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(image.TagImageOrImageIndex(imageName, nil, index, []string{"stable"}, []remote.Option{})).To(Succeed())
+
+		desc, err := remote.Head(imageName.Context().Tag("stable"))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(desc.Digest.String()).To(Equal(digest))
+	})
+})
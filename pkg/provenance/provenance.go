@@ -0,0 +1,137 @@
+// Copyright The Shipwright Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package provenance
+
+import (
+	"time"
+
+	"github.com/google/go-containerregistry/pkg/name"
+	containerreg "github.com/google/go-containerregistry/pkg/v1"
+)
+
+const (
+	// StatementType is the type of an in-toto statement
+	StatementType = "https://in-toto.io/Statement/v1"
+
+	// PredicateType is the predicate type of an SLSA provenance
+	PredicateType = "https://slsa.dev/provenance/v1"
+
+	// BuildType identifies the template of the build definition of a BuildRun
+	BuildType = "https://shipwright.io/build/BuildRun@v1"
+
+	// BuilderID identifies the Shipwright Build controller as the builder
+	BuilderID = "https://shipwright.io/build"
+
+	// GitCommitDigest is the digest algorithm of a Git commit SHA
+	GitCommitDigest = "gitCommit"
+)
+
+// Statement is an in-toto statement with an SLSA provenance predicate, see
+// https://slsa.dev/spec/v1.0/provenance
+type Statement struct {
+	Type          string               `json:"_type"`
+	Subject       []ResourceDescriptor `json:"subject"`
+	PredicateType string               `json:"predicateType"`
+	Predicate     Predicate            `json:"predicate"`
+}
+
+// ResourceDescriptor describes an artifact, like the output image or a source
+type ResourceDescriptor struct {
+	Name   string            `json:"name,omitempty"`
+	URI    string            `json:"uri,omitempty"`
+	Digest map[string]string `json:"digest,omitempty"`
+}
+
+// Predicate describes how the subject was built
+type Predicate struct {
+	BuildDefinition BuildDefinition `json:"buildDefinition"`
+	RunDetails      RunDetails      `json:"runDetails"`
+}
+
+// BuildDefinition describes the inputs of the build
+type BuildDefinition struct {
+	BuildType            string               `json:"buildType"`
+	ExternalParameters   ExternalParameters   `json:"externalParameters"`
+	InternalParameters   InternalParameters   `json:"internalParameters"`
+	ResolvedDependencies []ResourceDescriptor `json:"resolvedDependencies,omitempty"`
+}
+
+// ExternalParameters are the parameters of the build that are controlled by
+// the author of the Build and the BuildRun
+type ExternalParameters struct {
+	Namespace   string            `json:"namespace"`
+	Build       string            `json:"build,omitempty"`
+	BuildRun    string            `json:"buildRun"`
+	Sources     []Source          `json:"sources,omitempty"`
+	Strategy    Strategy          `json:"strategy"`
+	ParamValues map[string]string `json:"paramValues,omitempty"`
+	Output      string            `json:"output"`
+}
+
+// Source is a source of the Build
+type Source struct {
+	Name       string `json:"name"`
+	Type       string `json:"type"`
+	URL        string `json:"url,omitempty"`
+	Revision   string `json:"revision,omitempty"`
+	ContextDir string `json:"contextDir,omitempty"`
+}
+
+// Strategy references the build strategy of the Build
+type Strategy struct {
+	Kind string `json:"kind"`
+	Name string `json:"name"`
+}
+
+// InternalParameters are the parameters of the build that are controlled by
+// the cluster
+type InternalParameters struct {
+	StrategyGeneration int64 `json:"strategyGeneration"`
+	BuildGeneration    int64 `json:"buildGeneration,omitempty"`
+}
+
+// RunDetails describes the execution of the build
+type RunDetails struct {
+	Builder  Builder  `json:"builder"`
+	Metadata Metadata `json:"metadata"`
+}
+
+// Builder identifies the builder
+type Builder struct {
+	ID string `json:"id"`
+}
+
+// Metadata holds the invocation and the timing of the build
+type Metadata struct {
+	InvocationID string     `json:"invocationID"`
+	StartedOn    *time.Time `json:"startedOn,omitempty"`
+	FinishedOn   *time.Time `json:"finishedOn,omitempty"`
+}
+
+// Complete sets the facts that are only known once the image was pushed: the
+// image digest as the subject, the commit SHAs of the Git sources by the name
+// of the source, and the finish time of the build.
+func (s *Statement) Complete(digest name.Digest, commits map[string]string, finishedOn time.Time) error {
+	hash, err := containerreg.NewHash(digest.DigestStr())
+	if err != nil {
+		return err
+	}
+
+	s.Subject = []ResourceDescriptor{{
+		Name:   digest.Context().Name(),
+		Digest: map[string]string{hash.Algorithm: hash.Hex},
+	}}
+
+	for i, dependency := range s.Predicate.BuildDefinition.ResolvedDependencies {
+		if commit := commits[dependency.Name]; commit != "" {
+			s.Predicate.BuildDefinition.ResolvedDependencies[i].Digest = map[string]string{GitCommitDigest: commit}
+		}
+	}
+
+	finishedOn = finishedOn.UTC()
+	s.Predicate.RunDetails.Metadata.FinishedOn = &finishedOn
+
+	return nil
+}
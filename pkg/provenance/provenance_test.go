@@ -0,0 +1,60 @@
+// Copyright The Shipwright Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package provenance_test
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/google/go-containerregistry/pkg/name"
+
+	"github.com/shipwright-io/build/pkg/provenance"
+)
+
+var _ = Describe("Statement", func() {
+	Context("completing a statement", func() {
+		var statement provenance.Statement
+
+		BeforeEach(func() {
+			statement = provenance.Statement{
+				Type:          provenance.StatementType,
+				PredicateType: provenance.PredicateType,
+				Predicate: provenance.Predicate{
+					BuildDefinition: provenance.BuildDefinition{
+						ResolvedDependencies: []provenance.ResourceDescriptor{
+							{Name: "default", URI: "git+https://github.com/shipwright-io/sample-go@main"},
+							{Name: "assets", URI: "https://example.com/assets.tar.gz", Digest: map[string]string{"sha256": "0123"}},
+						},
+					},
+				},
+			}
+		})
+
+		It("sets the image digest as the subject", func() {
+			digest, err := name.NewDigest("registry.example.com/namespace/app@sha256:8f5d4d6c6bc5b4a1a6b2f3bf9f0a5e2c7d3c1b7a9e8d6f5c4b3a2918e7d6c5b4")
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(statement.Complete(digest, nil, time.Now())).To(Succeed())
+			Expect(statement.Subject).To(Equal([]provenance.ResourceDescriptor{{
+				Name:   "registry.example.com/namespace/app",
+				Digest: map[string]string{"sha256": "8f5d4d6c6bc5b4a1a6b2f3bf9f0a5e2c7d3c1b7a9e8d6f5c4b3a2918e7d6c5b4"},
+			}}))
+		})
+
+		It("sets the commit SHAs of the sources and the finish time", func() {
+			digest, err := name.NewDigest("registry.example.com/namespace/app@sha256:8f5d4d6c6bc5b4a1a6b2f3bf9f0a5e2c7d3c1b7a9e8d6f5c4b3a2918e7d6c5b4")
+			Expect(err).ToNot(HaveOccurred())
+
+			finishedOn := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+			Expect(statement.Complete(digest, map[string]string{"default": "0e0583421a5e4bf562ffe33f3651e16ba0c78591"}, finishedOn)).To(Succeed())
+
+			Expect(statement.Predicate.BuildDefinition.ResolvedDependencies[0].Digest).To(Equal(map[string]string{"gitCommit": "0e0583421a5e4bf562ffe33f3651e16ba0c78591"}))
+			Expect(statement.Predicate.BuildDefinition.ResolvedDependencies[1].Digest).To(Equal(map[string]string{"sha256": "0123"}))
+			Expect(*statement.Predicate.RunDetails.Metadata.FinishedOn).To(Equal(finishedOn))
+		})
+	})
+})
@@ -32,6 +32,7 @@ var validationTypes = [...]string{
 	validate.BuildName,
 	validate.Envs,
 	validate.Triggers,
+	validate.GitSource,
 }
 
 // ReconcileBuild reconciles a Build object
@@ -215,6 +215,22 @@ var _ = Describe("Reconcile Build", func() {
 			})
 		})
 
+		Context("when spec output signing secret is specified", func() {
+			It("fails when the secret does not exist", func() {
+				buildSample.Spec.Output.Credentials = nil
+				buildSample.Spec.Output.Signing = &build.ImageSigning{
+					SecretRef: corev1.LocalObjectReference{Name: "non-existing-signing"},
+				}
+
+				statusCall := ctl.StubFunc(corev1.ConditionFalse, build.SpecOutputSecretRefNotFound, "referenced secret non-existing-signing not found")
+				statusWriter.UpdateCalls(statusCall)
+
+				_, err := reconciler.Reconcile(context.TODO(), request)
+				Expect(err).To(BeNil())
+				Expect(statusWriter.UpdateCallCount()).To(Equal(1))
+			})
+		})
+
 		Context("when source secret and output secret are specified", func() {
 			It("fails when both secrets do not exist", func() {
 				buildSample.Spec.Source.Credentials = &corev1.LocalObjectReference{
@@ -247,6 +247,11 @@ func (r *ReconcileBuildRun) Reconcile(ctx context.Context, request reconcile.Req
 				ctxlog.Info(ctx, fmt.Sprintf("successfully updated BuildRun %s", buildRun.Name), namespace, request.Namespace, name, request.Name)
 			}
 
+			// Apply the revision override of the BuildRun, if any
+			if buildRun.Spec.Revision != nil && build.Spec.Source.URL != nil {
+				build.Spec.Source.Revision = buildRun.Spec.Revision
+			}
+
 			// Set the Build spec in the BuildRun status
 			buildRun.Status.BuildSpec = &build.Spec
 			ctxlog.Info(ctx, "updating BuildRun status", namespace, request.Namespace, name, request.Name)
@@ -466,6 +471,24 @@ func (r *ReconcileBuildRun) Reconcile(ctx context.Context, request reconcile.Req
 				}
 			}
 
+			// the controller signs the provenance from the recorded status of the BuildRun, because
+			// the steps of the strategy can modify anything in the build pod
+			if taskRunStatus == corev1.ConditionTrue && resources.ProvenanceRequested(buildRun) {
+				provenanceDigest, err := resources.AttachProvenance(ctx, r.client, buildRun, lastTaskRun)
+				if err != nil {
+					ctxlog.Error(ctx, err, "failed to attach the provenance to the output image", namespace, request.Namespace, name, request.Name)
+					buildRun.Status.SetCondition(&buildv1alpha1.Condition{
+						LastTransitionTime: metav1.Now(),
+						Type:               buildv1alpha1.Succeeded,
+						Status:             corev1.ConditionFalse,
+						Reason:             resources.ConditionProvenanceAttestationFailed,
+						Message:            fmt.Sprintf("failed to attach the provenance to the output image: %v", err),
+					})
+				} else {
+					buildRun.Status.Output.ProvenanceDigest = provenanceDigest
+				}
+			}
+
 			ctxlog.Info(ctx, "updating buildRun status", namespace, request.Namespace, name, request.Name)
 			if err := r.client.Status().Update(ctx, buildRun); err != nil {
 				return reconcile.Result{}, err
@@ -342,6 +342,34 @@ var _ = Describe("Reconcile BuildRun", func() {
 				Expect(client.StatusCallCount()).To(Equal(1))
 			})
 
+			It("updates the BuildRun status with a FALSE status when the provenance cannot be attached", func() {
+
+				taskRunSample = ctl.DefaultTaskRunWithStatus(taskRunName, buildRunName, ns, corev1.ConditionTrue, "Succeeded")
+
+				// the TaskRun has no result with the digest of the output image
+				buildSample.Spec.Output.Signing = &build.ImageSigning{
+					SecretRef:  corev1.LocalObjectReference{Name: "signing-key"},
+					Provenance: true,
+				}
+				buildRunSample.Status.BuildSpec = &buildSample.Spec
+
+				statusWriter.UpdateCalls(func(_ context.Context, object crc.Object, _ ...crc.SubResourceUpdateOption) error {
+					buildRun, ok := object.(*build.BuildRun)
+					Expect(ok).To(BeTrue())
+
+					condition := buildRun.Status.GetCondition(build.Succeeded)
+					Expect(condition.Status).To(Equal(corev1.ConditionFalse))
+					Expect(condition.Reason).To(Equal(resources.ConditionProvenanceAttestationFailed))
+					Expect(condition.Message).To(ContainSubstring("digest of the output image"))
+					return nil
+				})
+
+				result, err := reconciler.Reconcile(context.TODO(), taskRunRequest)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(reconcile.Result{}).To(Equal(result))
+				Expect(client.StatusCallCount()).To(Equal(1))
+			})
+
 			It("should recognize the BuildRun is canceled", func() {
 				// set cancel
 				buildRunSampleCopy := buildRunSample.DeepCopy()
@@ -1410,6 +1438,23 @@ var _ = Describe("Reconcile BuildRun", func() {
 						Expect(condition.Message).To(Equal("cannot use 'timeout' override and 'buildSpec' simultaneously"))
 					})
 				})
+
+				It("should mark BuildRun as invalid if Revision and BuildSpec are used", func() {
+					buildRunSample = &build.BuildRun{
+						ObjectMeta: metav1.ObjectMeta{
+							Name: buildRunName,
+						},
+						Spec: build.BuildRunSpec{
+							Revision:  pointer.String("main"),
+							BuildSpec: &build.BuildSpec{},
+						},
+					}
+
+					simpleReconcileRunWithCustomUpdateCall(func(condition *build.Condition) {
+						Expect(condition.Reason).To(Equal(resources.BuildRunBuildFieldOverrideForbidden))
+						Expect(condition.Message).To(Equal("cannot use 'revision' override and 'buildSpec' simultaneously"))
+					})
+				})
 			})
 
 			Context("valid BuildRun resource", func() {
@@ -44,6 +44,7 @@ const (
 	BuildRunNoRefOrSpec                              string = "BuildRunNoRefOrSpec"
 	BuildRunAmbiguousBuild                           string = "BuildRunAmbiguousBuild"
 	BuildRunBuildFieldOverrideForbidden              string = "BuildRunBuildFieldOverrideForbidden"
+	ConditionProvenanceAttestationFailed             string = "ProvenanceAttestationFailed"
 )
 
 // UpdateBuildRunUsingTaskRunCondition updates the BuildRun Succeeded Condition
@@ -18,6 +18,8 @@ const (
 	containerNameImageProcessing = "image-processing"
 	outputDirectoryMountPath     = "/workspace/output-image"
 	paramOutputDirectory         = "output-directory"
+	signingSecretMountPath       = "/workspace/shp-signing-secret"
+	mirrorSecretMountPath        = "/workspace/shp-mirror-secret"
 )
 
 // SetupImageProcessing appends the image-processing step to a TaskRun if desired
@@ -82,6 +84,42 @@ func SetupImageProcessing(taskRun *pipelineapi.TaskRun, cfg *config.Config, buil
 		stepArgs = append(stepArgs, convertMutateArgs("--label", labels)...)
 	}
 
+	// check if we need to push additional tags, the BuildRun overrides the Build
+	additionalTags := buildOutput.AdditionalTags
+	if len(buildRunOutput.AdditionalTags) > 0 {
+		additionalTags = buildRunOutput.AdditionalTags
+	}
+	for _, tag := range additionalTags {
+		stepArgs = append(stepArgs, "--additional-tag", tag)
+	}
+
+	// check if we need to sign the image, the BuildRun overrides the Build
+	signing := buildOutput.Signing
+	if buildRunOutput.Signing != nil {
+		signing = buildRunOutput.Signing
+	}
+	if signing != nil {
+		stepArgs = append(stepArgs, "--signing-secret-path", signingSecretMountPath)
+	}
+
+	// check if we need to attach an SBOM, the BuildRun overrides the Build
+	sbom := buildOutput.SBOM
+	if buildRunOutput.SBOM != nil {
+		sbom = buildRunOutput.SBOM
+	}
+	if sbom != nil {
+		stepArgs = append(stepArgs, "--sbom-format", string(sbom.Format))
+	}
+
+	// check if we need to copy the image to mirrors, the BuildRun overrides the Build
+	mirrors := buildOutput.Mirrors
+	if len(buildRunOutput.Mirrors) > 0 {
+		mirrors = buildRunOutput.Mirrors
+	}
+	for _, mirror := range mirrors {
+		stepArgs = append(stepArgs, "--mirror", mirror.Image)
+	}
+
 	// check if there is anything to do
 	if len(stepArgs) > 0 {
 		// add the image argument
@@ -135,6 +173,96 @@ func SetupImageProcessing(taskRun *pipelineapi.TaskRun, cfg *config.Config, buil
 			)
 		}
 
+		if signing != nil {
+			sources.AppendSecretVolume(taskRun.Spec.TaskSpec, signing.SecretRef.Name)
+
+			imageProcessingStep.VolumeMounts = append(imageProcessingStep.VolumeMounts, core.VolumeMount{
+				Name:      sources.SanitizeVolumeNameForSecretName(signing.SecretRef.Name),
+				MountPath: signingSecretMountPath,
+				ReadOnly:  true,
+			})
+
+			// add the result for the signature digest
+			taskRun.Spec.TaskSpec.Results = append(taskRun.Spec.TaskSpec.Results, pipelineapi.TaskResult{
+				Name:        fmt.Sprintf("%s-%s", prefixParamsResultsVolumes, imageSignatureDigestResult),
+				Description: "The digest of the signature of the image",
+			})
+
+			imageProcessingStep.Args = append(imageProcessingStep.Args,
+				"--result-file-signature-digest", fmt.Sprintf("$(results.%s-%s.path)", prefixParamsResultsVolumes, imageSignatureDigestResult),
+			)
+		}
+
+		if len(additionalTags) > 0 {
+			// provide the values of the placeholders of the tags
+			if buildRunName := taskRun.Labels[build.LabelBuildRun]; buildRunName != "" {
+				imageProcessingStep.Args = append(imageProcessingStep.Args, "--buildrun-name", buildRunName)
+			}
+
+			commitSHAResult := fmt.Sprintf("%s-source-%s-commit-sha", prefixParamsResultsVolumes, defaultSourceName)
+			for _, result := range taskRun.Spec.TaskSpec.Results {
+				if result.Name == commitSHAResult {
+					imageProcessingStep.Args = append(imageProcessingStep.Args, "--commit-sha-file", fmt.Sprintf("$(results.%s.path)", commitSHAResult))
+				}
+			}
+
+			// add the result for the pushed tags
+			taskRun.Spec.TaskSpec.Results = append(taskRun.Spec.TaskSpec.Results, pipelineapi.TaskResult{
+				Name:        fmt.Sprintf("%s-%s", prefixParamsResultsVolumes, imageTagsResult),
+				Description: "The tags that were pushed for the image, one per line",
+			})
+
+			imageProcessingStep.Args = append(imageProcessingStep.Args,
+				"--result-file-image-tags", fmt.Sprintf("$(results.%s-%s.path)", prefixParamsResultsVolumes, imageTagsResult),
+			)
+		}
+
+		if sbom != nil {
+			// add the result for the SBOM digest
+			taskRun.Spec.TaskSpec.Results = append(taskRun.Spec.TaskSpec.Results, pipelineapi.TaskResult{
+				Name:        fmt.Sprintf("%s-%s", prefixParamsResultsVolumes, imageSBOMDigestResult),
+				Description: "The digest of the software bill of materials of the image",
+			})
+
+			imageProcessingStep.Args = append(imageProcessingStep.Args,
+				"--result-file-sbom-digest", fmt.Sprintf("$(results.%s-%s.path)", prefixParamsResultsVolumes, imageSBOMDigestResult),
+			)
+		}
+
+		if len(mirrors) > 0 {
+			for i, mirror := range mirrors {
+				if mirror.Insecure != nil && *mirror.Insecure {
+					imageProcessingStep.Args = append(imageProcessingStep.Args, "--mirror-insecure", mirror.Image)
+				}
+
+				if mirror.Credentials != nil {
+					sources.AppendSecretVolume(taskRun.Spec.TaskSpec, mirror.Credentials.Name)
+
+					secretMountPath := fmt.Sprintf("%s-%d", mirrorSecretMountPath, i)
+
+					imageProcessingStep.VolumeMounts = append(imageProcessingStep.VolumeMounts, core.VolumeMount{
+						Name:      sources.SanitizeVolumeNameForSecretName(mirror.Credentials.Name),
+						MountPath: secretMountPath,
+						ReadOnly:  true,
+					})
+
+					imageProcessingStep.Args = append(imageProcessingStep.Args,
+						"--mirror-secret-path", fmt.Sprintf("%s=%s", mirror.Image, secretMountPath),
+					)
+				}
+			}
+
+			// add the result for the copies of the image
+			taskRun.Spec.TaskSpec.Results = append(taskRun.Spec.TaskSpec.Results, pipelineapi.TaskResult{
+				Name:        fmt.Sprintf("%s-%s", prefixParamsResultsVolumes, imageMirrorsResult),
+				Description: "The digests of the copies of the image and the errors that prevented a copy, in JSON",
+			})
+
+			imageProcessingStep.Args = append(imageProcessingStep.Args,
+				"--result-file-image-mirrors", fmt.Sprintf("$(results.%s-%s.path)", prefixParamsResultsVolumes, imageMirrorsResult),
+			)
+		}
+
 		// append the mutate step
 		taskRun.Spec.TaskSpec.Steps = append(taskRun.Spec.TaskSpec.Steps, imageProcessingStep)
 	}
@@ -9,6 +9,7 @@ import (
 	. "github.com/onsi/gomega"
 
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/utils/pointer"
 
 	buildv1alpha1 "github.com/shipwright-io/build/pkg/apis/build/v1alpha1"
 	"github.com/shipwright-io/build/pkg/config"
@@ -79,6 +80,179 @@ var _ = Describe("Image Processing overrides", func() {
 				Expect(processedTaskRun.Spec.TaskSpec.Steps[1].VolumeMounts).ToNot(utils.ContainNamedElement("shp-output-directory"))
 			})
 		})
+
+		Context("for a build with signing in the output", func() {
+			BeforeEach(func() {
+				processedTaskRun = taskRun.DeepCopy()
+				resources.SetupImageProcessing(processedTaskRun, config, buildv1alpha1.Image{
+					Image: "some-registry/some-namespace/some-image",
+					Signing: &buildv1alpha1.ImageSigning{
+						SecretRef: corev1.LocalObjectReference{Name: "build-signing-key"},
+					},
+				}, buildv1alpha1.Image{
+					Signing: &buildv1alpha1.ImageSigning{
+						SecretRef: corev1.LocalObjectReference{Name: "buildrun-signing-key"},
+					},
+				})
+			})
+
+			It("adds the image-processing step that signs the image using the key of the BuildRun", func() {
+				Expect(processedTaskRun.Spec.TaskSpec.Steps).To(HaveLen(2))
+				Expect(processedTaskRun.Spec.TaskSpec.Steps[1].Name).To(Equal("image-processing"))
+				Expect(processedTaskRun.Spec.TaskSpec.Steps[1].Args).To(Equal([]string{
+					"--signing-secret-path",
+					"/workspace/shp-signing-secret",
+					"--image",
+					"$(params.shp-output-image)",
+					"--insecure=$(params.shp-output-insecure)",
+					"--result-file-image-digest",
+					"$(results.shp-image-digest.path)",
+					"--result-file-image-size",
+					"$(results.shp-image-size.path)",
+					"--result-file-signature-digest",
+					"$(results.shp-image-signature-digest.path)",
+				}))
+				Expect(processedTaskRun.Spec.TaskSpec.Steps[1].VolumeMounts).To(utils.ContainNamedElement("shp-buildrun-signing-key"))
+				Expect(processedTaskRun.Spec.TaskSpec.Volumes).To(utils.ContainNamedElement("shp-buildrun-signing-key"))
+				Expect(processedTaskRun.Spec.TaskSpec.Volumes).ToNot(utils.ContainNamedElement("shp-build-signing-key"))
+			})
+
+			It("adds the result for the signature digest", func() {
+				Expect(processedTaskRun.Spec.TaskSpec.Results).To(utils.ContainNamedElement("shp-image-signature-digest"))
+			})
+		})
+
+		Context("for a build with an SBOM in the output", func() {
+			BeforeEach(func() {
+				processedTaskRun = taskRun.DeepCopy()
+				resources.SetupImageProcessing(processedTaskRun, config, buildv1alpha1.Image{
+					Image: "some-registry/some-namespace/some-image",
+					SBOM: &buildv1alpha1.ImageSBOM{
+						Format: buildv1alpha1.SBOMFormatSPDX,
+					},
+				}, buildv1alpha1.Image{
+					SBOM: &buildv1alpha1.ImageSBOM{
+						Format: buildv1alpha1.SBOMFormatCycloneDX,
+					},
+				})
+			})
+
+			It("adds the image-processing step that attaches an SBOM in the format of the BuildRun", func() {
+				Expect(processedTaskRun.Spec.TaskSpec.Steps).To(HaveLen(2))
+				Expect(processedTaskRun.Spec.TaskSpec.Steps[1].Name).To(Equal("image-processing"))
+				Expect(processedTaskRun.Spec.TaskSpec.Steps[1].Args).To(Equal([]string{
+					"--sbom-format",
+					"cyclonedx",
+					"--image",
+					"$(params.shp-output-image)",
+					"--insecure=$(params.shp-output-insecure)",
+					"--result-file-image-digest",
+					"$(results.shp-image-digest.path)",
+					"--result-file-image-size",
+					"$(results.shp-image-size.path)",
+					"--result-file-sbom-digest",
+					"$(results.shp-image-sbom-digest.path)",
+				}))
+			})
+
+			It("adds the result for the SBOM digest", func() {
+				Expect(processedTaskRun.Spec.TaskSpec.Results).To(utils.ContainNamedElement("shp-image-sbom-digest"))
+			})
+		})
+
+		Context("for a build with additional tags in the output", func() {
+			BeforeEach(func() {
+				processedTaskRun = taskRun.DeepCopy()
+				processedTaskRun.Labels = map[string]string{
+					"buildrun.shipwright.io/name": "some-buildrun",
+				}
+				processedTaskRun.Spec.TaskSpec.Results = []pipelineapi.TaskResult{{
+					Name: "shp-source-default-commit-sha",
+				}}
+				resources.SetupImageProcessing(processedTaskRun, config, buildv1alpha1.Image{
+					Image:          "some-registry/some-namespace/some-image",
+					AdditionalTags: []string{"latest"},
+				}, buildv1alpha1.Image{
+					AdditionalTags: []string{"$(commit-sha-short)", "$(buildrun-name)"},
+				})
+			})
+
+			It("adds the image-processing step that pushes the additional tags of the BuildRun", func() {
+				Expect(processedTaskRun.Spec.TaskSpec.Steps).To(HaveLen(2))
+				Expect(processedTaskRun.Spec.TaskSpec.Steps[1].Name).To(Equal("image-processing"))
+				Expect(processedTaskRun.Spec.TaskSpec.Steps[1].Args).To(Equal([]string{
+					"--additional-tag",
+					"$(commit-sha-short)",
+					"--additional-tag",
+					"$(buildrun-name)",
+					"--image",
+					"$(params.shp-output-image)",
+					"--insecure=$(params.shp-output-insecure)",
+					"--result-file-image-digest",
+					"$(results.shp-image-digest.path)",
+					"--result-file-image-size",
+					"$(results.shp-image-size.path)",
+					"--buildrun-name",
+					"some-buildrun",
+					"--commit-sha-file",
+					"$(results.shp-source-default-commit-sha.path)",
+					"--result-file-image-tags",
+					"$(results.shp-image-tags.path)",
+				}))
+			})
+
+			It("adds the result for the tags", func() {
+				Expect(processedTaskRun.Spec.TaskSpec.Results).To(utils.ContainNamedElement("shp-image-tags"))
+			})
+		})
+
+		Context("for a build with mirrors in the output", func() {
+			BeforeEach(func() {
+				processedTaskRun = taskRun.DeepCopy()
+				resources.SetupImageProcessing(processedTaskRun, config, buildv1alpha1.Image{
+					Image: "some-registry/some-namespace/some-image",
+					Mirrors: []buildv1alpha1.ImageMirror{{
+						Image: "build-registry/some-namespace/some-image",
+					}},
+				}, buildv1alpha1.Image{
+					Mirrors: []buildv1alpha1.ImageMirror{{
+						Image:    "dr-registry/some-namespace/some-image",
+						Insecure: pointer.Bool(true),
+						Credentials: &corev1.LocalObjectReference{
+							Name: "dr-registry-secret",
+						},
+					}},
+				})
+			})
+
+			It("adds the image-processing step that copies the image to the mirrors of the BuildRun", func() {
+				Expect(processedTaskRun.Spec.TaskSpec.Steps).To(HaveLen(2))
+				Expect(processedTaskRun.Spec.TaskSpec.Steps[1].Name).To(Equal("image-processing"))
+				Expect(processedTaskRun.Spec.TaskSpec.Steps[1].Args).To(Equal([]string{
+					"--mirror",
+					"dr-registry/some-namespace/some-image",
+					"--image",
+					"$(params.shp-output-image)",
+					"--insecure=$(params.shp-output-insecure)",
+					"--result-file-image-digest",
+					"$(results.shp-image-digest.path)",
+					"--result-file-image-size",
+					"$(results.shp-image-size.path)",
+					"--mirror-insecure",
+					"dr-registry/some-namespace/some-image",
+					"--mirror-secret-path",
+					"dr-registry/some-namespace/some-image=/workspace/shp-mirror-secret-0",
+					"--result-file-image-mirrors",
+					"$(results.shp-image-mirrors.path)",
+				}))
+				Expect(processedTaskRun.Spec.TaskSpec.Steps[1].VolumeMounts).To(utils.ContainNamedElement("shp-dr-registry-secret"))
+				Expect(processedTaskRun.Spec.TaskSpec.Volumes).To(utils.ContainNamedElement("shp-dr-registry-secret"))
+			})
+
+			It("adds the result for the mirrors", func() {
+				Expect(processedTaskRun.Spec.TaskSpec.Results).To(utils.ContainNamedElement("shp-image-mirrors"))
+			})
+		})
 	})
 
 	Context("for a TaskRun that references the output directory", func() {
@@ -0,0 +1,315 @@
+// Copyright The Shipwright Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package resources
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	imagename "github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	pipelineapi "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	buildv1alpha1 "github.com/shipwright-io/build/pkg/apis/build/v1alpha1"
+	"github.com/shipwright-io/build/pkg/image"
+	"github.com/shipwright-io/build/pkg/provenance"
+)
+
+// ProvenanceRequested returns whether the output of the BuildRun is signed
+// with a provenance attestation, the BuildRun overrides the signing of the Build
+func ProvenanceRequested(buildRun *buildv1alpha1.BuildRun) bool {
+	if buildRun.Status.BuildSpec == nil {
+		return false
+	}
+
+	signing := effectiveOutput(buildRun).Signing
+	return signing != nil && signing.Provenance
+}
+
+// AttachProvenance generates the SLSA provenance statement of the completed
+// BuildRun, signs it using the signing secret of the output, and attaches it to
+// the output image. It returns the digest of the attestation. The statement is
+// generated in the controller from the recorded status of the BuildRun and the
+// labels of its TaskRun, so that the steps of the strategy cannot forge it.
+func AttachProvenance(ctx context.Context, client client.Client, buildRun *buildv1alpha1.BuildRun, taskRun *pipelineapi.TaskRun) (string, error) {
+	if buildRun.Status.BuildSpec == nil {
+		return "", errors.New("the BuildRun does not record the Build specification")
+	}
+
+	if buildRun.Status.Output == nil || buildRun.Status.Output.Digest == "" {
+		return "", errors.New("the BuildRun does not record the digest of the output image")
+	}
+
+	output := effectiveOutput(buildRun)
+	if output.Signing == nil {
+		return "", errors.New("the output image is not signed")
+	}
+
+	// the Build as it was used by the BuildRun
+	build := &buildv1alpha1.Build{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      buildRun.Spec.BuildName(),
+			Namespace: buildRun.Namespace,
+		},
+		Spec: *buildRun.Status.BuildSpec,
+	}
+	build.Generation = generationLabel(taskRun, buildv1alpha1.LabelBuildGeneration)
+
+	strategyGenerationLabel := buildv1alpha1.LabelBuildStrategyGeneration
+	if build.Spec.Strategy.Kind != nil && *build.Spec.Strategy.Kind == buildv1alpha1.ClusterBuildStrategyKind {
+		strategyGenerationLabel = buildv1alpha1.LabelClusterBuildStrategyGeneration
+	}
+
+	statement := generateProvenance(build, buildRun, generationLabel(taskRun, strategyGenerationLabel), OverrideParams(build.Spec.ParamValues, buildRun.Spec.ParamValues))
+
+	ref, err := imagename.ParseReference(output.Image)
+	if err != nil {
+		return "", err
+	}
+	digest := ref.Context().Digest(buildRun.Status.Output.Digest)
+
+	commits := map[string]string{}
+	for _, source := range buildRun.Status.Sources {
+		if source.Git != nil {
+			commits[source.Name] = source.Git.CommitSha
+		}
+	}
+
+	finishedOn := metav1.Now()
+	if buildRun.Status.CompletionTime != nil {
+		finishedOn = *buildRun.Status.CompletionTime
+	}
+
+	if err := statement.Complete(digest, commits, finishedOn.Time); err != nil {
+		return "", err
+	}
+
+	data, err := json.Marshal(statement)
+	if err != nil {
+		return "", err
+	}
+
+	signingSecret := &corev1.Secret{}
+	if err := client.Get(ctx, types.NamespacedName{Namespace: buildRun.Namespace, Name: output.Signing.SecretRef.Name}, signingSecret); err != nil {
+		return "", fmt.Errorf("failed to get the signing secret %s: %w", output.Signing.SecretRef.Name, err)
+	}
+
+	key, err := image.ParsePrivateKey(signingSecret.Data["cosign.key"], bytes.TrimRight(signingSecret.Data["cosign.password"], "\r\n"))
+	if err != nil {
+		return "", fmt.Errorf("failed to load the private key of the signing secret %s: %w", output.Signing.SecretRef.Name, err)
+	}
+
+	insecure := output.Insecure != nil && *output.Insecure
+
+	var options []remote.Option
+	if output.Credentials != nil {
+		credentials := &corev1.Secret{}
+		if err := client.Get(ctx, types.NamespacedName{Namespace: buildRun.Namespace, Name: output.Credentials.Name}, credentials); err != nil {
+			return "", fmt.Errorf("failed to get the output secret %s: %w", output.Credentials.Name, err)
+		}
+
+		dockerConfigJSON, ok := credentials.Data[corev1.DockerConfigJsonKey]
+		if !ok {
+			return "", fmt.Errorf("secret %s does not contain the key %s", output.Credentials.Name, corev1.DockerConfigJsonKey)
+		}
+
+		if options, _, err = image.GetOptionsForDockerConfigJSON(ctx, ref, insecure, dockerConfigJSON, "Shipwright Build"); err != nil {
+			return "", err
+		}
+	} else {
+		if options, _, err = image.GetOptions(ctx, ref, insecure, "", "Shipwright Build"); err != nil {
+			return "", err
+		}
+	}
+
+	hash, err := image.Attest(digest, data, provenance.PredicateType, key, options...)
+	if err != nil {
+		return "", err
+	}
+
+	return hash.String(), nil
+}
+
+// effectiveOutput returns the output of the Build with the overrides of the
+// BuildRun
+func effectiveOutput(buildRun *buildv1alpha1.BuildRun) buildv1alpha1.Image {
+	output := buildRun.Status.BuildSpec.Output
+	if buildRun.Spec.Output == nil {
+		return output
+	}
+
+	if buildRun.Spec.Output.Image != "" {
+		output.Image = buildRun.Spec.Output.Image
+	}
+	if buildRun.Spec.Output.Insecure != nil {
+		output.Insecure = buildRun.Spec.Output.Insecure
+	}
+	if buildRun.Spec.Output.Credentials != nil {
+		output.Credentials = buildRun.Spec.Output.Credentials
+	}
+	if buildRun.Spec.Output.Signing != nil {
+		output.Signing = buildRun.Spec.Output.Signing
+	}
+
+	return output
+}
+
+// generationLabel returns the generation that is recorded in the label of the
+// TaskRun, or zero if the label is not set
+func generationLabel(taskRun *pipelineapi.TaskRun, label string) int64 {
+	generation, err := strconv.ParseInt(taskRun.Labels[label], 10, 64)
+	if err != nil {
+		return 0
+	}
+
+	return generation
+}
+
+// generateProvenance creates the SLSA provenance statement of the BuildRun
+// without the facts that are only known once the image was pushed
+func generateProvenance(build *buildv1alpha1.Build, buildRun *buildv1alpha1.BuildRun, strategyGeneration int64, paramValues []buildv1alpha1.ParamValue) provenance.Statement {
+	strategyKind := string(buildv1alpha1.NamespacedBuildStrategyKind)
+	if build.Spec.Strategy.Kind != nil {
+		strategyKind = string(*build.Spec.Strategy.Kind)
+	}
+
+	statement := provenance.Statement{
+		Type:          provenance.StatementType,
+		Subject:       []provenance.ResourceDescriptor{},
+		PredicateType: provenance.PredicateType,
+		Predicate: provenance.Predicate{
+			BuildDefinition: provenance.BuildDefinition{
+				BuildType: provenance.BuildType,
+				ExternalParameters: provenance.ExternalParameters{
+					Namespace: buildRun.Namespace,
+					Build:     build.Name,
+					BuildRun:  buildRun.Name,
+					Strategy: provenance.Strategy{
+						Kind: strategyKind,
+						Name: build.Spec.Strategy.Name,
+					},
+					Output: effectiveOutput(buildRun).Image,
+				},
+				InternalParameters: provenance.InternalParameters{
+					StrategyGeneration: strategyGeneration,
+					BuildGeneration:    build.Generation,
+				},
+			},
+			RunDetails: provenance.RunDetails{
+				Builder: provenance.Builder{ID: provenance.BuilderID},
+				Metadata: provenance.Metadata{
+					InvocationID: string(buildRun.UID),
+				},
+			},
+		},
+	}
+
+	// the build starts with the creation of the BuildRun
+	if !buildRun.CreationTimestamp.IsZero() {
+		startedOn := buildRun.CreationTimestamp.UTC()
+		statement.Predicate.RunDetails.Metadata.StartedOn = &startedOn
+	}
+
+	if len(paramValues) > 0 {
+		statement.Predicate.BuildDefinition.ExternalParameters.ParamValues = map[string]string{}
+		for _, paramValue := range paramValues {
+			statement.Predicate.BuildDefinition.ExternalParameters.ParamValues[paramValue.Name] = provenanceParamValue(paramValue)
+		}
+	}
+
+	addSource := func(name string, sourceType buildv1alpha1.BuildSourceType, source buildv1alpha1.Source) {
+		entry := provenance.Source{Name: name, Type: string(sourceType)}
+		dependency := provenance.ResourceDescriptor{Name: name}
+
+		switch {
+		case source.URL != nil:
+			entry.URL = *source.URL
+			dependency.URI = "git+" + *source.URL
+			if source.Revision != nil {
+				entry.Revision = *source.Revision
+				dependency.URI += "@" + *source.Revision
+			}
+
+		case source.BundleContainer != nil:
+			entry.URL = source.BundleContainer.Image
+			dependency.URI = "oci://" + source.BundleContainer.Image
+		}
+
+		if source.ContextDir != nil {
+			entry.ContextDir = *source.ContextDir
+		}
+
+		statement.Predicate.BuildDefinition.ExternalParameters.Sources = append(statement.Predicate.BuildDefinition.ExternalParameters.Sources, entry)
+		statement.Predicate.BuildDefinition.ResolvedDependencies = append(statement.Predicate.BuildDefinition.ResolvedDependencies, dependency)
+	}
+
+	switch {
+	case build.Spec.Source.BundleContainer != nil:
+		addSource(defaultSourceName, buildv1alpha1.Bundle, build.Spec.Source)
+	case build.Spec.Source.URL != nil:
+		addSource(defaultSourceName, buildv1alpha1.Git, build.Spec.Source)
+	}
+
+	for _, source := range namedSources(build) {
+		switch {
+		case source.Type == buildv1alpha1.HTTP:
+			dependency := provenance.ResourceDescriptor{Name: source.Name, URI: source.URL}
+			if source.SHA256 != nil {
+				// the validation accepts the digest with a prefix and in upper case
+				dependency.Digest = map[string]string{"sha256": strings.ToLower(strings.TrimPrefix(*source.SHA256, "sha256:"))}
+			}
+
+			statement.Predicate.BuildDefinition.ExternalParameters.Sources = append(statement.Predicate.BuildDefinition.ExternalParameters.Sources, provenance.Source{
+				Name: source.Name,
+				Type: string(source.Type),
+				URL:  source.URL,
+			})
+			statement.Predicate.BuildDefinition.ResolvedDependencies = append(statement.Predicate.BuildDefinition.ResolvedDependencies, dependency)
+
+		case source.Source != nil:
+			addSource(source.Name, source.Type, *source.Source)
+		}
+	}
+
+	return statement
+}
+
+// provenanceParamValue formats the value of a parameter, the values of
+// ConfigMaps and Secrets are not resolved by the controller and therefore
+// only referenced
+func provenanceParamValue(paramValue buildv1alpha1.ParamValue) string {
+	format := func(value buildv1alpha1.SingleValue) string {
+		switch {
+		case value.Value != nil:
+			return *value.Value
+		case value.ConfigMapValue != nil:
+			return fmt.Sprintf("configMap:%s/%s", value.ConfigMapValue.Name, value.ConfigMapValue.Key)
+		case value.SecretValue != nil:
+			return fmt.Sprintf("secret:%s/%s", value.SecretValue.Name, value.SecretValue.Key)
+		default:
+			return ""
+		}
+	}
+
+	if paramValue.SingleValue != nil {
+		return format(*paramValue.SingleValue)
+	}
+
+	values := make([]string, 0, len(paramValue.Values))
+	for _, value := range paramValue.Values {
+		values = append(values, format(value))
+	}
+
+	return "[" + strings.Join(values, ", ") + "]"
+}
@@ -0,0 +1,283 @@
+// Copyright The Shipwright Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package resources_test
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"io"
+	"log"
+	"net/http/httptest"
+	"strings"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/registry"
+	"github.com/google/go-containerregistry/pkg/v1/random"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	pipelineapi "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/pointer"
+	crc "sigs.k8s.io/controller-runtime/pkg/client"
+
+	buildv1alpha1 "github.com/shipwright-io/build/pkg/apis/build/v1alpha1"
+	"github.com/shipwright-io/build/pkg/controller/fakes"
+	"github.com/shipwright-io/build/pkg/image"
+	"github.com/shipwright-io/build/pkg/provenance"
+	"github.com/shipwright-io/build/pkg/reconciler/buildrun/resources"
+	test "github.com/shipwright-io/build/test/v1alpha1_samples"
+)
+
+var _ = Describe("Provenance", func() {
+	var (
+		build    *buildv1alpha1.Build
+		buildRun *buildv1alpha1.BuildRun
+		ctl      test.Catalog
+	)
+
+	BeforeEach(func() {
+		var err error
+		build, err = ctl.LoadBuildYAML([]byte(test.MinimalBuildahBuild))
+		Expect(err).ToNot(HaveOccurred())
+		build.Spec.Source.Revision = pointer.String("main")
+		build.Spec.Output.Image = "registry.example.com/build-test/sample-go"
+
+		buildRun, err = ctl.LoadBuildRunFromBytes([]byte(test.MinimalBuildahBuildRun))
+		Expect(err).ToNot(HaveOccurred())
+		buildRun.Namespace = "build-test"
+		buildRun.UID = "2f9a6c4e-0d7b-4c55-9d3c-6b1e6f0e4a11"
+		buildRun.Status.BuildSpec = &build.Spec
+	})
+
+	Context("checking whether a provenance is requested", func() {
+		It("returns false if the image is signed without provenance", func() {
+			build.Spec.Output.Signing = &buildv1alpha1.ImageSigning{
+				SecretRef: corev1.LocalObjectReference{Name: "signing-key"},
+			}
+
+			Expect(resources.ProvenanceRequested(buildRun)).To(BeFalse())
+		})
+
+		It("returns true if the Build requests provenance", func() {
+			build.Spec.Output.Signing = &buildv1alpha1.ImageSigning{
+				SecretRef:  corev1.LocalObjectReference{Name: "signing-key"},
+				Provenance: true,
+			}
+
+			Expect(resources.ProvenanceRequested(buildRun)).To(BeTrue())
+		})
+
+		It("returns false if the BuildRun overrides the signing without provenance", func() {
+			build.Spec.Output.Signing = &buildv1alpha1.ImageSigning{
+				SecretRef:  corev1.LocalObjectReference{Name: "signing-key"},
+				Provenance: true,
+			}
+			buildRun.Spec.Output = &buildv1alpha1.Image{
+				Signing: &buildv1alpha1.ImageSigning{
+					SecretRef: corev1.LocalObjectReference{Name: "other-signing-key"},
+				},
+			}
+
+			Expect(resources.ProvenanceRequested(buildRun)).To(BeFalse())
+		})
+	})
+
+	Context("attaching the provenance of a completed BuildRun", func() {
+		var (
+			client  *fakes.FakeClient
+			taskRun *pipelineapi.TaskRun
+			key     *ecdsa.PrivateKey
+			secrets map[string]*corev1.Secret
+		)
+
+		BeforeEach(func() {
+			server := httptest.NewServer(registry.New(registry.Logger(log.New(io.Discard, "", 0))))
+			DeferCleanup(server.Close)
+
+			img, err := random.Image(1024, 1)
+			Expect(err).ToNot(HaveOccurred())
+
+			build.Spec.Output.Image = fmt.Sprintf("%s/build-test/sample-go:latest", strings.TrimPrefix(server.URL, "http://"))
+			build.Spec.Output.Insecure = pointer.Bool(true)
+			build.Spec.Output.Signing = &buildv1alpha1.ImageSigning{
+				SecretRef:  corev1.LocalObjectReference{Name: "signing-key"},
+				Provenance: true,
+			}
+
+			tag, err := name.NewTag(build.Spec.Output.Image)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(remote.Write(tag, img)).To(Succeed())
+
+			hash, err := img.Digest()
+			Expect(err).ToNot(HaveOccurred())
+
+			completionTime := metav1.NewTime(time.Date(2024, 5, 1, 12, 30, 0, 0, time.UTC))
+			buildRun.Status.CompletionTime = &completionTime
+			buildRun.Status.Output = &buildv1alpha1.Output{Digest: hash.String()}
+			buildRun.Status.Sources = []buildv1alpha1.SourceResult{{
+				Name: "default",
+				Git:  &buildv1alpha1.GitSourceResult{CommitSha: "8b7e7e6a4d1c9b3f2a5e0d6c4b8a9f1e2d3c4b5a"},
+			}}
+
+			taskRun = &pipelineapi.TaskRun{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "buildah-run-xyz",
+					Namespace: "build-test",
+					Labels: map[string]string{
+						buildv1alpha1.LabelBuild:                          "buildah",
+						buildv1alpha1.LabelBuildGeneration:                "3",
+						buildv1alpha1.LabelClusterBuildStrategyGeneration: "7",
+					},
+				},
+			}
+
+			key, err = ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+			Expect(err).ToNot(HaveOccurred())
+
+			der, err := x509.MarshalPKCS8PrivateKey(key)
+			Expect(err).ToNot(HaveOccurred())
+
+			secrets = map[string]*corev1.Secret{
+				"signing-key": {
+					Data: map[string][]byte{
+						"cosign.key": pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}),
+					},
+				},
+			}
+
+			client = &fakes.FakeClient{}
+			client.GetCalls(func(_ context.Context, nn types.NamespacedName, object crc.Object, _ ...crc.GetOption) error {
+				if secret, ok := object.(*corev1.Secret); ok && nn.Namespace == "build-test" && secrets[nn.Name] != nil {
+					secrets[nn.Name].DeepCopyInto(secret)
+					return nil
+				}
+
+				return k8serrors.NewNotFound(schema.GroupResource{}, nn.Name)
+			})
+		})
+
+		verifiedStatement := func(provenanceDigest string) provenance.Statement {
+			ref, err := name.ParseReference(build.Spec.Output.Image)
+			Expect(err).ToNot(HaveOccurred())
+
+			data, err := image.VerifyAttestation(ref.Context().Digest(buildRun.Status.Output.Digest), provenance.PredicateType, []crypto.PublicKey{key.Public()})
+			Expect(err).ToNot(HaveOccurred())
+
+			var statement provenance.Statement
+			Expect(json.Unmarshal(data, &statement)).To(Succeed())
+
+			Expect(provenanceDigest).To(HavePrefix("sha256:"))
+			return statement
+		}
+
+		It("signs and attaches the statement generated from the status of the BuildRun", func() {
+			provenanceDigest, err := resources.AttachProvenance(context.TODO(), client, buildRun, taskRun)
+			Expect(err).ToNot(HaveOccurred())
+
+			statement := verifiedStatement(provenanceDigest)
+			Expect(statement.Type).To(Equal(provenance.StatementType))
+			Expect(statement.PredicateType).To(Equal(provenance.PredicateType))
+			Expect(statement.Subject).To(Equal([]provenance.ResourceDescriptor{{
+				Name:   strings.TrimSuffix(build.Spec.Output.Image, ":latest"),
+				Digest: map[string]string{"sha256": strings.TrimPrefix(buildRun.Status.Output.Digest, "sha256:")},
+			}}))
+
+			Expect(statement.Predicate.BuildDefinition.BuildType).To(Equal(provenance.BuildType))
+			Expect(statement.Predicate.BuildDefinition.ExternalParameters).To(Equal(provenance.ExternalParameters{
+				Namespace: "build-test",
+				Build:     "buildah",
+				BuildRun:  "buildah-run",
+				Sources: []provenance.Source{{
+					Name:     "default",
+					Type:     "Git",
+					URL:      "https://github.com/shipwright-io/sample-go",
+					Revision: "main",
+				}},
+				Strategy: provenance.Strategy{
+					Kind: "ClusterBuildStrategy",
+					Name: "buildah",
+				},
+				Output: build.Spec.Output.Image,
+			}))
+			Expect(statement.Predicate.BuildDefinition.InternalParameters).To(Equal(provenance.InternalParameters{
+				StrategyGeneration: 7,
+				BuildGeneration:    3,
+			}))
+			Expect(statement.Predicate.BuildDefinition.ResolvedDependencies).To(Equal([]provenance.ResourceDescriptor{{
+				Name:   "default",
+				URI:    "git+https://github.com/shipwright-io/sample-go@main",
+				Digest: map[string]string{provenance.GitCommitDigest: "8b7e7e6a4d1c9b3f2a5e0d6c4b8a9f1e2d3c4b5a"},
+			}}))
+			Expect(statement.Predicate.RunDetails.Builder.ID).To(Equal(provenance.BuilderID))
+			Expect(statement.Predicate.RunDetails.Metadata.InvocationID).To(Equal("2f9a6c4e-0d7b-4c55-9d3c-6b1e6f0e4a11"))
+			Expect(statement.Predicate.RunDetails.Metadata.FinishedOn).ToNot(BeNil())
+			Expect(*statement.Predicate.RunDetails.Metadata.FinishedOn).To(BeTemporally("==", buildRun.Status.CompletionTime.Time))
+		})
+
+		It("records the parameter values with the overrides of the BuildRun", func() {
+			build.Spec.ParamValues = []buildv1alpha1.ParamValue{{
+				Name:        "storage-driver",
+				SingleValue: &buildv1alpha1.SingleValue{Value: pointer.String("vfs")},
+			}}
+			buildRun.Spec.ParamValues = []buildv1alpha1.ParamValue{{
+				Name:        "storage-driver",
+				SingleValue: &buildv1alpha1.SingleValue{Value: pointer.String("overlay")},
+			}}
+
+			provenanceDigest, err := resources.AttachProvenance(context.TODO(), client, buildRun, taskRun)
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(verifiedStatement(provenanceDigest).Predicate.BuildDefinition.ExternalParameters.ParamValues).To(Equal(map[string]string{
+				"storage-driver": "overlay",
+			}))
+		})
+
+		It("records the normalized digest of a HTTP source", func() {
+			build.Spec.Sources = []buildv1alpha1.BuildSource{{
+				Name:   "logo",
+				Type:   buildv1alpha1.HTTP,
+				URL:    "https://shipwright.io/icons/logo.svg",
+				SHA256: pointer.String("sha256:8D3C0D1F4A2B4A4BE0A51DC2C2E7FF0D86B6C9D2A7C19F0C7C4D7E3F8B9A6C1D"),
+			}}
+
+			provenanceDigest, err := resources.AttachProvenance(context.TODO(), client, buildRun, taskRun)
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(verifiedStatement(provenanceDigest).Predicate.BuildDefinition.ResolvedDependencies).To(ContainElement(provenance.ResourceDescriptor{
+				Name:   "logo",
+				URI:    "https://shipwright.io/icons/logo.svg",
+				Digest: map[string]string{"sha256": "8d3c0d1f4a2b4a4be0a51dc2c2e7ff0d86b6c9d2a7c19f0c7c4d7e3f8b9a6c1d"},
+			}))
+		})
+
+		It("fails if the signing secret does not exist", func() {
+			delete(secrets, "signing-key")
+
+			_, err := resources.AttachProvenance(context.TODO(), client, buildRun, taskRun)
+			Expect(err).To(MatchError(ContainSubstring("failed to get the signing secret signing-key")))
+		})
+
+		It("fails if the BuildRun does not record the digest of the output image", func() {
+			buildRun.Status.Output = nil
+
+			_, err := resources.AttachProvenance(context.TODO(), client, buildRun, taskRun)
+			Expect(err).To(MatchError(ContainSubstring("digest of the output image")))
+		})
+	})
+})
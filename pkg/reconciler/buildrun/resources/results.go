@@ -6,8 +6,10 @@ package resources
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"strconv"
+	"strings"
 
 	build "github.com/shipwright-io/build/pkg/apis/build/v1alpha1"
 	"github.com/shipwright-io/build/pkg/ctxlog"
@@ -17,8 +19,12 @@ import (
 )
 
 const (
-	imageDigestResult = "image-digest"
-	imageSizeResult   = "image-size"
+	imageDigestResult          = "image-digest"
+	imageSizeResult            = "image-size"
+	imageSignatureDigestResult = "image-signature-digest"
+	imageSBOMDigestResult      = "image-sbom-digest"
+	imageTagsResult            = "image-tags"
+	imageMirrorsResult         = "image-mirrors"
 )
 
 // UpdateBuildRunUsingTaskResults surface the task results
@@ -51,6 +57,24 @@ func updateBuildRunStatusWithOutputResult(ctx context.Context, buildRun *build.B
 			} else {
 				buildRun.Status.Output.Size = size
 			}
+
+		case generateOutputResultName(imageSignatureDigestResult):
+			buildRun.Status.Output.SignatureDigest = result.Value.StringVal
+
+		case generateOutputResultName(imageSBOMDigestResult):
+			buildRun.Status.Output.SBOMDigest = result.Value.StringVal
+
+		case generateOutputResultName(imageTagsResult):
+			for _, tag := range strings.Split(result.Value.StringVal, "\n") {
+				if tag = strings.TrimSpace(tag); tag != "" {
+					buildRun.Status.Output.Tags = append(buildRun.Status.Output.Tags, tag)
+				}
+			}
+
+		case generateOutputResultName(imageMirrorsResult):
+			if err := json.Unmarshal([]byte(result.Value.StringVal), &buildRun.Status.Output.Mirrors); err != nil {
+				ctxlog.Info(ctx, "invalid value for output image mirrors from taskRun result", namespace, request.Namespace, name, request.Name, "error", err)
+			}
 		}
 	}
 }
@@ -75,6 +75,127 @@ var _ = Describe("TaskRun results to BuildRun", func() {
 			Expect(br.Status.Sources[0].Git.CommitAuthor).To(Equal("foo bar"))
 		})
 
+		It("should surface the TaskRun results about the shallow clone and the tags of the default(git) source step", func() {
+			br.Status.BuildSpec.Source.URL = pointer.String("https://github.com/shipwright-io/sample-go")
+
+			tr.Status.Results = append(tr.Status.Results,
+				pipelineapi.TaskRunResult{
+					Name: "shp-source-default-commit-sha",
+					Value: pipelineapi.ParamValue{
+						Type:      pipelineapi.ParamTypeString,
+						StringVal: "0e0583421a5e4bf562ffe33f3651e16ba0c78591",
+					},
+				},
+				pipelineapi.TaskRunResult{
+					Name: "shp-source-default-shallow",
+					Value: pipelineapi.ParamValue{
+						Type:      pipelineapi.ParamTypeString,
+						StringVal: "false",
+					},
+				},
+				pipelineapi.TaskRunResult{
+					Name: "shp-source-default-commit-details",
+					Value: pipelineapi.ParamValue{
+						Type:      pipelineapi.ParamTypeString,
+						StringVal: `{"timestamp":"1700000000","describe":"v0.1.0-3-g0e05834"}`,
+					},
+				})
+
+			resources.UpdateBuildRunUsingTaskResults(ctx, br, tr.Status.Results, taskRunRequest)
+
+			Expect(len(br.Status.Sources)).To(Equal(1))
+			Expect(br.Status.Sources[0].Git.Shallow).To(BeFalse())
+			Expect(br.Status.Sources[0].Git.Describe).To(Equal("v0.1.0-3-g0e05834"))
+		})
+
+		It("should surface the TaskRun result about the commit details of the default(git) source step", func() {
+			br.Status.BuildSpec.Source.URL = pointer.String("https://github.com/shipwright-io/sample-go")
+
+			tr.Status.Results = append(tr.Status.Results,
+				pipelineapi.TaskRunResult{
+					Name: "shp-source-default-commit-sha",
+					Value: pipelineapi.ParamValue{
+						Type:      pipelineapi.ParamTypeString,
+						StringVal: "0e0583421a5e4bf562ffe33f3651e16ba0c78591",
+					},
+				},
+				pipelineapi.TaskRunResult{
+					Name: "shp-source-default-commit-details",
+					Value: pipelineapi.ParamValue{
+						Type:      pipelineapi.ParamTypeString,
+						StringVal: `{"timestamp":"1700000000","subject":"Add a README","tags":["v1.10.0","v1.9.0"],"describe":"v1.10.0"}`,
+					},
+				})
+
+			resources.UpdateBuildRunUsingTaskResults(ctx, br, tr.Status.Results, taskRunRequest)
+
+			Expect(len(br.Status.Sources)).To(Equal(1))
+			Expect(br.Status.Sources[0].Git.CommitTimestamp).ToNot(BeNil())
+			Expect(br.Status.Sources[0].Git.CommitTimestamp.Unix()).To(Equal(int64(1700000000)))
+			Expect(br.Status.Sources[0].Git.CommitSubject).To(Equal("Add a README"))
+			Expect(br.Status.Sources[0].Git.Tags).To(Equal([]string{"v1.10.0", "v1.9.0"}))
+			Expect(br.Status.Sources[0].Git.Describe).To(Equal("v1.10.0"))
+		})
+
+		It("should surface the TaskRun result with the commit sha of the merge target of the default(git) source step", func() {
+			br.Status.BuildSpec.Source.URL = pointer.String("https://github.com/shipwright-io/sample-go")
+
+			tr.Status.Results = append(tr.Status.Results,
+				pipelineapi.TaskRunResult{
+					Name: "shp-source-default-commit-sha",
+					Value: pipelineapi.ParamValue{
+						Type:      pipelineapi.ParamTypeString,
+						StringVal: "0e0583421a5e4bf562ffe33f3651e16ba0c78591",
+					},
+				},
+				pipelineapi.TaskRunResult{
+					Name: "shp-source-default-merge-target-commit-sha",
+					Value: pipelineapi.ParamValue{
+						Type:      pipelineapi.ParamTypeString,
+						StringVal: "8016b0437a7a09079f961e5003e81e5ad54e6c26",
+					},
+				})
+
+			resources.UpdateBuildRunUsingTaskResults(ctx, br, tr.Status.Results, taskRunRequest)
+
+			Expect(len(br.Status.Sources)).To(Equal(1))
+			Expect(br.Status.Sources[0].Git.CommitSha).To(Equal("0e0583421a5e4bf562ffe33f3651e16ba0c78591"))
+			Expect(br.Status.Sources[0].Git.MergeTargetCommitSha).To(Equal("8016b0437a7a09079f961e5003e81e5ad54e6c26"))
+		})
+
+		It("should surface the TaskRun results with the signer of the default(git) source step", func() {
+			br.Status.BuildSpec.Source.URL = pointer.String("https://github.com/shipwright-io/sample-go")
+
+			tr.Status.Results = append(tr.Status.Results,
+				pipelineapi.TaskRunResult{
+					Name: "shp-source-default-commit-sha",
+					Value: pipelineapi.ParamValue{
+						Type:      pipelineapi.ParamTypeString,
+						StringVal: "0e0583421a5e4bf562ffe33f3651e16ba0c78591",
+					},
+				},
+				pipelineapi.TaskRunResult{
+					Name: "shp-source-default-signer",
+					Value: pipelineapi.ParamValue{
+						Type:      pipelineapi.ParamTypeString,
+						StringVal: "Shipwright <shipwright@example.com>",
+					},
+				},
+				pipelineapi.TaskRunResult{
+					Name: "shp-source-default-signer-key",
+					Value: pipelineapi.ParamValue{
+						Type:      pipelineapi.ParamTypeString,
+						StringVal: "0B4CEB7774540AE00FCED430721D33FD4A73094B",
+					},
+				})
+
+			resources.UpdateBuildRunUsingTaskResults(ctx, br, tr.Status.Results, taskRunRequest)
+
+			Expect(len(br.Status.Sources)).To(Equal(1))
+			Expect(br.Status.Sources[0].Git.Signer).To(Equal("Shipwright <shipwright@example.com>"))
+			Expect(br.Status.Sources[0].Git.SignerKey).To(Equal("0B4CEB7774540AE00FCED430721D33FD4A73094B"))
+		})
+
 		It("should surface the TaskRun results emitting from default(bundle) source step", func() {
 			bundleImageDigest := "sha256:fe1b73cd25ac3f11dec752755e2"
 			br.Status.BuildSpec.Source.BundleContainer = &build.BundleContainer{
@@ -96,6 +217,30 @@ var _ = Describe("TaskRun results to BuildRun", func() {
 			Expect(br.Status.Sources[0].Bundle.Digest).To(Equal(bundleImageDigest))
 		})
 
+		It("should surface the TaskRun results emitting from http source step", func() {
+			digest := "sha256:8d3c0d1f4a2b4a4be0a51dc2c2e7ff0d86b6c9d2a7c19f0c7c4d7e3f8b9a6c1d"
+			br.Status.BuildSpec.Sources = []build.BuildSource{{
+				Name: "archive",
+				Type: build.HTTP,
+				URL:  "https://example.com/archive.tar.gz",
+			}}
+
+			tr.Status.Results = append(tr.Status.Results,
+				pipelineapi.TaskRunResult{
+					Name: "shp-source-archive-digest",
+					Value: pipelineapi.ParamValue{
+						Type:      pipelineapi.ParamTypeString,
+						StringVal: digest,
+					},
+				})
+
+			resources.UpdateBuildRunUsingTaskResults(ctx, br, tr.Status.Results, taskRunRequest)
+
+			Expect(len(br.Status.Sources)).To(Equal(1))
+			Expect(br.Status.Sources[0].Name).To(Equal("archive"))
+			Expect(br.Status.Sources[0].HTTP.Digest).To(Equal(digest))
+		})
+
 		It("should surface the TaskRun results emitting from output step", func() {
 			imageDigest := "sha256:fe1b73cd25ac3f11dec752755e2"
 
@@ -121,6 +266,73 @@ var _ = Describe("TaskRun results to BuildRun", func() {
 			Expect(br.Status.Output.Size).To(Equal(int64(230)))
 		})
 
+		It("should surface the TaskRun result with the signature digest of the output image", func() {
+			signatureDigest := "sha256:3a9f5fb1ec31f5b2e3e1ad0c6a9be3e0bbd4d3e1"
+
+			tr.Status.Results = append(tr.Status.Results,
+				pipelineapi.TaskRunResult{
+					Name: "shp-image-signature-digest",
+					Value: pipelineapi.ParamValue{
+						Type:      pipelineapi.ParamTypeString,
+						StringVal: signatureDigest,
+					},
+				})
+
+			resources.UpdateBuildRunUsingTaskResults(ctx, br, tr.Status.Results, taskRunRequest)
+
+			Expect(br.Status.Output.SignatureDigest).To(Equal(signatureDigest))
+		})
+
+		It("should surface the TaskRun result with the SBOM digest of the output image", func() {
+			sbomDigest := "sha256:7d2c1e0f4b8a9c3d5e6f7a8b9c0d1e2f3a4b5c6d"
+
+			tr.Status.Results = append(tr.Status.Results,
+				pipelineapi.TaskRunResult{
+					Name: "shp-image-sbom-digest",
+					Value: pipelineapi.ParamValue{
+						Type:      pipelineapi.ParamTypeString,
+						StringVal: sbomDigest,
+					},
+				})
+
+			resources.UpdateBuildRunUsingTaskResults(ctx, br, tr.Status.Results, taskRunRequest)
+
+			Expect(br.Status.Output.SBOMDigest).To(Equal(sbomDigest))
+		})
+
+		It("should surface the TaskRun result with the tags of the output image", func() {
+			tr.Status.Results = append(tr.Status.Results,
+				pipelineapi.TaskRunResult{
+					Name: "shp-image-tags",
+					Value: pipelineapi.ParamValue{
+						Type:      pipelineapi.ParamTypeString,
+						StringVal: "latest\nv1\n",
+					},
+				})
+
+			resources.UpdateBuildRunUsingTaskResults(ctx, br, tr.Status.Results, taskRunRequest)
+
+			Expect(br.Status.Output.Tags).To(Equal([]string{"latest", "v1"}))
+		})
+
+		It("should surface the TaskRun result with the mirrors of the output image", func() {
+			tr.Status.Results = append(tr.Status.Results,
+				pipelineapi.TaskRunResult{
+					Name: "shp-image-mirrors",
+					Value: pipelineapi.ParamValue{
+						Type:      pipelineapi.ParamTypeString,
+						StringVal: `[{"image":"dr-registry/some-namespace/some-image","digest":"sha256:fe1b73cd25ac3f11dec752755e2"},{"image":"other-registry/some-namespace/some-image","error":"unauthorized"}]`,
+					},
+				})
+
+			resources.UpdateBuildRunUsingTaskResults(ctx, br, tr.Status.Results, taskRunRequest)
+
+			Expect(br.Status.Output.Mirrors).To(Equal([]build.MirrorResult{
+				{Image: "dr-registry/some-namespace/some-image", Digest: "sha256:fe1b73cd25ac3f11dec752755e2"},
+				{Image: "other-registry/some-namespace/some-image", Error: "unauthorized"},
+			}))
+		})
+
 		It("should surface the TaskRun results emitting from source and output step", func() {
 			commitSha := "0e0583421a5e4bf562ffe33f3651e16ba0c78591"
 			imageDigest := "sha256:fe1b73cd25ac3f11dec752755e2"
@@ -5,6 +5,8 @@
 package resources
 
 import (
+	"path"
+
 	buildv1alpha1 "github.com/shipwright-io/build/pkg/apis/build/v1alpha1"
 	"github.com/shipwright-io/build/pkg/config"
 	"github.com/shipwright-io/build/pkg/reconciler/buildrun/resources/sources"
@@ -12,7 +14,7 @@ import (
 	pipelineapi "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
 )
 
-const defaultSourceName = "default"
+const defaultSourceName = buildv1alpha1.DefaultSourceName
 
 // isLocalCopyBuildSource appends all "Sources" in a single slice, and if any entry is typed
 // "LocalCopy" it returns first LocalCopy typed BuildSource found, or nil.
@@ -42,7 +44,7 @@ func AmendTaskSpecWithSources(
 	buildRun *buildv1alpha1.BuildRun,
 ) {
 	if localCopy := isLocalCopyBuildSource(build, buildRun); localCopy != nil {
-		sources.AppendLocalCopyStep(cfg, taskSpec, localCopy.Timeout)
+		sources.AppendLocalCopyStep(cfg, taskSpec, localCopy.Timeout, localCopy.UploadSecretRef)
 	} else {
 		// create the step for spec.source, either Git or Bundle
 		switch {
@@ -53,15 +55,64 @@ func AmendTaskSpecWithSources(
 		}
 	}
 
-	// inspecting .spec.sources looking for "http" typed sources to generate the TaskSpec items
-	// in order to handle remote artifacts
+	// inspecting .spec.sources looking for "http" typed sources to handle remote artifacts, and
+	// for named Git or bundle sources, which are fetched into their own subdirectory
 	for _, source := range build.Spec.Sources {
-		if source.Type == buildv1alpha1.HTTP {
+		switch {
+		case source.Type == buildv1alpha1.HTTP:
 			sources.AppendHTTPStep(cfg, taskSpec, source)
+
+		case source.Type == buildv1alpha1.Git && source.Source != nil && source.Source.URL != nil:
+			sources.AppendGitStep(cfg, taskSpec, *source.Source, source.Name)
+
+		case source.Type == buildv1alpha1.Bundle && source.Source != nil && source.Source.BundleContainer != nil:
+			sources.AppendBundleStep(cfg, taskSpec, *source.Source, source.Name)
 		}
 	}
 }
 
+// namedSources returns the named sources of the Build
+func namedSources(build *buildv1alpha1.Build) []buildv1alpha1.BuildSource {
+	var result []buildv1alpha1.BuildSource
+	for _, source := range build.Spec.Sources {
+		switch source.Type {
+		case buildv1alpha1.HTTP, buildv1alpha1.Git, buildv1alpha1.Bundle:
+			if source.Name != defaultSourceName {
+				result = append(result, source)
+			}
+		}
+	}
+
+	return result
+}
+
+// namedSourceDirectory returns the directory a named source is fetched into,
+// HTTP sources are downloaded into the source root like they always were, all
+// other sources into their own subdirectory of the source directory
+func namedSourceDirectory(source buildv1alpha1.BuildSource) string {
+	if source.Type == buildv1alpha1.HTTP {
+		return "/workspace/source"
+	}
+
+	return path.Join("/workspace/source", source.Name)
+}
+
+// gitSourceNames returns the names of the Git sources of the Build
+func gitSourceNames(build *buildv1alpha1.Build) []string {
+	var result []string
+	if build.Spec.Source.URL != nil {
+		result = append(result, defaultSourceName)
+	}
+
+	for _, source := range namedSources(build) {
+		if source.Type == buildv1alpha1.Git {
+			result = append(result, source.Name)
+		}
+	}
+
+	return result
+}
+
 func updateBuildRunStatusWithSourceResult(buildrun *buildv1alpha1.BuildRun, results []pipelineapi.TaskRunResult) {
 	buildSpec := buildrun.Status.BuildSpec
 
@@ -73,5 +124,16 @@ func updateBuildRunStatusWithSourceResult(buildrun *buildv1alpha1.BuildRun, resu
 		sources.AppendGitResult(buildrun, defaultSourceName, results)
 	}
 
-	// no results for HTTP sources yet
+	for _, source := range buildSpec.Sources {
+		switch source.Type {
+		case buildv1alpha1.HTTP:
+			sources.AppendHTTPResult(buildrun, source.Name, results)
+
+		case buildv1alpha1.Git:
+			sources.AppendGitResult(buildrun, source.Name, results)
+
+		case buildv1alpha1.Bundle:
+			sources.AppendBundleResult(buildrun, source.Name, results)
+		}
+	}
 }
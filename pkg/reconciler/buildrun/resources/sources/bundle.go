@@ -6,6 +6,7 @@ package sources
 
 import (
 	"fmt"
+	"strconv"
 	"strings"
 
 	core "k8s.io/api/core/v1"
@@ -37,8 +38,10 @@ func AppendBundleStep(
 		Command:         cfg.BundleContainerTemplate.Command,
 		Args: []string{
 			"--image", source.BundleContainer.Image,
-			"--target", fmt.Sprintf("$(params.%s-%s)", prefixParamsResultsVolumes, paramSourceRoot),
+			"--target", sourceTarget(name),
 			"--result-file-image-digest", fmt.Sprintf("$(results.%s-source-%s-image-digest.path)", prefixParamsResultsVolumes, name),
+			"--result-file-error-message", fmt.Sprintf("$(results.%s-error-message.path)", prefixParamsResultsVolumes),
+			"--result-file-error-reason", fmt.Sprintf("$(results.%s-error-reason.path)", prefixParamsResultsVolumes),
 		},
 		Env:              cfg.BundleContainerTemplate.Env,
 		ComputeResources: cfg.BundleContainerTemplate.Resources,
@@ -65,6 +68,34 @@ func AppendBundleStep(
 		)
 	}
 
+	// add the trusted public keys mount, if the signature is verified
+	if source.BundleContainer.VerifySignature != nil {
+		AppendSecretVolume(taskSpec, source.BundleContainer.VerifySignature.SecretRef.Name)
+
+		keysMountPath := fmt.Sprintf("/workspace/%s-source-%s-signature-keys", prefixParamsResultsVolumes, name)
+
+		bundleStep.VolumeMounts = append(bundleStep.VolumeMounts, core.VolumeMount{
+			Name:      SanitizeVolumeNameForSecretName(source.BundleContainer.VerifySignature.SecretRef.Name),
+			MountPath: keysMountPath,
+			ReadOnly:  true,
+		})
+
+		bundleStep.Args = append(bundleStep.Args, "--verify-signature-keys", keysMountPath)
+	}
+
+	// add the limits of unpacking the bundle image
+	if cfg.BundleUnpackLimits.MaxTotalSize > 0 {
+		bundleStep.Args = append(bundleStep.Args, "--max-total-size", strconv.FormatInt(cfg.BundleUnpackLimits.MaxTotalSize, 10))
+	}
+
+	if cfg.BundleUnpackLimits.MaxFileCount > 0 {
+		bundleStep.Args = append(bundleStep.Args, "--max-file-count", strconv.FormatInt(cfg.BundleUnpackLimits.MaxFileCount, 10))
+	}
+
+	if cfg.BundleUnpackLimits.MaxFileSize > 0 {
+		bundleStep.Args = append(bundleStep.Args, "--max-file-size", strconv.FormatInt(cfg.BundleUnpackLimits.MaxFileSize, 10))
+	}
+
 	// add prune flag in when prune after pull is configured
 	if source.BundleContainer.Prune != nil && *source.BundleContainer.Prune == build.PruneAfterPull {
 		bundleStep.Args = append(bundleStep.Args, "--prune")
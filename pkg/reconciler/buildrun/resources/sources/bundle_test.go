@@ -0,0 +1,92 @@
+// Copyright The Shipwright Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package sources_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+
+	buildv1alpha1 "github.com/shipwright-io/build/pkg/apis/build/v1alpha1"
+	"github.com/shipwright-io/build/pkg/config"
+	"github.com/shipwright-io/build/pkg/reconciler/buildrun/resources/sources"
+
+	pipelineapi "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+)
+
+var _ = Describe("Bundle", func() {
+
+	cfg := config.NewDefaultConfig()
+
+	Context("when adding a bundle source", func() {
+		var taskSpec *pipelineapi.TaskSpec
+
+		BeforeEach(func() {
+			taskSpec = &pipelineapi.TaskSpec{}
+		})
+
+		It("adds the step and the result", func() {
+			sources.AppendBundleStep(cfg, taskSpec, buildv1alpha1.Source{
+				BundleContainer: &buildv1alpha1.BundleContainer{
+					Image: "ghcr.io/shipwright-io/sample-go/source-bundle:latest",
+				},
+			}, "default")
+
+			Expect(len(taskSpec.Results)).To(Equal(1))
+			Expect(taskSpec.Results[0].Name).To(Equal("shp-source-default-image-digest"))
+
+			Expect(len(taskSpec.Steps)).To(Equal(1))
+			Expect(taskSpec.Steps[0].Name).To(Equal("source-default"))
+			Expect(taskSpec.Steps[0].Image).To(Equal(cfg.BundleContainerTemplate.Image))
+			Expect(taskSpec.Steps[0].Args).To(Equal([]string{
+				"--image", "ghcr.io/shipwright-io/sample-go/source-bundle:latest",
+				"--target", "$(params.shp-source-root)",
+				"--result-file-image-digest", "$(results.shp-source-default-image-digest.path)",
+				"--result-file-error-message", "$(results.shp-error-message.path)",
+				"--result-file-error-reason", "$(results.shp-error-reason.path)",
+				"--max-total-size", "10737418240",
+				"--max-file-count", "1000000",
+			}))
+		})
+
+		It("adds the configured limits", func() {
+			cfg := config.NewDefaultConfig()
+			cfg.BundleUnpackLimits = config.BundleUnpackLimits{MaxFileSize: 1024}
+
+			sources.AppendBundleStep(cfg, taskSpec, buildv1alpha1.Source{
+				BundleContainer: &buildv1alpha1.BundleContainer{
+					Image: "ghcr.io/shipwright-io/sample-go/source-bundle:latest",
+				},
+			}, "default")
+
+			Expect(taskSpec.Steps[0].Args).ToNot(ContainElement("--max-total-size"))
+			Expect(taskSpec.Steps[0].Args).ToNot(ContainElement("--max-file-count"))
+			Expect(taskSpec.Steps[0].Args).To(ContainElements("--max-file-size", "1024"))
+		})
+
+		It("mounts the trusted public keys when the signature is verified", func() {
+			sources.AppendBundleStep(cfg, taskSpec, buildv1alpha1.Source{
+				BundleContainer: &buildv1alpha1.BundleContainer{
+					Image: "ghcr.io/shipwright-io/sample-go/source-bundle:latest",
+					VerifySignature: &buildv1alpha1.BundleSignatureVerification{
+						SecretRef: corev1.LocalObjectReference{Name: "bundle-keys"},
+					},
+				},
+			}, "default")
+
+			Expect(len(taskSpec.Volumes)).To(Equal(1))
+			Expect(taskSpec.Volumes[0].Name).To(Equal("shp-bundle-keys"))
+			Expect(taskSpec.Volumes[0].VolumeSource.Secret.SecretName).To(Equal("bundle-keys"))
+
+			Expect(len(taskSpec.Steps)).To(Equal(1))
+			Expect(len(taskSpec.Steps[0].VolumeMounts)).To(Equal(1))
+			Expect(taskSpec.Steps[0].VolumeMounts[0].Name).To(Equal("shp-bundle-keys"))
+			Expect(taskSpec.Steps[0].VolumeMounts[0].MountPath).To(Equal("/workspace/shp-source-default-signature-keys"))
+			Expect(taskSpec.Steps[0].VolumeMounts[0].ReadOnly).To(BeTrue())
+			Expect(taskSpec.Steps[0].Args).To(ContainElements("--verify-signature-keys", "/workspace/shp-source-default-signature-keys"))
+		})
+	})
+})
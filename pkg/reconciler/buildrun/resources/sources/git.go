@@ -5,21 +5,39 @@
 package sources
 
 import (
+	"encoding/json"
 	"fmt"
+	"strconv"
 	"strings"
+	"time"
 
 	buildv1alpha1 "github.com/shipwright-io/build/pkg/apis/build/v1alpha1"
 	"github.com/shipwright-io/build/pkg/config"
 	pipelineapi "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 const (
-	commitSHAResult    = "commit-sha"
-	commitAuthorResult = "commit-author"
-	branchName         = "branch-name"
+	commitSHAResult     = "commit-sha"
+	commitAuthorResult  = "commit-author"
+	branchName          = "branch-name"
+	shallowResult       = "shallow"
+	mergeTargetResult   = "merge-target-commit-sha"
+	signerResult        = "signer"
+	signerKeyResult     = "signer-key"
+	commitDetailsResult = "commit-details"
 )
 
+// commitDetails are the details of the commit that the Git step writes as JSON
+// into a single result, to keep the results of the TaskRun small
+type commitDetails struct {
+	Timestamp string   `json:"timestamp"`
+	Subject   string   `json:"subject"`
+	Tags      []string `json:"tags"`
+	Describe  string   `json:"describe"`
+}
+
 // AppendGitStep appends the Git step and results and volume if needed to the TaskSpec
 func AppendGitStep(
 	cfg *config.Config,
@@ -37,6 +55,9 @@ func AppendGitStep(
 	}, pipelineapi.TaskResult{
 		Name:        fmt.Sprintf("%s-source-%s-%s", prefixParamsResultsVolumes, name, branchName),
 		Description: "The name of the branch used of the cloned source.",
+	}, pipelineapi.TaskResult{
+		Name:        fmt.Sprintf("%s-source-%s-%s", prefixParamsResultsVolumes, name, commitDetailsResult),
+		Description: "The timestamp, subject, tags, and description of the commit of the cloned source as JSON.",
 	})
 
 	// initialize the step from the template and the build-specific arguments
@@ -49,13 +70,17 @@ func AppendGitStep(
 			"--url",
 			*source.URL,
 			"--target",
-			fmt.Sprintf("$(params.%s-%s)", prefixParamsResultsVolumes, paramSourceRoot),
+			sourceTarget(name),
 			"--result-file-commit-sha",
 			fmt.Sprintf("$(results.%s-source-%s-%s.path)", prefixParamsResultsVolumes, name, commitSHAResult),
 			"--result-file-commit-author",
 			fmt.Sprintf("$(results.%s-source-%s-%s.path)", prefixParamsResultsVolumes, name, commitAuthorResult),
 			"--result-file-branch-name",
 			fmt.Sprintf("$(results.%s-source-%s-%s.path)", prefixParamsResultsVolumes, name, branchName),
+			"--result-file-commit-details",
+			fmt.Sprintf("$(results.%s-source-%s-%s.path)", prefixParamsResultsVolumes, name, commitDetailsResult),
+			"--env-file",
+			SourceEnvFile(name),
 			"--result-file-error-message",
 			fmt.Sprintf("$(results.%s-error-message.path)", prefixParamsResultsVolumes),
 			"--result-file-error-reason",
@@ -77,6 +102,142 @@ func AppendGitStep(
 		)
 	}
 
+	// Check if the revision is merged into a target branch, the result holds the commit of the target branch
+	if source.MergeTarget != nil && *source.MergeTarget != "" {
+		taskSpec.Results = append(taskSpec.Results, pipelineapi.TaskResult{
+			Name:        fmt.Sprintf("%s-source-%s-%s", prefixParamsResultsVolumes, name, mergeTargetResult),
+			Description: "The commit SHA of the branch the cloned source was merged into.",
+		})
+
+		gitStep.Args = append(
+			gitStep.Args,
+			"--merge-target",
+			*source.MergeTarget,
+			"--result-file-merge-target-commit-sha",
+			fmt.Sprintf("$(results.%s-source-%s-%s.path)", prefixParamsResultsVolumes, name, mergeTargetResult),
+		)
+	}
+
+	// Check if a sparse checkout is defined, the paths default to the context directory
+	if source.SparseCheckout != nil {
+		sparseCheckoutPaths := source.SparseCheckout.Paths
+		if len(sparseCheckoutPaths) == 0 && source.ContextDir != nil && *source.ContextDir != "" {
+			sparseCheckoutPaths = []string{*source.ContextDir}
+		}
+
+		for _, sparseCheckoutPath := range sparseCheckoutPaths {
+			gitStep.Args = append(
+				gitStep.Args,
+				"--sparse-checkout",
+				sparseCheckoutPath,
+			)
+		}
+	}
+
+	// Check if a partial clone filter is defined
+	if source.CloneFilter != nil && *source.CloneFilter != "" {
+		gitStep.Args = append(
+			gitStep.Args,
+			"--filter",
+			*source.CloneFilter,
+		)
+	}
+
+	// Check if a clone depth is defined, the result tells whether the clone is shallow
+	if source.Depth != nil {
+		taskSpec.Results = append(taskSpec.Results, pipelineapi.TaskResult{
+			Name:        fmt.Sprintf("%s-source-%s-%s", prefixParamsResultsVolumes, name, shallowResult),
+			Description: "Whether the clone of the source is shallow.",
+		})
+
+		gitStep.Args = append(
+			gitStep.Args,
+			"--depth",
+			strconv.Itoa(int(*source.Depth)),
+			"--result-file-shallow",
+			fmt.Sprintf("$(results.%s-source-%s-%s.path)", prefixParamsResultsVolumes, name, shallowResult),
+		)
+	}
+
+	// Check if tags should be fetched, the tags and the description of the commit details are based on them
+	if source.FetchTags != nil && *source.FetchTags {
+		gitStep.Args = append(gitStep.Args, "--tags")
+	}
+
+	// Check if the download of LFS files is configured
+	if source.LFS != nil {
+		if source.LFS.Enabled != nil && !*source.LFS.Enabled {
+			gitStep.Args = append(gitStep.Args, "--lfs=false")
+		}
+
+		for _, include := range source.LFS.Include {
+			gitStep.Args = append(gitStep.Args, "--lfs-include", include)
+		}
+
+		for _, exclude := range source.LFS.Exclude {
+			gitStep.Args = append(gitStep.Args, "--lfs-exclude", exclude)
+		}
+	}
+
+	// Check if the signature of the revision is verified, the results hold the signer and its key
+	if source.VerifySignature != nil {
+		taskSpec.Results = append(taskSpec.Results, pipelineapi.TaskResult{
+			Name:        fmt.Sprintf("%s-source-%s-%s", prefixParamsResultsVolumes, name, signerResult),
+			Description: "The identity of the trusted key that signed the cloned source.",
+		}, pipelineapi.TaskResult{
+			Name:        fmt.Sprintf("%s-source-%s-%s", prefixParamsResultsVolumes, name, signerKeyResult),
+			Description: "The fingerprint of the trusted key that signed the cloned source.",
+		})
+
+		keysMountPath := fmt.Sprintf("/workspace/%s-source-signature-keys", prefixParamsResultsVolumes)
+
+		var volumeName string
+		switch {
+		case source.VerifySignature.SecretRef != nil:
+			AppendSecretVolume(taskSpec, source.VerifySignature.SecretRef.Name)
+			volumeName = SanitizeVolumeNameForSecretName(source.VerifySignature.SecretRef.Name)
+
+		case source.VerifySignature.ConfigMapRef != nil:
+			AppendConfigMapVolume(taskSpec, source.VerifySignature.ConfigMapRef.Name)
+			volumeName = SanitizeVolumeNameForConfigMapName(source.VerifySignature.ConfigMapRef.Name)
+		}
+
+		if volumeName != "" {
+			gitStep.VolumeMounts = append(gitStep.VolumeMounts, corev1.VolumeMount{
+				Name:      volumeName,
+				MountPath: keysMountPath,
+				ReadOnly:  true,
+			})
+		}
+
+		gitStep.Args = append(
+			gitStep.Args,
+			"--verify-signature-keys",
+			keysMountPath,
+			"--result-file-signer",
+			fmt.Sprintf("$(results.%s-source-%s-%s.path)", prefixParamsResultsVolumes, name, signerResult),
+			"--result-file-signer-key",
+			fmt.Sprintf("$(results.%s-source-%s-%s.path)", prefixParamsResultsVolumes, name, signerKeyResult),
+		)
+	}
+
+	// Check if a cache volume is configured, the mirrors are cloned from instead of the remote repository
+	if source.Cache != nil {
+		cacheMountPath := fmt.Sprintf("/workspace/%s-source-%s-git-cache", prefixParamsResultsVolumes, name)
+
+		AppendPersistentVolumeClaimVolume(taskSpec, source.Cache.PersistentVolumeClaimRef.Name)
+		gitStep.VolumeMounts = append(gitStep.VolumeMounts, corev1.VolumeMount{
+			Name:      SanitizeVolumeNameForPersistentVolumeClaimName(source.Cache.PersistentVolumeClaimRef.Name),
+			MountPath: cacheMountPath,
+		})
+
+		gitStep.Args = append(gitStep.Args, "--cache-dir", cacheMountPath)
+
+		if source.Cache.MaxSize != nil && source.Cache.MaxSize.Value() > 0 {
+			gitStep.Args = append(gitStep.Args, "--cache-max-size", strconv.FormatInt(source.Cache.MaxSize.Value(), 10))
+		}
+	}
+
 	// If configure, use Git URL rewrite flag
 	if cfg.GitRewriteRule {
 		gitStep.Args = append(gitStep.Args, "--git-url-rewrite")
@@ -112,15 +273,38 @@ func AppendGitResult(buildRun *buildv1alpha1.BuildRun, name string, results []pi
 	commitAuthor := findResultValue(results, fmt.Sprintf("%s-source-%s-%s", prefixParamsResultsVolumes, name, commitAuthorResult))
 	commitSha := findResultValue(results, fmt.Sprintf("%s-source-%s-%s", prefixParamsResultsVolumes, name, commitSHAResult))
 	branchName := findResultValue(results, fmt.Sprintf("%s-source-%s-%s", prefixParamsResultsVolumes, name, branchName))
+	shallow := findResultValue(results, fmt.Sprintf("%s-source-%s-%s", prefixParamsResultsVolumes, name, shallowResult))
+	mergeTargetCommitSha := findResultValue(results, fmt.Sprintf("%s-source-%s-%s", prefixParamsResultsVolumes, name, mergeTargetResult))
+	signer := findResultValue(results, fmt.Sprintf("%s-source-%s-%s", prefixParamsResultsVolumes, name, signerResult))
+	signerKey := findResultValue(results, fmt.Sprintf("%s-source-%s-%s", prefixParamsResultsVolumes, name, signerKeyResult))
+	details := findResultValue(results, fmt.Sprintf("%s-source-%s-%s", prefixParamsResultsVolumes, name, commitDetailsResult))
 
 	if strings.TrimSpace(commitAuthor) != "" || strings.TrimSpace(commitSha) != "" || strings.TrimSpace(branchName) != "" {
+		gitResult := &buildv1alpha1.GitSourceResult{
+			CommitAuthor:         commitAuthor,
+			CommitSha:            commitSha,
+			BranchName:           branchName,
+			Shallow:              strings.TrimSpace(shallow) == "true",
+			MergeTargetCommitSha: strings.TrimSpace(mergeTargetCommitSha),
+			Signer:               strings.TrimSpace(signer),
+			SignerKey:            strings.TrimSpace(signerKey),
+		}
+
+		var commit commitDetails
+		if err := json.Unmarshal([]byte(details), &commit); err == nil {
+			gitResult.CommitSubject = commit.Subject
+			gitResult.Tags = commit.Tags
+			gitResult.Describe = commit.Describe
+
+			if seconds, err := strconv.ParseInt(commit.Timestamp, 10, 64); err == nil {
+				timestamp := metav1.NewTime(time.Unix(seconds, 0).UTC())
+				gitResult.CommitTimestamp = &timestamp
+			}
+		}
+
 		buildRun.Status.Sources = append(buildRun.Status.Sources, buildv1alpha1.SourceResult{
 			Name: name,
-			Git: &buildv1alpha1.GitSourceResult{
-				CommitAuthor: commitAuthor,
-				CommitSha:    commitSha,
-				BranchName:   branchName,
-			},
+			Git:  gitResult,
 		})
 	}
 }
@@ -14,6 +14,7 @@ import (
 
 	pipelineapi "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	"k8s.io/utils/pointer"
 )
 
@@ -36,10 +37,11 @@ var _ = Describe("Git", func() {
 		})
 
 		It("adds results for the commit sha, commit author and branch name", func() {
-			Expect(len(taskSpec.Results)).To(Equal(3))
+			Expect(len(taskSpec.Results)).To(Equal(4))
 			Expect(taskSpec.Results[0].Name).To(Equal("shp-source-default-commit-sha"))
 			Expect(taskSpec.Results[1].Name).To(Equal("shp-source-default-commit-author"))
 			Expect(taskSpec.Results[2].Name).To(Equal("shp-source-default-branch-name"))
+			Expect(taskSpec.Results[3].Name).To(Equal("shp-source-default-commit-details"))
 		})
 
 		It("adds a step", func() {
@@ -57,6 +59,10 @@ var _ = Describe("Git", func() {
 				"$(results.shp-source-default-commit-author.path)",
 				"--result-file-branch-name",
 				"$(results.shp-source-default-branch-name.path)",
+				"--result-file-commit-details",
+				"$(results.shp-source-default-commit-details.path)",
+				"--env-file",
+				"/workspace/shp-source-default.env",
 				"--result-file-error-message",
 				"$(results.shp-error-message.path)",
 				"--result-file-error-reason",
@@ -65,6 +71,35 @@ var _ = Describe("Git", func() {
 		})
 	})
 
+	Context("when adding a named Git source", func() {
+
+		var taskSpec *pipelineapi.TaskSpec
+
+		BeforeEach(func() {
+			taskSpec = &pipelineapi.TaskSpec{}
+		})
+
+		JustBeforeEach(func() {
+			sources.AppendGitStep(cfg, taskSpec, buildv1alpha1.Source{
+				URL: pointer.String("https://github.com/shipwright-io/sample-config"),
+			}, "config")
+		})
+
+		It("adds results for the named source", func() {
+			Expect(len(taskSpec.Results)).To(Equal(4))
+			Expect(taskSpec.Results[0].Name).To(Equal("shp-source-config-commit-sha"))
+			Expect(taskSpec.Results[1].Name).To(Equal("shp-source-config-commit-author"))
+			Expect(taskSpec.Results[2].Name).To(Equal("shp-source-config-branch-name"))
+			Expect(taskSpec.Results[3].Name).To(Equal("shp-source-config-commit-details"))
+		})
+
+		It("adds a step that clones into the directory of the named source", func() {
+			Expect(len(taskSpec.Steps)).To(Equal(1))
+			Expect(taskSpec.Steps[0].Name).To(Equal("source-config"))
+			Expect(taskSpec.Steps[0].Args).To(ContainElements("--target", "$(params.shp-source-config-path)"))
+		})
+	})
+
 	Context("when adding a private Git source", func() {
 
 		var taskSpec *pipelineapi.TaskSpec
@@ -83,10 +118,11 @@ var _ = Describe("Git", func() {
 		})
 
 		It("adds results for the commit sha, commit author and branch name", func() {
-			Expect(len(taskSpec.Results)).To(Equal(3))
+			Expect(len(taskSpec.Results)).To(Equal(4))
 			Expect(taskSpec.Results[0].Name).To(Equal("shp-source-default-commit-sha"))
 			Expect(taskSpec.Results[1].Name).To(Equal("shp-source-default-commit-author"))
 			Expect(taskSpec.Results[2].Name).To(Equal("shp-source-default-branch-name"))
+			Expect(taskSpec.Results[3].Name).To(Equal("shp-source-default-commit-details"))
 		})
 
 		It("adds a volume for the secret", func() {
@@ -111,6 +147,10 @@ var _ = Describe("Git", func() {
 				"$(results.shp-source-default-commit-author.path)",
 				"--result-file-branch-name",
 				"$(results.shp-source-default-branch-name.path)",
+				"--result-file-commit-details",
+				"$(results.shp-source-default-commit-details.path)",
+				"--env-file",
+				"/workspace/shp-source-default.env",
 				"--result-file-error-message",
 				"$(results.shp-error-message.path)",
 				"--result-file-error-reason",
@@ -124,4 +164,199 @@ var _ = Describe("Git", func() {
 			Expect(taskSpec.Steps[0].VolumeMounts[0].ReadOnly).To(BeTrue())
 		})
 	})
+
+	Context("when adding a Git source with a sparse checkout and a partial clone filter", func() {
+
+		var (
+			taskSpec *pipelineapi.TaskSpec
+			source   buildv1alpha1.Source
+		)
+
+		BeforeEach(func() {
+			taskSpec = &pipelineapi.TaskSpec{}
+			source = buildv1alpha1.Source{
+				URL:            pointer.String("https://github.com/shipwright-io/build"),
+				ContextDir:     pointer.String("samples/go"),
+				SparseCheckout: &buildv1alpha1.SparseCheckout{},
+				CloneFilter:    pointer.String("blob:none"),
+			}
+		})
+
+		JustBeforeEach(func() {
+			sources.AppendGitStep(cfg, taskSpec, source, "default")
+		})
+
+		It("checks out the context directory by default", func() {
+			Expect(len(taskSpec.Steps)).To(Equal(1))
+			Expect(taskSpec.Steps[0].Args[18:]).To(Equal([]string{
+				"--sparse-checkout",
+				"samples/go",
+				"--filter",
+				"blob:none",
+			}))
+		})
+
+		Context("with sparse checkout paths", func() {
+
+			BeforeEach(func() {
+				source.SparseCheckout.Paths = []string{"samples/go", "pkg/lib"}
+			})
+
+			It("checks out the sparse checkout paths", func() {
+				Expect(len(taskSpec.Steps)).To(Equal(1))
+				Expect(taskSpec.Steps[0].Args[18:]).To(Equal([]string{
+					"--sparse-checkout",
+					"samples/go",
+					"--sparse-checkout",
+					"pkg/lib",
+					"--filter",
+					"blob:none",
+				}))
+			})
+		})
+	})
+
+	Context("when adding a Git source with a clone depth, tags, and LFS patterns", func() {
+
+		var taskSpec *pipelineapi.TaskSpec
+
+		BeforeEach(func() {
+			taskSpec = &pipelineapi.TaskSpec{}
+		})
+
+		JustBeforeEach(func() {
+			sources.AppendGitStep(cfg, taskSpec, buildv1alpha1.Source{
+				URL:       pointer.String("https://github.com/shipwright-io/build"),
+				Depth:     pointer.Int32(0),
+				FetchTags: pointer.Bool(true),
+				LFS: &buildv1alpha1.GitLFS{
+					Include: []string{"assets/**"},
+					Exclude: []string{"*.mp4"},
+				},
+			}, "default")
+		})
+
+		It("adds a result for whether the clone is shallow", func() {
+			Expect(len(taskSpec.Results)).To(Equal(5))
+			Expect(taskSpec.Results[4].Name).To(Equal("shp-source-default-shallow"))
+		})
+
+		It("adds the arguments to the step", func() {
+			Expect(len(taskSpec.Steps)).To(Equal(1))
+			Expect(taskSpec.Steps[0].Args[18:]).To(Equal([]string{
+				"--depth",
+				"0",
+				"--result-file-shallow",
+				"$(results.shp-source-default-shallow.path)",
+				"--tags",
+				"--lfs-include",
+				"assets/**",
+				"--lfs-exclude",
+				"*.mp4",
+			}))
+		})
+	})
+
+	Context("when adding a Git source with LFS disabled", func() {
+
+		It("adds the argument to skip the LFS download", func() {
+			taskSpec := &pipelineapi.TaskSpec{}
+			sources.AppendGitStep(cfg, taskSpec, buildv1alpha1.Source{
+				URL: pointer.String("https://github.com/shipwright-io/build"),
+				LFS: &buildv1alpha1.GitLFS{Enabled: pointer.Bool(false)},
+			}, "default")
+
+			Expect(len(taskSpec.Results)).To(Equal(4))
+			Expect(taskSpec.Steps[0].Args[18:]).To(Equal([]string{"--lfs=false"}))
+		})
+	})
+
+	Context("when adding a Git source with a pull request reference and a merge target", func() {
+
+		It("adds a result for the commit sha of the merge target and the arguments to the step", func() {
+			taskSpec := &pipelineapi.TaskSpec{}
+			sources.AppendGitStep(cfg, taskSpec, buildv1alpha1.Source{
+				URL:         pointer.String("https://github.com/shipwright-io/build"),
+				Revision:    pointer.String("refs/pull/123/head"),
+				MergeTarget: pointer.String("main"),
+			}, "default")
+
+			Expect(len(taskSpec.Results)).To(Equal(5))
+			Expect(taskSpec.Results[4].Name).To(Equal("shp-source-default-merge-target-commit-sha"))
+			Expect(taskSpec.Steps[0].Args[18:]).To(Equal([]string{
+				"--revision",
+				"refs/pull/123/head",
+				"--merge-target",
+				"main",
+				"--result-file-merge-target-commit-sha",
+				"$(results.shp-source-default-merge-target-commit-sha.path)",
+			}))
+		})
+	})
+
+	Context("when adding a Git source with signature verification using a config map", func() {
+
+		It("adds results for the signer, the volume, and the arguments to the step", func() {
+			taskSpec := &pipelineapi.TaskSpec{}
+			sources.AppendGitStep(cfg, taskSpec, buildv1alpha1.Source{
+				URL: pointer.String("https://github.com/shipwright-io/build"),
+				VerifySignature: &buildv1alpha1.GitSignatureVerification{
+					ConfigMapRef: &corev1.LocalObjectReference{Name: "trusted-keys"},
+				},
+			}, "default")
+
+			Expect(len(taskSpec.Results)).To(Equal(6))
+			Expect(taskSpec.Results[4].Name).To(Equal("shp-source-default-signer"))
+			Expect(taskSpec.Results[5].Name).To(Equal("shp-source-default-signer-key"))
+
+			Expect(len(taskSpec.Volumes)).To(Equal(1))
+			Expect(taskSpec.Volumes[0].Name).To(Equal("shp-configmap-trusted-keys"))
+			Expect(taskSpec.Volumes[0].ConfigMap.Name).To(Equal("trusted-keys"))
+
+			Expect(taskSpec.Steps[0].VolumeMounts).To(Equal([]corev1.VolumeMount{{
+				Name:      "shp-configmap-trusted-keys",
+				MountPath: "/workspace/shp-source-signature-keys",
+				ReadOnly:  true,
+			}}))
+			Expect(taskSpec.Steps[0].Args[18:]).To(Equal([]string{
+				"--verify-signature-keys",
+				"/workspace/shp-source-signature-keys",
+				"--result-file-signer",
+				"$(results.shp-source-default-signer.path)",
+				"--result-file-signer-key",
+				"$(results.shp-source-default-signer-key.path)",
+			}))
+		})
+	})
+
+	Context("when adding a Git source with a cache volume", func() {
+
+		It("adds the volume, the mount, and the arguments to the step", func() {
+			maxSize := resource.MustParse("10Gi")
+
+			taskSpec := &pipelineapi.TaskSpec{}
+			sources.AppendGitStep(cfg, taskSpec, buildv1alpha1.Source{
+				URL: pointer.String("https://github.com/shipwright-io/build"),
+				Cache: &buildv1alpha1.GitCache{
+					PersistentVolumeClaimRef: corev1.LocalObjectReference{Name: "git-cache"},
+					MaxSize:                  &maxSize,
+				},
+			}, "default")
+
+			Expect(len(taskSpec.Volumes)).To(Equal(1))
+			Expect(taskSpec.Volumes[0].Name).To(Equal("shp-pvc-git-cache"))
+			Expect(taskSpec.Volumes[0].PersistentVolumeClaim.ClaimName).To(Equal("git-cache"))
+
+			Expect(taskSpec.Steps[0].VolumeMounts).To(Equal([]corev1.VolumeMount{{
+				Name:      "shp-pvc-git-cache",
+				MountPath: "/workspace/shp-source-default-git-cache",
+			}}))
+			Expect(taskSpec.Steps[0].Args[18:]).To(Equal([]string{
+				"--cache-dir",
+				"/workspace/shp-source-default-git-cache",
+				"--cache-max-size",
+				"10737418240",
+			}))
+		})
+	})
 })
@@ -6,14 +6,17 @@ package sources
 
 import (
 	"fmt"
+	"strconv"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
 
 	buildv1alpha1 "github.com/shipwright-io/build/pkg/apis/build/v1alpha1"
 	"github.com/shipwright-io/build/pkg/config"
 	pipelineapi "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
 )
 
-// RemoteArtifactsContainerName name for the container dealing with remote artifacts download.
-const RemoteArtifactsContainerName = "sources-http"
+const digestResult = "digest"
 
 // AppendHTTPStep appends the step for a HTTP source to the TaskSpec
 func AppendHTTPStep(
@@ -21,36 +24,88 @@ func AppendHTTPStep(
 	taskSpec *pipelineapi.TaskSpec,
 	source buildv1alpha1.BuildSource,
 ) {
-	// HTTP is done currently all in a single step, see if there is already one
-	httpStep := findExistingHTTPSourcesStep(taskSpec)
-	if httpStep != nil {
-		httpStep.Args[3] = fmt.Sprintf("%s ; wget %q", httpStep.Args[3], source.URL)
-	} else {
-		httpStep := pipelineapi.Step{
-			Name:       RemoteArtifactsContainerName,
-			Image:      cfg.RemoteArtifactsContainerImage,
-			WorkingDir: fmt.Sprintf("$(params.%s-%s)", prefixParamsResultsVolumes, paramSourceRoot),
-			Command: []string{
-				"/bin/sh",
-			},
-			Args: []string{
-				"-e",
-				"-x",
-				"-c",
-				fmt.Sprintf("wget %q", source.URL),
-			},
+	// append the result
+	taskSpec.Results = append(taskSpec.Results, pipelineapi.TaskResult{
+		Name:        fmt.Sprintf("%s-source-%s-%s", prefixParamsResultsVolumes, source.Name, digestResult),
+		Description: "The digest of the downloaded remote artifact.",
+	})
+
+	// initialize the step from the template and the build-specific arguments
+	httpStep := pipelineapi.Step{
+		Name:            fmt.Sprintf("source-%s", source.Name),
+		Image:           cfg.HTTPContainerTemplate.Image,
+		ImagePullPolicy: cfg.HTTPContainerTemplate.ImagePullPolicy,
+		Command:         cfg.HTTPContainerTemplate.Command,
+		Args: []string{
+			"--url", source.URL,
+			"--target", fmt.Sprintf("$(params.%s-%s)", prefixParamsResultsVolumes, paramSourceRoot),
+			"--result-file-digest", fmt.Sprintf("$(results.%s-source-%s-%s.path)", prefixParamsResultsVolumes, source.Name, digestResult),
+		},
+		Env:              cfg.HTTPContainerTemplate.Env,
+		ComputeResources: cfg.HTTPContainerTemplate.Resources,
+		SecurityContext:  cfg.HTTPContainerTemplate.SecurityContext,
+		WorkingDir:       cfg.HTTPContainerTemplate.WorkingDir,
+	}
+
+	if source.SHA256 != nil && *source.SHA256 != "" {
+		httpStep.Args = append(httpStep.Args, "--sha256", *source.SHA256)
+	}
+
+	if source.Extract != nil && *source.Extract {
+		httpStep.Args = append(httpStep.Args, "--extract")
+
+		if source.StripComponents != nil && *source.StripComponents > 0 {
+			httpStep.Args = append(httpStep.Args, "--strip-components", strconv.Itoa(int(*source.StripComponents)))
+		}
+
+		// the limits of unpacking bundle images apply to extracted archives, too
+		if cfg.BundleUnpackLimits.MaxTotalSize > 0 {
+			httpStep.Args = append(httpStep.Args, "--max-total-size", strconv.FormatInt(cfg.BundleUnpackLimits.MaxTotalSize, 10))
+		}
+
+		if cfg.BundleUnpackLimits.MaxFileCount > 0 {
+			httpStep.Args = append(httpStep.Args, "--max-file-count", strconv.FormatInt(cfg.BundleUnpackLimits.MaxFileCount, 10))
 		}
 
-		// append the git step
-		taskSpec.Steps = append(taskSpec.Steps, httpStep)
+		if cfg.BundleUnpackLimits.MaxFileSize > 0 {
+			httpStep.Args = append(httpStep.Args, "--max-file-size", strconv.FormatInt(cfg.BundleUnpackLimits.MaxFileSize, 10))
+		}
+	}
+
+	if source.Retries != nil {
+		httpStep.Args = append(httpStep.Args, "--retries", strconv.Itoa(int(*source.Retries)))
+	}
+
+	// add the authentication secret mount, if provided
+	if source.AuthSecretRef != nil && source.AuthSecretRef.Name != "" {
+		AppendSecretVolume(taskSpec, source.AuthSecretRef.Name)
+
+		secretMountPath := fmt.Sprintf("/workspace/%s-source-%s-secret", prefixParamsResultsVolumes, source.Name)
+
+		// define the volume mount on the container
+		httpStep.VolumeMounts = append(httpStep.VolumeMounts, corev1.VolumeMount{
+			Name:      SanitizeVolumeNameForSecretName(source.AuthSecretRef.Name),
+			MountPath: secretMountPath,
+			ReadOnly:  true,
+		})
+
+		// append the argument
+		httpStep.Args = append(httpStep.Args, "--secret-path", secretMountPath)
 	}
+
+	taskSpec.Steps = append(taskSpec.Steps, httpStep)
 }
 
-func findExistingHTTPSourcesStep(taskSpec *pipelineapi.TaskSpec) *pipelineapi.Step {
-	for _, candidateStep := range taskSpec.Steps {
-		if candidateStep.Name == RemoteArtifactsContainerName {
-			return &candidateStep
-		}
+// AppendHTTPResult append HTTP source result to build run
+func AppendHTTPResult(buildRun *buildv1alpha1.BuildRun, name string, results []pipelineapi.TaskRunResult) {
+	digest := findResultValue(results, fmt.Sprintf("%s-source-%s-%s", prefixParamsResultsVolumes, name, digestResult))
+
+	if strings.TrimSpace(digest) != "" {
+		buildRun.Status.Sources = append(buildRun.Status.Sources, buildv1alpha1.SourceResult{
+			Name: name,
+			HTTP: &buildv1alpha1.HTTPSourceResult{
+				Digest: digest,
+			},
+		})
 	}
-	return nil
 }
@@ -8,6 +8,9 @@ import (
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/utils/pointer"
+
 	buildv1alpha1 "github.com/shipwright-io/build/pkg/apis/build/v1alpha1"
 	"github.com/shipwright-io/build/pkg/config"
 	"github.com/shipwright-io/build/pkg/reconciler/buildrun/resources/sources"
@@ -26,59 +29,75 @@ var _ = Describe("HTTP", func() {
 			taskSpec = &pipelineapi.TaskSpec{}
 		})
 
-		It("adds the first step", func() {
+		It("adds the step and the result", func() {
 			sources.AppendHTTPStep(cfg, taskSpec, buildv1alpha1.BuildSource{
 				Name: "logo",
 				URL:  "https://shipwright.io/icons/logo.svg",
 			})
 
+			Expect(len(taskSpec.Results)).To(Equal(1))
+			Expect(taskSpec.Results[0].Name).To(Equal("shp-source-logo-digest"))
+
 			Expect(len(taskSpec.Steps)).To(Equal(1))
-			Expect(taskSpec.Steps[0].Name).To(Equal(sources.RemoteArtifactsContainerName))
-			Expect(taskSpec.Steps[0].Image).To(Equal(cfg.RemoteArtifactsContainerImage))
-			Expect(taskSpec.Steps[0].WorkingDir).To(Equal("$(params.shp-source-root)"))
-			Expect(taskSpec.Steps[0].Args[3]).To(Equal("wget \"https://shipwright.io/icons/logo.svg\""))
+			Expect(taskSpec.Steps[0].Name).To(Equal("source-logo"))
+			Expect(taskSpec.Steps[0].Image).To(Equal(cfg.HTTPContainerTemplate.Image))
+			Expect(taskSpec.Steps[0].Command).To(Equal(cfg.HTTPContainerTemplate.Command))
+			Expect(taskSpec.Steps[0].Args).To(Equal([]string{
+				"--url", "https://shipwright.io/icons/logo.svg",
+				"--target", "$(params.shp-source-root)",
+				"--result-file-digest", "$(results.shp-source-logo-digest.path)",
+			}))
 		})
-	})
 
-	Context("when a TaskSpec already contains the http step", func() {
-		var taskSpec *pipelineapi.TaskSpec
+		It("adds the arguments for the checksum, extraction, and retries", func() {
+			sources.AppendHTTPStep(cfg, taskSpec, buildv1alpha1.BuildSource{
+				Name:            "archive",
+				Type:            buildv1alpha1.HTTP,
+				URL:             "https://example.com/archive.tar.gz",
+				SHA256:          pointer.String("sha256:8d3c0d1f4a2b4a4be0a51dc2c2e7ff0d86b6c9d2a7c19f0c7c4d7e3f8b9a6c1d"),
+				Extract:         pointer.Bool(true),
+				StripComponents: pointer.Int32(1),
+				Retries:         pointer.Int32(5),
+			})
 
-		BeforeEach(func() {
-			taskSpec = &pipelineapi.TaskSpec{
-				Steps: []pipelineapi.Step{
-					{
-						Name:       sources.RemoteArtifactsContainerName,
-						Image:      cfg.RemoteArtifactsContainerImage,
-						WorkingDir: "$(params.shp-source-root)",
-						Command: []string{
-							"/bin/sh",
-						},
-						Args: []string{
-							"-e",
-							"-x",
-							"-c",
-							"wget \"https://tekton.dev/images/tekton-horizontal-color.png\"",
-						},
-					},
-				},
-			}
+			Expect(len(taskSpec.Steps)).To(Equal(1))
+			Expect(taskSpec.Steps[0].Args).To(Equal([]string{
+				"--url", "https://example.com/archive.tar.gz",
+				"--target", "$(params.shp-source-root)",
+				"--result-file-digest", "$(results.shp-source-archive-digest.path)",
+				"--sha256", "sha256:8d3c0d1f4a2b4a4be0a51dc2c2e7ff0d86b6c9d2a7c19f0c7c4d7e3f8b9a6c1d",
+				"--extract",
+				"--strip-components", "1",
+				"--max-total-size", "10737418240",
+				"--max-file-count", "1000000",
+				"--retries", "5",
+			}))
 		})
 
-		It("updates the existing step", func() {
+		It("mounts the authentication secret", func() {
 			sources.AppendHTTPStep(cfg, taskSpec, buildv1alpha1.BuildSource{
-				Name: "logo",
-				URL:  "https://shipwright.io/icons/logo.svg",
+				Name: "archive",
+				Type: buildv1alpha1.HTTP,
+				URL:  "https://example.com/archive.tar.gz",
+				AuthSecretRef: &corev1.LocalObjectReference{
+					Name: "download-token",
+				},
 			})
 
+			Expect(len(taskSpec.Volumes)).To(Equal(1))
+			Expect(taskSpec.Volumes[0].Name).To(Equal("shp-download-token"))
+			Expect(taskSpec.Volumes[0].VolumeSource.Secret.SecretName).To(Equal("download-token"))
+
 			Expect(len(taskSpec.Steps)).To(Equal(1))
-			Expect(taskSpec.Steps[0].Name).To(Equal(sources.RemoteArtifactsContainerName))
-			Expect(taskSpec.Steps[0].Image).To(Equal(cfg.RemoteArtifactsContainerImage))
-			Expect(taskSpec.Steps[0].WorkingDir).To(Equal("$(params.shp-source-root)"))
-			Expect(taskSpec.Steps[0].Args[3]).To(Equal("wget \"https://tekton.dev/images/tekton-horizontal-color.png\" ; wget \"https://shipwright.io/icons/logo.svg\""))
+			Expect(len(taskSpec.Steps[0].VolumeMounts)).To(Equal(1))
+			Expect(taskSpec.Steps[0].VolumeMounts[0].Name).To(Equal("shp-download-token"))
+			Expect(taskSpec.Steps[0].VolumeMounts[0].MountPath).To(Equal("/workspace/shp-source-archive-secret"))
+			Expect(taskSpec.Steps[0].VolumeMounts[0].ReadOnly).To(BeTrue())
+			Expect(taskSpec.Steps[0].Args).To(ContainElements("--secret-path", "/workspace/shp-source-archive-secret"))
 		})
 	})
 
-	Context("when a TaskSpec already another source step step", func() {
+	Context("when a TaskSpec already contains another source step", func() {
 		var taskSpec *pipelineapi.TaskSpec
 
 		BeforeEach(func() {
@@ -98,10 +117,8 @@ var _ = Describe("HTTP", func() {
 			})
 
 			Expect(len(taskSpec.Steps)).To(Equal(2))
-			Expect(taskSpec.Steps[1].Name).To(Equal(sources.RemoteArtifactsContainerName))
-			Expect(taskSpec.Steps[1].Image).To(Equal(cfg.RemoteArtifactsContainerImage))
-			Expect(taskSpec.Steps[1].WorkingDir).To(Equal("$(params.shp-source-root)"))
-			Expect(taskSpec.Steps[1].Args[3]).To(Equal("wget \"https://shipwright.io/icons/logo.svg\""))
+			Expect(taskSpec.Steps[1].Name).To(Equal("source-logo"))
+			Expect(taskSpec.Steps[1].Image).To(Equal(cfg.HTTPContainerTemplate.Image))
 		})
 	})
 })
@@ -6,6 +6,7 @@ package sources
 import (
 	"fmt"
 
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	"github.com/shipwright-io/build/pkg/config"
@@ -15,9 +16,14 @@ import (
 // WaiterContainerName name given to the container watier container.
 const WaiterContainerName = "source-local"
 
+// WaiterUploadPort is the port the waiter serves the upload endpoint on, when an upload secret is
+// referenced by the source.
+const WaiterUploadPort = 8080
+
 // AppendLocalCopyStep defines and append a new task based on the waiter container template, passed
-// by the configuration instance.
-func AppendLocalCopyStep(cfg *config.Config, taskSpec *pipelineapi.TaskSpec, timeout *metav1.Duration) {
+// by the configuration instance. With an upload secret, the waiter also serves the upload endpoint
+// that authenticates with the token of the secret, and extracts the upload into the source root.
+func AppendLocalCopyStep(cfg *config.Config, taskSpec *pipelineapi.TaskSpec, timeout *metav1.Duration, uploadSecretRef *corev1.LocalObjectReference) {
 	step := pipelineapi.Step{
 		// the data upload mechanism targets a specific POD, and in this POD it aims for a specific
 		// container name, and having a static name, makes this process straight forward.
@@ -35,5 +41,41 @@ func AppendLocalCopyStep(cfg *config.Config, taskSpec *pipelineapi.TaskSpec, tim
 	if timeout != nil {
 		step.Args = append(step.Args, fmt.Sprintf("--timeout=%s", timeout.Duration.String()))
 	}
+
+	if uploadSecretRef != nil && uploadSecretRef.Name != "" {
+		secretMountPath := fmt.Sprintf("/workspace/%s-upload-secret", prefixParamsResultsVolumes)
+
+		AppendSecretVolume(taskSpec, uploadSecretRef.Name)
+		step.VolumeMounts = append(step.VolumeMounts, corev1.VolumeMount{
+			Name:      SanitizeVolumeNameForSecretName(uploadSecretRef.Name),
+			MountPath: secretMountPath,
+			ReadOnly:  true,
+		})
+
+		step.Args = append(
+			step.Args,
+			fmt.Sprintf("--upload-address=:%d", WaiterUploadPort),
+			fmt.Sprintf("--upload-token-file=%s/token", secretMountPath),
+			fmt.Sprintf("--upload-target=$(params.%s-%s)", prefixParamsResultsVolumes, paramSourceRoot),
+		)
+
+		// the limits of bundle images apply to uploads as well, the upload itself is limited to the
+		// total size, so that the temporary file does not exceed it either
+		if cfg.BundleUnpackLimits.MaxTotalSize > 0 {
+			step.Args = append(
+				step.Args,
+				fmt.Sprintf("--upload-max-size=%d", cfg.BundleUnpackLimits.MaxTotalSize),
+				fmt.Sprintf("--max-total-size=%d", cfg.BundleUnpackLimits.MaxTotalSize),
+			)
+		}
+
+		if cfg.BundleUnpackLimits.MaxFileCount > 0 {
+			step.Args = append(step.Args, fmt.Sprintf("--max-file-count=%d", cfg.BundleUnpackLimits.MaxFileCount))
+		}
+
+		if cfg.BundleUnpackLimits.MaxFileSize > 0 {
+			step.Args = append(step.Args, fmt.Sprintf("--max-file-size=%d", cfg.BundleUnpackLimits.MaxFileSize))
+		}
+	}
 	taskSpec.Steps = append(taskSpec.Steps, step)
 }
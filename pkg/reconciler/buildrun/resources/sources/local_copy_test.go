@@ -8,6 +8,7 @@ import (
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	pipelineapi "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
@@ -24,7 +25,7 @@ var _ = Describe("LocalCopy", func() {
 
 		BeforeEach(func() {
 			taskSpec = &pipelineapi.TaskSpec{}
-			sources.AppendLocalCopyStep(cfg, taskSpec, &metav1.Duration{Duration: time.Minute})
+			sources.AppendLocalCopyStep(cfg, taskSpec, &metav1.Duration{Duration: time.Minute}, nil)
 		})
 
 		It("produces a local-copy step", func() {
@@ -33,6 +34,40 @@ var _ = Describe("LocalCopy", func() {
 			Expect(taskSpec.Steps[0].Name).To(Equal(sources.WaiterContainerName))
 			Expect(taskSpec.Steps[0].Image).To(Equal(cfg.WaiterContainerTemplate.Image))
 			Expect(taskSpec.Steps[0].Args).To(Equal([]string{"start", "--timeout=1m0s"}))
+			Expect(len(taskSpec.Volumes)).To(Equal(0))
+		})
+	})
+
+	Context("when LocalCopy source type references an upload secret", func() {
+		var taskSpec *pipelineapi.TaskSpec
+
+		BeforeEach(func() {
+			taskSpec = &pipelineapi.TaskSpec{}
+			sources.AppendLocalCopyStep(cfg, taskSpec, nil, &corev1.LocalObjectReference{Name: "upload.token"})
+		})
+
+		It("adds a volume for the secret", func() {
+			Expect(len(taskSpec.Volumes)).To(Equal(1))
+			Expect(taskSpec.Volumes[0].Name).To(Equal("shp-upload-token"))
+			Expect(taskSpec.Volumes[0].VolumeSource.Secret).NotTo(BeNil())
+			Expect(taskSpec.Volumes[0].VolumeSource.Secret.SecretName).To(Equal("upload.token"))
+		})
+
+		It("serves the upload endpoint with the token of the secret and the bundle unpack limits", func() {
+			Expect(len(taskSpec.Steps)).To(Equal(1))
+			Expect(len(taskSpec.Steps[0].VolumeMounts)).To(Equal(1))
+			Expect(taskSpec.Steps[0].VolumeMounts[0].Name).To(Equal("shp-upload-token"))
+			Expect(taskSpec.Steps[0].VolumeMounts[0].MountPath).To(Equal("/workspace/shp-upload-secret"))
+			Expect(taskSpec.Steps[0].VolumeMounts[0].ReadOnly).To(BeTrue())
+			Expect(taskSpec.Steps[0].Args).To(Equal([]string{
+				"start",
+				"--upload-address=:8080",
+				"--upload-token-file=/workspace/shp-upload-secret/token",
+				"--upload-target=$(params.shp-source-root)",
+				"--upload-max-size=10737418240",
+				"--max-total-size=10737418240",
+				"--max-file-count=1000000",
+			}))
 		})
 	})
 })
@@ -9,6 +9,7 @@ import (
 	"regexp"
 	"strings"
 
+	buildv1alpha1 "github.com/shipwright-io/build/pkg/apis/build/v1alpha1"
 	pipelineapi "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/utils/pointer"
@@ -27,6 +28,38 @@ var (
 	secretMountMode = pointer.Int32(0444)
 )
 
+// SourcePathParamName returns the name of the parameter that holds the directory
+// of a named source
+func SourcePathParamName(name string) string {
+	return fmt.Sprintf("%s-source-%s-path", prefixParamsResultsVolumes, name)
+}
+
+// SourceEnvFileParamName returns the name of the parameter that holds the file
+// with the details of the commit of a Git source
+func SourceEnvFileParamName(name string) string {
+	if name == buildv1alpha1.DefaultSourceName {
+		return fmt.Sprintf("%s-source-env-file", prefixParamsResultsVolumes)
+	}
+
+	return fmt.Sprintf("%s-source-%s-env-file", prefixParamsResultsVolumes, name)
+}
+
+// SourceEnvFile returns the file the Git step writes the details of the commit
+// to, outside of the source directory so that it is not part of the build context
+func SourceEnvFile(name string) string {
+	return fmt.Sprintf("/workspace/%s-source-%s.env", prefixParamsResultsVolumes, name)
+}
+
+// sourceTarget returns the directory the source is fetched into, the default
+// source is fetched into the source root, and named sources into a subdirectory
+func sourceTarget(name string) string {
+	if name == buildv1alpha1.DefaultSourceName {
+		return fmt.Sprintf("$(params.%s-%s)", prefixParamsResultsVolumes, paramSourceRoot)
+	}
+
+	return fmt.Sprintf("$(params.%s)", SourcePathParamName(name))
+}
+
 // AppendSecretVolume checks if a volume for a secret already exists, if not it appends it to the TaskSpec
 func AppendSecretVolume(
 	taskSpec *pipelineapi.TaskSpec,
@@ -53,10 +86,77 @@ func AppendSecretVolume(
 	})
 }
 
+// AppendConfigMapVolume checks if a volume for a ConfigMap already exists, if not it appends it to the TaskSpec
+func AppendConfigMapVolume(
+	taskSpec *pipelineapi.TaskSpec,
+	configMapName string,
+) {
+	volumeName := SanitizeVolumeNameForConfigMapName(configMapName)
+
+	// ensure we do not add the config map twice
+	for _, volume := range taskSpec.Volumes {
+		if volume.VolumeSource.ConfigMap != nil && volume.Name == volumeName {
+			return
+		}
+	}
+
+	// append volume for config map
+	taskSpec.Volumes = append(taskSpec.Volumes, corev1.Volume{
+		Name: volumeName,
+		VolumeSource: corev1.VolumeSource{
+			ConfigMap: &corev1.ConfigMapVolumeSource{
+				LocalObjectReference: corev1.LocalObjectReference{
+					Name: configMapName,
+				},
+				DefaultMode: secretMountMode,
+			},
+		},
+	})
+}
+
+// AppendPersistentVolumeClaimVolume checks if a volume for a PersistentVolumeClaim already exists, if not it appends it to the TaskSpec
+func AppendPersistentVolumeClaimVolume(
+	taskSpec *pipelineapi.TaskSpec,
+	claimName string,
+) {
+	volumeName := SanitizeVolumeNameForPersistentVolumeClaimName(claimName)
+
+	// ensure we do not add the claim twice
+	for _, volume := range taskSpec.Volumes {
+		if volume.VolumeSource.PersistentVolumeClaim != nil && volume.Name == volumeName {
+			return
+		}
+	}
+
+	// append volume for persistent volume claim
+	taskSpec.Volumes = append(taskSpec.Volumes, corev1.Volume{
+		Name: volumeName,
+		VolumeSource: corev1.VolumeSource{
+			PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+				ClaimName: claimName,
+			},
+		},
+	})
+}
+
 // SanitizeVolumeNameForSecretName creates the name of a Volume for a Secret
 func SanitizeVolumeNameForSecretName(secretName string) string {
+	return sanitizeVolumeName(fmt.Sprintf("%s-%s", prefixParamsResultsVolumes, secretName))
+}
+
+// SanitizeVolumeNameForConfigMapName creates the name of a Volume for a ConfigMap
+func SanitizeVolumeNameForConfigMapName(configMapName string) string {
+	return sanitizeVolumeName(fmt.Sprintf("%s-configmap-%s", prefixParamsResultsVolumes, configMapName))
+}
+
+// SanitizeVolumeNameForPersistentVolumeClaimName creates the name of a Volume for a PersistentVolumeClaim
+func SanitizeVolumeNameForPersistentVolumeClaimName(claimName string) string {
+	return sanitizeVolumeName(fmt.Sprintf("%s-pvc-%s", prefixParamsResultsVolumes, claimName))
+}
+
+func sanitizeVolumeName(name string) string {
 	// remove forbidden characters
-	sanitizedName := dnsLabel1123Forbidden.ReplaceAllString(fmt.Sprintf("%s-%s", prefixParamsResultsVolumes, secretName), "-")
+	sanitizedName := dnsLabel1123Forbidden.ReplaceAllString(name, "-")
 
 	// ensure maximum length
 	if len(sanitizedName) > 63 {
@@ -31,6 +31,14 @@ var _ = Describe("Utils", func() {
 			// "shp-" + "abcdefghijklmnopqrstuvwxyz-abcdefghijklmnopqrstuvwxyz-abcd-efgh" reduced to 63 characters would be "shp-abcdefghijklmnopqrstuvwxyz-abcdefghijklmnopqrstuvwxyz-abcd-"
 			Expect(sources.SanitizeVolumeNameForSecretName("abcdefghijklmnopqrstuvwxyz-abcdefghijklmnopqrstuvwxyz-abcd-efgh")).To(Equal("shp-abcdefghijklmnopqrstuvwxyz-abcdefghijklmnopqrstuvwxyz-abcd"))
 		})
+
+		It("adds a different prefix for config maps", func() {
+			Expect(sources.SanitizeVolumeNameForConfigMapName("bad.name")).To(Equal("shp-configmap-bad-name"))
+		})
+
+		It("adds a different prefix for persistent volume claims", func() {
+			Expect(sources.SanitizeVolumeNameForPersistentVolumeClaimName("git.cache")).To(Equal("shp-pvc-git-cache"))
+		})
 	})
 
 	Context("when a TaskSpec does not contain any volume", func() {
@@ -82,5 +90,14 @@ var _ = Describe("Utils", func() {
 
 			Expect(len(taskSpec.Volumes)).To(Equal(1))
 		})
+
+		It("adds a config map with the same name as the secret", func() {
+			sources.AppendConfigMapVolume(taskSpec, "a-secret")
+
+			Expect(len(taskSpec.Volumes)).To(Equal(2))
+			Expect(taskSpec.Volumes[1].Name).To(Equal("shp-configmap-a-secret"))
+			Expect(taskSpec.Volumes[1].VolumeSource.ConfigMap).NotTo(BeNil())
+			Expect(taskSpec.Volumes[1].VolumeSource.ConfigMap.Name).To(Equal("a-secret"))
+		})
 	})
 })
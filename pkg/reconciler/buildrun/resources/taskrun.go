@@ -16,6 +16,7 @@ import (
 	buildv1alpha1 "github.com/shipwright-io/build/pkg/apis/build/v1alpha1"
 	"github.com/shipwright-io/build/pkg/config"
 	"github.com/shipwright-io/build/pkg/env"
+	"github.com/shipwright-io/build/pkg/reconciler/buildrun/resources/sources"
 	"github.com/shipwright-io/build/pkg/reconciler/buildrun/resources/steps"
 	"github.com/shipwright-io/build/pkg/volumes"
 	pipelineapi "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
@@ -117,6 +118,24 @@ func GenerateTaskSpec(
 		},
 	}
 
+	// the directory of each named source is available as parameter
+	for _, source := range namedSources(build) {
+		generatedTaskSpec.Params = append(generatedTaskSpec.Params, pipelineapi.ParamSpec{
+			Name:        sources.SourcePathParamName(source.Name),
+			Description: fmt.Sprintf("The directory of the source %s", source.Name),
+			Type:        pipelineapi.ParamTypeString,
+		})
+	}
+
+	// the file with the commit details of each Git source is available as parameter
+	for _, name := range gitSourceNames(build) {
+		generatedTaskSpec.Params = append(generatedTaskSpec.Params, pipelineapi.ParamSpec{
+			Name:        sources.SourceEnvFileParamName(name),
+			Description: fmt.Sprintf("The file with the commit details of the source %s", name),
+			Type:        pipelineapi.ParamTypeString,
+		})
+	}
+
 	generatedTaskSpec.Results = append(getTaskSpecResults(), getFailureDetailsTaskSpecResults()...)
 
 	if build.Spec.Builder != nil {
@@ -392,6 +411,26 @@ func GenerateTaskRun(
 		})
 	}
 
+	for _, source := range namedSources(build) {
+		params = append(params, pipelineapi.Param{
+			Name: sources.SourcePathParamName(source.Name),
+			Value: pipelineapi.ParamValue{
+				Type:      pipelineapi.ParamTypeString,
+				StringVal: namedSourceDirectory(source),
+			},
+		})
+	}
+
+	for _, name := range gitSourceNames(build) {
+		params = append(params, pipelineapi.Param{
+			Name: sources.SourceEnvFileParamName(name),
+			Value: pipelineapi.ParamValue{
+				Type:      pipelineapi.ParamTypeString,
+				StringVal: sources.SourceEnvFile(name),
+			},
+		})
+	}
+
 	expectedTaskRun.Spec.Params = params
 
 	// Ensure a proper override of params between Build and BuildRun
@@ -17,6 +17,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
 
 	buildv1alpha1 "github.com/shipwright-io/build/pkg/apis/build/v1alpha1"
 	"github.com/shipwright-io/build/pkg/config"
@@ -94,6 +95,10 @@ var _ = Describe("GenerateTaskrun", func() {
 					"$(results.shp-source-default-commit-author.path)",
 					"--result-file-branch-name",
 					"$(results.shp-source-default-branch-name.path)",
+					"--result-file-commit-details",
+					"$(results.shp-source-default-commit-details.path)",
+					"--env-file",
+					"/workspace/shp-source-default.env",
 					"--result-file-error-message",
 					"$(results.shp-error-message.path)",
 					"--result-file-error-reason",
@@ -143,13 +148,14 @@ var _ = Describe("GenerateTaskrun", func() {
 				Expect(got.Params).To(utils.ContainNamedElement("shp-source-context"))
 				Expect(got.Params).To(utils.ContainNamedElement("shp-output-image"))
 				Expect(got.Params).To(utils.ContainNamedElement("shp-output-insecure"))
+				Expect(got.Params).To(utils.ContainNamedElement("shp-source-env-file"))
 
 				// legacy params
 				Expect(got.Params).ToNot(utils.ContainNamedElement("BUILDER_IMAGE")) // test build has no builder image
 				Expect(got.Params).To(utils.ContainNamedElement("CONTEXT_DIR"))
 				Expect(got.Params).To(utils.ContainNamedElement("DOCKERFILE"))
 
-				Expect(len(got.Params)).To(Equal(6))
+				Expect(len(got.Params)).To(Equal(7))
 			})
 
 			It("should contain a step to mutate the image with single mutate args", func() {
@@ -224,6 +230,95 @@ var _ = Describe("GenerateTaskrun", func() {
 			})
 		})
 
+		Context("when named sources are defined", func() {
+			var taskRun *pipelineapi.TaskRun
+
+			BeforeEach(func() {
+				build, err = ctl.LoadBuildYAML([]byte(test.MinimalBuildahBuild))
+				Expect(err).To(BeNil())
+
+				build.Spec.Sources = []buildv1alpha1.BuildSource{
+					{
+						Name: "config",
+						Type: buildv1alpha1.Git,
+						Source: &buildv1alpha1.Source{
+							URL: pointer.String("https://github.com/shipwright-io/sample-config"),
+						},
+					},
+					{
+						Name: "vendor",
+						Type: buildv1alpha1.Bundle,
+						Source: &buildv1alpha1.Source{
+							BundleContainer: &buildv1alpha1.BundleContainer{
+								Image: "ghcr.io/shipwright-io/sample-vendor:latest",
+							},
+						},
+					},
+					{
+						Name: "logo",
+						Type: buildv1alpha1.HTTP,
+						URL:  "https://shipwright.io/icons/logo.svg",
+					},
+				}
+
+				buildRun, err = ctl.LoadBuildRunFromBytes([]byte(test.MinimalBuildahBuildRun))
+				Expect(err).To(BeNil())
+
+				buildStrategy, err = ctl.LoadBuildStrategyFromBytes([]byte(test.MinimalBuildahBuildStrategy))
+				Expect(err).To(BeNil())
+			})
+
+			JustBeforeEach(func() {
+				taskRun, err = resources.GenerateTaskRun(config.NewDefaultConfig(), build, buildRun, "", buildStrategy)
+				Expect(err).ToNot(HaveOccurred())
+				got = taskRun.Spec.TaskSpec
+			})
+
+			It("should contain a step for every source that fetches it into its own directory", func() {
+				Expect(got.Steps[0].Name).To(Equal("source-default"))
+				Expect(got.Steps[0].Args).To(ContainElements("--target", "$(params.shp-source-root)"))
+
+				Expect(got.Steps[1].Name).To(Equal("source-config"))
+				Expect(got.Steps[1].Command[0]).To(Equal("/ko-app/git"))
+				Expect(got.Steps[1].Args).To(ContainElements("--target", "$(params.shp-source-config-path)"))
+
+				Expect(got.Steps[2].Name).To(Equal("source-vendor"))
+				Expect(got.Steps[2].Command[0]).To(Equal("/ko-app/bundle"))
+				Expect(got.Steps[2].Args).To(ContainElements("--target", "$(params.shp-source-vendor-path)"))
+			})
+
+			It("should download HTTP sources into the source root", func() {
+				Expect(got.Steps[3].Name).To(Equal("source-logo"))
+				Expect(got.Steps[3].Args).To(ContainElements("--target", "$(params.shp-source-root)"))
+
+				values := map[string]string{}
+				for _, param := range taskRun.Spec.Params {
+					values[param.Name] = param.Value.StringVal
+				}
+
+				Expect(values).To(HaveKeyWithValue("shp-source-logo-path", "/workspace/source"))
+			})
+
+			It("should contain results for every source", func() {
+				Expect(got.Results).To(utils.ContainNamedElement("shp-source-default-commit-sha"))
+				Expect(got.Results).To(utils.ContainNamedElement("shp-source-config-commit-sha"))
+				Expect(got.Results).To(utils.ContainNamedElement("shp-source-vendor-image-digest"))
+			})
+
+			It("should contain a parameter with the directory of every named source", func() {
+				Expect(got.Params).To(utils.ContainNamedElement("shp-source-config-path"))
+				Expect(got.Params).To(utils.ContainNamedElement("shp-source-vendor-path"))
+
+				values := map[string]string{}
+				for _, param := range taskRun.Spec.Params {
+					values[param.Name] = param.Value.StringVal
+				}
+
+				Expect(values).To(HaveKeyWithValue("shp-source-config-path", "/workspace/source/config"))
+				Expect(values).To(HaveKeyWithValue("shp-source-vendor-path", "/workspace/source/vendor"))
+			})
+		})
+
 		Context("when env vars are defined", func() {
 			BeforeEach(func() {
 				build, err = ctl.LoadBuildYAML([]byte(test.MinimalBuildahBuild))
@@ -513,6 +608,7 @@ var _ = Describe("GenerateTaskrun", func() {
 				paramSourceContextFound := false
 				paramOutputImageFound := false
 				paramOutputInsecureFound := false
+				paramSourceEnvFileFound := false
 
 				// legacy params
 				paramBuilderImageFound := false
@@ -537,6 +633,10 @@ var _ = Describe("GenerateTaskrun", func() {
 						paramOutputInsecureFound = true
 						Expect(param.Value.StringVal).To(Equal("false"))
 
+					case "shp-source-env-file":
+						paramSourceEnvFileFound = true
+						Expect(param.Value.StringVal).To(Equal("/workspace/shp-source-default.env"))
+
 					case "BUILDER_IMAGE":
 						paramBuilderImageFound = true
 						Expect(param.Value.StringVal).To(Equal(builderImage.Image))
@@ -558,6 +658,7 @@ var _ = Describe("GenerateTaskrun", func() {
 				Expect(paramSourceContextFound).To(BeTrue())
 				Expect(paramOutputImageFound).To(BeTrue())
 				Expect(paramOutputInsecureFound).To(BeTrue())
+				Expect(paramSourceEnvFileFound).To(BeTrue())
 
 				Expect(paramBuilderImageFound).To(BeTrue())
 				Expect(paramDockerfileFound).To(BeTrue())
@@ -0,0 +1,235 @@
+// Copyright The Shipwright Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package commitstatus
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	build "github.com/shipwright-io/build/pkg/apis/build/v1alpha1"
+	"github.com/shipwright-io/build/pkg/config"
+	"github.com/shipwright-io/build/pkg/ctxlog"
+	"github.com/shipwright-io/build/pkg/git"
+	"github.com/shipwright-io/build/pkg/trigger"
+	"github.com/shipwright-io/build/pkg/trigger/commitstatus"
+)
+
+type newReporterFunc func(triggerType build.TriggerType, host string, apiURL *string, token string) (commitstatus.Reporter, error)
+
+// ReconcileCommitStatus reports the state of BuildRuns triggered by Git events
+// as commit status to the Git provider
+type ReconcileCommitStatus struct {
+	// This client, initialized using mgr.Client() above, is a split client
+	// that reads objects from the cache and writes to the apiserver
+	config      *config.Config
+	client      client.Client
+	apiServer   string
+	newReporter newReporterFunc
+}
+
+// NewReconciler returns a new reconcile.Reconciler
+func NewReconciler(c *config.Config, mgr manager.Manager, newReporter newReporterFunc) reconcile.Reconciler {
+	var apiServer string
+	if restConfig := mgr.GetConfig(); restConfig != nil {
+		apiServer = restConfig.Host
+	}
+
+	return &ReconcileCommitStatus{
+		config:      c,
+		client:      mgr.GetClient(),
+		apiServer:   apiServer,
+		newReporter: newReporter,
+	}
+}
+
+// Reconcile reports the state of the BuildRun when it changed since it was last
+// reported. The reported state is recorded in an annotation of the BuildRun.
+func (r *ReconcileCommitStatus) Reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
+	// Set the ctx to be Background, as the top-level context for incoming requests.
+	ctx, cancel := context.WithTimeout(ctx, r.config.CtxTimeOut)
+	defer cancel()
+
+	ctxlog.Debug(ctx, "start reconciling commit status", namespace, request.Namespace, name, request.Name)
+
+	buildRun := &build.BuildRun{}
+	if err := r.client.Get(ctx, request.NamespacedName, buildRun); err != nil {
+		if apierrors.IsNotFound(err) {
+			ctxlog.Debug(ctx, "finish reconciling commit status. BuildRun was not found", namespace, request.Namespace, name, request.Name)
+			return reconcile.Result{}, nil
+		}
+		return reconcile.Result{}, err
+	}
+
+	state := commitState(buildRun)
+	if !reportsCommitStatus(buildRun) || buildRun.Annotations[build.AnnotationBuildRunCommitStatus] == string(state) {
+		return reconcile.Result{}, nil
+	}
+
+	b := &build.Build{}
+	if err := r.client.Get(ctx, types.NamespacedName{Namespace: buildRun.Namespace, Name: buildRun.Spec.BuildRef.Name}, b); err != nil {
+		if apierrors.IsNotFound(err) {
+			return reconcile.Result{}, nil
+		}
+		return reconcile.Result{}, err
+	}
+
+	if b.Spec.Trigger == nil || b.Spec.Trigger.CommitStatus == nil || b.Spec.Source.URL == nil {
+		return reconcile.Result{}, nil
+	}
+
+	host, repository, ok := trigger.SplitRepositoryURL(*b.Spec.Source.URL)
+	if !ok {
+		ctxlog.Info(ctx, "skipping commit status, the repository URL is not supported", namespace, b.Namespace, name, b.Name, "url", *b.Spec.Source.URL)
+		return reconcile.Result{}, nil
+	}
+
+	triggerType := build.TriggerType(buildRun.Annotations[build.AnnotationBuildRunTriggerType])
+	token, err := r.token(ctx, b, triggerType, host)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+
+	reporter, err := r.newReporter(triggerType, host, b.Spec.Trigger.CommitStatus.APIURL, token)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+
+	statusContext := fmt.Sprintf("shipwright/%s", b.Name)
+	if b.Spec.Trigger.CommitStatus.Context != nil && *b.Spec.Trigger.CommitStatus.Context != "" {
+		statusContext = *b.Spec.Trigger.CommitStatus.Context
+	}
+
+	if err := reporter.Report(ctx, repository, commitstatus.Status{
+		Revision:    *buildRun.Spec.Revision,
+		State:       state,
+		Context:     statusContext,
+		Description: description(buildRun, state),
+		TargetURL:   r.targetURL(buildRun),
+	}); err != nil {
+		return reconcile.Result{}, err
+	}
+
+	ctxlog.Info(ctx, "reported commit status", namespace, buildRun.Namespace, name, buildRun.Name, "revision", *buildRun.Spec.Revision, "state", state)
+
+	patch := client.MergeFrom(buildRun.DeepCopy())
+	if buildRun.Annotations == nil {
+		buildRun.Annotations = map[string]string{}
+	}
+	buildRun.Annotations[build.AnnotationBuildRunCommitStatus] = string(state)
+	if err := r.client.Patch(ctx, buildRun, patch); err != nil {
+		return reconcile.Result{}, err
+	}
+
+	ctxlog.Debug(ctx, "finish reconciling commit status", namespace, request.Namespace, name, request.Name)
+	return reconcile.Result{}, nil
+}
+
+// token returns the API token of the secret, for GitHub the secret can contain
+// the credentials of a GitHub App instead, which are exchanged for an installation token
+func (r *ReconcileCommitStatus) token(ctx context.Context, b *build.Build, triggerType build.TriggerType, host string) (string, error) {
+	secret := &corev1.Secret{}
+	if err := r.client.Get(ctx, types.NamespacedName{Namespace: b.Namespace, Name: b.Spec.Trigger.CommitStatus.SecretRef.Name}, secret); err != nil {
+		return "", err
+	}
+
+	if triggerType == build.GitHubWebHookTrigger && git.HasGitHubAppCredentials(secret.Data) {
+		app, err := git.NewGitHubApp(secret.Data, commitstatus.GitHubAPIURL(host, b.Spec.Trigger.CommitStatus.APIURL))
+		if err != nil {
+			return "", err
+		}
+
+		return app.InstallationToken(ctx)
+	}
+
+	token, ok := secret.Data[commitstatus.SecretTokenKey]
+	if !ok || len(token) == 0 {
+		return "", fmt.Errorf("secret %s/%s does not contain the key %q", secret.Namespace, secret.Name, commitstatus.SecretTokenKey)
+	}
+
+	return string(token), nil
+}
+
+// targetURL returns the configured link to the BuildRun, or its URL in the Kubernetes API
+func (r *ReconcileCommitStatus) targetURL(buildRun *build.BuildRun) string {
+	if r.config.Triggers.CommitStatusTargetURL != "" {
+		return strings.NewReplacer("{namespace}", buildRun.Namespace, "{name}", buildRun.Name).Replace(r.config.Triggers.CommitStatusTargetURL)
+	}
+
+	if r.apiServer == "" {
+		return ""
+	}
+
+	return fmt.Sprintf("%s/apis/shipwright.io/v1beta1/namespaces/%s/buildruns/%s", strings.TrimSuffix(r.apiServer, "/"), buildRun.Namespace, buildRun.Name)
+}
+
+// reportsCommitStatus checks whether the BuildRun was created for a commit by a
+// trigger type that supports commit statuses
+func reportsCommitStatus(buildRun *build.BuildRun) bool {
+	if buildRun.Spec.Revision == nil || *buildRun.Spec.Revision == "" || buildRun.Spec.BuildRef == nil {
+		return false
+	}
+
+	switch build.TriggerType(buildRun.Annotations[build.AnnotationBuildRunTriggerType]) {
+	case build.GitHubWebHookTrigger, build.GitLabWebHookTrigger:
+		return true
+	default:
+		return false
+	}
+}
+
+// commitState maps the state of the BuildRun to a commit status state
+func commitState(buildRun *build.BuildRun) commitstatus.State {
+	switch {
+	case buildRun.IsSuccessful():
+		return commitstatus.StateSuccess
+
+	case buildRun.IsDone() && buildRun.IsCanceled():
+		return commitstatus.StateCanceled
+
+	case buildRun.IsDone():
+		return commitstatus.StateFailure
+
+	case buildRun.HasStarted():
+		return commitstatus.StateRunning
+
+	default:
+		return commitstatus.StatePending
+	}
+}
+
+// description returns the commit status description, for failures it contains
+// the reason and message of the failure
+func description(buildRun *build.BuildRun, state commitstatus.State) string {
+	switch state {
+	case commitstatus.StateSuccess:
+		return fmt.Sprintf("BuildRun %s succeeded", buildRun.Name)
+
+	case commitstatus.StateCanceled:
+		return fmt.Sprintf("BuildRun %s was canceled", buildRun.Name)
+
+	case commitstatus.StateFailure:
+		if details := buildRun.Status.FailureDetails; details != nil && details.Reason != "" {
+			return fmt.Sprintf("%s: %s", details.Reason, details.Message)
+		}
+		if condition := buildRun.Status.GetCondition(build.Succeeded); condition != nil {
+			return fmt.Sprintf("%s: %s", condition.GetReason(), condition.GetMessage())
+		}
+		return fmt.Sprintf("BuildRun %s failed", buildRun.Name)
+
+	case commitstatus.StateRunning:
+		return fmt.Sprintf("BuildRun %s is running", buildRun.Name)
+
+	default:
+		return fmt.Sprintf("BuildRun %s is pending", buildRun.Name)
+	}
+}
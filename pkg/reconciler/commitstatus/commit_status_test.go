@@ -0,0 +1,210 @@
+// Copyright The Shipwright Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package commitstatus_test
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/rest"
+	"k8s.io/utils/pointer"
+	crc "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	build "github.com/shipwright-io/build/pkg/apis/build/v1alpha1"
+	"github.com/shipwright-io/build/pkg/config"
+	"github.com/shipwright-io/build/pkg/controller/fakes"
+	"github.com/shipwright-io/build/pkg/git"
+	"github.com/shipwright-io/build/pkg/reconciler/commitstatus"
+	reporter "github.com/shipwright-io/build/pkg/trigger/commitstatus"
+)
+
+type recordingReporter struct {
+	repository string
+	statuses   []reporter.Status
+}
+
+func (r *recordingReporter) Report(_ context.Context, repository string, status reporter.Status) error {
+	r.repository = repository
+	r.statuses = append(r.statuses, status)
+	return nil
+}
+
+var _ = Describe("Reconcile Commit Status", func() {
+	var (
+		manager        *fakes.FakeManager
+		client         *fakes.FakeClient
+		cfg            *config.Config
+		reconciler     reconcile.Reconciler
+		request        reconcile.Request
+		buildSample    *build.Build
+		buildRunSample *build.BuildRun
+		recorder       *recordingReporter
+		reporterType   build.TriggerType
+		reporterHost   string
+		reporterToken  string
+		secretData     map[string][]byte
+	)
+
+	BeforeEach(func() {
+		request = reconcile.Request{NamespacedName: types.NamespacedName{Name: "a-build-abcde", Namespace: "a-namespace"}}
+		recorder = &recordingReporter{}
+		secretData = map[string][]byte{reporter.SecretTokenKey: []byte("s3cr3t")}
+		cfg = config.NewDefaultConfig()
+
+		buildSample = &build.Build{
+			ObjectMeta: metav1.ObjectMeta{Name: "a-build", Namespace: "a-namespace"},
+			Spec: build.BuildSpec{
+				Source: build.Source{URL: pointer.String("https://github.com/shipwright-io/sample-go")},
+				Trigger: &build.Trigger{
+					CommitStatus: &build.CommitStatus{SecretRef: corev1.LocalObjectReference{Name: "status-token"}},
+				},
+			},
+		}
+
+		buildRunSample = &build.BuildRun{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        "a-build-abcde",
+				Namespace:   "a-namespace",
+				Annotations: map[string]string{build.AnnotationBuildRunTriggerType: string(build.GitHubWebHookTrigger)},
+			},
+			Spec: build.BuildRunSpec{
+				BuildRef: &build.BuildRef{Name: "a-build"},
+				Revision: pointer.String("0a1b2c3d4e5f60718293a4b5c6d7e8f901234567"),
+			},
+		}
+
+		manager = &fakes.FakeManager{}
+		client = &fakes.FakeClient{}
+		manager.GetClientReturns(client)
+		manager.GetConfigReturns(&rest.Config{Host: "https://kubernetes.example.com:6443"})
+
+		client.GetCalls(func(_ context.Context, nn types.NamespacedName, object crc.Object, _ ...crc.GetOption) error {
+			switch o := object.(type) {
+			case *build.Build:
+				buildSample.DeepCopyInto(o)
+				return nil
+			case *build.BuildRun:
+				buildRunSample.DeepCopyInto(o)
+				return nil
+			case *corev1.Secret:
+				o.Data = secretData
+				return nil
+			}
+			return k8serrors.NewNotFound(schema.GroupResource{}, nn.Name)
+		})
+	})
+
+	JustBeforeEach(func() {
+		reconciler = commitstatus.NewReconciler(cfg, manager, func(triggerType build.TriggerType, host string, _ *string, token string) (reporter.Reporter, error) {
+			reporterType, reporterHost, reporterToken = triggerType, host, token
+			return recorder, nil
+		})
+	})
+
+	patchedBuildRun := func() *build.BuildRun {
+		Expect(client.PatchCallCount()).To(Equal(1))
+		_, object, _, _ := client.PatchArgsForCall(0)
+		return object.(*build.BuildRun)
+	}
+
+	It("should report a pending status linking to the BuildRun", func() {
+		_, err := reconciler.Reconcile(context.TODO(), request)
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(reporterType).To(Equal(build.GitHubWebHookTrigger))
+		Expect(reporterHost).To(Equal("github.com"))
+		Expect(reporterToken).To(Equal("s3cr3t"))
+		Expect(recorder.repository).To(Equal("shipwright-io/sample-go"))
+		Expect(recorder.statuses).To(HaveLen(1))
+		Expect(recorder.statuses[0].State).To(Equal(reporter.StatePending))
+		Expect(recorder.statuses[0].Revision).To(Equal("0a1b2c3d4e5f60718293a4b5c6d7e8f901234567"))
+		Expect(recorder.statuses[0].Context).To(Equal("shipwright/a-build"))
+		Expect(recorder.statuses[0].TargetURL).To(Equal("https://kubernetes.example.com:6443/apis/shipwright.io/v1beta1/namespaces/a-namespace/buildruns/a-build-abcde"))
+
+		Expect(patchedBuildRun().Annotations).To(HaveKeyWithValue(build.AnnotationBuildRunCommitStatus, "pending"))
+	})
+
+	It("should report the failure details of a failed BuildRun", func() {
+		cfg.Triggers.CommitStatusTargetURL = "https://console.example.com/{namespace}/{name}"
+		buildSample.Spec.Trigger.CommitStatus.Context = pointer.String("ci/shipwright")
+		buildRunSample.Annotations[build.AnnotationBuildRunCommitStatus] = "running"
+		buildRunSample.Status.Conditions = build.Conditions{{Type: build.Succeeded, Status: corev1.ConditionFalse, Reason: "Failed"}}
+		buildRunSample.Status.FailureDetails = &build.FailureDetails{Reason: "DockerfileNotFound", Message: "no Dockerfile in the context directory"}
+
+		_, err := reconciler.Reconcile(context.TODO(), request)
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(recorder.statuses).To(HaveLen(1))
+		Expect(recorder.statuses[0].State).To(Equal(reporter.StateFailure))
+		Expect(recorder.statuses[0].Description).To(Equal("DockerfileNotFound: no Dockerfile in the context directory"))
+		Expect(recorder.statuses[0].Context).To(Equal("ci/shipwright"))
+		Expect(recorder.statuses[0].TargetURL).To(Equal("https://console.example.com/a-namespace/a-build-abcde"))
+		Expect(patchedBuildRun().Annotations).To(HaveKeyWithValue(build.AnnotationBuildRunCommitStatus, "failure"))
+	})
+
+	It("should report using an installation token of a GitHub App", func() {
+		privateKey, err := rsa.GenerateKey(rand.Reader, 2048)
+		Expect(err).ToNot(HaveOccurred())
+
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			Expect(r.URL.Path).To(Equal("/app/installations/67890/access_tokens"))
+			w.WriteHeader(http.StatusCreated)
+			_, _ = w.Write([]byte(`{"token":"ghs_installation"}`))
+		}))
+		defer server.Close()
+
+		buildSample.Spec.Trigger.CommitStatus.APIURL = pointer.String(server.URL)
+		secretData = map[string][]byte{
+			git.GitHubAppIDKey:             []byte("12345"),
+			git.GitHubAppInstallationIDKey: []byte("67890"),
+			git.GitHubAppPrivateKeyKey:     pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(privateKey)}),
+		}
+
+		_, err = reconciler.Reconcile(context.TODO(), request)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(reporterToken).To(Equal("ghs_installation"))
+		Expect(recorder.statuses).To(HaveLen(1))
+	})
+
+	It("should not report the same state twice", func() {
+		buildRunSample.Annotations[build.AnnotationBuildRunCommitStatus] = "success"
+		buildRunSample.Status.Conditions = build.Conditions{{Type: build.Succeeded, Status: corev1.ConditionTrue}}
+
+		_, err := reconciler.Reconcile(context.TODO(), request)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(recorder.statuses).To(BeEmpty())
+		Expect(client.PatchCallCount()).To(BeZero())
+	})
+
+	It("should not report BuildRuns that were not triggered by a GitHub or GitLab event", func() {
+		buildRunSample.Annotations[build.AnnotationBuildRunTriggerType] = string(build.ImageTrigger)
+
+		_, err := reconciler.Reconcile(context.TODO(), request)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(recorder.statuses).To(BeEmpty())
+	})
+
+	It("should not report if the Build does not configure commit statuses", func() {
+		buildSample.Spec.Trigger.CommitStatus = nil
+
+		_, err := reconciler.Reconcile(context.TODO(), request)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(recorder.statuses).To(BeEmpty())
+	})
+})
@@ -0,0 +1,258 @@
+// Copyright The Shipwright Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package imagetrigger
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	imagename "github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	build "github.com/shipwright-io/build/pkg/apis/build/v1alpha1"
+	"github.com/shipwright-io/build/pkg/config"
+	"github.com/shipwright-io/build/pkg/ctxlog"
+	"github.com/shipwright-io/build/pkg/image"
+	"github.com/shipwright-io/build/pkg/trigger"
+)
+
+// ImageChangeEvent is the event name used for BuildRuns created due to a changed image digest
+const ImageChangeEvent = "ImageChange"
+
+type resolveDigestFunc func(ctx context.Context, imageName string, dockerConfigJSON []byte) (string, error)
+
+// ReconcileImageTrigger reconciles the Image triggers of a Build object
+type ReconcileImageTrigger struct {
+	// This client, initialized using mgr.Client() above, is a split client
+	// that reads objects from the cache and writes to the apiserver
+	config        *config.Config
+	client        client.Client
+	resolveDigest resolveDigestFunc
+}
+
+// NewReconciler returns a new reconcile.Reconciler
+func NewReconciler(c *config.Config, mgr manager.Manager, resolve resolveDigestFunc) reconcile.Reconciler {
+	return &ReconcileImageTrigger{
+		config:        c,
+		client:        mgr.GetClient(),
+		resolveDigest: resolve,
+	}
+}
+
+// Reconcile resolves the images listed in the Image triggers of a Build. When the
+// digest of an image changed since it was last observed, a BuildRun is created. The
+// first observation of an image only records its digest.
+func (r *ReconcileImageTrigger) Reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
+	// Set the ctx to be Background, as the top-level context for incoming requests.
+	ctx, cancel := context.WithTimeout(ctx, r.config.CtxTimeOut)
+	defer cancel()
+
+	ctxlog.Debug(ctx, "start reconciling image triggers", namespace, request.Namespace, name, request.Name)
+
+	b := &build.Build{}
+	if err := r.client.Get(ctx, request.NamespacedName, b); err != nil {
+		if apierrors.IsNotFound(err) {
+			ctxlog.Debug(ctx, "finish reconciling image triggers. Build was not found", namespace, request.Namespace, name, request.Name)
+			return reconcile.Result{}, nil
+		}
+		return reconcile.Result{}, err
+	}
+
+	whens := trigger.TriggersOfType(b, build.ImageTrigger)
+	if len(whens) == 0 {
+		// drop the status of images that are no longer watched
+		if b.Status.Trigger != nil && len(b.Status.Trigger.Images) > 0 {
+			b.Status.Trigger.Images = nil
+			return reconcile.Result{}, r.client.Status().Update(ctx, b)
+		}
+		return reconcile.Result{}, nil
+	}
+
+	var (
+		images        []build.ImageTriggerStatus
+		changedBy     *build.TriggerWhen
+		changes       []string
+		statusChanged bool
+		now           = metav1.Now()
+	)
+
+	for i := range whens {
+		if whens[i].Image == nil {
+			continue
+		}
+
+		dockerConfigJSON, secretErr := r.pullSecretData(ctx, b.Namespace, whens[i].Image)
+		if secretErr != nil {
+			ctxlog.Error(ctx, secretErr, "failed to read the image pull secret", namespace, b.Namespace, name, b.Name, "trigger", whens[i].Name)
+		}
+
+		for _, imageName := range whens[i].Image.Names {
+			if containsImage(images, imageName) {
+				continue
+			}
+
+			previous := b.Status.Trigger.GetImage(imageName)
+
+			digest, err := "", secretErr
+			if err == nil {
+				digest, err = r.resolveDigest(ctx, imageName, dockerConfigJSON)
+			}
+
+			if err != nil {
+				ctxlog.Error(ctx, err, "failed to resolve image digest", namespace, b.Namespace, name, b.Name, "image", imageName)
+
+				// keep the last observed state until the image can be resolved again
+				if previous != nil {
+					images = append(images, *previous)
+				}
+				continue
+			}
+
+			switch {
+			case previous == nil:
+				images = append(images, build.ImageTriggerStatus{Name: imageName, Digest: digest, LastChangeTime: &now})
+				statusChanged = true
+
+			case previous.Digest != digest:
+				ctxlog.Info(ctx, "image digest changed", namespace, b.Namespace, name, b.Name, "image", imageName, "previous", previous.Digest, "current", digest)
+				images = append(images, build.ImageTriggerStatus{Name: imageName, Digest: digest, LastChangeTime: &now})
+				statusChanged = true
+				changes = append(changes, changeKey(previous, digest))
+				if changedBy == nil {
+					changedBy = &whens[i]
+				}
+
+			default:
+				images = append(images, *previous)
+			}
+		}
+	}
+
+	// one BuildRun per Build, even if multiple images changed. The name is
+	// derived from the observed changes, so that the BuildRun is not created
+	// twice if the status update below fails and the changes are seen again.
+	if changedBy != nil {
+		buildRun := trigger.WithDeterministicName(trigger.NewBuildRun(b, changedBy, ImageChangeEvent, nil), strings.Join(changes, ","))
+		switch err := r.client.Create(ctx, buildRun); {
+		case apierrors.IsAlreadyExists(err):
+			ctxlog.Info(ctx, "BuildRun for changed image already exists", namespace, buildRun.Namespace, name, buildRun.Name)
+
+		case err != nil:
+			return reconcile.Result{}, err
+
+		default:
+			ctxlog.Info(ctx, "created BuildRun for changed image", namespace, buildRun.Namespace, name, buildRun.Name)
+		}
+	}
+
+	if b.Status.Trigger == nil || len(b.Status.Trigger.Images) != len(images) {
+		statusChanged = true
+	}
+
+	if statusChanged {
+		if b.Status.Trigger == nil {
+			b.Status.Trigger = &build.TriggerStatus{}
+		}
+		b.Status.Trigger.Images = images
+		if err := r.client.Status().Update(ctx, b); err != nil {
+			return reconcile.Result{}, err
+		}
+	}
+
+	ctxlog.Debug(ctx, "finish reconciling image triggers", namespace, request.Namespace, name, request.Name)
+	return reconcile.Result{RequeueAfter: r.config.Triggers.ImagePollInterval}, nil
+}
+
+// pullSecretData returns the Docker config.json of the pull secret of the
+// Image trigger, or nil if the images are resolved anonymously
+func (r *ReconcileImageTrigger) pullSecretData(ctx context.Context, namespace string, when *build.WhenImage) ([]byte, error) {
+	if when.SecretRef == nil || when.SecretRef.Name == "" {
+		return nil, nil
+	}
+
+	secret := &corev1.Secret{}
+	if err := r.client.Get(ctx, types.NamespacedName{Namespace: namespace, Name: when.SecretRef.Name}, secret); err != nil {
+		return nil, err
+	}
+
+	data, ok := secret.Data[corev1.DockerConfigJsonKey]
+	if !ok {
+		return nil, fmt.Errorf("secret %s does not contain the key %s", when.SecretRef.Name, corev1.DockerConfigJsonKey)
+	}
+
+	return data, nil
+}
+
+// changeKey identifies the change of the image digest, the time of the
+// previous change distinguishes an image that changes back to a former digest
+func changeKey(previous *build.ImageTriggerStatus, digest string) string {
+	var since string
+	if previous.LastChangeTime != nil {
+		since = previous.LastChangeTime.UTC().Format(time.RFC3339)
+	}
+
+	return fmt.Sprintf("%s|%s|%s|%s", previous.Name, previous.Digest, since, digest)
+}
+
+func containsImage(images []build.ImageTriggerStatus, imageName string) bool {
+	for _, i := range images {
+		if i.Name == imageName {
+			return true
+		}
+	}
+	return false
+}
+
+// resolveDigest returns the digest of the image or image index in the registry,
+// using the credentials of the Docker config.json if one is provided
+func resolveDigest(ctx context.Context, imageName string, dockerConfigJSON []byte) (string, error) {
+	ref, err := imagename.ParseReference(imageName)
+	if err != nil {
+		return "", err
+	}
+
+	var options []remote.Option
+	if dockerConfigJSON != nil {
+		options, _, err = image.GetOptionsForDockerConfigJSON(ctx, ref, false, dockerConfigJSON, "Shipwright Build")
+	} else {
+		options, _, err = image.GetOptions(ctx, ref, false, "", "Shipwright Build")
+	}
+	if err != nil {
+		return "", err
+	}
+
+	img, imageIndex, err := image.LoadImageOrImageIndexFromRegistry(ref, options)
+	if err != nil {
+		return "", err
+	}
+
+	switch {
+	case imageIndex != nil:
+		digest, err := imageIndex.Digest()
+		if err != nil {
+			return "", err
+		}
+		return digest.String(), nil
+
+	case img != nil:
+		digest, err := img.Digest()
+		if err != nil {
+			return "", err
+		}
+		return digest.String(), nil
+
+	default:
+		return "", fmt.Errorf("no image found for %q", imageName)
+	}
+}
@@ -0,0 +1,212 @@
+// Copyright The Shipwright Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package imagetrigger_test
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	crc "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	build "github.com/shipwright-io/build/pkg/apis/build/v1alpha1"
+	"github.com/shipwright-io/build/pkg/config"
+	"github.com/shipwright-io/build/pkg/controller/fakes"
+	"github.com/shipwright-io/build/pkg/reconciler/imagetrigger"
+)
+
+var _ = Describe("Reconcile Image Trigger", func() {
+	const baseImage = "ghcr.io/some/base-image:latest"
+
+	var (
+		manager      *fakes.FakeManager
+		client       *fakes.FakeClient
+		statusWriter *fakes.FakeStatusWriter
+		reconciler   reconcile.Reconciler
+		request      reconcile.Request
+		buildSample  *build.Build
+		digests      map[string]string
+		created      []*build.BuildRun
+		dockerConfig []byte
+	)
+
+	BeforeEach(func() {
+		request = reconcile.Request{NamespacedName: types.NamespacedName{Name: "a-build", Namespace: "a-namespace"}}
+		digests = map[string]string{baseImage: "sha256:1111"}
+		created = nil
+		dockerConfig = nil
+
+		buildSample = &build.Build{
+			ObjectMeta: metav1.ObjectMeta{Name: "a-build", Namespace: "a-namespace"},
+			Spec: build.BuildSpec{
+				Trigger: &build.Trigger{
+					When: []build.TriggerWhen{{
+						Name:  "base-image",
+						Type:  build.ImageTrigger,
+						Image: &build.WhenImage{Names: []string{baseImage}},
+					}},
+				},
+			},
+		}
+
+		manager = &fakes.FakeManager{}
+		client = &fakes.FakeClient{}
+		statusWriter = &fakes.FakeStatusWriter{}
+		client.StatusCalls(func() crc.StatusWriter { return statusWriter })
+		manager.GetClientReturns(client)
+
+		client.GetCalls(func(_ context.Context, nn types.NamespacedName, object crc.Object, _ ...crc.GetOption) error {
+			switch o := object.(type) {
+			case *build.Build:
+				if buildSample == nil {
+					return k8serrors.NewNotFound(schema.GroupResource{}, nn.Name)
+				}
+				buildSample.DeepCopyInto(o)
+				return nil
+
+			case *corev1.Secret:
+				if nn.Name == "registry-credentials" {
+					o.Data = map[string][]byte{corev1.DockerConfigJsonKey: []byte(`{"auths":{}}`)}
+					return nil
+				}
+			}
+			return k8serrors.NewNotFound(schema.GroupResource{}, nn.Name)
+		})
+
+		client.CreateCalls(func(_ context.Context, object crc.Object, _ ...crc.CreateOption) error {
+			for _, buildRun := range created {
+				if buildRun.Name == object.GetName() {
+					return k8serrors.NewAlreadyExists(schema.GroupResource{}, object.GetName())
+				}
+			}
+			created = append(created, object.(*build.BuildRun))
+			return nil
+		})
+	})
+
+	JustBeforeEach(func() {
+		cfg := config.NewDefaultConfig()
+		cfg.Triggers.ImagePollInterval = time.Minute
+
+		reconciler = imagetrigger.NewReconciler(cfg, manager, func(_ context.Context, imageName string, dockerConfigJSON []byte) (string, error) {
+			dockerConfig = dockerConfigJSON
+			digest, ok := digests[imageName]
+			if !ok {
+				return "", fmt.Errorf("image %s not found", imageName)
+			}
+			return digest, nil
+		})
+	})
+
+	updatedBuild := func() *build.Build {
+		Expect(statusWriter.UpdateCallCount()).To(Equal(1))
+		_, object, _ := statusWriter.UpdateArgsForCall(0)
+		return object.(*build.Build)
+	}
+
+	It("should record the digest of a newly watched image without creating a BuildRun", func() {
+		result, err := reconciler.Reconcile(context.TODO(), request)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result.RequeueAfter).To(Equal(time.Minute))
+		Expect(created).To(BeEmpty())
+
+		status := updatedBuild().Status.Trigger.GetImage(baseImage)
+		Expect(status).ToNot(BeNil())
+		Expect(status.Digest).To(Equal("sha256:1111"))
+	})
+
+	It("should not update anything if the digest did not change", func() {
+		buildSample.Status.Trigger = &build.TriggerStatus{Images: []build.ImageTriggerStatus{{Name: baseImage, Digest: "sha256:1111"}}}
+
+		_, err := reconciler.Reconcile(context.TODO(), request)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(created).To(BeEmpty())
+		Expect(statusWriter.UpdateCallCount()).To(BeZero())
+	})
+
+	It("should create a BuildRun and record the new digest when the image changed", func() {
+		buildSample.Status.Trigger = &build.TriggerStatus{Images: []build.ImageTriggerStatus{{Name: baseImage, Digest: "sha256:0000"}}}
+
+		_, err := reconciler.Reconcile(context.TODO(), request)
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(created).To(HaveLen(1))
+		Expect(created[0].Spec.BuildRef.Name).To(Equal("a-build"))
+		Expect(created[0].Annotations).To(HaveKeyWithValue(build.AnnotationBuildRunTriggerName, "base-image"))
+		Expect(created[0].Annotations).To(HaveKeyWithValue(build.AnnotationBuildRunTriggerEvent, imagetrigger.ImageChangeEvent))
+
+		Expect(updatedBuild().Status.Trigger.GetImage(baseImage).Digest).To(Equal("sha256:1111"))
+	})
+
+	It("should not create a second BuildRun for the same change if the digest could not be recorded", func() {
+		buildSample.Status.Trigger = &build.TriggerStatus{Images: []build.ImageTriggerStatus{{Name: baseImage, Digest: "sha256:0000"}}}
+		statusWriter.UpdateReturnsOnCall(0, k8serrors.NewConflict(schema.GroupResource{}, "a-build", fmt.Errorf("conflict")))
+
+		_, err := reconciler.Reconcile(context.TODO(), request)
+		Expect(err).To(HaveOccurred())
+
+		_, err = reconciler.Reconcile(context.TODO(), request)
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(client.CreateCallCount()).To(Equal(2))
+		Expect(created).To(HaveLen(1))
+		Expect(statusWriter.UpdateCallCount()).To(Equal(2))
+	})
+
+	It("should resolve the images using the pull secret of the trigger", func() {
+		buildSample.Spec.Trigger.When[0].Image.SecretRef = &corev1.LocalObjectReference{Name: "registry-credentials"}
+
+		_, err := reconciler.Reconcile(context.TODO(), request)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(string(dockerConfig)).To(Equal(`{"auths":{}}`))
+	})
+
+	It("should keep the last observed digest if the pull secret cannot be read", func() {
+		buildSample.Spec.Trigger.When[0].Image.SecretRef = &corev1.LocalObjectReference{Name: "missing"}
+		buildSample.Status.Trigger = &build.TriggerStatus{Images: []build.ImageTriggerStatus{{Name: baseImage, Digest: "sha256:0000"}}}
+
+		_, err := reconciler.Reconcile(context.TODO(), request)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(created).To(BeEmpty())
+		Expect(statusWriter.UpdateCallCount()).To(BeZero())
+	})
+
+	It("should keep the last observed digest if the image cannot be resolved", func() {
+		buildSample.Status.Trigger = &build.TriggerStatus{Images: []build.ImageTriggerStatus{{Name: baseImage, Digest: "sha256:0000"}}}
+		delete(digests, baseImage)
+
+		_, err := reconciler.Reconcile(context.TODO(), request)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(created).To(BeEmpty())
+		Expect(statusWriter.UpdateCallCount()).To(BeZero())
+	})
+
+	It("should drop the image status once the Build has no Image triggers anymore", func() {
+		buildSample.Spec.Trigger = nil
+		buildSample.Status.Trigger = &build.TriggerStatus{Images: []build.ImageTriggerStatus{{Name: baseImage, Digest: "sha256:0000"}}}
+
+		result, err := reconciler.Reconcile(context.TODO(), request)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result).To(Equal(reconcile.Result{}))
+		Expect(updatedBuild().Status.Trigger.Images).To(BeEmpty())
+	})
+
+	It("should stop reconciling if the Build does not exist", func() {
+		buildSample = nil
+
+		result, err := reconciler.Reconcile(context.TODO(), request)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result).To(Equal(reconcile.Result{}))
+	})
+})
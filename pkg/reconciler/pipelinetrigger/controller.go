@@ -0,0 +1,71 @@
+// Copyright The Shipwright Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package pipelinetrigger
+
+import (
+	"context"
+
+	pipelineapi "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	"github.com/shipwright-io/build/pkg/config"
+)
+
+const (
+	namespace string = "namespace"
+	name      string = "name"
+)
+
+// Add creates a new pipeline trigger Controller and adds it to the Manager. The Manager will set fields on the Controller
+// and Start it when the Manager is Started
+func Add(_ context.Context, c *config.Config, mgr manager.Manager) error {
+	return add(mgr, NewReconciler(c, mgr), c.Controllers.Build.MaxConcurrentReconciles)
+}
+
+func add(mgr manager.Manager, r reconcile.Reconciler, maxConcurrentReconciles int) error {
+	// Create the controller options
+	options := controller.Options{
+		Reconciler: r,
+	}
+
+	if maxConcurrentReconciles > 0 {
+		options.MaxConcurrentReconciles = maxConcurrentReconciles
+	}
+
+	// Create a new controller
+	c, err := controller.New("pipeline-trigger-controller", mgr, options)
+	if err != nil {
+		return err
+	}
+
+	pred := predicate.Funcs{
+		CreateFunc: func(e event.CreateEvent) bool {
+			// The CreateFunc is also called when the controller is started and iterates over all objects,
+			// completed PipelineRuns are reconciled to not miss any that completed during a restart
+			o := e.Object.(*pipelineapi.PipelineRun)
+			return o.IsDone()
+		},
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			n := e.ObjectNew.(*pipelineapi.PipelineRun)
+			o := e.ObjectOld.(*pipelineapi.PipelineRun)
+
+			// Only reconcile once the PipelineRun completed
+			return !o.IsDone() && n.IsDone()
+		},
+		DeleteFunc: func(e event.DeleteEvent) bool {
+			// Never reconcile on deletion, there is nothing we have to do
+			return false
+		},
+	}
+
+	// Watch for changes to Tekton PipelineRuns
+	return c.Watch(&source.Kind{Type: &pipelineapi.PipelineRun{}}, &handler.EnqueueRequestForObject{}, pred)
+}
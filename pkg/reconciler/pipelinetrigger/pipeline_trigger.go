@@ -0,0 +1,199 @@
+// Copyright The Shipwright Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package pipelinetrigger
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"sort"
+	"strings"
+
+	pipelineapi "github.com/tektoncd/pipeline/pkg/apis/pipeline"
+	pipelinev1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"knative.dev/pkg/apis"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	build "github.com/shipwright-io/build/pkg/apis/build/v1alpha1"
+	"github.com/shipwright-io/build/pkg/config"
+	"github.com/shipwright-io/build/pkg/ctxlog"
+	"github.com/shipwright-io/build/pkg/trigger"
+)
+
+const (
+	// PipelineRunSucceededStatus is the status matching every successfully completed PipelineRun
+	PipelineRunSucceededStatus = "Succeeded"
+
+	// PipelineRunFailedStatus is the status matching every failed PipelineRun
+	PipelineRunFailedStatus = "Failed"
+)
+
+// ReconcilePipelineTrigger creates BuildRuns for the Pipeline triggers of Builds
+// based on completed Tekton PipelineRuns
+type ReconcilePipelineTrigger struct {
+	// This client, initialized using mgr.Client() above, is a split client
+	// that reads objects from the cache and writes to the apiserver
+	config *config.Config
+	client client.Client
+}
+
+// NewReconciler returns a new reconcile.Reconciler
+func NewReconciler(c *config.Config, mgr manager.Manager) reconcile.Reconciler {
+	return &ReconcilePipelineTrigger{
+		config: c,
+		client: mgr.GetClient(),
+	}
+}
+
+// Reconcile matches a completed PipelineRun against the Pipeline triggers of the Builds
+// in the same namespace and creates a BuildRun for every Build with a matching condition
+func (r *ReconcilePipelineTrigger) Reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
+	// Set the ctx to be Background, as the top-level context for incoming requests.
+	ctx, cancel := context.WithTimeout(ctx, r.config.CtxTimeOut)
+	defer cancel()
+
+	ctxlog.Debug(ctx, "start reconciling pipeline triggers", namespace, request.Namespace, name, request.Name)
+
+	pipelineRun := &pipelinev1.PipelineRun{}
+	if err := r.client.Get(ctx, request.NamespacedName, pipelineRun); err != nil {
+		if apierrors.IsNotFound(err) {
+			ctxlog.Debug(ctx, "finish reconciling pipeline triggers. PipelineRun was not found", namespace, request.Namespace, name, request.Name)
+			return reconcile.Result{}, nil
+		}
+		return reconcile.Result{}, err
+	}
+
+	if !pipelineRun.IsDone() {
+		return reconcile.Result{}, nil
+	}
+
+	buildList := &build.BuildList{}
+	if err := r.client.List(ctx, buildList, client.InNamespace(pipelineRun.Namespace)); err != nil {
+		return reconcile.Result{}, err
+	}
+
+	status := pipelineRunStatus(pipelineRun)
+	names := []string{pipelineRun.Name, pipelineRun.Labels[pipelineapi.PipelineLabelKey]}
+	triggered := triggeredBuilds(pipelineRun)
+
+	var newlyTriggered []string
+	for i := range buildList.Items {
+		b := &buildList.Items[i]
+
+		// ignore PipelineRuns that completed before the Build was created
+		if completion := pipelineRun.Status.CompletionTime; completion != nil && completion.Before(&b.CreationTimestamp) {
+			continue
+		}
+
+		if triggered[b.Name] {
+			continue
+		}
+
+		when := matchingTrigger(b, names, pipelineRun.Labels, status)
+		if when == nil {
+			continue
+		}
+
+		// the name is derived from the PipelineRun and the Build, so that a BuildRun that was
+		// created, but not recorded on the PipelineRun, is not created twice, and so that Builds
+		// with a common name prefix do not share the name of the BuildRun
+		buildRun := trigger.WithDeterministicName(trigger.NewBuildRun(b, when, status[0], nil), string(pipelineRun.UID)+"/"+b.Name)
+		buildRun.Labels[build.LabelBuildRunTriggerPipelineRun] = pipelineRunLabelValue(pipelineRun.Name)
+		buildRun.Annotations[build.AnnotationBuildRunTriggerPipelineRun] = pipelineRun.Name
+		switch err := r.client.Create(ctx, buildRun); {
+		case apierrors.IsAlreadyExists(err):
+			ctxlog.Info(ctx, "BuildRun for completed PipelineRun already exists", namespace, buildRun.Namespace, name, buildRun.Name, "pipelineRun", pipelineRun.Name)
+
+		case err != nil:
+			return reconcile.Result{}, err
+
+		default:
+			ctxlog.Info(ctx, "created BuildRun for completed PipelineRun", namespace, buildRun.Namespace, name, buildRun.Name, "pipelineRun", pipelineRun.Name)
+		}
+
+		newlyTriggered = append(newlyTriggered, b.Name)
+	}
+
+	// record the triggered Builds on the PipelineRun, which outlives the BuildRuns
+	if len(newlyTriggered) > 0 {
+		if err := r.recordTriggeredBuilds(ctx, pipelineRun, triggered, newlyTriggered); err != nil {
+			return reconcile.Result{}, err
+		}
+	}
+
+	ctxlog.Debug(ctx, "finish reconciling pipeline triggers", namespace, request.Namespace, name, request.Name)
+	return reconcile.Result{}, nil
+}
+
+// triggeredBuilds returns the names of the Builds that a BuildRun was already created for
+func triggeredBuilds(pipelineRun *pipelinev1.PipelineRun) map[string]bool {
+	result := map[string]bool{}
+	for _, buildName := range strings.Split(pipelineRun.Annotations[build.AnnotationPipelineRunTriggeredBuilds], ",") {
+		if buildName != "" {
+			result[buildName] = true
+		}
+	}
+
+	return result
+}
+
+// recordTriggeredBuilds adds the names of the Builds to the annotation of the PipelineRun
+func (r *ReconcilePipelineTrigger) recordTriggeredBuilds(ctx context.Context, pipelineRun *pipelinev1.PipelineRun, triggered map[string]bool, newlyTriggered []string) error {
+	buildNames := newlyTriggered
+	for buildName := range triggered {
+		buildNames = append(buildNames, buildName)
+	}
+	sort.Strings(buildNames)
+
+	patch := client.MergeFrom(pipelineRun.DeepCopy())
+	if pipelineRun.Annotations == nil {
+		pipelineRun.Annotations = map[string]string{}
+	}
+	pipelineRun.Annotations[build.AnnotationPipelineRunTriggeredBuilds] = strings.Join(buildNames, ",")
+
+	return r.client.Patch(ctx, pipelineRun, patch)
+}
+
+// pipelineRunLabelValue returns a hash of the PipelineRun name, which fits into a label
+// value regardless of the length of the name
+func pipelineRunLabelValue(pipelineRunName string) string {
+	return fmt.Sprintf("%x", sha256.Sum256([]byte(pipelineRunName)))[:63]
+}
+
+// matchingTrigger returns the first Pipeline trigger condition of the Build matching the PipelineRun
+func matchingTrigger(b *build.Build, names []string, labels map[string]string, status []string) *build.TriggerWhen {
+	whens := trigger.TriggersOfType(b, build.PipelineTrigger)
+	for i := range whens {
+		if trigger.MatchesObjectRef(&whens[i], names, labels) && trigger.MatchesObjectStatus(&whens[i], status...) {
+			return &whens[i]
+		}
+	}
+	return nil
+}
+
+// pipelineRunStatus returns the status values of a completed PipelineRun, this is the
+// reason of its condition, for example `Completed` or `Cancelled`, preceded by either
+// `Succeeded` or `Failed`
+func pipelineRunStatus(pipelineRun *pipelinev1.PipelineRun) []string {
+	condition := pipelineRun.Status.GetCondition(apis.ConditionSucceeded)
+	if condition == nil {
+		return []string{""}
+	}
+
+	status := PipelineRunFailedStatus
+	if condition.Status == corev1.ConditionTrue {
+		status = PipelineRunSucceededStatus
+	}
+
+	if condition.Reason != "" && condition.Reason != status {
+		return []string{status, condition.Reason}
+	}
+
+	return []string{status}
+}
@@ -0,0 +1,239 @@
+// Copyright The Shipwright Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package pipelinetrigger_test
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	pipelineapi "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	knativeapi "knative.dev/pkg/apis"
+	knativev1 "knative.dev/pkg/apis/duck/v1"
+	crc "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	build "github.com/shipwright-io/build/pkg/apis/build/v1alpha1"
+	"github.com/shipwright-io/build/pkg/config"
+	"github.com/shipwright-io/build/pkg/controller/fakes"
+	"github.com/shipwright-io/build/pkg/reconciler/pipelinetrigger"
+)
+
+var _ = Describe("Reconcile Pipeline Trigger", func() {
+	var (
+		manager     *fakes.FakeManager
+		client      *fakes.FakeClient
+		reconciler  reconcile.Reconciler
+		request     reconcile.Request
+		pipelineRun *pipelineapi.PipelineRun
+		builds      []build.Build
+		created     []*build.BuildRun
+	)
+
+	newBuild := func(name string, objectRef build.WhenObjectRef) build.Build {
+		return build.Build{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:              name,
+				Namespace:         "a-namespace",
+				CreationTimestamp: metav1.NewTime(time.Now().Add(-time.Hour)),
+			},
+			Spec: build.BuildSpec{
+				Trigger: &build.Trigger{
+					When: []build.TriggerWhen{{
+						Name:      "after-" + name,
+						Type:      build.PipelineTrigger,
+						ObjectRef: &objectRef,
+					}},
+				},
+			},
+		}
+	}
+
+	setCondition := func(status corev1.ConditionStatus, reason string) {
+		pipelineRun.Status.Conditions = knativev1.Conditions{{
+			Type:   knativeapi.ConditionSucceeded,
+			Status: status,
+			Reason: reason,
+		}}
+	}
+
+	BeforeEach(func() {
+		request = reconcile.Request{NamespacedName: types.NamespacedName{Name: "tests-abcde", Namespace: "a-namespace"}}
+		created = nil
+
+		completion := metav1.Now()
+		pipelineRun = &pipelineapi.PipelineRun{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "tests-abcde",
+				Namespace: "a-namespace",
+				Labels: map[string]string{
+					"tekton.dev/pipeline": "tests",
+					"team":                "a-team",
+				},
+			},
+		}
+		pipelineRun.Status.CompletionTime = &completion
+		setCondition(corev1.ConditionTrue, "Succeeded")
+
+		builds = []build.Build{
+			newBuild("by-name", build.WhenObjectRef{Name: "tests", Status: []string{"Succeeded"}}),
+			newBuild("by-selector", build.WhenObjectRef{Selector: map[string]string{"team": "a-team"}, Status: []string{"Failed"}}),
+		}
+
+		manager = &fakes.FakeManager{}
+		client = &fakes.FakeClient{}
+		manager.GetClientReturns(client)
+
+		client.GetCalls(func(_ context.Context, nn types.NamespacedName, object crc.Object, _ ...crc.GetOption) error {
+			switch o := object.(type) {
+			case *pipelineapi.PipelineRun:
+				if pipelineRun == nil {
+					return k8serrors.NewNotFound(schema.GroupResource{}, nn.Name)
+				}
+				pipelineRun.DeepCopyInto(o)
+				return nil
+			}
+			return k8serrors.NewNotFound(schema.GroupResource{}, nn.Name)
+		})
+
+		client.ListCalls(func(_ context.Context, list crc.ObjectList, _ ...crc.ListOption) error {
+			switch l := list.(type) {
+			case *build.BuildList:
+				l.Items = builds
+			}
+			return nil
+		})
+
+		client.CreateCalls(func(_ context.Context, object crc.Object, _ ...crc.CreateOption) error {
+			created = append(created, object.(*build.BuildRun))
+			return nil
+		})
+	})
+
+	JustBeforeEach(func() {
+		reconciler = pipelinetrigger.NewReconciler(config.NewDefaultConfig(), manager)
+	})
+
+	It("should create a BuildRun for a Build referencing the Pipeline by name", func() {
+		_, err := reconciler.Reconcile(context.TODO(), request)
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(created).To(HaveLen(1))
+		Expect(created[0].Spec.BuildRef.Name).To(Equal("by-name"))
+		Expect(created[0].Labels).To(HaveKey(build.LabelBuildRunTriggerPipelineRun))
+		Expect(created[0].Annotations).To(HaveKeyWithValue(build.AnnotationBuildRunTriggerPipelineRun, "tests-abcde"))
+		Expect(created[0].Annotations).To(HaveKeyWithValue(build.AnnotationBuildRunTriggerName, "after-by-name"))
+		Expect(created[0].Annotations).To(HaveKeyWithValue(build.AnnotationBuildRunTriggerEvent, "Succeeded"))
+	})
+
+	It("should create a BuildRun for a Build selecting a failed PipelineRun by labels", func() {
+		setCondition(corev1.ConditionFalse, "PipelineRunTimeout")
+
+		_, err := reconciler.Reconcile(context.TODO(), request)
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(created).To(HaveLen(1))
+		Expect(created[0].Spec.BuildRef.Name).To(Equal("by-selector"))
+	})
+
+	It("should match the reason of the PipelineRun condition", func() {
+		builds = []build.Build{newBuild("by-reason", build.WhenObjectRef{Name: "tests", Status: []string{"Completed"}})}
+		setCondition(corev1.ConditionTrue, "Completed")
+
+		_, err := reconciler.Reconcile(context.TODO(), request)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(created).To(HaveLen(1))
+	})
+
+	It("should not create a BuildRun for a PipelineRun that is still running", func() {
+		setCondition(corev1.ConditionUnknown, "Running")
+
+		_, err := reconciler.Reconcile(context.TODO(), request)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(created).To(BeEmpty())
+	})
+
+	It("should record the triggered Builds on the PipelineRun", func() {
+		pipelineRun.Annotations = map[string]string{build.AnnotationPipelineRunTriggeredBuilds: "other"}
+
+		_, err := reconciler.Reconcile(context.TODO(), request)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(created).To(HaveLen(1))
+
+		Expect(client.PatchCallCount()).To(Equal(1))
+		_, object, _, _ := client.PatchArgsForCall(0)
+		Expect(object.GetAnnotations()).To(HaveKeyWithValue(build.AnnotationPipelineRunTriggeredBuilds, "by-name,other"))
+	})
+
+	It("should not create a BuildRun twice for the same PipelineRun", func() {
+		pipelineRun.Annotations = map[string]string{build.AnnotationPipelineRunTriggeredBuilds: "by-name"}
+
+		_, err := reconciler.Reconcile(context.TODO(), request)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(created).To(BeEmpty())
+		Expect(client.PatchCallCount()).To(BeZero())
+	})
+
+	It("should record a Build whose BuildRun already exists", func() {
+		client.CreateReturns(k8serrors.NewAlreadyExists(schema.GroupResource{}, "by-name"))
+
+		_, err := reconciler.Reconcile(context.TODO(), request)
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(client.PatchCallCount()).To(Equal(1))
+		_, object, _, _ := client.PatchArgsForCall(0)
+		Expect(object.GetAnnotations()).To(HaveKeyWithValue(build.AnnotationPipelineRunTriggeredBuilds, "by-name"))
+	})
+
+	It("should label the BuildRun of a PipelineRun with a name longer than a label value", func() {
+		pipelineRun.Name = strings.Repeat("a", 100)
+
+		_, err := reconciler.Reconcile(context.TODO(), request)
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(created).To(HaveLen(1))
+		Expect(len(created[0].Labels[build.LabelBuildRunTriggerPipelineRun])).To(BeNumerically("<=", 63))
+		Expect(created[0].Annotations).To(HaveKeyWithValue(build.AnnotationBuildRunTriggerPipelineRun, pipelineRun.Name))
+	})
+
+	It("should name the BuildRuns of Builds with a long common name prefix differently", func() {
+		prefix := strings.Repeat("a", 60)
+		builds = []build.Build{
+			newBuild(prefix+"-one", build.WhenObjectRef{Name: "tests", Status: []string{"Succeeded"}}),
+			newBuild(prefix+"-two", build.WhenObjectRef{Name: "tests", Status: []string{"Succeeded"}}),
+		}
+
+		_, err := reconciler.Reconcile(context.TODO(), request)
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(created).To(HaveLen(2))
+		Expect(created[0].Name).ToNot(Equal(created[1].Name))
+	})
+
+	It("should ignore PipelineRuns that completed before the Build was created", func() {
+		builds[0].CreationTimestamp = metav1.NewTime(time.Now().Add(time.Hour))
+
+		_, err := reconciler.Reconcile(context.TODO(), request)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(created).To(BeEmpty())
+	})
+
+	It("should stop reconciling if the PipelineRun does not exist", func() {
+		pipelineRun = nil
+
+		result, err := reconciler.Reconcile(context.TODO(), request)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result).To(Equal(reconcile.Result{}))
+		Expect(client.ListCallCount()).To(BeZero())
+	})
+})
@@ -0,0 +1,217 @@
+// Copyright The Shipwright Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package scheduletrigger
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	build "github.com/shipwright-io/build/pkg/apis/build/v1alpha1"
+	"github.com/shipwright-io/build/pkg/config"
+	"github.com/shipwright-io/build/pkg/ctxlog"
+	"github.com/shipwright-io/build/pkg/trigger"
+)
+
+// ScheduleEvent is the event name used for BuildRuns created by a Schedule trigger
+const ScheduleEvent = "Schedule"
+
+// ReconcileScheduleTrigger reconciles the Schedule triggers of a Build object
+type ReconcileScheduleTrigger struct {
+	// This client, initialized using mgr.Client() above, is a split client
+	// that reads objects from the cache and writes to the apiserver
+	config *config.Config
+	client client.Client
+	now    func() time.Time
+}
+
+// NewReconciler returns a new reconcile.Reconciler
+func NewReconciler(c *config.Config, mgr manager.Manager, now func() time.Time) reconcile.Reconciler {
+	return &ReconcileScheduleTrigger{
+		config: c,
+		client: mgr.GetClient(),
+		now:    now,
+	}
+}
+
+// Reconcile creates a BuildRun for every Schedule trigger of a Build that is due,
+// and requeues the Build for the next schedule time. A newly added schedule only
+// records its next schedule time.
+func (r *ReconcileScheduleTrigger) Reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
+	// Set the ctx to be Background, as the top-level context for incoming requests.
+	ctx, cancel := context.WithTimeout(ctx, r.config.CtxTimeOut)
+	defer cancel()
+
+	ctxlog.Debug(ctx, "start reconciling schedule triggers", namespace, request.Namespace, name, request.Name)
+
+	b := &build.Build{}
+	if err := r.client.Get(ctx, request.NamespacedName, b); err != nil {
+		if apierrors.IsNotFound(err) {
+			ctxlog.Debug(ctx, "finish reconciling schedule triggers. Build was not found", namespace, request.Namespace, name, request.Name)
+			return reconcile.Result{}, nil
+		}
+		return reconcile.Result{}, err
+	}
+
+	whens := trigger.TriggersOfType(b, build.ScheduleTrigger)
+	if len(whens) == 0 {
+		// drop the status of schedules that no longer exist
+		if b.Status.Trigger != nil && len(b.Status.Trigger.Schedules) > 0 {
+			b.Status.Trigger.Schedules = nil
+			return reconcile.Result{}, r.client.Status().Update(ctx, b)
+		}
+		return reconcile.Result{}, nil
+	}
+
+	var (
+		schedules    []build.ScheduleTriggerStatus
+		requeueAfter time.Duration
+		now          = r.now()
+	)
+
+	for i := range whens {
+		when := &whens[i]
+
+		schedule, err := trigger.ParseSchedule(when.Schedule)
+		if err != nil {
+			// the Build validation reports invalid schedules
+			ctxlog.Info(ctx, "skipping invalid schedule", namespace, b.Namespace, name, b.Name, "trigger", when.Name, "error", err.Error())
+			continue
+		}
+
+		status := build.ScheduleTriggerStatus{Name: when.Name}
+		if previous := b.Status.Trigger.GetSchedule(when.Name); previous != nil {
+			status = *previous
+		}
+
+		// schedule times missed while the controller was not running result in a
+		// single BuildRun
+		if status.NextScheduleTime != nil && !now.Before(status.NextScheduleTime.Time) {
+			created, err := r.runSchedule(ctx, b, when, status.NextScheduleTime.Time)
+			if err != nil {
+				return reconcile.Result{}, err
+			}
+
+			if created {
+				status.LastScheduleTime = status.NextScheduleTime.DeepCopy()
+			}
+		}
+
+		status.NextScheduleTime = nil
+		if next := schedule.Next(now); !next.IsZero() {
+			status.NextScheduleTime = &metav1.Time{Time: next}
+
+			if wait := next.Sub(now); requeueAfter == 0 || wait < requeueAfter {
+				requeueAfter = wait
+			}
+		}
+
+		schedules = append(schedules, status)
+	}
+
+	var previous []build.ScheduleTriggerStatus
+	if b.Status.Trigger != nil {
+		previous = b.Status.Trigger.Schedules
+	}
+
+	if !equality.Semantic.DeepEqual(previous, schedules) {
+		if b.Status.Trigger == nil {
+			b.Status.Trigger = &build.TriggerStatus{}
+		}
+		b.Status.Trigger.Schedules = schedules
+		if err := r.client.Status().Update(ctx, b); err != nil {
+			return reconcile.Result{}, err
+		}
+	}
+
+	ctxlog.Debug(ctx, "finish reconciling schedule triggers", namespace, request.Namespace, name, request.Name)
+	return reconcile.Result{RequeueAfter: requeueAfter}, nil
+}
+
+// runSchedule creates the BuildRun for a due schedule while honoring its
+// concurrency policy. It returns false if the BuildRun was skipped. The name
+// of the BuildRun is derived from the schedule time, so that a schedule time
+// whose BuildRun was created, but not recorded in the status, does not create
+// a second one.
+func (r *ReconcileScheduleTrigger) runSchedule(ctx context.Context, b *build.Build, when *build.TriggerWhen, scheduleTime time.Time) (bool, error) {
+	buildRun := trigger.WithDeterministicName(
+		trigger.NewBuildRun(b, when, ScheduleEvent, nil),
+		fmt.Sprintf("%s|%s", when.Name, scheduleTime.UTC().Format(time.RFC3339)),
+	)
+
+	running, err := r.runningBuildRuns(ctx, b, when)
+	if err != nil {
+		return false, err
+	}
+
+	for i := range running {
+		if running[i].Name == buildRun.Name {
+			ctxlog.Info(ctx, "BuildRun for schedule already exists", namespace, buildRun.Namespace, name, buildRun.Name, "trigger", when.Name)
+			return true, nil
+		}
+	}
+
+	if len(running) > 0 {
+		switch when.Schedule.ConcurrencyPolicy {
+		case build.ReplaceConcurrencyPolicy:
+			for i := range running {
+				buildRun := &running[i]
+				if buildRun.IsCanceled() {
+					continue
+				}
+
+				ctxlog.Info(ctx, "canceling BuildRun replaced by schedule", namespace, buildRun.Namespace, name, buildRun.Name, "trigger", when.Name)
+				buildRun.Spec.State = build.BuildRunRequestedStatePtr(build.BuildRunStateCancel)
+				if err := r.client.Update(ctx, buildRun); err != nil && !apierrors.IsNotFound(err) {
+					return false, err
+				}
+			}
+
+		default:
+			ctxlog.Info(ctx, "skipping schedule, a BuildRun of the schedule is still running", namespace, b.Namespace, name, b.Name, "trigger", when.Name)
+			return false, nil
+		}
+	}
+
+	if err := r.client.Create(ctx, buildRun); err != nil {
+		if apierrors.IsAlreadyExists(err) {
+			ctxlog.Info(ctx, "BuildRun for schedule already exists", namespace, buildRun.Namespace, name, buildRun.Name, "trigger", when.Name)
+			return true, nil
+		}
+		return false, err
+	}
+
+	ctxlog.Info(ctx, "created BuildRun for schedule", namespace, buildRun.Namespace, name, buildRun.Name, "trigger", when.Name)
+	return true, nil
+}
+
+// runningBuildRuns returns the BuildRuns created by the Schedule trigger that are not done yet
+func (r *ReconcileScheduleTrigger) runningBuildRuns(ctx context.Context, b *build.Build, when *build.TriggerWhen) ([]build.BuildRun, error) {
+	buildRunList := &build.BuildRunList{}
+	if err := r.client.List(ctx, buildRunList, client.InNamespace(b.Namespace), client.MatchingLabels{build.LabelBuild: b.Name}); err != nil {
+		return nil, err
+	}
+
+	var running []build.BuildRun
+	for _, buildRun := range buildRunList.Items {
+		if buildRun.Annotations[build.AnnotationBuildRunTriggerType] != string(build.ScheduleTrigger) ||
+			buildRun.Annotations[build.AnnotationBuildRunTriggerName] != when.Name {
+			continue
+		}
+
+		if !buildRun.IsDone() {
+			running = append(running, buildRun)
+		}
+	}
+
+	return running, nil
+}
@@ -0,0 +1,254 @@
+// Copyright The Shipwright Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package scheduletrigger_test
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/pointer"
+	crc "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	build "github.com/shipwright-io/build/pkg/apis/build/v1alpha1"
+	"github.com/shipwright-io/build/pkg/config"
+	"github.com/shipwright-io/build/pkg/controller/fakes"
+	"github.com/shipwright-io/build/pkg/reconciler/scheduletrigger"
+)
+
+var _ = Describe("Reconcile Schedule Trigger", func() {
+	var (
+		manager      *fakes.FakeManager
+		client       *fakes.FakeClient
+		statusWriter *fakes.FakeStatusWriter
+		reconciler   reconcile.Reconciler
+		request      reconcile.Request
+		buildSample  *build.Build
+		buildRuns    []build.BuildRun
+		created      []*build.BuildRun
+		updated      []*build.BuildRun
+		now          time.Time
+	)
+
+	// the schedule is due at 02:00, the reconcile happens shortly after
+	var (
+		scheduled = time.Date(2023, time.June, 1, 2, 0, 0, 0, time.UTC)
+		next      = time.Date(2023, time.June, 2, 2, 0, 0, 0, time.UTC)
+	)
+
+	scheduleBuildRun := func(name string, done bool) build.BuildRun {
+		buildRun := build.BuildRun{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: "a-namespace",
+				Labels:    map[string]string{build.LabelBuild: "a-build"},
+				Annotations: map[string]string{
+					build.AnnotationBuildRunTriggerName: "nightly",
+					build.AnnotationBuildRunTriggerType: string(build.ScheduleTrigger),
+				},
+			},
+		}
+		if done {
+			buildRun.Status.Conditions = build.Conditions{{Type: build.Succeeded, Status: corev1.ConditionTrue}}
+		}
+		return buildRun
+	}
+
+	BeforeEach(func() {
+		request = reconcile.Request{NamespacedName: types.NamespacedName{Name: "a-build", Namespace: "a-namespace"}}
+		now = scheduled.Add(5 * time.Second)
+		buildRuns = nil
+		created = nil
+		updated = nil
+
+		buildSample = &build.Build{
+			ObjectMeta: metav1.ObjectMeta{Name: "a-build", Namespace: "a-namespace"},
+			Spec: build.BuildSpec{
+				Trigger: &build.Trigger{
+					When: []build.TriggerWhen{{
+						Name:     "nightly",
+						Type:     build.ScheduleTrigger,
+						Schedule: &build.WhenSchedule{Cron: "0 2 * * *"},
+					}},
+				},
+			},
+			Status: build.BuildStatus{
+				Trigger: &build.TriggerStatus{Schedules: []build.ScheduleTriggerStatus{{
+					Name:             "nightly",
+					NextScheduleTime: &metav1.Time{Time: scheduled},
+				}}},
+			},
+		}
+
+		manager = &fakes.FakeManager{}
+		client = &fakes.FakeClient{}
+		statusWriter = &fakes.FakeStatusWriter{}
+		client.StatusCalls(func() crc.StatusWriter { return statusWriter })
+		manager.GetClientReturns(client)
+
+		client.GetCalls(func(_ context.Context, nn types.NamespacedName, object crc.Object, _ ...crc.GetOption) error {
+			switch o := object.(type) {
+			case *build.Build:
+				if buildSample == nil {
+					return k8serrors.NewNotFound(schema.GroupResource{}, nn.Name)
+				}
+				buildSample.DeepCopyInto(o)
+				return nil
+			}
+			return k8serrors.NewNotFound(schema.GroupResource{}, nn.Name)
+		})
+
+		client.ListCalls(func(_ context.Context, list crc.ObjectList, _ ...crc.ListOption) error {
+			list.(*build.BuildRunList).Items = buildRuns
+			return nil
+		})
+
+		client.CreateCalls(func(_ context.Context, object crc.Object, _ ...crc.CreateOption) error {
+			created = append(created, object.(*build.BuildRun))
+			return nil
+		})
+
+		client.UpdateCalls(func(_ context.Context, object crc.Object, _ ...crc.UpdateOption) error {
+			updated = append(updated, object.(*build.BuildRun))
+			return nil
+		})
+	})
+
+	JustBeforeEach(func() {
+		reconciler = scheduletrigger.NewReconciler(config.NewDefaultConfig(), manager, func() time.Time { return now })
+	})
+
+	updatedBuild := func() *build.Build {
+		Expect(statusWriter.UpdateCallCount()).To(Equal(1))
+		_, object, _ := statusWriter.UpdateArgsForCall(0)
+		return object.(*build.Build)
+	}
+
+	It("should only record the next schedule time of a new schedule", func() {
+		buildSample.Status.Trigger = nil
+
+		result, err := reconciler.Reconcile(context.TODO(), request)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result.RequeueAfter).To(Equal(next.Sub(now)))
+		Expect(created).To(BeEmpty())
+
+		status := updatedBuild().Status.Trigger.GetSchedule("nightly")
+		Expect(status).ToNot(BeNil())
+		Expect(status.LastScheduleTime).To(BeNil())
+		Expect(status.NextScheduleTime.Time).To(BeTemporally("==", next))
+	})
+
+	It("should create a BuildRun when the schedule is due", func() {
+		buildRuns = []build.BuildRun{scheduleBuildRun("a-build-done", true)}
+
+		result, err := reconciler.Reconcile(context.TODO(), request)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result.RequeueAfter).To(Equal(next.Sub(now)))
+
+		Expect(created).To(HaveLen(1))
+		Expect(created[0].Spec.BuildRef.Name).To(Equal("a-build"))
+		Expect(created[0].Annotations).To(HaveKeyWithValue(build.AnnotationBuildRunTriggerName, "nightly"))
+		Expect(created[0].Annotations).To(HaveKeyWithValue(build.AnnotationBuildRunTriggerEvent, scheduletrigger.ScheduleEvent))
+
+		status := updatedBuild().Status.Trigger.GetSchedule("nightly")
+		Expect(status.LastScheduleTime.Time).To(BeTemporally("==", scheduled))
+		Expect(status.NextScheduleTime.Time).To(BeTemporally("==", next))
+	})
+
+	It("should not do anything before the schedule is due", func() {
+		now = scheduled.Add(-time.Minute)
+
+		result, err := reconciler.Reconcile(context.TODO(), request)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result.RequeueAfter).To(Equal(time.Minute))
+		Expect(created).To(BeEmpty())
+		Expect(statusWriter.UpdateCallCount()).To(BeZero())
+	})
+
+	It("should skip the schedule while a BuildRun of the schedule is running", func() {
+		buildRuns = []build.BuildRun{scheduleBuildRun("a-build-running", false)}
+
+		_, err := reconciler.Reconcile(context.TODO(), request)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(created).To(BeEmpty())
+
+		status := updatedBuild().Status.Trigger.GetSchedule("nightly")
+		Expect(status.LastScheduleTime).To(BeNil())
+		Expect(status.NextScheduleTime.Time).To(BeTemporally("==", next))
+	})
+
+	It("should cancel a running BuildRun of the schedule when replacing it", func() {
+		buildSample.Spec.Trigger.When[0].Schedule.ConcurrencyPolicy = build.ReplaceConcurrencyPolicy
+		buildRuns = []build.BuildRun{scheduleBuildRun("a-build-running", false)}
+
+		_, err := reconciler.Reconcile(context.TODO(), request)
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(updated).To(HaveLen(1))
+		Expect(updated[0].Name).To(Equal("a-build-running"))
+		Expect(updated[0].IsCanceled()).To(BeTrue())
+		Expect(created).To(HaveLen(1))
+	})
+
+	It("should not create a second BuildRun for a schedule time that could not be recorded", func() {
+		buildSample.Spec.Trigger.When[0].Schedule.ConcurrencyPolicy = build.ReplaceConcurrencyPolicy
+		statusWriter.UpdateReturnsOnCall(0, k8serrors.NewConflict(schema.GroupResource{}, "a-build", fmt.Errorf("conflict")))
+
+		_, err := reconciler.Reconcile(context.TODO(), request)
+		Expect(err).To(HaveOccurred())
+		Expect(created).To(HaveLen(1))
+
+		// the BuildRun of the first attempt is still running
+		buildRuns = []build.BuildRun{*created[0]}
+
+		_, err = reconciler.Reconcile(context.TODO(), request)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(created).To(HaveLen(1))
+		Expect(updated).To(BeEmpty())
+
+		Expect(statusWriter.UpdateCallCount()).To(Equal(2))
+		_, object, _ := statusWriter.UpdateArgsForCall(1)
+		status := object.(*build.Build).Status.Trigger.GetSchedule("nightly")
+		Expect(status.LastScheduleTime.Time).To(BeTemporally("==", scheduled))
+	})
+
+	It("should evaluate the schedule in its time zone", func() {
+		buildSample.Status.Trigger = nil
+		buildSample.Spec.Trigger.When[0].Schedule.TimeZone = pointer.String("America/New_York")
+
+		_, err := reconciler.Reconcile(context.TODO(), request)
+		Expect(err).ToNot(HaveOccurred())
+
+		// 02:00 in New York during daylight saving time
+		status := updatedBuild().Status.Trigger.GetSchedule("nightly")
+		Expect(status.NextScheduleTime.Time).To(BeTemporally("==", time.Date(2023, time.June, 1, 6, 0, 0, 0, time.UTC)))
+	})
+
+	It("should drop the schedule status once the Build has no Schedule triggers anymore", func() {
+		buildSample.Spec.Trigger = nil
+
+		result, err := reconciler.Reconcile(context.TODO(), request)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result).To(Equal(reconcile.Result{}))
+		Expect(updatedBuild().Status.Trigger.Schedules).To(BeEmpty())
+	})
+
+	It("should stop reconciling if the Build does not exist", func() {
+		buildSample = nil
+
+		result, err := reconciler.Reconcile(context.TODO(), request)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result).To(Equal(reconcile.Result{}))
+	})
+})
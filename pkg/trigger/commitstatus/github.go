@@ -0,0 +1,56 @@
+// Copyright The Shipwright Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package commitstatus
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// gitHubDescriptionLimit is the maximum length of a commit status description
+const gitHubDescriptionLimit = 140
+
+// GitHub reports commit statuses using the GitHub statuses API
+type GitHub struct {
+	APIURL string
+	Token  string
+	Client *http.Client
+}
+
+type gitHubStatus struct {
+	State       string `json:"state"`
+	TargetURL   string `json:"target_url,omitempty"`
+	Description string `json:"description,omitempty"`
+	Context     string `json:"context"`
+}
+
+// Report creates a commit status for the revision, GitHub has no running and
+// canceled states, pending and error are used instead
+func (g *GitHub) Report(ctx context.Context, repository string, status Status) error {
+	var state string
+	switch status.State {
+	case StatePending, StateRunning:
+		state = "pending"
+	case StateSuccess:
+		state = "success"
+	case StateFailure:
+		state = "failure"
+	default:
+		state = "error"
+	}
+
+	header := http.Header{}
+	header.Set("Accept", "application/vnd.github+json")
+	header.Set("Authorization", "Bearer "+g.Token)
+
+	return post(ctx, g.Client, fmt.Sprintf("%s/repos/%s/statuses/%s", strings.TrimSuffix(g.APIURL, "/"), repository, status.Revision), header, gitHubStatus{
+		State:       state,
+		TargetURL:   status.TargetURL,
+		Description: truncate(status.Description, gitHubDescriptionLimit),
+		Context:     status.Context,
+	})
+}
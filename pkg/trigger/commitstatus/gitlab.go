@@ -0,0 +1,58 @@
+// Copyright The Shipwright Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package commitstatus
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// gitLabDescriptionLimit is the maximum length of a commit status description
+const gitLabDescriptionLimit = 255
+
+// GitLab reports commit statuses using the GitLab commit status API
+type GitLab struct {
+	APIURL string
+	Token  string
+	Client *http.Client
+}
+
+type gitLabStatus struct {
+	State       string `json:"state"`
+	Name        string `json:"name"`
+	TargetURL   string `json:"target_url,omitempty"`
+	Description string `json:"description,omitempty"`
+}
+
+// Report sets the commit status of the revision, the project is addressed by
+// its URL encoded path
+func (g *GitLab) Report(ctx context.Context, repository string, status Status) error {
+	var state string
+	switch status.State {
+	case StatePending:
+		state = "pending"
+	case StateRunning:
+		state = "running"
+	case StateSuccess:
+		state = "success"
+	case StateFailure:
+		state = "failed"
+	default:
+		state = "canceled"
+	}
+
+	header := http.Header{}
+	header.Set("PRIVATE-TOKEN", g.Token)
+
+	return post(ctx, g.Client, fmt.Sprintf("%s/projects/%s/statuses/%s", strings.TrimSuffix(g.APIURL, "/"), url.PathEscape(repository), status.Revision), header, gitLabStatus{
+		State:       state,
+		Name:        status.Context,
+		TargetURL:   status.TargetURL,
+		Description: truncate(status.Description, gitLabDescriptionLimit),
+	})
+}
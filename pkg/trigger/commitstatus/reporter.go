@@ -0,0 +1,139 @@
+// Copyright The Shipwright Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Package commitstatus reports the state of BuildRuns as commit status to the
+// Git provider hosting the source repository.
+package commitstatus
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	buildv1alpha1 "github.com/shipwright-io/build/pkg/apis/build/v1alpha1"
+	"github.com/shipwright-io/build/pkg/git"
+)
+
+// SecretTokenKey is the key in the commit status secret holding the Git provider API token
+const SecretTokenKey = "token"
+
+// State is the provider independent state of a commit status
+type State string
+
+const (
+	// StatePending the BuildRun was created, but did not start yet
+	StatePending State = "pending"
+
+	// StateRunning the BuildRun is running
+	StateRunning State = "running"
+
+	// StateSuccess the BuildRun succeeded
+	StateSuccess State = "success"
+
+	// StateFailure the BuildRun failed
+	StateFailure State = "failure"
+
+	// StateCanceled the BuildRun was canceled
+	StateCanceled State = "canceled"
+)
+
+// Status is a commit status to be reported for a revision
+type Status struct {
+	// Revision the commit SHA the status applies to
+	Revision string
+
+	// State of the BuildRun
+	State State
+
+	// Context the name distinguishing the status from the ones of other systems
+	Context string
+
+	// Description a short human readable description of the state
+	Description string
+
+	// TargetURL the link to the BuildRun
+	TargetURL string
+}
+
+// Reporter reports commit statuses to a Git provider
+type Reporter interface {
+	// Report posts the status for the revision of the repository, which is
+	// identified by its path, for example `shipwright-io/build`
+	Report(ctx context.Context, repository string, status Status) error
+}
+
+// NewReporter returns the Reporter for the Git provider of the trigger type. The
+// API URL defaults to the API of the repository host.
+func NewReporter(triggerType buildv1alpha1.TriggerType, host string, apiURL *string, token string) (Reporter, error) {
+	client := &http.Client{Timeout: 30 * time.Second}
+
+	switch triggerType {
+	case buildv1alpha1.GitHubWebHookTrigger:
+		return &GitHub{APIURL: GitHubAPIURL(host, apiURL), Token: token, Client: client}, nil
+
+	case buildv1alpha1.GitLabWebHookTrigger:
+		url := fmt.Sprintf("https://%s/api/v4", host)
+		if apiURL != nil && *apiURL != "" {
+			url = *apiURL
+		}
+		return &GitLab{APIURL: url, Token: token, Client: client}, nil
+
+	default:
+		return nil, fmt.Errorf("commit status reporting is not supported for trigger type %q", triggerType)
+	}
+}
+
+// GitHubAPIURL returns the configured API URL, or the API URL of the GitHub host
+func GitHubAPIURL(host string, apiURL *string) string {
+	if apiURL != nil && *apiURL != "" {
+		return *apiURL
+	}
+
+	return git.GitHubAPIURL(host)
+}
+
+// post sends the JSON body to the API and fails for responses other than 2xx
+func post(ctx context.Context, client *http.Client, url string, header http.Header, body interface{}) error {
+	data, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+
+	for key, values := range header {
+		req.Header[key] = values
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		message, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("failed to report commit status, %s returned %d: %s", url, resp.StatusCode, strings.TrimSpace(string(message)))
+	}
+
+	return nil
+}
+
+// truncate shortens the text to the maximum number of characters
+func truncate(text string, max int) string {
+	runes := []rune(text)
+	if len(runes) <= max {
+		return text
+	}
+	return string(runes[:max-3]) + "..."
+}
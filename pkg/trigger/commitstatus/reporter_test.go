@@ -0,0 +1,120 @@
+// Copyright The Shipwright Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package commitstatus_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"k8s.io/utils/pointer"
+
+	build "github.com/shipwright-io/build/pkg/apis/build/v1alpha1"
+	"github.com/shipwright-io/build/pkg/trigger/commitstatus"
+)
+
+var _ = Describe("Reporter", func() {
+	var (
+		server   *httptest.Server
+		requests []*http.Request
+		bodies   []map[string]string
+		code     int
+	)
+
+	status := commitstatus.Status{
+		Revision:    "0a1b2c3d4e5f60718293a4b5c6d7e8f901234567",
+		State:       commitstatus.StateFailure,
+		Context:     "shipwright/a-build",
+		Description: "BuildRun failed: " + strings.Repeat("x", 200),
+		TargetURL:   "https://console.example.com/a-buildrun",
+	}
+
+	BeforeEach(func() {
+		requests, bodies, code = nil, nil, http.StatusCreated
+
+		server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			body := map[string]string{}
+			Expect(json.NewDecoder(r.Body).Decode(&body)).To(Succeed())
+			requests, bodies = append(requests, r), append(bodies, body)
+			w.WriteHeader(code)
+			_, _ = w.Write([]byte(`{"message": "something"}`))
+		}))
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	Context("GitHub", func() {
+		It("should create a commit status", func() {
+			reporter, err := commitstatus.NewReporter(build.GitHubWebHookTrigger, "github.com", pointer.String(server.URL), "s3cr3t")
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(reporter.Report(context.TODO(), "shipwright-io/sample-go", status)).To(Succeed())
+
+			Expect(requests).To(HaveLen(1))
+			Expect(requests[0].Method).To(Equal(http.MethodPost))
+			Expect(requests[0].URL.Path).To(Equal("/repos/shipwright-io/sample-go/statuses/0a1b2c3d4e5f60718293a4b5c6d7e8f901234567"))
+			Expect(requests[0].Header.Get("Authorization")).To(Equal("Bearer s3cr3t"))
+			Expect(bodies[0]).To(HaveKeyWithValue("state", "failure"))
+			Expect(bodies[0]).To(HaveKeyWithValue("context", "shipwright/a-build"))
+			Expect(bodies[0]).To(HaveKeyWithValue("target_url", "https://console.example.com/a-buildrun"))
+			Expect(bodies[0]["description"]).To(HaveLen(140))
+		})
+
+		It("should report running BuildRuns as pending", func() {
+			reporter, err := commitstatus.NewReporter(build.GitHubWebHookTrigger, "github.com", pointer.String(server.URL), "s3cr3t")
+			Expect(err).ToNot(HaveOccurred())
+
+			running := status
+			running.State = commitstatus.StateRunning
+			Expect(reporter.Report(context.TODO(), "shipwright-io/sample-go", running)).To(Succeed())
+			Expect(bodies[0]).To(HaveKeyWithValue("state", "pending"))
+		})
+
+		It("should fail if the API rejects the status", func() {
+			code = http.StatusNotFound
+			reporter, err := commitstatus.NewReporter(build.GitHubWebHookTrigger, "github.com", pointer.String(server.URL), "s3cr3t")
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(reporter.Report(context.TODO(), "shipwright-io/sample-go", status)).To(MatchError(ContainSubstring("returned 404")))
+		})
+
+		It("should default to the API of the repository host", func() {
+			reporter, err := commitstatus.NewReporter(build.GitHubWebHookTrigger, "github.com", nil, "s3cr3t")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(reporter.(*commitstatus.GitHub).APIURL).To(Equal("https://api.github.com"))
+
+			reporter, err = commitstatus.NewReporter(build.GitHubWebHookTrigger, "github.example.com", nil, "s3cr3t")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(reporter.(*commitstatus.GitHub).APIURL).To(Equal("https://github.example.com/api/v3"))
+		})
+	})
+
+	Context("GitLab", func() {
+		It("should set the commit status of the project", func() {
+			reporter, err := commitstatus.NewReporter(build.GitLabWebHookTrigger, "gitlab.com", pointer.String(server.URL+"/api/v4"), "s3cr3t")
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(reporter.Report(context.TODO(), "group/subgroup/project", status)).To(Succeed())
+
+			Expect(requests).To(HaveLen(1))
+			Expect(requests[0].URL.EscapedPath()).To(Equal("/api/v4/projects/group%2Fsubgroup%2Fproject/statuses/0a1b2c3d4e5f60718293a4b5c6d7e8f901234567"))
+			Expect(requests[0].Header.Get("PRIVATE-TOKEN")).To(Equal("s3cr3t"))
+			Expect(bodies[0]).To(HaveKeyWithValue("state", "failed"))
+			Expect(bodies[0]).To(HaveKeyWithValue("name", "shipwright/a-build"))
+		})
+	})
+
+	It("should not support other trigger types", func() {
+		_, err := commitstatus.NewReporter(build.BitbucketWebHookTrigger, "bitbucket.org", nil, "s3cr3t")
+		Expect(err).To(HaveOccurred())
+	})
+})
@@ -0,0 +1,107 @@
+// Copyright The Shipwright Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package trigger
+
+import (
+	"path"
+	"strings"
+
+	buildv1alpha1 "github.com/shipwright-io/build/pkg/apis/build/v1alpha1"
+)
+
+// MatchesPaths checks whether the changed files satisfy the changed-path filters
+// of the trigger condition. Without filters, any change matches. Otherwise, at
+// least one changed file must be included, either by the include globs or by
+// being part of the context directory of the Build, and must not be excluded.
+func MatchesPaths(build *buildv1alpha1.Build, when *buildv1alpha1.TriggerWhen, changedFiles []string) bool {
+	paths := when.GetPaths(when.Type)
+	if paths == nil {
+		return true
+	}
+
+	include := paths.Include
+	if contextDir := cleanPath(build.Spec.Source.ContextDir); contextDir != "" {
+		include = append([]string{contextDir}, include...)
+	}
+
+	for _, file := range changedFiles {
+		file = strings.TrimPrefix(file, "/")
+
+		if len(include) > 0 && !matchesAnyGlob(include, file) {
+			continue
+		}
+
+		if matchesAnyGlob(paths.Exclude, file) {
+			continue
+		}
+
+		return true
+	}
+
+	return false
+}
+
+// ValidateGlob checks the syntax of a changed-path glob
+func ValidateGlob(glob string) error {
+	for _, segment := range strings.Split(strings.Trim(glob, "/"), "/") {
+		if _, err := path.Match(segment, ""); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// cleanPath returns the context directory relative to the repository root, or
+// an empty string if it is the repository root itself
+func cleanPath(contextDir *string) string {
+	if contextDir == nil {
+		return ""
+	}
+
+	return strings.TrimPrefix(path.Clean("/"+*contextDir), "/")
+}
+
+func matchesAnyGlob(globs []string, file string) bool {
+	for _, glob := range globs {
+		if matchesGlob(glob, file) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchesGlob matches the file path against the glob segment by segment, `**`
+// matches any number of segments. A glob matching a parent directory of the
+// file matches the file, too.
+func matchesGlob(glob string, file string) bool {
+	return matchSegments(strings.Split(strings.Trim(glob, "/"), "/"), strings.Split(file, "/"))
+}
+
+func matchSegments(glob []string, file []string) bool {
+	for len(glob) > 0 {
+		if glob[0] == "**" {
+			// try to match the remaining glob at every depth
+			for i := 0; i <= len(file); i++ {
+				if matchSegments(glob[1:], file[i:]) {
+					return true
+				}
+			}
+			return false
+		}
+
+		if len(file) == 0 {
+			return false
+		}
+
+		if ok, err := path.Match(glob[0], file[0]); err != nil || !ok {
+			return false
+		}
+
+		glob, file = glob[1:], file[1:]
+	}
+
+	// the glob matched the file or one of its parent directories
+	return true
+}
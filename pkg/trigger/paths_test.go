@@ -0,0 +1,80 @@
+// Copyright The Shipwright Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package trigger_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"k8s.io/utils/pointer"
+
+	build "github.com/shipwright-io/build/pkg/apis/build/v1alpha1"
+	"github.com/shipwright-io/build/pkg/trigger"
+)
+
+var _ = Describe("Paths", func() {
+	var b *build.Build
+
+	BeforeEach(func() {
+		b = &build.Build{
+			Spec: build.BuildSpec{
+				Source: build.Source{URL: pointer.String("https://github.com/shipwright-io/monorepo")},
+			},
+		}
+	})
+
+	withPaths := func(paths *build.WhenPaths) *build.TriggerWhen {
+		return &build.TriggerWhen{Type: build.GitHubWebHookTrigger, GitHub: &build.WhenGitHub{Paths: paths}}
+	}
+
+	It("should match any change without path filters", func() {
+		Expect(trigger.MatchesPaths(b, withPaths(nil), []string{"README.md"})).To(BeTrue())
+	})
+
+	It("should match changes in the context directory", func() {
+		b.Spec.Source.ContextDir = pointer.String("./services/api/")
+		when := withPaths(&build.WhenPaths{})
+
+		Expect(trigger.MatchesPaths(b, when, []string{"services/api/main.go"})).To(BeTrue())
+		Expect(trigger.MatchesPaths(b, when, []string{"services/web/main.go"})).To(BeFalse())
+	})
+
+	It("should match changes in the context directory or included paths", func() {
+		b.Spec.Source.ContextDir = pointer.String("services/api")
+		when := withPaths(&build.WhenPaths{Include: []string{"libs/**/*.go"}})
+
+		Expect(trigger.MatchesPaths(b, when, []string{"libs/common/log/log.go"})).To(BeTrue())
+		Expect(trigger.MatchesPaths(b, when, []string{"libs/common/README.md"})).To(BeFalse())
+	})
+
+	It("should not match excluded changes", func() {
+		b.Spec.Source.ContextDir = pointer.String("services/api")
+		when := withPaths(&build.WhenPaths{Exclude: []string{"**/*.md"}})
+
+		Expect(trigger.MatchesPaths(b, when, []string{"services/api/README.md"})).To(BeFalse())
+		Expect(trigger.MatchesPaths(b, when, []string{"services/api/README.md", "services/api/main.go"})).To(BeTrue())
+	})
+
+	It("should match everything but excluded changes without includes or context directory", func() {
+		when := withPaths(&build.WhenPaths{Exclude: []string{"docs"}})
+
+		Expect(trigger.MatchesPaths(b, when, []string{"docs/index.md"})).To(BeFalse())
+		Expect(trigger.MatchesPaths(b, when, []string{"main.go"})).To(BeTrue())
+	})
+
+	DescribeTable("glob validation",
+		func(glob string, valid bool) {
+			if valid {
+				Expect(trigger.ValidateGlob(glob)).To(Succeed())
+			} else {
+				Expect(trigger.ValidateGlob(glob)).ToNot(Succeed())
+			}
+		},
+		Entry("directory", "services/api", true),
+		Entry("double star", "**/*.go", true),
+		Entry("character class", "v[0-9]/*", true),
+		Entry("unterminated class", "v[0-9/*", false),
+	)
+})
@@ -0,0 +1,215 @@
+// Copyright The Shipwright Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package trigger
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	buildv1alpha1 "github.com/shipwright-io/build/pkg/apis/build/v1alpha1"
+)
+
+// maxScheduleSearch limits the search for the next schedule time, expressions
+// like `0 0 30 2 *` never match
+const maxScheduleSearch = 5 * 366 * 24 * time.Hour
+
+var cronDescriptors = map[string]string{
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+	"@monthly":  "0 0 1 * *",
+	"@weekly":   "0 0 * * 0",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@hourly":   "0 * * * *",
+}
+
+var monthNames = map[string]int{
+	"jan": 1, "feb": 2, "mar": 3, "apr": 4, "may": 5, "jun": 6,
+	"jul": 7, "aug": 8, "sep": 9, "oct": 10, "nov": 11, "dec": 12,
+}
+
+var dayNames = map[string]int{
+	"sun": 0, "mon": 1, "tue": 2, "wed": 3, "thu": 4, "fri": 5, "sat": 6,
+}
+
+type cronField struct {
+	name     string
+	min, max int
+	names    map[string]int
+}
+
+var (
+	minuteField     = cronField{name: "minute", min: 0, max: 59}
+	hourField       = cronField{name: "hour", min: 0, max: 23}
+	dayOfMonthField = cronField{name: "day of month", min: 1, max: 31}
+	monthField      = cronField{name: "month", min: 1, max: 12, names: monthNames}
+	dayOfWeekField  = cronField{name: "day of week", min: 0, max: 7, names: dayNames}
+)
+
+// Schedule is a parsed Schedule trigger condition
+type Schedule struct {
+	minute, hour, dayOfMonth, month, dayOfWeek uint64
+
+	// restricted day fields, if both are restricted a day matches when
+	// either of them matches
+	dayOfMonthRestricted, dayOfWeekRestricted bool
+
+	location *time.Location
+}
+
+// ParseSchedule parses the cron expression and the time zone of a Schedule
+// trigger condition
+func ParseSchedule(when *buildv1alpha1.WhenSchedule) (*Schedule, error) {
+	if when == nil {
+		return nil, fmt.Errorf("no schedule defined")
+	}
+
+	location := time.UTC
+	if when.TimeZone != nil && *when.TimeZone != "" {
+		var err error
+		if location, err = time.LoadLocation(*when.TimeZone); err != nil {
+			return nil, fmt.Errorf("unknown time zone %q", *when.TimeZone)
+		}
+	}
+
+	expr := strings.TrimSpace(when.Cron)
+	if descriptor, ok := cronDescriptors[strings.ToLower(expr)]; ok {
+		expr = descriptor
+	}
+
+	fields := strings.Fields(expr)
+	if len(fields) != 5 {
+		return nil, fmt.Errorf("invalid cron expression %q: expected 5 fields, found %d", when.Cron, len(fields))
+	}
+
+	s := &Schedule{location: location}
+	for i, f := range []struct {
+		field cronField
+		bits  *uint64
+	}{
+		{minuteField, &s.minute},
+		{hourField, &s.hour},
+		{dayOfMonthField, &s.dayOfMonth},
+		{monthField, &s.month},
+		{dayOfWeekField, &s.dayOfWeek},
+	} {
+		bits, err := parseCronField(fields[i], f.field)
+		if err != nil {
+			return nil, fmt.Errorf("invalid cron expression %q: %w", when.Cron, err)
+		}
+		*f.bits = bits
+	}
+
+	// Sunday can be written as 0 or 7
+	if s.dayOfWeek&(1<<7) != 0 {
+		s.dayOfWeek |= 1
+	}
+
+	s.dayOfMonthRestricted = !strings.HasPrefix(fields[2], "*")
+	s.dayOfWeekRestricted = !strings.HasPrefix(fields[4], "*")
+
+	return s, nil
+}
+
+// Next returns the first schedule time after the given time, or the zero time
+// if the schedule never matches
+func (s *Schedule) Next(after time.Time) time.Time {
+	t := after.In(s.location).Truncate(time.Minute).Add(time.Minute)
+	limit := t.Add(maxScheduleSearch)
+
+	for t.Before(limit) {
+		switch {
+		case s.month&(1<<uint(t.Month())) == 0:
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, s.location)
+
+		case !s.matchesDay(t):
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, s.location)
+
+		case s.hour&(1<<uint(t.Hour())) == 0:
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, s.location)
+
+		case s.minute&(1<<uint(t.Minute())) == 0:
+			t = t.Add(time.Minute)
+
+		default:
+			return t
+		}
+	}
+
+	return time.Time{}
+}
+
+func (s *Schedule) matchesDay(t time.Time) bool {
+	dayOfMonth := s.dayOfMonth&(1<<uint(t.Day())) != 0
+	dayOfWeek := s.dayOfWeek&(1<<uint(t.Weekday())) != 0
+
+	if s.dayOfMonthRestricted && s.dayOfWeekRestricted {
+		return dayOfMonth || dayOfWeek
+	}
+
+	return dayOfMonth && dayOfWeek
+}
+
+// parseCronField parses a comma separated list of values, ranges, and steps
+// into a bit set of the matching values
+func parseCronField(value string, field cronField) (uint64, error) {
+	var bits uint64
+	for _, part := range strings.Split(value, ",") {
+		rangeExpr, stepExpr, hasStep := strings.Cut(part, "/")
+
+		step := 1
+		if hasStep {
+			var err error
+			if step, err = strconv.Atoi(stepExpr); err != nil || step <= 0 {
+				return 0, fmt.Errorf("invalid step %q in %s field", stepExpr, field.name)
+			}
+		}
+
+		start, end := field.min, field.max
+		if rangeExpr != "*" {
+			startExpr, endExpr, isRange := strings.Cut(rangeExpr, "-")
+
+			var err error
+			if start, err = parseCronValue(startExpr, field); err != nil {
+				return 0, err
+			}
+
+			switch {
+			case isRange:
+				if end, err = parseCronValue(endExpr, field); err != nil {
+					return 0, err
+				}
+
+			case !hasStep:
+				end = start
+			}
+
+			if start > end {
+				return 0, fmt.Errorf("invalid range %q in %s field", rangeExpr, field.name)
+			}
+		}
+
+		for i := start; i <= end; i += step {
+			bits |= 1 << uint(i)
+		}
+	}
+
+	return bits, nil
+}
+
+func parseCronValue(value string, field cronField) (int, error) {
+	if i, ok := field.names[strings.ToLower(value)]; ok {
+		return i, nil
+	}
+
+	i, err := strconv.Atoi(value)
+	if err != nil || i < field.min || i > field.max {
+		return 0, fmt.Errorf("invalid value %q in %s field", value, field.name)
+	}
+
+	return i, nil
+}
@@ -0,0 +1,68 @@
+// Copyright The Shipwright Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package trigger_test
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"k8s.io/utils/pointer"
+
+	build "github.com/shipwright-io/build/pkg/apis/build/v1alpha1"
+	"github.com/shipwright-io/build/pkg/trigger"
+)
+
+var _ = Describe("Schedule", func() {
+	// Thursday, 1st of June 2023
+	var now = time.Date(2023, time.June, 1, 10, 17, 30, 0, time.UTC)
+
+	next := func(cron string, timeZone *string) time.Time {
+		schedule, err := trigger.ParseSchedule(&build.WhenSchedule{Cron: cron, TimeZone: timeZone})
+		Expect(err).ToNot(HaveOccurred())
+		return schedule.Next(now)
+	}
+
+	DescribeTable("next schedule time",
+		func(cron string, expected time.Time) {
+			Expect(next(cron, nil)).To(Equal(expected.In(time.UTC)))
+		},
+		Entry("every minute", "* * * * *", time.Date(2023, time.June, 1, 10, 18, 0, 0, time.UTC)),
+		Entry("every 15 minutes", "*/15 * * * *", time.Date(2023, time.June, 1, 10, 30, 0, 0, time.UTC)),
+		Entry("nightly", "0 2 * * *", time.Date(2023, time.June, 2, 2, 0, 0, 0, time.UTC)),
+		Entry("list and range", "0 9-11,14 * * *", time.Date(2023, time.June, 1, 11, 0, 0, 0, time.UTC)),
+		Entry("weekdays by name", "0 8 * * MON-FRI", time.Date(2023, time.June, 2, 8, 0, 0, 0, time.UTC)),
+		Entry("sunday as 7", "0 0 * * 7", time.Date(2023, time.June, 4, 0, 0, 0, 0, time.UTC)),
+		Entry("month by name", "0 0 1 JAN *", time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)),
+		Entry("day of month or day of week", "0 0 15 * SAT", time.Date(2023, time.June, 3, 0, 0, 0, 0, time.UTC)),
+		Entry("leap day", "0 0 29 2 *", time.Date(2024, time.February, 29, 0, 0, 0, 0, time.UTC)),
+		Entry("descriptor", "@monthly", time.Date(2023, time.July, 1, 0, 0, 0, 0, time.UTC)),
+	)
+
+	It("should evaluate the schedule in the time zone", func() {
+		berlin, err := time.LoadLocation("Europe/Berlin")
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(next("0 2 * * *", pointer.String("Europe/Berlin"))).To(Equal(time.Date(2023, time.June, 2, 2, 0, 0, 0, berlin)))
+	})
+
+	It("should return the zero time for a schedule that never matches", func() {
+		Expect(next("0 0 30 2 *", nil).IsZero()).To(BeTrue())
+	})
+
+	DescribeTable("invalid schedules",
+		func(schedule build.WhenSchedule, message string) {
+			_, err := trigger.ParseSchedule(&schedule)
+			Expect(err).To(MatchError(ContainSubstring(message)))
+		},
+		Entry("too few fields", build.WhenSchedule{Cron: "0 2 * *"}, "expected 5 fields"),
+		Entry("out of range", build.WhenSchedule{Cron: "60 * * * *"}, "invalid value \"60\" in minute field"),
+		Entry("invalid step", build.WhenSchedule{Cron: "*/0 * * * *"}, "invalid step"),
+		Entry("inverted range", build.WhenSchedule{Cron: "0 10-8 * * *"}, "invalid range"),
+		Entry("unknown name", build.WhenSchedule{Cron: "0 0 * * MONDAY"}, "in day of week field"),
+		Entry("unknown time zone", build.WhenSchedule{Cron: "@daily", TimeZone: pointer.String("Nowhere/Town")}, "unknown time zone"),
+	)
+})
@@ -0,0 +1,216 @@
+// Copyright The Shipwright Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Package trigger contains the shared logic to match external events against
+// the `.spec.trigger` of Build resources and to create the resulting BuildRuns.
+package trigger
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"net/url"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+
+	buildv1alpha1 "github.com/shipwright-io/build/pkg/apis/build/v1alpha1"
+)
+
+// DefaultRevision is the branch name assumed when neither the trigger nor the
+// Build source define a revision
+const DefaultRevision = "main"
+
+// NewBuildRun returns a BuildRun for the given Build, annotated with the trigger
+// condition and event that caused it. When revision is set, the BuildRun pins
+// the Git source to it.
+func NewBuildRun(build *buildv1alpha1.Build, when *buildv1alpha1.TriggerWhen, event string, revision *string) *buildv1alpha1.BuildRun {
+	return &buildv1alpha1.BuildRun{
+		ObjectMeta: metav1.ObjectMeta{
+			GenerateName: build.Name + "-",
+			Namespace:    build.Namespace,
+			Labels: map[string]string{
+				buildv1alpha1.LabelBuild: build.Name,
+			},
+			Annotations: map[string]string{
+				buildv1alpha1.AnnotationBuildRunTriggerName:  when.Name,
+				buildv1alpha1.AnnotationBuildRunTriggerType:  string(when.Type),
+				buildv1alpha1.AnnotationBuildRunTriggerEvent: event,
+			},
+		},
+		Spec: buildv1alpha1.BuildRunSpec{
+			BuildRef: &buildv1alpha1.BuildRef{
+				Name: build.Name,
+			},
+			Revision: revision,
+		},
+	}
+}
+
+// WithDeterministicName sets a name on the BuildRun that is derived from the
+// key instead of generating one. A controller that creates the BuildRun for
+// the same key again, for example after it failed to record that it already
+// created it, gets an AlreadyExists error instead of creating a duplicate.
+func WithDeterministicName(buildRun *buildv1alpha1.BuildRun, key string) *buildv1alpha1.BuildRun {
+	// the BuildRun name is used as label value and must not exceed 63 characters
+	prefix := strings.TrimSuffix(buildRun.GenerateName, "-")
+	if len(prefix) > 52 {
+		prefix = strings.TrimRight(prefix[:52], "-.")
+	}
+
+	hash := sha256.Sum256([]byte(key))
+	buildRun.GenerateName = ""
+	buildRun.Name = fmt.Sprintf("%s-%x", prefix, hash[:5])
+	return buildRun
+}
+
+// TriggersOfType returns the trigger conditions of the Build matching the given type
+func TriggersOfType(build *buildv1alpha1.Build, triggerType buildv1alpha1.TriggerType) []buildv1alpha1.TriggerWhen {
+	if build.Spec.Trigger == nil {
+		return nil
+	}
+
+	var result []buildv1alpha1.TriggerWhen
+	for _, when := range build.Spec.Trigger.When {
+		if when.Type == triggerType {
+			result = append(result, when)
+		}
+	}
+
+	return result
+}
+
+// SameRepository compares two Git repository URLs ignoring the scheme, letter
+// case of the host, credentials, trailing slashes, and the `.git` suffix
+func SameRepository(a, b string) bool {
+	na, nb := normalizeRepositoryURL(a), normalizeRepositoryURL(b)
+	return na != "" && na == nb
+}
+
+// normalizeRepositoryURL reduces a repository URL to `host/path`
+func normalizeRepositoryURL(repoURL string) string {
+	host, path, ok := SplitRepositoryURL(repoURL)
+	if !ok {
+		return ""
+	}
+	return host + "/" + path
+}
+
+// SplitRepositoryURL splits a Git repository URL into the lower case host and
+// the repository path without the `.git` suffix, for example `github.com` and
+// `shipwright-io/build`. Both URLs and the scp-like syntax are supported.
+func SplitRepositoryURL(repoURL string) (string, string, bool) {
+	repoURL = strings.TrimSpace(repoURL)
+
+	var host, path string
+	switch {
+	case strings.Contains(repoURL, "://"):
+		u, err := url.Parse(repoURL)
+		if err != nil {
+			return "", "", false
+		}
+		host, path = u.Hostname(), u.Path
+
+	// scp-like syntax, for example git@github.com:shipwright-io/build.git
+	case strings.Contains(repoURL, ":"):
+		parts := strings.SplitN(repoURL, ":", 2)
+		host, path = parts[0], parts[1]
+		if i := strings.LastIndex(host, "@"); i >= 0 {
+			host = host[i+1:]
+		}
+
+	default:
+		return "", "", false
+	}
+
+	path = strings.Trim(path, "/")
+	path = strings.TrimSuffix(path, ".git")
+	return strings.ToLower(host), path, true
+}
+
+// MatchesBranch checks whether the branch is covered by the trigger condition.
+// The branches of the condition are checked first, when empty the Build source
+// revision is used, and ultimately the default revision.
+func MatchesBranch(build *buildv1alpha1.Build, when *buildv1alpha1.TriggerWhen, branch string) bool {
+	if branches := when.GetBranches(when.Type); len(branches) > 0 {
+		for _, b := range branches {
+			if b == branch {
+				return true
+			}
+		}
+		return false
+	}
+
+	if build.Spec.Source.Revision != nil && *build.Spec.Source.Revision != "" {
+		return *build.Spec.Source.Revision == branch
+	}
+
+	return branch == DefaultRevision
+}
+
+// BranchFromRef extracts the branch name out of a Git reference, for example
+// `refs/heads/main`. It returns false for references that are not branches.
+func BranchFromRef(ref string) (string, bool) {
+	if !strings.HasPrefix(ref, "refs/heads/") {
+		return "", false
+	}
+	return strings.TrimPrefix(ref, "refs/heads/"), true
+}
+
+// TagFromRef extracts the tag name out of a Git reference, for example
+// `refs/tags/v1.0.0`. It returns false for references that are not tags.
+func TagFromRef(ref string) (string, bool) {
+	if !strings.HasPrefix(ref, "refs/tags/") {
+		return "", false
+	}
+	return strings.TrimPrefix(ref, "refs/tags/"), true
+}
+
+// IsZeroCommit checks whether the commit SHA consists of zeros only, Git
+// providers use it as the new revision of deleted references
+func IsZeroCommit(sha string) bool {
+	return strings.Trim(sha, "0") == ""
+}
+
+// MatchesObjectRef checks whether an object, identified by its names and labels, is
+// referenced by the trigger condition. The condition either refers to one of the
+// names, or selects the object by its labels.
+func MatchesObjectRef(when *buildv1alpha1.TriggerWhen, names []string, objectLabels map[string]string) bool {
+	if when.ObjectRef == nil {
+		return false
+	}
+
+	if when.ObjectRef.Name != "" {
+		for _, n := range names {
+			if n == when.ObjectRef.Name {
+				return true
+			}
+		}
+		return false
+	}
+
+	if len(when.ObjectRef.Selector) == 0 {
+		return false
+	}
+
+	return labels.SelectorFromSet(when.ObjectRef.Selector).Matches(labels.Set(objectLabels))
+}
+
+// MatchesObjectStatus checks whether one of the given status values of an object is
+// listed in the trigger condition
+func MatchesObjectStatus(when *buildv1alpha1.TriggerWhen, status ...string) bool {
+	if when.ObjectRef == nil {
+		return false
+	}
+
+	for _, expected := range when.ObjectRef.Status {
+		for _, s := range status {
+			if s != "" && s == expected {
+				return true
+			}
+		}
+	}
+
+	return false
+}
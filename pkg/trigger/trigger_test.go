@@ -0,0 +1,196 @@
+// Copyright The Shipwright Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package trigger_test
+
+import (
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+
+	build "github.com/shipwright-io/build/pkg/apis/build/v1alpha1"
+	"github.com/shipwright-io/build/pkg/trigger"
+)
+
+var _ = Describe("Trigger", func() {
+	var b *build.Build
+
+	BeforeEach(func() {
+		b = &build.Build{
+			ObjectMeta: metav1.ObjectMeta{Name: "a-build", Namespace: "a-namespace"},
+			Spec: build.BuildSpec{
+				Source: build.Source{URL: pointer.String("https://github.com/shipwright-io/sample-go")},
+				Trigger: &build.Trigger{
+					When: []build.TriggerWhen{
+						{Name: "push", Type: build.GitHubWebHookTrigger, GitHub: &build.WhenGitHub{Events: []build.GitHubEventName{build.GitHubPushEvent}}},
+						{Name: "image", Type: build.ImageTrigger, Image: &build.WhenImage{Names: []string{"ghcr.io/some/base-image"}}},
+					},
+				},
+			},
+		}
+	})
+
+	Context("NewBuildRun", func() {
+		It("should reference the Build and carry the trigger details", func() {
+			buildRun := trigger.NewBuildRun(b, &b.Spec.Trigger.When[0], "Push", pointer.String("abcdef"))
+
+			Expect(buildRun.GenerateName).To(Equal("a-build-"))
+			Expect(buildRun.Namespace).To(Equal("a-namespace"))
+			Expect(buildRun.Labels).To(HaveKeyWithValue(build.LabelBuild, "a-build"))
+			Expect(buildRun.Annotations).To(HaveKeyWithValue(build.AnnotationBuildRunTriggerName, "push"))
+			Expect(buildRun.Annotations).To(HaveKeyWithValue(build.AnnotationBuildRunTriggerType, "GitHub"))
+			Expect(buildRun.Annotations).To(HaveKeyWithValue(build.AnnotationBuildRunTriggerEvent, "Push"))
+			Expect(buildRun.Spec.BuildRef.Name).To(Equal("a-build"))
+			Expect(buildRun.Spec.Revision).To(Equal(pointer.String("abcdef")))
+		})
+	})
+
+	Context("WithDeterministicName", func() {
+		It("should derive the same name from the same key", func() {
+			first := trigger.WithDeterministicName(trigger.NewBuildRun(b, &b.Spec.Trigger.When[1], "ImageChange", nil), "key")
+			second := trigger.WithDeterministicName(trigger.NewBuildRun(b, &b.Spec.Trigger.When[1], "ImageChange", nil), "key")
+			other := trigger.WithDeterministicName(trigger.NewBuildRun(b, &b.Spec.Trigger.When[1], "ImageChange", nil), "other-key")
+
+			Expect(first.GenerateName).To(BeEmpty())
+			Expect(first.Name).To(HavePrefix("a-build-"))
+			Expect(first.Name).To(Equal(second.Name))
+			Expect(first.Name).ToNot(Equal(other.Name))
+		})
+
+		It("should keep the name of a long Build within the label value limit", func() {
+			b.Name = strings.Repeat("a", 63)
+			buildRun := trigger.WithDeterministicName(trigger.NewBuildRun(b, &b.Spec.Trigger.When[1], "ImageChange", nil), "key")
+
+			Expect(len(buildRun.Name)).To(BeNumerically("<=", 63))
+		})
+	})
+
+	Context("TriggersOfType", func() {
+		It("should only return the conditions of the requested type", func() {
+			whens := trigger.TriggersOfType(b, build.ImageTrigger)
+			Expect(whens).To(HaveLen(1))
+			Expect(whens[0].Name).To(Equal("image"))
+		})
+
+		It("should return nothing for a Build without triggers", func() {
+			b.Spec.Trigger = nil
+			Expect(trigger.TriggersOfType(b, build.GitHubWebHookTrigger)).To(BeEmpty())
+		})
+	})
+
+	Context("SameRepository", func() {
+		DescribeTable("comparing repository URLs",
+			func(a, b string, expected bool) {
+				Expect(trigger.SameRepository(a, b)).To(Equal(expected))
+			},
+			Entry("identical", "https://github.com/shipwright-io/build", "https://github.com/shipwright-io/build", true),
+			Entry("git suffix", "https://github.com/shipwright-io/build", "https://github.com/shipwright-io/build.git", true),
+			Entry("trailing slash", "https://github.com/shipwright-io/build/", "https://github.com/shipwright-io/build", true),
+			Entry("host case", "https://GitHub.com/shipwright-io/build", "https://github.com/shipwright-io/build", true),
+			Entry("scp-like syntax", "git@github.com:shipwright-io/build.git", "https://github.com/shipwright-io/build", true),
+			Entry("ssh scheme", "ssh://git@github.com/shipwright-io/build.git", "https://github.com/shipwright-io/build", true),
+			Entry("different repository", "https://github.com/shipwright-io/build", "https://github.com/shipwright-io/cli", false),
+			Entry("different host", "https://github.com/shipwright-io/build", "https://gitlab.com/shipwright-io/build", false),
+			Entry("empty", "", "", false),
+		)
+	})
+
+	Context("SplitRepositoryURL", func() {
+		It("should split a URL into host and path", func() {
+			host, path, ok := trigger.SplitRepositoryURL("https://GitLab.com/group/subgroup/project.git")
+			Expect(ok).To(BeTrue())
+			Expect(host).To(Equal("gitlab.com"))
+			Expect(path).To(Equal("group/subgroup/project"))
+		})
+
+		It("should split the scp-like syntax", func() {
+			host, path, ok := trigger.SplitRepositoryURL("git@github.com:shipwright-io/build.git")
+			Expect(ok).To(BeTrue())
+			Expect(host).To(Equal("github.com"))
+			Expect(path).To(Equal("shipwright-io/build"))
+		})
+	})
+
+	Context("MatchesBranch", func() {
+		It("should match against the branches of the trigger condition", func() {
+			when := &build.TriggerWhen{Type: build.GitHubWebHookTrigger, GitHub: &build.WhenGitHub{Branches: []string{"release"}}}
+			Expect(trigger.MatchesBranch(b, when, "release")).To(BeTrue())
+			Expect(trigger.MatchesBranch(b, when, "main")).To(BeFalse())
+		})
+
+		It("should match against the source revision when the condition has no branches", func() {
+			b.Spec.Source.Revision = pointer.String("develop")
+			Expect(trigger.MatchesBranch(b, &b.Spec.Trigger.When[0], "develop")).To(BeTrue())
+			Expect(trigger.MatchesBranch(b, &b.Spec.Trigger.When[0], "main")).To(BeFalse())
+		})
+
+		It("should match the default revision as last resort", func() {
+			Expect(trigger.MatchesBranch(b, &b.Spec.Trigger.When[0], "main")).To(BeTrue())
+			Expect(trigger.MatchesBranch(b, &b.Spec.Trigger.When[0], "develop")).To(BeFalse())
+		})
+	})
+
+	Context("MatchesObjectRef", func() {
+		It("should match an object by name", func() {
+			when := &build.TriggerWhen{ObjectRef: &build.WhenObjectRef{Name: "tests"}}
+			Expect(trigger.MatchesObjectRef(when, []string{"tests-abcde", "tests"}, nil)).To(BeTrue())
+			Expect(trigger.MatchesObjectRef(when, []string{"other"}, nil)).To(BeFalse())
+		})
+
+		It("should match an object by labels", func() {
+			when := &build.TriggerWhen{ObjectRef: &build.WhenObjectRef{Selector: map[string]string{"team": "a"}}}
+			Expect(trigger.MatchesObjectRef(when, nil, map[string]string{"team": "a", "other": "label"})).To(BeTrue())
+			Expect(trigger.MatchesObjectRef(when, nil, map[string]string{"team": "b"})).To(BeFalse())
+		})
+
+		It("should not match without an object reference", func() {
+			Expect(trigger.MatchesObjectRef(&build.TriggerWhen{}, []string{"tests"}, nil)).To(BeFalse())
+		})
+	})
+
+	Context("MatchesObjectStatus", func() {
+		It("should match any of the listed status values", func() {
+			when := &build.TriggerWhen{ObjectRef: &build.WhenObjectRef{Status: []string{"Succeeded", "Completed"}}}
+			Expect(trigger.MatchesObjectStatus(when, "Failed", "Completed")).To(BeTrue())
+			Expect(trigger.MatchesObjectStatus(when, "Failed", "Cancelled")).To(BeFalse())
+		})
+	})
+
+	Context("BranchFromRef", func() {
+		It("should extract the branch name", func() {
+			branch, ok := trigger.BranchFromRef("refs/heads/feature/a")
+			Expect(ok).To(BeTrue())
+			Expect(branch).To(Equal("feature/a"))
+		})
+
+		It("should ignore tags", func() {
+			_, ok := trigger.BranchFromRef("refs/tags/v1.0.0")
+			Expect(ok).To(BeFalse())
+		})
+	})
+
+	Context("TagFromRef", func() {
+		It("should extract the tag name", func() {
+			tag, ok := trigger.TagFromRef("refs/tags/v1.0.0")
+			Expect(ok).To(BeTrue())
+			Expect(tag).To(Equal("v1.0.0"))
+		})
+
+		It("should ignore branches", func() {
+			_, ok := trigger.TagFromRef("refs/heads/main")
+			Expect(ok).To(BeFalse())
+		})
+	})
+
+	Context("IsZeroCommit", func() {
+		It("should detect the zero commit", func() {
+			Expect(trigger.IsZeroCommit("0000000000000000000000000000000000000000")).To(BeTrue())
+			Expect(trigger.IsZeroCommit("0a1b2c3d4e5f60718293a4b5c6d7e8f901234567")).To(BeFalse())
+		})
+	})
+})
@@ -0,0 +1,212 @@
+// Copyright The Shipwright Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package webhook
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	buildv1alpha1 "github.com/shipwright-io/build/pkg/apis/build/v1alpha1"
+)
+
+const (
+	// BitbucketEventHeader is the request header carrying the Bitbucket event key
+	BitbucketEventHeader = "X-Event-Key"
+
+	// BitbucketSignatureHeader is the request header carrying the HMAC-SHA256 signature
+	BitbucketSignatureHeader = "X-Hub-Signature"
+)
+
+type bitbucketRepository struct {
+	FullName string `json:"full_name"`
+	Links    struct {
+		HTML struct {
+			Href string `json:"href"`
+		} `json:"html"`
+	} `json:"links"`
+}
+
+func (r bitbucketRepository) urls() []string {
+	urls := []string{r.Links.HTML.Href}
+	if r.FullName != "" {
+		urls = append(urls, fmt.Sprintf("git@bitbucket.org:%s.git", r.FullName))
+	}
+	return urls
+}
+
+type bitbucketPushEvent struct {
+	Push struct {
+		Changes []struct {
+			New *struct {
+				Type   string `json:"type"`
+				Name   string `json:"name"`
+				Target struct {
+					Hash string `json:"hash"`
+				} `json:"target"`
+			} `json:"new"`
+		} `json:"changes"`
+	} `json:"push"`
+	Repository bitbucketRepository `json:"repository"`
+}
+
+type bitbucketPullRequestEvent struct {
+	PullRequest struct {
+		Links struct {
+			Self struct {
+				Href string `json:"href"`
+			} `json:"self"`
+		} `json:"links"`
+		Source struct {
+			Commit struct {
+				Hash string `json:"hash"`
+			} `json:"commit"`
+		} `json:"source"`
+		Destination struct {
+			Branch struct {
+				Name string `json:"name"`
+			} `json:"branch"`
+		} `json:"destination"`
+	} `json:"pullrequest"`
+	Repository bitbucketRepository `json:"repository"`
+}
+
+// Bitbucket implements the Provider interface for Bitbucket Cloud webhooks
+type Bitbucket struct{}
+
+// TriggerType returns the Bitbucket trigger type
+func (Bitbucket) TriggerType() buildv1alpha1.TriggerType {
+	return buildv1alpha1.BitbucketWebHookTrigger
+}
+
+// Events returns the Bitbucket event names of the trigger condition
+func (Bitbucket) Events(when *buildv1alpha1.TriggerWhen) []string {
+	if when.Bitbucket == nil {
+		return nil
+	}
+
+	events := make([]string, 0, len(when.Bitbucket.Events))
+	for _, e := range when.Bitbucket.Events {
+		events = append(events, string(e))
+	}
+	return events
+}
+
+// Parse parses repository push and pull request events, pushes of tags are
+// reported as tag events, other events are ignored
+func (Bitbucket) Parse(req *http.Request, payload []byte) (*Event, error) {
+	switch req.Header.Get(BitbucketEventHeader) {
+	case "repo:push":
+		var push bitbucketPushEvent
+		if err := json.Unmarshal(payload, &push); err != nil {
+			return nil, fmt.Errorf("failed to parse push event: %w", err)
+		}
+
+		// a push can update multiple references, the first created or updated
+		// one is used, deleted references have no new state
+		for _, change := range push.Push.Changes {
+			if change.New == nil {
+				continue
+			}
+
+			switch change.New.Type {
+			case "branch":
+				return &Event{
+					Name:           string(buildv1alpha1.BitbucketPushEvent),
+					RepositoryURLs: push.Repository.urls(),
+					Branch:         change.New.Name,
+					Revision:       change.New.Target.Hash,
+				}, nil
+
+			case "tag", "annotated_tag":
+				return &Event{
+					Name:           string(buildv1alpha1.BitbucketTagEvent),
+					RepositoryURLs: push.Repository.urls(),
+					Tag:            change.New.Name,
+					Revision:       change.New.Target.Hash,
+				}, nil
+			}
+		}
+
+		return nil, nil
+
+	case "pullrequest:created", "pullrequest:updated":
+		var pr bitbucketPullRequestEvent
+		if err := json.Unmarshal(payload, &pr); err != nil {
+			return nil, fmt.Errorf("failed to parse pull request event: %w", err)
+		}
+
+		event := &Event{
+			Name:           string(buildv1alpha1.BitbucketPullRequestEvent),
+			RepositoryURLs: pr.Repository.urls(),
+			Branch:         pr.PullRequest.Destination.Branch.Name,
+			Revision:       pr.PullRequest.Source.Commit.Hash,
+		}
+
+		// the self link is the API URL of the pull request
+		if pr.PullRequest.Links.Self.Href != "" {
+			event.ChangedFilesURL = pr.PullRequest.Links.Self.Href + "/diffstat?pagelen=100"
+		}
+
+		return event, nil
+
+	case "":
+		return nil, fmt.Errorf("missing %s header", BitbucketEventHeader)
+
+	default:
+		return nil, nil
+	}
+}
+
+// ChangedFiles lists the files changed by a pull request using its diffstat,
+// the pages link to the next page in the response body
+func (Bitbucket) ChangedFiles(ctx context.Context, client *http.Client, event *Event, apiToken []byte) ([]string, error) {
+	header := http.Header{}
+	if len(apiToken) > 0 {
+		header.Set("Authorization", "Bearer "+string(apiToken))
+	}
+
+	return requestChangedFiles(ctx, client, event.ChangedFilesURL, header, func(_ *http.Response, body []byte) ([]string, string, error) {
+		type file struct {
+			Path string `json:"path"`
+		}
+
+		var diffstat struct {
+			Values []struct {
+				Old *file `json:"old"`
+				New *file `json:"new"`
+			} `json:"values"`
+			Next string `json:"next"`
+		}
+
+		if err := json.Unmarshal(body, &diffstat); err != nil {
+			return nil, "", err
+		}
+
+		paths := make([]string, 0, len(diffstat.Values))
+		for _, value := range diffstat.Values {
+			// added files have no old and removed files no new path
+			for _, f := range []*file{value.New, value.Old} {
+				if f != nil {
+					paths = append(paths, f.Path)
+				}
+			}
+		}
+
+		return paths, diffstat.Next, nil
+	})
+}
+
+// Verify validates the HMAC-SHA256 signature of the payload
+func (Bitbucket) Verify(req *http.Request, payload []byte, secret []byte) error {
+	signature := req.Header.Get(BitbucketSignatureHeader)
+	if signature == "" {
+		return ErrMissingSignature
+	}
+
+	return verifyHMAC(sha256.New, "sha256=", signature, payload, secret)
+}
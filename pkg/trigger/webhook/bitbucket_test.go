@@ -0,0 +1,125 @@
+// Copyright The Shipwright Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package webhook_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/shipwright-io/build/pkg/trigger/webhook"
+)
+
+const bitbucketPushPayload = `{
+  "push": {
+    "changes": [
+      {"new": null},
+      {"new": {"type": "branch", "name": "main", "target": {"hash": "0a1b2c3d4e5f60718293a4b5c6d7e8f901234567"}}}
+    ]
+  },
+  "repository": {
+    "full_name": "shipwright-io/sample-go",
+    "links": {"html": {"href": "https://bitbucket.org/shipwright-io/sample-go"}}
+  }
+}`
+
+const bitbucketPullRequestPayload = `{
+  "pullrequest": {
+    "links": {"self": {"href": "https://api.bitbucket.org/2.0/repositories/shipwright-io/sample-go/pullrequests/3"}},
+    "source": {"commit": {"hash": "fedcba987654"}},
+    "destination": {"branch": {"name": "main"}}
+  },
+  "repository": {
+    "full_name": "shipwright-io/sample-go",
+    "links": {"html": {"href": "https://bitbucket.org/shipwright-io/sample-go"}}
+  }
+}`
+
+func bitbucketRequest(event string, payload string) *http.Request {
+	req := httptest.NewRequest(http.MethodPost, "/bitbucket", strings.NewReader(payload))
+	req.Header.Set(webhook.BitbucketEventHeader, event)
+	return req
+}
+
+var _ = Describe("Bitbucket", func() {
+	var provider webhook.Bitbucket
+
+	Context("Parse", func() {
+		It("should parse a push event skipping deleted references", func() {
+			event, err := provider.Parse(bitbucketRequest("repo:push", bitbucketPushPayload), []byte(bitbucketPushPayload))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(event).ToNot(BeNil())
+			Expect(event.Name).To(Equal("Push"))
+			Expect(event.Branch).To(Equal("main"))
+			Expect(event.Revision).To(Equal("0a1b2c3d4e5f60718293a4b5c6d7e8f901234567"))
+			Expect(event.RepositoryURLs).To(ConsistOf(
+				"https://bitbucket.org/shipwright-io/sample-go",
+				"git@bitbucket.org:shipwright-io/sample-go.git",
+			))
+		})
+
+		It("should parse a push of a tag as tag event", func() {
+			payload := strings.Replace(bitbucketPushPayload, `"type": "branch", "name": "main"`, `"type": "tag", "name": "v1.0.0"`, 1)
+			event, err := provider.Parse(bitbucketRequest("repo:push", payload), []byte(payload))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(event).ToNot(BeNil())
+			Expect(event.Name).To(Equal("Tag"))
+			Expect(event.Tag).To(Equal("v1.0.0"))
+		})
+
+		It("should parse a pull request update", func() {
+			event, err := provider.Parse(bitbucketRequest("pullrequest:updated", bitbucketPullRequestPayload), []byte(bitbucketPullRequestPayload))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(event).ToNot(BeNil())
+			Expect(event.Name).To(Equal("PullRequest"))
+			Expect(event.Branch).To(Equal("main"))
+			Expect(event.Revision).To(Equal("fedcba987654"))
+			Expect(event.ChangedFilesURL).To(Equal("https://api.bitbucket.org/2.0/repositories/shipwright-io/sample-go/pullrequests/3/diffstat?pagelen=100"))
+		})
+
+		It("should ignore other events", func() {
+			event, err := provider.Parse(bitbucketRequest("pullrequest:fulfilled", bitbucketPullRequestPayload), []byte(bitbucketPullRequestPayload))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(event).To(BeNil())
+		})
+	})
+
+	Context("ChangedFiles", func() {
+		It("should list the files of all pages of the diffstat", func() {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				Expect(r.Header.Get("Authorization")).To(Equal("Bearer bitbucket_token"))
+				if r.URL.Query().Get("page") == "" {
+					fmt.Fprintf(w, `{"values": [{"old": null, "new": {"path": "services/api/main.go"}}], "next": "http://%s/diffstat?page=2"}`, r.Host)
+					return
+				}
+				fmt.Fprint(w, `{"values": [{"old": {"path": "docs/old.md"}, "new": null}]}`)
+			}))
+			defer server.Close()
+
+			files, err := provider.ChangedFiles(context.TODO(), server.Client(), &webhook.Event{ChangedFilesURL: server.URL + "/diffstat"}, []byte("bitbucket_token"))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(files).To(Equal([]string{"services/api/main.go", "docs/old.md"}))
+		})
+	})
+
+	Context("Verify", func() {
+		It("should accept a valid signature", func() {
+			req := bitbucketRequest("repo:push", bitbucketPushPayload)
+			req.Header.Set(webhook.BitbucketSignatureHeader, sign(bitbucketPushPayload, "s3cr3t"))
+			Expect(provider.Verify(req, []byte(bitbucketPushPayload), []byte("s3cr3t"))).To(Succeed())
+		})
+
+		It("should reject a signature created with a different secret", func() {
+			req := bitbucketRequest("repo:push", bitbucketPushPayload)
+			req.Header.Set(webhook.BitbucketSignatureHeader, sign(bitbucketPushPayload, "other"))
+			Expect(provider.Verify(req, []byte(bitbucketPushPayload), []byte("s3cr3t"))).To(MatchError(webhook.ErrInvalidSignature))
+		})
+	})
+})
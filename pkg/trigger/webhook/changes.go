@@ -0,0 +1,126 @@
+// Copyright The Shipwright Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package webhook
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// SecretAPITokenKey is the optional key in the trigger secret holding the
+// token that is used to list the files changed by pull and merge requests
+// using the API of the provider, it is required for private repositories
+const SecretAPITokenKey = "api-token"
+
+// maxChangedFilesPages is the upper limit for the number of pages requested
+// from the changed files API, GitHub stops listing the files after 3000 files
+// which are 30 pages
+const maxChangedFilesPages = 100
+
+// changedFilesTimeout is the timeout for listing the changed files of a pull
+// or merge request, the provider waits for the response of the webhook
+const changedFilesTimeout = 10 * time.Second
+
+// changedFilesPage decodes a response page of a changed files API, it returns
+// the paths of the files and the URL of the next page, if any
+type changedFilesPage func(resp *http.Response, body []byte) ([]string, string, error)
+
+// newAPIClient returns the client for the provider APIs, redirects to other
+// hosts are not followed so that the token is not sent to them
+func newAPIClient() *http.Client {
+	return &http.Client{
+		Timeout: changedFilesTimeout,
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			if req.URL.Host != via[0].URL.Host {
+				return http.ErrUseLastResponse
+			}
+
+			if len(via) >= 10 {
+				return errors.New("stopped after 10 redirects")
+			}
+
+			return nil
+		},
+	}
+}
+
+// requestChangedFiles requests all pages of a changed files API and returns
+// the distinct paths of the files
+func requestChangedFiles(ctx context.Context, client *http.Client, url string, header http.Header, decode changedFilesPage) ([]string, error) {
+	var (
+		files = []string{}
+		seen  = map[string]bool{}
+	)
+
+	for page := 0; url != ""; page++ {
+		if page == maxChangedFilesPages {
+			return nil, fmt.Errorf("the changed files exceed %d pages", maxChangedFilesPages)
+		}
+
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		req.Header = header.Clone()
+		req.Header.Set("Accept", "application/json")
+
+		resp, err := client.Do(req)
+		if err != nil {
+			return nil, err
+		}
+
+		body, err := io.ReadAll(io.LimitReader(resp.Body, maxPayloadSize))
+		resp.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+
+		if resp.StatusCode != http.StatusOK {
+			return nil, fmt.Errorf("failed to list the changed files, the request to %s returned %s", req.URL.Redacted(), resp.Status)
+		}
+
+		pageFiles, next, err := decode(resp, body)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse the changed files: %w", err)
+		}
+
+		for _, file := range pageFiles {
+			if file != "" && !seen[file] {
+				seen[file] = true
+				files = append(files, file)
+			}
+		}
+
+		url = next
+	}
+
+	return files, nil
+}
+
+// nextLink returns the URL of the next page from the Link header, which is
+// used by GitHub, GitLab, and Gitea for the pagination
+func nextLink(resp *http.Response) string {
+	for _, link := range strings.Split(strings.Join(resp.Header.Values("Link"), ","), ",") {
+		target, params, ok := strings.Cut(link, ";")
+		if !ok || !strings.Contains(params, `rel="next"`) {
+			continue
+		}
+
+		next, err := resp.Request.URL.Parse(strings.Trim(strings.TrimSpace(target), "<>"))
+		if err != nil {
+			return ""
+		}
+
+		return next.String()
+	}
+
+	return ""
+}
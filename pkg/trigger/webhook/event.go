@@ -0,0 +1,106 @@
+// Copyright The Shipwright Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Package webhook implements the HTTP receiver for Git provider webhooks, it
+// turns matching `.spec.trigger.when[]` conditions into BuildRuns.
+package webhook
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	buildv1alpha1 "github.com/shipwright-io/build/pkg/apis/build/v1alpha1"
+)
+
+// ErrInvalidSignature is returned when the signature of a webhook request does
+// not match the payload and the trigger secret
+var ErrInvalidSignature = errors.New("invalid webhook signature")
+
+// ErrMissingSignature is returned when a webhook request does not carry a signature
+var ErrMissingSignature = errors.New("missing webhook signature")
+
+// Event is the provider independent representation of a webhook request
+type Event struct {
+	// Name of the event, matching the event names used in the trigger conditions
+	Name string
+
+	// RepositoryURLs the known URLs of the repository, for example the HTTPS
+	// and SSH clone URLs
+	RepositoryURLs []string
+
+	// Branch the event applies to. For pull requests, this is the target branch.
+	Branch string
+
+	// Tag the event applies to, only set for tag events which are not subject
+	// to the branch filters of the trigger conditions
+	Tag string
+
+	// Revision is the commit SHA the BuildRun is pinned to
+	Revision string
+
+	// ChangedFiles the paths of the files changed by the event, nil if the
+	// payload does not list them
+	ChangedFiles []string
+
+	// ChangedFilesURL the provider API URL that lists the files changed by a
+	// pull or merge request, whose payloads do not list them. The files are
+	// only requested if a matching trigger condition filters by paths. Path
+	// filters do not apply if neither the files nor the URL are known.
+	ChangedFilesURL string
+}
+
+// Provider parses and verifies the webhook requests of a Git provider
+type Provider interface {
+	// TriggerType returns the trigger type served by the provider
+	TriggerType() buildv1alpha1.TriggerType
+
+	// Parse turns the request into an Event. It returns nil without an error
+	// for requests that must be acknowledged, but do not trigger anything.
+	Parse(req *http.Request, payload []byte) (*Event, error)
+
+	// Verify checks the authenticity of the request using the trigger secret
+	Verify(req *http.Request, payload []byte, secret []byte) error
+
+	// Events returns the event names the trigger condition subscribed to
+	Events(when *buildv1alpha1.TriggerWhen) []string
+
+	// ChangedFiles requests the files changed by a pull or merge request from
+	// the ChangedFilesURL of the event, the API token is optional
+	ChangedFiles(ctx context.Context, client *http.Client, event *Event, apiToken []byte) ([]string, error)
+}
+
+// gitCommit is the commit representation shared by the push events of GitHub,
+// GitLab, and Gitea
+type gitCommit struct {
+	Added    []string `json:"added"`
+	Removed  []string `json:"removed"`
+	Modified []string `json:"modified"`
+}
+
+// changedFiles returns the distinct files changed by the commits, or nil if no
+// commits are known, e.g. because the payload omitted them
+func changedFiles(commits []gitCommit) []string {
+	if len(commits) == 0 {
+		return nil
+	}
+
+	var (
+		files = []string{}
+		seen  = map[string]bool{}
+	)
+
+	for _, commit := range commits {
+		for _, list := range [][]string{commit.Added, commit.Removed, commit.Modified} {
+			for _, file := range list {
+				if !seen[file] {
+					seen[file] = true
+					files = append(files, file)
+				}
+			}
+		}
+	}
+
+	return files
+}
@@ -0,0 +1,144 @@
+// Copyright The Shipwright Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package webhook
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	buildv1alpha1 "github.com/shipwright-io/build/pkg/apis/build/v1alpha1"
+	"github.com/shipwright-io/build/pkg/trigger"
+)
+
+const (
+	// GiteaEventHeader is the request header carrying the Gitea event name
+	GiteaEventHeader = "X-Gitea-Event"
+
+	// GiteaSignatureHeader is the request header carrying the HMAC-SHA256 signature
+	GiteaSignatureHeader = "X-Gitea-Signature"
+)
+
+// Gitea implements the Provider interface for Gitea webhooks, the payloads
+// are compatible with the GitHub ones
+type Gitea struct{}
+
+// TriggerType returns the Gitea trigger type
+func (Gitea) TriggerType() buildv1alpha1.TriggerType {
+	return buildv1alpha1.GiteaWebHookTrigger
+}
+
+// Events returns the Gitea event names of the trigger condition
+func (Gitea) Events(when *buildv1alpha1.TriggerWhen) []string {
+	if when.Gitea == nil {
+		return nil
+	}
+
+	events := make([]string, 0, len(when.Gitea.Events))
+	for _, e := range when.Gitea.Events {
+		events = append(events, string(e))
+	}
+	return events
+}
+
+// Parse parses push and pull_request events, pushes of tags are reported as tag
+// events, other events are ignored
+func (Gitea) Parse(req *http.Request, payload []byte) (*Event, error) {
+	switch req.Header.Get(GiteaEventHeader) {
+	case "push":
+		var push gitHubPushEvent
+		if err := json.Unmarshal(payload, &push); err != nil {
+			return nil, fmt.Errorf("failed to parse push event: %w", err)
+		}
+
+		if trigger.IsZeroCommit(push.After) {
+			return nil, nil
+		}
+
+		if tag, isTag := trigger.TagFromRef(push.Ref); isTag {
+			return &Event{
+				Name:           string(buildv1alpha1.GiteaTagEvent),
+				RepositoryURLs: push.Repository.urls(),
+				Tag:            tag,
+				Revision:       push.After,
+			}, nil
+		}
+
+		branch, isBranch := trigger.BranchFromRef(push.Ref)
+		if !isBranch {
+			return nil, nil
+		}
+
+		return &Event{
+			Name:           string(buildv1alpha1.GiteaPushEvent),
+			RepositoryURLs: push.Repository.urls(),
+			Branch:         branch,
+			Revision:       push.After,
+			ChangedFiles:   push.changedFiles(),
+		}, nil
+
+	case "pull_request":
+		var pr gitHubPullRequestEvent
+		if err := json.Unmarshal(payload, &pr); err != nil {
+			return nil, fmt.Errorf("failed to parse pull_request event: %w", err)
+		}
+
+		// only changes of the pull request code are of interest
+		switch pr.Action {
+		case "opened", "reopened", "synchronized":
+		default:
+			return nil, nil
+		}
+
+		return &Event{
+			Name:            string(buildv1alpha1.GiteaPullRequestEvent),
+			RepositoryURLs:  pr.Repository.urls(),
+			Branch:          pr.PullRequest.Base.Ref,
+			Revision:        pr.PullRequest.Head.SHA,
+			ChangedFilesURL: giteaChangedFilesURL(pr),
+		}, nil
+
+	case "":
+		return nil, fmt.Errorf("missing %s header", GiteaEventHeader)
+
+	default:
+		return nil, nil
+	}
+}
+
+// giteaChangedFilesURL returns the API URL of the files of the pull request,
+// the API is served below the web URL of the Gitea instance
+func giteaChangedFilesURL(pr gitHubPullRequestEvent) string {
+	repository := pr.Repository
+	if repository.FullName == "" || pr.Number == 0 || !strings.HasSuffix(repository.HTMLURL, "/"+repository.FullName) {
+		return ""
+	}
+
+	baseURL := strings.TrimSuffix(repository.HTMLURL, "/"+repository.FullName)
+	return fmt.Sprintf("%s/api/v1/repos/%s/pulls/%d/files?limit=50", baseURL, repository.FullName, pr.Number)
+}
+
+// ChangedFiles lists the files changed by a pull request
+func (Gitea) ChangedFiles(ctx context.Context, client *http.Client, event *Event, apiToken []byte) ([]string, error) {
+	header := http.Header{}
+	if len(apiToken) > 0 {
+		header.Set("Authorization", "token "+string(apiToken))
+	}
+
+	return requestChangedFiles(ctx, client, event.ChangedFilesURL, header, decodeGitHubFiles)
+}
+
+// Verify validates the HMAC-SHA256 signature of the payload
+func (Gitea) Verify(req *http.Request, payload []byte, secret []byte) error {
+	signature := req.Header.Get(GiteaSignatureHeader)
+	if signature == "" {
+		return ErrMissingSignature
+	}
+
+	return verifyHMAC(sha256.New, "", signature, payload, secret)
+}
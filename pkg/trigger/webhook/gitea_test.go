@@ -0,0 +1,106 @@
+// Copyright The Shipwright Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package webhook_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/shipwright-io/build/pkg/trigger/webhook"
+)
+
+const giteaTagPushPayload = `{
+  "ref": "refs/tags/v1.0.0",
+  "after": "0a1b2c3d4e5f60718293a4b5c6d7e8f901234567",
+  "repository": {
+    "clone_url": "https://gitea.com/shipwright-io/sample-go.git"
+  }
+}`
+
+func giteaRequest(event string, payload string) *http.Request {
+	req := httptest.NewRequest(http.MethodPost, "/gitea", strings.NewReader(payload))
+	req.Header.Set(webhook.GiteaEventHeader, event)
+	return req
+}
+
+var _ = Describe("Gitea", func() {
+	var provider webhook.Gitea
+
+	Context("Parse", func() {
+		It("should parse a push event", func() {
+			event, err := provider.Parse(giteaRequest("push", pushPayload), []byte(pushPayload))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(event).ToNot(BeNil())
+			Expect(event.Name).To(Equal("Push"))
+			Expect(event.Branch).To(Equal("main"))
+			Expect(event.Revision).To(Equal("0a1b2c3d4e5f60718293a4b5c6d7e8f901234567"))
+		})
+
+		It("should parse a push of a tag as tag event", func() {
+			event, err := provider.Parse(giteaRequest("push", giteaTagPushPayload), []byte(giteaTagPushPayload))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(event).ToNot(BeNil())
+			Expect(event.Name).To(Equal("Tag"))
+			Expect(event.Tag).To(Equal("v1.0.0"))
+		})
+
+		It("should ignore a push deleting a branch", func() {
+			payload := `{"ref": "refs/heads/main", "after": "0000000000000000000000000000000000000000"}`
+			event, err := provider.Parse(giteaRequest("push", payload), []byte(payload))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(event).To(BeNil())
+		})
+
+		It("should parse a synchronized pull request", func() {
+			payload := strings.Replace(pullRequestPayload, `"synchronize"`, `"synchronized"`, 1)
+			event, err := provider.Parse(giteaRequest("pull_request", payload), []byte(payload))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(event).ToNot(BeNil())
+			Expect(event.Name).To(Equal("PullRequest"))
+			Expect(event.Branch).To(Equal("main"))
+			Expect(event.Revision).To(Equal("fedcba9876543210fedcba9876543210fedcba98"))
+			Expect(event.ChangedFilesURL).To(Equal("https://github.com/api/v1/repos/shipwright-io/sample-go/pulls/42/files?limit=50"))
+		})
+	})
+
+	Context("ChangedFiles", func() {
+		It("should list the files of the pull request using the API token", func() {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				Expect(r.Header.Get("Authorization")).To(Equal("token gitea_token"))
+				fmt.Fprint(w, `[{"filename": "services/api/main.go", "status": "changed"}]`)
+			}))
+			defer server.Close()
+
+			files, err := provider.ChangedFiles(context.TODO(), server.Client(), &webhook.Event{ChangedFilesURL: server.URL + "/files"}, []byte("gitea_token"))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(files).To(Equal([]string{"services/api/main.go"}))
+		})
+	})
+
+	Context("Verify", func() {
+		It("should accept a valid signature", func() {
+			req := giteaRequest("push", pushPayload)
+			req.Header.Set(webhook.GiteaSignatureHeader, strings.TrimPrefix(sign(pushPayload, "s3cr3t"), "sha256="))
+			Expect(provider.Verify(req, []byte(pushPayload), []byte("s3cr3t"))).To(Succeed())
+		})
+
+		It("should reject a signature created with a different secret", func() {
+			req := giteaRequest("push", pushPayload)
+			req.Header.Set(webhook.GiteaSignatureHeader, strings.TrimPrefix(sign(pushPayload, "other"), "sha256="))
+			Expect(provider.Verify(req, []byte(pushPayload), []byte("s3cr3t"))).To(MatchError(webhook.ErrInvalidSignature))
+		})
+
+		It("should reject a request without a signature", func() {
+			req := giteaRequest("push", pushPayload)
+			Expect(provider.Verify(req, []byte(pushPayload), []byte("s3cr3t"))).To(MatchError(webhook.ErrMissingSignature))
+		})
+	})
+})
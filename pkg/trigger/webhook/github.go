@@ -0,0 +1,220 @@
+// Copyright The Shipwright Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package webhook
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha1" // #nosec G505 used only as fallback for the legacy X-Hub-Signature header
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"hash"
+	"net/http"
+	"strings"
+
+	buildv1alpha1 "github.com/shipwright-io/build/pkg/apis/build/v1alpha1"
+	"github.com/shipwright-io/build/pkg/trigger"
+)
+
+const (
+	// GitHubEventHeader is the request header carrying the GitHub event name
+	GitHubEventHeader = "X-GitHub-Event"
+
+	// GitHubSignature256Header is the request header carrying the HMAC-SHA256 signature
+	GitHubSignature256Header = "X-Hub-Signature-256"
+
+	// GitHubSignatureHeader is the legacy request header carrying the HMAC-SHA1 signature
+	GitHubSignatureHeader = "X-Hub-Signature"
+)
+
+type gitHubRepository struct {
+	FullName string `json:"full_name"`
+	CloneURL string `json:"clone_url"`
+	HTMLURL  string `json:"html_url"`
+	SSHURL   string `json:"ssh_url"`
+}
+
+func (r gitHubRepository) urls() []string {
+	return []string{r.CloneURL, r.HTMLURL, r.SSHURL}
+}
+
+type gitHubPushEvent struct {
+	Ref        string           `json:"ref"`
+	After      string           `json:"after"`
+	Deleted    bool             `json:"deleted"`
+	Commits    []gitCommit      `json:"commits"`
+	Repository gitHubRepository `json:"repository"`
+
+	// TotalCommits is only sent by Gitea, which limits the number of commits in the payload
+	TotalCommits *int `json:"total_commits"`
+}
+
+// changedFiles returns the files changed by the push, or nil if the payload
+// does not list all commits
+func (p gitHubPushEvent) changedFiles() []string {
+	if p.TotalCommits != nil && *p.TotalCommits > len(p.Commits) {
+		return nil
+	}
+	return changedFiles(p.Commits)
+}
+
+type gitHubPullRequestEvent struct {
+	Action      string `json:"action"`
+	Number      int    `json:"number"`
+	PullRequest struct {
+		// URL is the API URL of the pull request on GitHub, and the web URL
+		// on Gitea
+		URL  string `json:"url"`
+		Head struct {
+			SHA string `json:"sha"`
+		} `json:"head"`
+		Base struct {
+			Ref string `json:"ref"`
+		} `json:"base"`
+	} `json:"pull_request"`
+	Repository gitHubRepository `json:"repository"`
+}
+
+// GitHub implements the Provider interface for GitHub webhooks
+type GitHub struct{}
+
+// TriggerType returns the GitHub trigger type
+func (GitHub) TriggerType() buildv1alpha1.TriggerType {
+	return buildv1alpha1.GitHubWebHookTrigger
+}
+
+// Events returns the GitHub event names of the trigger condition
+func (GitHub) Events(when *buildv1alpha1.TriggerWhen) []string {
+	if when.GitHub == nil {
+		return nil
+	}
+
+	events := make([]string, 0, len(when.GitHub.Events))
+	for _, e := range when.GitHub.Events {
+		events = append(events, string(e))
+	}
+	return events
+}
+
+// Parse parses push and pull_request events, other events are ignored
+func (GitHub) Parse(req *http.Request, payload []byte) (*Event, error) {
+	switch req.Header.Get(GitHubEventHeader) {
+	case "push":
+		var push gitHubPushEvent
+		if err := json.Unmarshal(payload, &push); err != nil {
+			return nil, fmt.Errorf("failed to parse push event: %w", err)
+		}
+
+		branch, isBranch := trigger.BranchFromRef(push.Ref)
+		if !isBranch || push.Deleted {
+			return nil, nil
+		}
+
+		return &Event{
+			Name:           string(buildv1alpha1.GitHubPushEvent),
+			RepositoryURLs: push.Repository.urls(),
+			Branch:         branch,
+			Revision:       push.After,
+			ChangedFiles:   push.changedFiles(),
+		}, nil
+
+	case "pull_request":
+		var pr gitHubPullRequestEvent
+		if err := json.Unmarshal(payload, &pr); err != nil {
+			return nil, fmt.Errorf("failed to parse pull_request event: %w", err)
+		}
+
+		// only changes of the pull request code are of interest
+		switch pr.Action {
+		case "opened", "reopened", "synchronize":
+		default:
+			return nil, nil
+		}
+
+		event := &Event{
+			Name:           string(buildv1alpha1.GitHubPullRequestEvent),
+			RepositoryURLs: pr.Repository.urls(),
+			Branch:         pr.PullRequest.Base.Ref,
+			Revision:       pr.PullRequest.Head.SHA,
+		}
+
+		if pr.PullRequest.URL != "" {
+			event.ChangedFilesURL = pr.PullRequest.URL + "/files?per_page=100"
+		}
+
+		return event, nil
+
+	case "":
+		return nil, fmt.Errorf("missing %s header", GitHubEventHeader)
+
+	default:
+		return nil, nil
+	}
+}
+
+// Verify validates the HMAC signature of the payload, the SHA256 signature is
+// preferred over the legacy SHA1 one
+func (GitHub) Verify(req *http.Request, payload []byte, secret []byte) error {
+	if signature := req.Header.Get(GitHubSignature256Header); signature != "" {
+		return verifyHMAC(sha256.New, "sha256=", signature, payload, secret)
+	}
+
+	if signature := req.Header.Get(GitHubSignatureHeader); signature != "" {
+		return verifyHMAC(sha1.New, "sha1=", signature, payload, secret)
+	}
+
+	return ErrMissingSignature
+}
+
+// ChangedFiles lists the files changed by a pull request
+func (GitHub) ChangedFiles(ctx context.Context, client *http.Client, event *Event, apiToken []byte) ([]string, error) {
+	header := http.Header{}
+	if len(apiToken) > 0 {
+		header.Set("Authorization", "Bearer "+string(apiToken))
+	}
+
+	return requestChangedFiles(ctx, client, event.ChangedFilesURL, header, decodeGitHubFiles)
+}
+
+// decodeGitHubFiles decodes the files of a pull request, renamed files are
+// also listed with their previous path. Gitea uses the same format.
+func decodeGitHubFiles(resp *http.Response, body []byte) ([]string, string, error) {
+	var files []struct {
+		Filename         string `json:"filename"`
+		PreviousFilename string `json:"previous_filename"`
+	}
+
+	if err := json.Unmarshal(body, &files); err != nil {
+		return nil, "", err
+	}
+
+	paths := make([]string, 0, len(files))
+	for _, file := range files {
+		paths = append(paths, file.Filename, file.PreviousFilename)
+	}
+
+	return paths, nextLink(resp), nil
+}
+
+func verifyHMAC(h func() hash.Hash, prefix string, signature string, payload []byte, secret []byte) error {
+	if !strings.HasPrefix(signature, prefix) {
+		return ErrInvalidSignature
+	}
+
+	expected, err := hex.DecodeString(strings.TrimPrefix(signature, prefix))
+	if err != nil {
+		return ErrInvalidSignature
+	}
+
+	mac := hmac.New(h, secret)
+	mac.Write(payload)
+	if !hmac.Equal(mac.Sum(nil), expected) {
+		return ErrInvalidSignature
+	}
+
+	return nil
+}
@@ -0,0 +1,188 @@
+// Copyright The Shipwright Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package webhook_test
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/shipwright-io/build/pkg/trigger/webhook"
+)
+
+const pushPayload = `{
+  "ref": "refs/heads/main",
+  "after": "0a1b2c3d4e5f60718293a4b5c6d7e8f901234567",
+  "deleted": false,
+  "commits": [
+    {"added": ["services/api/handler.go"], "removed": [], "modified": ["go.mod"]},
+    {"added": [], "removed": ["docs/old.md"], "modified": ["go.mod"]}
+  ],
+  "repository": {
+    "clone_url": "https://github.com/shipwright-io/sample-go.git",
+    "html_url": "https://github.com/shipwright-io/sample-go",
+    "ssh_url": "git@github.com:shipwright-io/sample-go.git"
+  }
+}`
+
+const pullRequestPayload = `{
+  "action": "synchronize",
+  "number": 42,
+  "pull_request": {
+    "url": "https://api.github.com/repos/shipwright-io/sample-go/pulls/42",
+    "head": {"sha": "fedcba9876543210fedcba9876543210fedcba98"},
+    "base": {"ref": "main"}
+  },
+  "repository": {
+    "full_name": "shipwright-io/sample-go",
+    "clone_url": "https://github.com/shipwright-io/sample-go.git",
+    "html_url": "https://github.com/shipwright-io/sample-go"
+  }
+}`
+
+func gitHubRequest(event string, payload string) *http.Request {
+	req := httptest.NewRequest(http.MethodPost, "/github", strings.NewReader(payload))
+	req.Header.Set(webhook.GitHubEventHeader, event)
+	return req
+}
+
+func sign(payload string, secret string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(payload))
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+var _ = Describe("GitHub", func() {
+	var provider webhook.GitHub
+
+	Context("Parse", func() {
+		It("should parse a push event", func() {
+			event, err := provider.Parse(gitHubRequest("push", pushPayload), []byte(pushPayload))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(event).ToNot(BeNil())
+			Expect(event.Name).To(Equal("Push"))
+			Expect(event.Branch).To(Equal("main"))
+			Expect(event.Revision).To(Equal("0a1b2c3d4e5f60718293a4b5c6d7e8f901234567"))
+			Expect(event.RepositoryURLs).To(ContainElement("https://github.com/shipwright-io/sample-go.git"))
+		})
+
+		It("should report the distinct files changed by the pushed commits", func() {
+			event, err := provider.Parse(gitHubRequest("push", pushPayload), []byte(pushPayload))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(event.ChangedFiles).To(ConsistOf("services/api/handler.go", "go.mod", "docs/old.md"))
+		})
+
+		It("should report the API URL of the files changed by a pull request", func() {
+			event, err := provider.Parse(gitHubRequest("pull_request", pullRequestPayload), []byte(pullRequestPayload))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(event.ChangedFiles).To(BeNil())
+			Expect(event.ChangedFilesURL).To(Equal("https://api.github.com/repos/shipwright-io/sample-go/pulls/42/files?per_page=100"))
+		})
+
+		It("should ignore a push of a tag", func() {
+			payload := strings.Replace(pushPayload, "refs/heads/main", "refs/tags/v1.0.0", 1)
+			event, err := provider.Parse(gitHubRequest("push", payload), []byte(payload))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(event).To(BeNil())
+		})
+
+		It("should parse a pull_request event using the target branch and the head commit", func() {
+			event, err := provider.Parse(gitHubRequest("pull_request", pullRequestPayload), []byte(pullRequestPayload))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(event).ToNot(BeNil())
+			Expect(event.Name).To(Equal("PullRequest"))
+			Expect(event.Branch).To(Equal("main"))
+			Expect(event.Revision).To(Equal("fedcba9876543210fedcba9876543210fedcba98"))
+		})
+
+		It("should ignore a closed pull request", func() {
+			payload := strings.Replace(pullRequestPayload, "synchronize", "closed", 1)
+			event, err := provider.Parse(gitHubRequest("pull_request", payload), []byte(payload))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(event).To(BeNil())
+		})
+
+		It("should ignore other events", func() {
+			event, err := provider.Parse(gitHubRequest("ping", `{}`), []byte(`{}`))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(event).To(BeNil())
+		})
+
+		It("should fail without the event header", func() {
+			_, err := provider.Parse(gitHubRequest("", `{}`), []byte(`{}`))
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("should fail for an invalid payload", func() {
+			_, err := provider.Parse(gitHubRequest("push", `{`), []byte(`{`))
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Context("ChangedFiles", func() {
+		It("should list the files of all pages using the API token", func() {
+			var authorization []string
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				authorization = append(authorization, r.Header.Get("Authorization"))
+				if r.URL.Query().Get("page") == "" {
+					w.Header().Set("Link", fmt.Sprintf(`<http://%s/files?page=2>; rel="next", <http://%s/files?page=2>; rel="last"`, r.Host, r.Host))
+					fmt.Fprint(w, `[{"filename": "services/api/main.go"}, {"filename": "docs/new.md", "previous_filename": "docs/old.md"}]`)
+					return
+				}
+				fmt.Fprint(w, `[{"filename": "go.mod"}, {"filename": "services/api/main.go"}]`)
+			}))
+			defer server.Close()
+
+			files, err := provider.ChangedFiles(context.TODO(), server.Client(), &webhook.Event{ChangedFilesURL: server.URL + "/files"}, []byte("ghp_token"))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(files).To(Equal([]string{"services/api/main.go", "docs/new.md", "docs/old.md", "go.mod"}))
+			Expect(authorization).To(Equal([]string{"Bearer ghp_token", "Bearer ghp_token"}))
+		})
+
+		It("should fail if the API rejects the request", func() {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				Expect(r.Header.Get("Authorization")).To(BeEmpty())
+				http.Error(w, "Not Found", http.StatusNotFound)
+			}))
+			defer server.Close()
+
+			_, err := provider.ChangedFiles(context.TODO(), server.Client(), &webhook.Event{ChangedFilesURL: server.URL + "/files"}, nil)
+			Expect(err).To(MatchError(ContainSubstring("404 Not Found")))
+		})
+	})
+
+	Context("Verify", func() {
+		It("should accept a valid signature", func() {
+			req := gitHubRequest("push", pushPayload)
+			req.Header.Set(webhook.GitHubSignature256Header, sign(pushPayload, "s3cr3t"))
+			Expect(provider.Verify(req, []byte(pushPayload), []byte("s3cr3t"))).To(Succeed())
+		})
+
+		It("should reject a signature created with a different secret", func() {
+			req := gitHubRequest("push", pushPayload)
+			req.Header.Set(webhook.GitHubSignature256Header, sign(pushPayload, "other"))
+			Expect(provider.Verify(req, []byte(pushPayload), []byte("s3cr3t"))).To(MatchError(webhook.ErrInvalidSignature))
+		})
+
+		It("should reject a malformed signature", func() {
+			req := gitHubRequest("push", pushPayload)
+			req.Header.Set(webhook.GitHubSignature256Header, "sha256=not-hex")
+			Expect(provider.Verify(req, []byte(pushPayload), []byte("s3cr3t"))).To(MatchError(webhook.ErrInvalidSignature))
+		})
+
+		It("should reject a request without a signature", func() {
+			req := gitHubRequest("push", pushPayload)
+			Expect(provider.Verify(req, []byte(pushPayload), []byte("s3cr3t"))).To(MatchError(webhook.ErrMissingSignature))
+		})
+	})
+})
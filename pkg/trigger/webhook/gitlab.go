@@ -0,0 +1,216 @@
+// Copyright The Shipwright Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package webhook
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	buildv1alpha1 "github.com/shipwright-io/build/pkg/apis/build/v1alpha1"
+	"github.com/shipwright-io/build/pkg/trigger"
+)
+
+const (
+	// GitLabEventHeader is the request header carrying the GitLab event name
+	GitLabEventHeader = "X-Gitlab-Event"
+
+	// GitLabTokenHeader is the request header carrying the GitLab secret token
+	GitLabTokenHeader = "X-Gitlab-Token"
+)
+
+type gitLabProject struct {
+	ID                int    `json:"id"`
+	PathWithNamespace string `json:"path_with_namespace"`
+	GitHTTPURL        string `json:"git_http_url"`
+	GitSSHURL         string `json:"git_ssh_url"`
+	WebURL            string `json:"web_url"`
+}
+
+func (p gitLabProject) urls() []string {
+	return []string{p.GitHTTPURL, p.GitSSHURL, p.WebURL}
+}
+
+type gitLabPushEvent struct {
+	Ref               string        `json:"ref"`
+	After             string        `json:"after"`
+	CheckoutSHA       string        `json:"checkout_sha"`
+	Commits           []gitCommit   `json:"commits"`
+	TotalCommitsCount int           `json:"total_commits_count"`
+	Project           gitLabProject `json:"project"`
+}
+
+// changedFiles returns the files changed by the push, or nil if the payload
+// does not list all commits, GitLab limits them to 20
+func (p gitLabPushEvent) changedFiles() []string {
+	if p.TotalCommitsCount > len(p.Commits) {
+		return nil
+	}
+	return changedFiles(p.Commits)
+}
+
+type gitLabMergeRequestEvent struct {
+	ObjectAttributes struct {
+		IID          int    `json:"iid"`
+		Action       string `json:"action"`
+		OldRev       string `json:"oldrev"`
+		TargetBranch string `json:"target_branch"`
+		LastCommit   struct {
+			ID string `json:"id"`
+		} `json:"last_commit"`
+	} `json:"object_attributes"`
+	Project gitLabProject `json:"project"`
+}
+
+// GitLab implements the Provider interface for GitLab webhooks
+type GitLab struct{}
+
+// TriggerType returns the GitLab trigger type
+func (GitLab) TriggerType() buildv1alpha1.TriggerType {
+	return buildv1alpha1.GitLabWebHookTrigger
+}
+
+// Events returns the GitLab event names of the trigger condition
+func (GitLab) Events(when *buildv1alpha1.TriggerWhen) []string {
+	if when.GitLab == nil {
+		return nil
+	}
+
+	events := make([]string, 0, len(when.GitLab.Events))
+	for _, e := range when.GitLab.Events {
+		events = append(events, string(e))
+	}
+	return events
+}
+
+// Parse parses push, tag push and merge request events, other events are ignored
+func (GitLab) Parse(req *http.Request, payload []byte) (*Event, error) {
+	switch req.Header.Get(GitLabEventHeader) {
+	case "Push Hook":
+		var push gitLabPushEvent
+		if err := json.Unmarshal(payload, &push); err != nil {
+			return nil, fmt.Errorf("failed to parse push event: %w", err)
+		}
+
+		branch, isBranch := trigger.BranchFromRef(push.Ref)
+		if !isBranch || push.CheckoutSHA == "" || trigger.IsZeroCommit(push.After) {
+			return nil, nil
+		}
+
+		return &Event{
+			Name:           string(buildv1alpha1.GitLabPushEvent),
+			RepositoryURLs: push.Project.urls(),
+			Branch:         branch,
+			Revision:       push.CheckoutSHA,
+			ChangedFiles:   push.changedFiles(),
+		}, nil
+
+	case "Tag Push Hook":
+		var push gitLabPushEvent
+		if err := json.Unmarshal(payload, &push); err != nil {
+			return nil, fmt.Errorf("failed to parse tag push event: %w", err)
+		}
+
+		// deleted tags have no checkout SHA
+		tag, isTag := trigger.TagFromRef(push.Ref)
+		if !isTag || push.CheckoutSHA == "" {
+			return nil, nil
+		}
+
+		return &Event{
+			Name:           string(buildv1alpha1.GitLabTagEvent),
+			RepositoryURLs: push.Project.urls(),
+			Tag:            tag,
+			Revision:       push.CheckoutSHA,
+		}, nil
+
+	case "Merge Request Hook":
+		var mr gitLabMergeRequestEvent
+		if err := json.Unmarshal(payload, &mr); err != nil {
+			return nil, fmt.Errorf("failed to parse merge request event: %w", err)
+		}
+
+		// only changes of the merge request code are of interest, updates
+		// carry the previous revision if new commits were pushed
+		switch mr.ObjectAttributes.Action {
+		case "open", "reopen":
+		case "update":
+			if mr.ObjectAttributes.OldRev == "" {
+				return nil, nil
+			}
+		default:
+			return nil, nil
+		}
+
+		return &Event{
+			Name:            string(buildv1alpha1.GitLabMergeRequestEvent),
+			RepositoryURLs:  mr.Project.urls(),
+			Branch:          mr.ObjectAttributes.TargetBranch,
+			Revision:        mr.ObjectAttributes.LastCommit.ID,
+			ChangedFilesURL: gitLabChangedFilesURL(mr),
+		}, nil
+
+	case "":
+		return nil, fmt.Errorf("missing %s header", GitLabEventHeader)
+
+	default:
+		return nil, nil
+	}
+}
+
+// gitLabChangedFilesURL returns the API URL of the diffs of the merge request,
+// the API is served below the web URL of the GitLab instance
+func gitLabChangedFilesURL(mr gitLabMergeRequestEvent) string {
+	project := mr.Project
+	if project.ID == 0 || mr.ObjectAttributes.IID == 0 || project.PathWithNamespace == "" || !strings.HasSuffix(project.WebURL, "/"+project.PathWithNamespace) {
+		return ""
+	}
+
+	baseURL := strings.TrimSuffix(project.WebURL, "/"+project.PathWithNamespace)
+	return fmt.Sprintf("%s/api/v4/projects/%d/merge_requests/%d/diffs?per_page=100", baseURL, project.ID, mr.ObjectAttributes.IID)
+}
+
+// ChangedFiles lists the files changed by a merge request
+func (GitLab) ChangedFiles(ctx context.Context, client *http.Client, event *Event, apiToken []byte) ([]string, error) {
+	header := http.Header{}
+	if len(apiToken) > 0 {
+		header.Set("PRIVATE-TOKEN", string(apiToken))
+	}
+
+	return requestChangedFiles(ctx, client, event.ChangedFilesURL, header, func(resp *http.Response, body []byte) ([]string, string, error) {
+		var diffs []struct {
+			OldPath string `json:"old_path"`
+			NewPath string `json:"new_path"`
+		}
+
+		if err := json.Unmarshal(body, &diffs); err != nil {
+			return nil, "", err
+		}
+
+		paths := make([]string, 0, len(diffs))
+		for _, diff := range diffs {
+			paths = append(paths, diff.NewPath, diff.OldPath)
+		}
+
+		return paths, nextLink(resp), nil
+	})
+}
+
+// Verify compares the secret token GitLab sends with the trigger secret
+func (GitLab) Verify(req *http.Request, _ []byte, secret []byte) error {
+	token := req.Header.Get(GitLabTokenHeader)
+	if token == "" {
+		return ErrMissingSignature
+	}
+
+	if subtle.ConstantTimeCompare([]byte(token), secret) != 1 {
+		return ErrInvalidSignature
+	}
+
+	return nil
+}
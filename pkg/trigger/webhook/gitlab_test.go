@@ -0,0 +1,162 @@
+// Copyright The Shipwright Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package webhook_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/shipwright-io/build/pkg/trigger/webhook"
+)
+
+const gitLabPushPayload = `{
+  "ref": "refs/heads/main",
+  "after": "0a1b2c3d4e5f60718293a4b5c6d7e8f901234567",
+  "checkout_sha": "0a1b2c3d4e5f60718293a4b5c6d7e8f901234567",
+  "commits": [{"added": [], "removed": [], "modified": ["services/api/main.go"]}],
+  "total_commits_count": 1,
+  "project": {
+    "git_http_url": "https://gitlab.com/shipwright-io/sample-go.git",
+    "git_ssh_url": "git@gitlab.com:shipwright-io/sample-go.git",
+    "web_url": "https://gitlab.com/shipwright-io/sample-go"
+  }
+}`
+
+const gitLabTagPushPayload = `{
+  "ref": "refs/tags/v1.0.0",
+  "after": "0a1b2c3d4e5f60718293a4b5c6d7e8f901234567",
+  "checkout_sha": "0a1b2c3d4e5f60718293a4b5c6d7e8f901234567",
+  "project": {
+    "git_http_url": "https://gitlab.com/shipwright-io/sample-go.git"
+  }
+}`
+
+const gitLabMergeRequestPayload = `{
+  "object_attributes": {
+    "iid": 7,
+    "action": "update",
+    "oldrev": "0a1b2c3d4e5f60718293a4b5c6d7e8f901234567",
+    "target_branch": "main",
+    "last_commit": {"id": "fedcba9876543210fedcba9876543210fedcba98"}
+  },
+  "project": {
+    "id": 1234,
+    "path_with_namespace": "shipwright-io/sample-go",
+    "git_http_url": "https://gitlab.com/shipwright-io/sample-go.git",
+    "web_url": "https://gitlab.com/shipwright-io/sample-go"
+  }
+}`
+
+func gitLabRequest(event string, payload string) *http.Request {
+	req := httptest.NewRequest(http.MethodPost, "/gitlab", strings.NewReader(payload))
+	req.Header.Set(webhook.GitLabEventHeader, event)
+	return req
+}
+
+var _ = Describe("GitLab", func() {
+	var provider webhook.GitLab
+
+	Context("Parse", func() {
+		It("should parse a push event", func() {
+			event, err := provider.Parse(gitLabRequest("Push Hook", gitLabPushPayload), []byte(gitLabPushPayload))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(event).ToNot(BeNil())
+			Expect(event.Name).To(Equal("Push"))
+			Expect(event.Branch).To(Equal("main"))
+			Expect(event.Revision).To(Equal("0a1b2c3d4e5f60718293a4b5c6d7e8f901234567"))
+			Expect(event.RepositoryURLs).To(ContainElement("https://gitlab.com/shipwright-io/sample-go.git"))
+		})
+
+		It("should report the changed files of a push", func() {
+			event, err := provider.Parse(gitLabRequest("Push Hook", gitLabPushPayload), []byte(gitLabPushPayload))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(event.ChangedFiles).To(ConsistOf("services/api/main.go"))
+		})
+
+		It("should not report changed files if the payload omitted commits", func() {
+			payload := strings.Replace(gitLabPushPayload, `"total_commits_count": 1`, `"total_commits_count": 42`, 1)
+			event, err := provider.Parse(gitLabRequest("Push Hook", payload), []byte(payload))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(event.ChangedFiles).To(BeNil())
+		})
+
+		It("should ignore a push deleting a branch", func() {
+			payload := `{"ref": "refs/heads/main", "after": "0000000000000000000000000000000000000000", "checkout_sha": null}`
+			event, err := provider.Parse(gitLabRequest("Push Hook", payload), []byte(payload))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(event).To(BeNil())
+		})
+
+		It("should parse a tag push event", func() {
+			event, err := provider.Parse(gitLabRequest("Tag Push Hook", gitLabTagPushPayload), []byte(gitLabTagPushPayload))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(event).ToNot(BeNil())
+			Expect(event.Name).To(Equal("Tag"))
+			Expect(event.Tag).To(Equal("v1.0.0"))
+			Expect(event.Branch).To(BeEmpty())
+		})
+
+		It("should parse a merge request update with new commits", func() {
+			event, err := provider.Parse(gitLabRequest("Merge Request Hook", gitLabMergeRequestPayload), []byte(gitLabMergeRequestPayload))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(event).ToNot(BeNil())
+			Expect(event.Name).To(Equal("MergeRequest"))
+			Expect(event.Branch).To(Equal("main"))
+			Expect(event.Revision).To(Equal("fedcba9876543210fedcba9876543210fedcba98"))
+			Expect(event.ChangedFilesURL).To(Equal("https://gitlab.com/api/v4/projects/1234/merge_requests/7/diffs?per_page=100"))
+		})
+
+		It("should ignore a merge request update without new commits", func() {
+			payload := strings.Replace(gitLabMergeRequestPayload, `"oldrev": "0a1b2c3d4e5f60718293a4b5c6d7e8f901234567",`, "", 1)
+			event, err := provider.Parse(gitLabRequest("Merge Request Hook", payload), []byte(payload))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(event).To(BeNil())
+		})
+
+		It("should fail without the event header", func() {
+			_, err := provider.Parse(gitLabRequest("", gitLabPushPayload), []byte(gitLabPushPayload))
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Context("ChangedFiles", func() {
+		It("should list the old and new paths of the diffs using the API token", func() {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				Expect(r.Header.Get("PRIVATE-TOKEN")).To(Equal("glpat_token"))
+				fmt.Fprint(w, `[{"old_path": "services/api/main.go", "new_path": "services/api/main.go"}, {"old_path": "docs/old.md", "new_path": "docs/new.md"}]`)
+			}))
+			defer server.Close()
+
+			files, err := provider.ChangedFiles(context.TODO(), server.Client(), &webhook.Event{ChangedFilesURL: server.URL + "/diffs"}, []byte("glpat_token"))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(files).To(Equal([]string{"services/api/main.go", "docs/new.md", "docs/old.md"}))
+		})
+	})
+
+	Context("Verify", func() {
+		It("should accept a matching token", func() {
+			req := gitLabRequest("Push Hook", gitLabPushPayload)
+			req.Header.Set(webhook.GitLabTokenHeader, "s3cr3t")
+			Expect(provider.Verify(req, []byte(gitLabPushPayload), []byte("s3cr3t"))).To(Succeed())
+		})
+
+		It("should reject a different token", func() {
+			req := gitLabRequest("Push Hook", gitLabPushPayload)
+			req.Header.Set(webhook.GitLabTokenHeader, "other")
+			Expect(provider.Verify(req, []byte(gitLabPushPayload), []byte("s3cr3t"))).To(MatchError(webhook.ErrInvalidSignature))
+		})
+
+		It("should reject a request without a token", func() {
+			req := gitLabRequest("Push Hook", gitLabPushPayload)
+			Expect(provider.Verify(req, []byte(gitLabPushPayload), []byte("s3cr3t"))).To(MatchError(webhook.ErrMissingSignature))
+		})
+	})
+})
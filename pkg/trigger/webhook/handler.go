@@ -0,0 +1,268 @@
+// Copyright The Shipwright Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	buildv1alpha1 "github.com/shipwright-io/build/pkg/apis/build/v1alpha1"
+	"github.com/shipwright-io/build/pkg/ctxlog"
+	"github.com/shipwright-io/build/pkg/trigger"
+)
+
+// SecretTokenKey is the key in the trigger secret holding the token that is
+// used to verify the webhook request signatures
+const SecretTokenKey = "token"
+
+// maxPayloadSize is the upper limit for the size of a webhook request body,
+// in line with the limit GitHub uses for the payloads it delivers
+const maxPayloadSize = 25 * 1024 * 1024
+
+// Response is the body returned for successfully handled webhook requests
+type Response struct {
+	// BuildRuns the namespaced names of the BuildRuns created for the event
+	BuildRuns []string `json:"buildRuns"`
+}
+
+// Handler receives the webhook requests of a provider and creates BuildRuns
+// for the Builds with matching trigger conditions
+type Handler struct {
+	ctx       context.Context
+	client    client.Client
+	provider  Provider
+	apiClient *http.Client
+}
+
+// NewHandler returns a webhook handler for the given provider
+func NewHandler(ctx context.Context, c client.Client, provider Provider) *Handler {
+	return &Handler{
+		ctx:       ctx,
+		client:    c,
+		provider:  provider,
+		apiClient: newAPIClient(),
+	}
+}
+
+// ServeHTTP handles a single webhook request
+func (h *Handler) ServeHTTP(resp http.ResponseWriter, req *http.Request) {
+	ctx := h.ctx
+
+	if req.Method != http.MethodPost {
+		http.Error(resp, "only POST requests are supported", http.StatusMethodNotAllowed)
+		return
+	}
+
+	payload, err := io.ReadAll(io.LimitReader(req.Body, maxPayloadSize))
+	if err != nil {
+		http.Error(resp, "failed to read request body", http.StatusBadRequest)
+		return
+	}
+
+	event, err := h.provider.Parse(req, payload)
+	if err != nil {
+		ctxlog.Debug(ctx, "failed to parse webhook request", "error", err.Error())
+		http.Error(resp, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	// acknowledge events that do not trigger anything, e.g. ping
+	if event == nil {
+		writeResponse(resp, http.StatusOK, Response{BuildRuns: []string{}})
+		return
+	}
+
+	buildRuns, err := h.handleEvent(ctx, req, payload, event)
+	switch {
+	case errors.Is(err, ErrInvalidSignature), errors.Is(err, ErrMissingSignature):
+		http.Error(resp, err.Error(), http.StatusUnauthorized)
+		return
+
+	case err != nil:
+		ctxlog.Error(ctx, err, "failed to handle webhook event", "event", event.Name)
+		http.Error(resp, "failed to handle webhook event", http.StatusInternalServerError)
+		return
+	}
+
+	writeResponse(resp, http.StatusOK, Response{BuildRuns: buildRuns})
+}
+
+// handleEvent creates a BuildRun for every Build that has a trigger condition
+// matching the event. Builds are only considered when the request signature
+// can be verified with their trigger secret.
+func (h *Handler) handleEvent(ctx context.Context, req *http.Request, payload []byte, event *Event) ([]string, error) {
+	buildList := &buildv1alpha1.BuildList{}
+	if err := h.client.List(ctx, buildList); err != nil {
+		return nil, err
+	}
+
+	var (
+		buildRuns  = []string{}
+		candidates int
+		verified   int
+
+		// the changed files of a pull or merge request by API token, the
+		// Builds can use different tokens
+		changedFiles = map[string][]string{}
+	)
+
+	for i := range buildList.Items {
+		build := &buildList.Items[i]
+
+		if !h.sameRepository(build, event) {
+			continue
+		}
+
+		whens := h.matchingTriggers(build, event)
+		if len(whens) == 0 {
+			continue
+		}
+		candidates++
+
+		if build.Spec.Trigger.SecretRef == nil {
+			ctxlog.Info(ctx, "skipping Build with matching trigger, but no trigger secret", "namespace", build.Namespace, "name", build.Name)
+			continue
+		}
+
+		secret, err := h.triggerSecret(ctx, build)
+		if err != nil {
+			ctxlog.Error(ctx, err, "failed to retrieve trigger secret", "namespace", build.Namespace, "name", build.Name)
+			continue
+		}
+
+		if err := h.provider.Verify(req, payload, secret.Data[SecretTokenKey]); err != nil {
+			ctxlog.Info(ctx, "webhook signature does not match trigger secret", "namespace", build.Namespace, "name", build.Name)
+			continue
+		}
+		verified++
+
+		// the changed files of pull and merge requests are only requested
+		// from the provider for verified events and if they are needed
+		if event.ChangedFiles == nil && event.ChangedFilesURL != "" && hasPaths(whens) {
+			apiToken := secret.Data[SecretAPITokenKey]
+			files, ok := changedFiles[string(apiToken)]
+			if !ok {
+				if files, err = h.provider.ChangedFiles(ctx, h.apiClient, event, apiToken); err != nil {
+					ctxlog.Error(ctx, err, "failed to list the changed files", "namespace", build.Namespace, "name", build.Name, "event", event.Name)
+					continue
+				}
+				changedFiles[string(apiToken)] = files
+			}
+
+			if whens = matchingPaths(build, whens, files); len(whens) == 0 {
+				continue
+			}
+		}
+
+		// one BuildRun per Build, even if multiple trigger conditions match
+		buildRun := trigger.NewBuildRun(build, &whens[0], event.Name, &event.Revision)
+		if err := h.client.Create(ctx, buildRun); err != nil {
+			return buildRuns, fmt.Errorf("failed to create BuildRun for Build %s/%s: %w", build.Namespace, build.Name, err)
+		}
+
+		ctxlog.Info(ctx, "created BuildRun", "namespace", buildRun.Namespace, "name", buildRun.Name, "event", event.Name, "revision", event.Revision)
+		buildRuns = append(buildRuns, fmt.Sprintf("%s/%s", buildRun.Namespace, buildRun.Name))
+	}
+
+	if candidates > 0 && verified == 0 {
+		return nil, ErrInvalidSignature
+	}
+
+	return buildRuns, nil
+}
+
+func (h *Handler) sameRepository(build *buildv1alpha1.Build, event *Event) bool {
+	if build.Spec.Source.URL == nil {
+		return false
+	}
+
+	for _, repoURL := range event.RepositoryURLs {
+		if trigger.SameRepository(*build.Spec.Source.URL, repoURL) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (h *Handler) matchingTriggers(build *buildv1alpha1.Build, event *Event) []buildv1alpha1.TriggerWhen {
+	var result []buildv1alpha1.TriggerWhen
+	for _, when := range trigger.TriggersOfType(build, h.provider.TriggerType()) {
+		when := when
+		if !contains(h.provider.Events(&when), event.Name) {
+			continue
+		}
+
+		if event.Tag == "" && !trigger.MatchesBranch(build, &when, event.Branch) {
+			continue
+		}
+
+		if event.ChangedFiles != nil && !trigger.MatchesPaths(build, &when, event.ChangedFiles) {
+			continue
+		}
+
+		result = append(result, when)
+	}
+
+	return result
+}
+
+// hasPaths returns whether one of the trigger conditions filters by paths
+func hasPaths(whens []buildv1alpha1.TriggerWhen) bool {
+	for i := range whens {
+		if whens[i].GetPaths(whens[i].Type) != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// matchingPaths returns the trigger conditions whose path filters match the
+// changed files
+func matchingPaths(build *buildv1alpha1.Build, whens []buildv1alpha1.TriggerWhen, changedFiles []string) []buildv1alpha1.TriggerWhen {
+	var result []buildv1alpha1.TriggerWhen
+	for i := range whens {
+		if trigger.MatchesPaths(build, &whens[i], changedFiles) {
+			result = append(result, whens[i])
+		}
+	}
+	return result
+}
+
+func (h *Handler) triggerSecret(ctx context.Context, build *buildv1alpha1.Build) (*corev1.Secret, error) {
+	secret := &corev1.Secret{}
+	if err := h.client.Get(ctx, types.NamespacedName{Namespace: build.Namespace, Name: build.Spec.Trigger.SecretRef.Name}, secret); err != nil {
+		return nil, err
+	}
+
+	if token, ok := secret.Data[SecretTokenKey]; !ok || len(token) == 0 {
+		return nil, fmt.Errorf("secret %s/%s does not contain the key %q", secret.Namespace, secret.Name, SecretTokenKey)
+	}
+
+	return secret, nil
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+func writeResponse(resp http.ResponseWriter, status int, body Response) {
+	resp.Header().Set("Content-Type", "application/json")
+	resp.WriteHeader(status)
+	_ = json.NewEncoder(resp).Encode(body)
+}
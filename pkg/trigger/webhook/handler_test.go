@@ -0,0 +1,283 @@
+// Copyright The Shipwright Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package webhook_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/pointer"
+	crc "sigs.k8s.io/controller-runtime/pkg/client"
+
+	build "github.com/shipwright-io/build/pkg/apis/build/v1alpha1"
+	"github.com/shipwright-io/build/pkg/controller/fakes"
+	"github.com/shipwright-io/build/pkg/trigger/webhook"
+)
+
+var _ = Describe("Handler", func() {
+	var (
+		client    *fakes.FakeClient
+		builds    []build.Build
+		created   []*build.BuildRun
+		handler   http.Handler
+		recorder  *httptest.ResponseRecorder
+		newGitHub func(name string, events ...build.GitHubEventName) build.Build
+	)
+
+	BeforeEach(func() {
+		client = &fakes.FakeClient{}
+		created = nil
+		recorder = httptest.NewRecorder()
+
+		newGitHub = func(name string, events ...build.GitHubEventName) build.Build {
+			return build.Build{
+				ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+				Spec: build.BuildSpec{
+					Source: build.Source{URL: pointer.String("https://github.com/shipwright-io/sample-go")},
+					Trigger: &build.Trigger{
+						SecretRef: &corev1.LocalObjectReference{Name: "webhook-secret"},
+						When: []build.TriggerWhen{{
+							Name:   "on-" + name,
+							Type:   build.GitHubWebHookTrigger,
+							GitHub: &build.WhenGitHub{Events: events},
+						}},
+					},
+				},
+			}
+		}
+
+		builds = []build.Build{newGitHub("push-build", build.GitHubPushEvent)}
+
+		client.ListCalls(func(_ context.Context, list crc.ObjectList, _ ...crc.ListOption) error {
+			switch l := list.(type) {
+			case *build.BuildList:
+				l.Items = builds
+				return nil
+			}
+			return fmt.Errorf("unexpected list %T", list)
+		})
+
+		client.GetCalls(func(_ context.Context, nn types.NamespacedName, object crc.Object, _ ...crc.GetOption) error {
+			switch o := object.(type) {
+			case *corev1.Secret:
+				o.ObjectMeta = metav1.ObjectMeta{Name: nn.Name, Namespace: nn.Namespace}
+				o.Data = map[string][]byte{webhook.SecretTokenKey: []byte("s3cr3t"), webhook.SecretAPITokenKey: []byte("ghp_token")}
+				return nil
+			}
+			return fmt.Errorf("unexpected get %T", object)
+		})
+
+		client.CreateCalls(func(_ context.Context, object crc.Object, _ ...crc.CreateOption) error {
+			switch o := object.(type) {
+			case *build.BuildRun:
+				o.Name = o.GenerateName + "abcde"
+				created = append(created, o)
+				return nil
+			}
+			return fmt.Errorf("unexpected create %T", object)
+		})
+
+		handler = webhook.NewHandler(context.TODO(), client, webhook.GitHub{})
+	})
+
+	signedRequest := func(event string, payload string, secret string) *http.Request {
+		req := gitHubRequest(event, payload)
+		req.Header.Set(webhook.GitHubSignature256Header, sign(payload, secret))
+		return req
+	}
+
+	It("should create a BuildRun pinned to the pushed commit", func() {
+		handler.ServeHTTP(recorder, signedRequest("push", pushPayload, "s3cr3t"))
+
+		Expect(recorder.Code).To(Equal(http.StatusOK))
+		Expect(created).To(HaveLen(1))
+		Expect(created[0].Namespace).To(Equal("default"))
+		Expect(created[0].Spec.BuildRef.Name).To(Equal("push-build"))
+		Expect(created[0].Spec.Revision).To(Equal(pointer.String("0a1b2c3d4e5f60718293a4b5c6d7e8f901234567")))
+		Expect(created[0].Annotations).To(HaveKeyWithValue(build.AnnotationBuildRunTriggerEvent, "Push"))
+
+		var response webhook.Response
+		Expect(json.Unmarshal(recorder.Body.Bytes(), &response)).To(Succeed())
+		Expect(response.BuildRuns).To(ConsistOf("default/push-build-abcde"))
+	})
+
+	It("should only create BuildRuns for Builds subscribed to the event", func() {
+		builds = append(builds, newGitHub("pr-build", build.GitHubPullRequestEvent))
+
+		handler.ServeHTTP(recorder, signedRequest("pull_request", pullRequestPayload, "s3cr3t"))
+
+		Expect(recorder.Code).To(Equal(http.StatusOK))
+		Expect(created).To(HaveLen(1))
+		Expect(created[0].Spec.BuildRef.Name).To(Equal("pr-build"))
+		Expect(created[0].Spec.Revision).To(Equal(pointer.String("fedcba9876543210fedcba9876543210fedcba98")))
+	})
+
+	It("should not create a BuildRun for a branch that does not match", func() {
+		builds[0].Spec.Trigger.When[0].GitHub.Branches = []string{"release"}
+
+		handler.ServeHTTP(recorder, signedRequest("push", pushPayload, "s3cr3t"))
+
+		Expect(recorder.Code).To(Equal(http.StatusOK))
+		Expect(created).To(BeEmpty())
+	})
+
+	It("should only create a BuildRun if the push changed included paths", func() {
+		builds = append(builds, newGitHub("web-build", build.GitHubPushEvent))
+		builds[0].Spec.Source.ContextDir = pointer.String("services/api")
+		builds[0].Spec.Trigger.When[0].GitHub.Paths = &build.WhenPaths{}
+		builds[1].Spec.Source.ContextDir = pointer.String("services/web")
+		builds[1].Spec.Trigger.When[0].GitHub.Paths = &build.WhenPaths{Include: []string{"docs"}}
+
+		handler.ServeHTTP(recorder, signedRequest("push", pushPayload, "s3cr3t"))
+
+		Expect(recorder.Code).To(Equal(http.StatusOK))
+		Expect(created).To(HaveLen(2))
+		Expect(created[0].Spec.BuildRef.Name).To(Equal("push-build"))
+		Expect(created[1].Spec.BuildRef.Name).To(Equal("web-build"))
+	})
+
+	It("should not create a BuildRun if the push only changed excluded paths", func() {
+		builds[0].Spec.Trigger.When[0].GitHub.Paths = &build.WhenPaths{Include: []string{"services/api"}, Exclude: []string{"**/*.go"}}
+
+		handler.ServeHTTP(recorder, signedRequest("push", pushPayload, "s3cr3t"))
+
+		Expect(recorder.Code).To(Equal(http.StatusOK))
+		Expect(created).To(BeEmpty())
+	})
+
+	Context("for a pull request with path filters", func() {
+		var (
+			server   *httptest.Server
+			requests []*http.Request
+			status   int
+			payload  string
+		)
+
+		BeforeEach(func() {
+			requests = nil
+			status = http.StatusOK
+			server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				requests = append(requests, r)
+				w.WriteHeader(status)
+				fmt.Fprint(w, `[{"filename": "services/api/main.go"}, {"filename": "README.md"}]`)
+			}))
+			DeferCleanup(server.Close)
+
+			payload = strings.Replace(pullRequestPayload, "https://api.github.com", server.URL, 1)
+
+			builds = []build.Build{
+				newGitHub("api-build", build.GitHubPullRequestEvent),
+				newGitHub("web-build", build.GitHubPullRequestEvent),
+			}
+			builds[0].Spec.Source.ContextDir = pointer.String("services/api")
+			builds[0].Spec.Trigger.When[0].GitHub.Paths = &build.WhenPaths{}
+			builds[1].Spec.Source.ContextDir = pointer.String("services/web")
+			builds[1].Spec.Trigger.When[0].GitHub.Paths = &build.WhenPaths{Exclude: []string{"**/*.md"}}
+		})
+
+		It("should only create a BuildRun if the pull request changed included paths", func() {
+			handler.ServeHTTP(recorder, signedRequest("pull_request", payload, "s3cr3t"))
+
+			Expect(recorder.Code).To(Equal(http.StatusOK))
+			Expect(created).To(HaveLen(1))
+			Expect(created[0].Spec.BuildRef.Name).To(Equal("api-build"))
+
+			// the files are requested once for both Builds using the same token
+			Expect(requests).To(HaveLen(1))
+			Expect(requests[0].URL.Path).To(Equal("/repos/shipwright-io/sample-go/pulls/42/files"))
+			Expect(requests[0].Header.Get("Authorization")).To(Equal("Bearer ghp_token"))
+		})
+
+		It("should not create a BuildRun if the changed files cannot be listed", func() {
+			status = http.StatusForbidden
+
+			handler.ServeHTTP(recorder, signedRequest("pull_request", payload, "s3cr3t"))
+
+			Expect(recorder.Code).To(Equal(http.StatusOK))
+			Expect(created).To(BeEmpty())
+		})
+
+		It("should not list the changed files for an invalid signature", func() {
+			handler.ServeHTTP(recorder, signedRequest("pull_request", payload, "wrong"))
+
+			Expect(recorder.Code).To(Equal(http.StatusUnauthorized))
+			Expect(requests).To(BeEmpty())
+		})
+	})
+
+	It("should not create a BuildRun for a different repository", func() {
+		builds[0].Spec.Source.URL = pointer.String("https://github.com/shipwright-io/build")
+
+		handler.ServeHTTP(recorder, signedRequest("push", pushPayload, "s3cr3t"))
+
+		Expect(recorder.Code).To(Equal(http.StatusOK))
+		Expect(created).To(BeEmpty())
+	})
+
+	It("should skip Builds without a trigger secret", func() {
+		builds[0].Spec.Trigger.SecretRef = nil
+
+		handler.ServeHTTP(recorder, signedRequest("push", pushPayload, "s3cr3t"))
+
+		Expect(recorder.Code).To(Equal(http.StatusUnauthorized))
+		Expect(created).To(BeEmpty())
+	})
+
+	It("should reject a request with an invalid signature", func() {
+		handler.ServeHTTP(recorder, signedRequest("push", pushPayload, "wrong"))
+
+		Expect(recorder.Code).To(Equal(http.StatusUnauthorized))
+		Expect(created).To(BeEmpty())
+	})
+
+	It("should acknowledge a ping event", func() {
+		handler.ServeHTTP(recorder, signedRequest("ping", `{"zen":"Keep it logically awesome."}`, "s3cr3t"))
+
+		Expect(recorder.Code).To(Equal(http.StatusOK))
+		Expect(client.ListCallCount()).To(BeZero())
+	})
+
+	It("should not apply the branch filters to tag events", func() {
+		builds = []build.Build{{
+			ObjectMeta: metav1.ObjectMeta{Name: "tag-build", Namespace: "default"},
+			Spec: build.BuildSpec{
+				Source: build.Source{URL: pointer.String("https://gitea.com/shipwright-io/sample-go")},
+				Trigger: &build.Trigger{
+					SecretRef: &corev1.LocalObjectReference{Name: "webhook-secret"},
+					When: []build.TriggerWhen{{
+						Name:  "on-tag",
+						Type:  build.GiteaWebHookTrigger,
+						Gitea: &build.WhenGitea{Events: []build.GiteaEventName{build.GiteaTagEvent}, Branches: []string{"release"}},
+					}},
+				},
+			},
+		}}
+
+		req := giteaRequest("push", giteaTagPushPayload)
+		req.Header.Set(webhook.GiteaSignatureHeader, strings.TrimPrefix(sign(giteaTagPushPayload, "s3cr3t"), "sha256="))
+		webhook.NewHandler(context.TODO(), client, webhook.Gitea{}).ServeHTTP(recorder, req)
+
+		Expect(recorder.Code).To(Equal(http.StatusOK))
+		Expect(created).To(HaveLen(1))
+		Expect(created[0].Spec.BuildRef.Name).To(Equal("tag-build"))
+		Expect(created[0].Annotations).To(HaveKeyWithValue(build.AnnotationBuildRunTriggerEvent, "Tag"))
+	})
+
+	It("should reject requests other than POST", func() {
+		handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/github", nil))
+
+		Expect(recorder.Code).To(Equal(http.StatusMethodNotAllowed))
+	})
+})
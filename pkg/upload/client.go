@@ -0,0 +1,169 @@
+// Copyright The Shipwright Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package upload
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/klauspost/compress/zstd"
+
+	"github.com/shipwright-io/build/pkg/bundle"
+)
+
+// Client uploads local source code into the upload endpoint of a waiter
+type Client struct {
+	// Endpoint is the base URL of the waiter, for example http://localhost:8080
+	Endpoint string
+
+	// Token is the bearer token to authenticate the upload
+	Token string
+
+	// Compress defines whether the tar stream is compressed with zstd
+	Compress bool
+
+	// Progress is called while the upload is sent with the number of bytes
+	// that were sent so far and the total number of bytes
+	Progress func(sent int64, total int64)
+
+	HTTPClient *http.Client
+}
+
+// StatusError is returned when the waiter refuses an upload
+type StatusError struct {
+	StatusCode int
+	Message    string
+}
+
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("upload failed with %d %s: %s", e.StatusCode, http.StatusText(e.StatusCode), e.Message)
+}
+
+// UploadDirectory packs the directory into a tar stream like a bundle image,
+// which respects the .shpignore file, and uploads it
+func (c *Client) UploadDirectory(ctx context.Context, directory string) (*Result, error) {
+	content, err := bundle.Pack(directory)
+	if err != nil {
+		return nil, err
+	}
+	defer content.Close()
+
+	// the checksum is part of the request headers, therefore the tar stream
+	// is stored in a temporary file first
+	file, err := os.CreateTemp("", "upload-*.tar")
+	if err != nil {
+		return nil, err
+	}
+
+	defer os.Remove(file.Name())
+	defer file.Close()
+
+	hash := sha256.New()
+	var writer io.WriteCloser = nopWriteCloser{io.MultiWriter(file, hash)}
+
+	var mediaType = MediaTypeTar
+	if c.Compress {
+		mediaType = MediaTypeTarZstd
+		if writer, err = zstd.NewWriter(writer); err != nil {
+			return nil, err
+		}
+	}
+
+	if _, err := io.Copy(writer, content); err != nil {
+		return nil, err
+	}
+
+	if err := writer.Close(); err != nil {
+		return nil, err
+	}
+
+	size, err := file.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := file.Seek(0, io.SeekStart); err != nil {
+		return nil, err
+	}
+
+	return c.Upload(ctx, file, size, mediaType, "sha256:"+hex.EncodeToString(hash.Sum(nil)))
+}
+
+// Upload sends the tar stream of the given size, media type, and checksum to
+// the waiter, which extracts it and releases its lock
+func (c *Client) Upload(ctx context.Context, body io.Reader, size int64, mediaType string, checksum string) (*Result, error) {
+	if c.Progress != nil {
+		body = &sendProgress{reader: body, total: size, progress: c.Progress}
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, strings.TrimSuffix(c.Endpoint, "/")+Path, body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.ContentLength = size
+	req.Header.Set("Authorization", "Bearer "+c.Token)
+	req.Header.Set("Content-Type", mediaType)
+	req.Header.Set(ChecksumHeader, checksum)
+
+	client := c.HTTPClient
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		var errResp errorResponse
+		if err := json.NewDecoder(resp.Body).Decode(&errResp); err != nil {
+			errResp.Error = "unexpected response"
+		}
+
+		return nil, &StatusError{StatusCode: resp.StatusCode, Message: errResp.Error}
+	}
+
+	var result Result
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("failed to decode the upload result: %w", err)
+	}
+
+	return &result, nil
+}
+
+// sendProgress reports the number of bytes that were read from the request
+// body, which are the bytes that were sent
+type sendProgress struct {
+	reader   io.Reader
+	sent     int64
+	total    int64
+	progress func(sent int64, total int64)
+}
+
+func (p *sendProgress) Read(buf []byte) (int, error) {
+	n, err := p.reader.Read(buf)
+	if n > 0 {
+		p.sent += int64(n)
+		p.progress(p.sent, p.total)
+	}
+
+	return n, err
+}
+
+type nopWriteCloser struct {
+	io.Writer
+}
+
+func (nopWriteCloser) Close() error { return nil }
@@ -0,0 +1,244 @@
+// Copyright The Shipwright Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package upload
+
+import (
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"mime"
+	"net/http"
+	"os"
+	"regexp"
+	"sync"
+	"time"
+
+	"github.com/klauspost/compress/zstd"
+
+	"github.com/shipwright-io/build/pkg/bundle"
+)
+
+// progressInterval is how often the progress of an upload is logged
+const progressInterval = time.Second
+
+var checksumRegexp = regexp.MustCompile(`^sha256:[0-9a-f]{64}$`)
+
+// errChecksumMismatch is returned when the received bytes do not match the
+// declared checksum
+var errChecksumMismatch = errors.New("checksum mismatch")
+
+// HandlerOptions are the limits of the uploads of a Handler, zero means that
+// the respective property is not limited
+type HandlerOptions struct {
+	// MaxSize is the maximum number of bytes of the request body
+	MaxSize int64
+
+	// Limits are the limits of the content that is extracted from the upload
+	Limits bundle.UnpackOptions
+}
+
+// Handler serves the upload endpoint, it accepts a single successful upload
+// into the target directory and calls the done function afterwards
+type Handler struct {
+	target  string
+	token   string
+	options HandlerOptions
+	done    func() error
+
+	mu        sync.Mutex
+	busy      bool
+	completed bool
+}
+
+// NewHandler creates the handler of the upload endpoint that extracts the
+// uploaded source code into the target directory within the limits of the
+// options. Requests must authenticate with the bearer token. The done function
+// is called after the first successful upload, for example to release the
+// lock of the waiter.
+func NewHandler(target string, token string, options HandlerOptions, done func() error) *Handler {
+	return &Handler{target: target, token: token, options: options, done: done}
+}
+
+// ServeHTTP implements the http.Handler interface
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPut {
+		w.Header().Set("Allow", http.MethodPut)
+		writeError(w, http.StatusMethodNotAllowed, "only %s is supported", http.MethodPut)
+		return
+	}
+
+	if h.token == "" || subtle.ConstantTimeCompare([]byte(r.Header.Get("Authorization")), []byte("Bearer "+h.token)) != 1 {
+		w.Header().Set("WWW-Authenticate", "Bearer")
+		writeError(w, http.StatusUnauthorized, "invalid or missing bearer token")
+		return
+	}
+
+	mediaType, _, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if err != nil || (mediaType != MediaTypeTar && mediaType != MediaTypeTarZstd) {
+		writeError(w, http.StatusUnsupportedMediaType, "the content type must be either %s or %s", MediaTypeTar, MediaTypeTarZstd)
+		return
+	}
+
+	checksum := r.Header.Get(ChecksumHeader)
+	if !checksumRegexp.MatchString(checksum) {
+		writeError(w, http.StatusBadRequest, "the %s header must contain the hex encoded SHA-256 digest of the body prefixed with sha256:", ChecksumHeader)
+		return
+	}
+
+	if status, message := h.acquire(); status != http.StatusOK {
+		writeError(w, status, "%s", message)
+		return
+	}
+
+	var body io.Reader = r.Body
+	if h.options.MaxSize > 0 {
+		body = http.MaxBytesReader(w, r.Body, h.options.MaxSize)
+	}
+
+	size, err := h.receive(body, mediaType, checksum)
+	h.release(err == nil)
+
+	var unpackErr *bundle.UnpackError
+	var maxBytesErr *http.MaxBytesError
+	switch {
+	case errors.As(err, &maxBytesErr):
+		log.Printf("Upload failed: %v\n", err)
+		writeError(w, http.StatusRequestEntityTooLarge, "the upload exceeds the maximum size of %d bytes", h.options.MaxSize)
+		return
+
+	case errors.As(err, &unpackErr), errors.Is(err, errChecksumMismatch):
+		log.Printf("Upload failed: %v\n", err)
+		writeError(w, http.StatusBadRequest, "%s", err.Error())
+		return
+
+	case err != nil:
+		log.Printf("Upload failed: %v\n", err)
+		writeError(w, http.StatusInternalServerError, "%s", err.Error())
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(Result{Size: size, Checksum: checksum})
+}
+
+// acquire marks the handler busy, unless an upload is in progress or was
+// already completed
+func (h *Handler) acquire() (int, string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	switch {
+	case h.completed:
+		return http.StatusConflict, "the source code was already uploaded"
+
+	case h.busy:
+		return http.StatusConflict, "another upload is in progress"
+	}
+
+	h.busy = true
+	return http.StatusOK, ""
+}
+
+func (h *Handler) release(completed bool) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.busy = false
+	h.completed = completed
+}
+
+// receive stores the body in a temporary file outside of the target directory
+// to verify its checksum before the content is extracted into the target
+// directory, and calls the done function once the temporary file is removed
+func (h *Handler) receive(body io.Reader, mediaType string, checksum string) (int64, error) {
+	size, err := h.extract(body, mediaType, checksum)
+	if err != nil {
+		return size, err
+	}
+
+	if h.done != nil {
+		if err := h.done(); err != nil {
+			return size, err
+		}
+	}
+
+	return size, nil
+}
+
+// extract verifies the checksum of the body and extracts it into the target
+// directory, the temporary file is removed when it returns
+func (h *Handler) extract(body io.Reader, mediaType string, checksum string) (int64, error) {
+	file, err := os.CreateTemp("", "upload-*")
+	if err != nil {
+		return 0, err
+	}
+
+	defer os.Remove(file.Name())
+	defer file.Close()
+
+	hash := sha256.New()
+	size, err := io.Copy(io.MultiWriter(file, hash), &progressReader{reader: body, last: time.Now()})
+	if err != nil {
+		return size, fmt.Errorf("failed to receive the upload: %w", err)
+	}
+
+	if actual := "sha256:" + hex.EncodeToString(hash.Sum(nil)); actual != checksum {
+		return size, fmt.Errorf("%w: received %d bytes with checksum %s, expected %s", errChecksumMismatch, size, actual, checksum)
+	}
+
+	log.Printf("Received %d bytes with checksum %s\n", size, checksum)
+
+	if _, err := file.Seek(0, io.SeekStart); err != nil {
+		return size, err
+	}
+
+	var content io.Reader = file
+	if mediaType == MediaTypeTarZstd {
+		decoder, err := zstd.NewReader(file)
+		if err != nil {
+			return size, err
+		}
+		defer decoder.Close()
+
+		content = decoder
+	}
+
+	if err := bundle.UnpackWithOptions(content, h.target, h.options.Limits); err != nil {
+		return size, err
+	}
+
+	log.Printf("Extracted the source code into %s\n", h.target)
+	return size, nil
+}
+
+// progressReader logs the number of bytes that were read in intervals
+type progressReader struct {
+	reader io.Reader
+	total  int64
+	last   time.Time
+}
+
+func (p *progressReader) Read(buf []byte) (int, error) {
+	n, err := p.reader.Read(buf)
+	p.total += int64(n)
+
+	if time.Since(p.last) >= progressInterval {
+		log.Printf("Receiving upload, %d bytes so far\n", p.total)
+		p.last = time.Now()
+	}
+
+	return n, err
+}
+
+func writeError(w http.ResponseWriter, status int, format string, args ...interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(errorResponse{Error: fmt.Sprintf(format, args...)})
+}
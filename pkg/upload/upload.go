@@ -0,0 +1,49 @@
+// Copyright The Shipwright Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Package upload implements the protocol to upload local source code into the
+// waiter of a BuildRun with a Local source.
+//
+// The client sends the source code as a tar stream, optionally compressed with
+// zstd, in the body of a PUT request to the Path of the waiter:
+//
+//	PUT /upload HTTP/1.1
+//	Authorization: Bearer <token>
+//	Content-Type: application/x-tar | application/zstd
+//	Shp-Upload-Checksum: sha256:<hex encoded SHA-256 digest of the body>
+//
+// The waiter verifies the checksum of the body, extracts the tar stream into
+// the source directory, and releases its lock, so that the build continues.
+// It responds with a JSON encoded Result. An upload that fails can be retried,
+// the lock is only released by a successful upload.
+package upload
+
+const (
+	// Path is the path of the upload endpoint
+	Path = "/upload"
+
+	// ChecksumHeader is the HTTP header declaring the checksum of the request
+	// body, the value is the hex encoded SHA-256 digest prefixed with "sha256:"
+	ChecksumHeader = "Shp-Upload-Checksum"
+
+	// MediaTypeTar is the content type of an uncompressed tar stream
+	MediaTypeTar = "application/x-tar"
+
+	// MediaTypeTarZstd is the content type of a zstd compressed tar stream
+	MediaTypeTarZstd = "application/zstd"
+)
+
+// Result is the response of a successful upload
+type Result struct {
+	// Size is the number of bytes that were received
+	Size int64 `json:"size"`
+
+	// Checksum is the verified checksum of the received bytes
+	Checksum string `json:"checksum"`
+}
+
+// errorResponse is the response of a failed upload
+type errorResponse struct {
+	Error string `json:"error"`
+}
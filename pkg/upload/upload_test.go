@@ -0,0 +1,167 @@
+// Copyright The Shipwright Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package upload_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/shipwright-io/build/pkg/bundle"
+	"github.com/shipwright-io/build/pkg/upload"
+)
+
+var _ = Describe("Upload", func() {
+	const token = "secret-token"
+
+	var (
+		source    string
+		target    string
+		options   upload.HandlerOptions
+		doneCall  int
+		doneFiles []string
+		server    *httptest.Server
+	)
+
+	BeforeEach(func() {
+		tempDir, err := os.MkdirTemp("", "upload")
+		Expect(err).ToNot(HaveOccurred())
+		DeferCleanup(os.RemoveAll, tempDir)
+
+		source = filepath.Join(tempDir, "source")
+		target = filepath.Join(tempDir, "target")
+
+		Expect(os.MkdirAll(filepath.Join(source, "src"), 0755)).To(Succeed())
+		Expect(os.WriteFile(filepath.Join(source, "src", "main.go"), []byte("package main"), 0644)).To(Succeed())
+		Expect(os.WriteFile(filepath.Join(source, "README.md"), []byte("hello"), 0644)).To(Succeed())
+
+		options = upload.HandlerOptions{}
+		doneCall = 0
+		doneFiles = nil
+	})
+
+	JustBeforeEach(func() {
+		mux := http.NewServeMux()
+		mux.Handle(upload.Path, upload.NewHandler(target, token, options, func() error {
+			doneCall++
+
+			// the files of the target directory when the build continues
+			entries, err := os.ReadDir(target)
+			Expect(err).ToNot(HaveOccurred())
+			for _, entry := range entries {
+				doneFiles = append(doneFiles, entry.Name())
+			}
+
+			return nil
+		}))
+
+		server = httptest.NewServer(mux)
+		DeferCleanup(server.Close)
+	})
+
+	statusCodeOf := func(err error) int {
+		var statusErr *upload.StatusError
+		Expect(errors.As(err, &statusErr)).To(BeTrue(), "expected a StatusError, got %v", err)
+		return statusErr.StatusCode
+	}
+
+	It("should extract an uploaded directory and call done", func() {
+		client := &upload.Client{Endpoint: server.URL, Token: token}
+
+		result, err := client.UploadDirectory(context.Background(), source)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result.Checksum).To(HavePrefix("sha256:"))
+		Expect(result.Size).To(BeNumerically(">", 0))
+
+		content, err := os.ReadFile(filepath.Join(target, "src", "main.go"))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(string(content)).To(Equal("package main"))
+		Expect(doneCall).To(Equal(1))
+		Expect(doneFiles).To(ConsistOf("README.md", "src"))
+	})
+
+	It("should extract a zstd compressed upload and report the progress", func() {
+		var sent, total int64
+		client := &upload.Client{Endpoint: server.URL, Token: token, Compress: true, Progress: func(s int64, t int64) {
+			sent, total = s, t
+		}}
+
+		result, err := client.UploadDirectory(context.Background(), source)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(sent).To(Equal(result.Size))
+		Expect(total).To(Equal(result.Size))
+
+		Expect(filepath.Join(target, "README.md")).To(BeAnExistingFile())
+		Expect(doneCall).To(Equal(1))
+	})
+
+	It("should refuse an upload with an invalid token", func() {
+		client := &upload.Client{Endpoint: server.URL, Token: "wrong"}
+
+		_, err := client.UploadDirectory(context.Background(), source)
+		Expect(statusCodeOf(err)).To(Equal(http.StatusUnauthorized))
+		Expect(doneCall).To(Equal(0))
+	})
+
+	It("should refuse an upload with a mismatching checksum and accept a retry", func() {
+		client := &upload.Client{Endpoint: server.URL, Token: token}
+
+		body := "not a tar stream"
+		_, err := client.Upload(context.Background(), strings.NewReader(body), int64(len(body)), upload.MediaTypeTar, "sha256:"+strings.Repeat("0", 64))
+		Expect(statusCodeOf(err)).To(Equal(http.StatusBadRequest))
+		Expect(doneCall).To(Equal(0))
+
+		_, err = client.UploadDirectory(context.Background(), source)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(doneCall).To(Equal(1))
+	})
+
+	Context("with a maximum size", func() {
+		BeforeEach(func() {
+			options.MaxSize = 1024
+		})
+
+		It("should refuse an upload that exceeds the maximum size", func() {
+			client := &upload.Client{Endpoint: server.URL, Token: token}
+
+			_, err := client.UploadDirectory(context.Background(), source)
+			Expect(statusCodeOf(err)).To(Equal(http.StatusRequestEntityTooLarge))
+			Expect(doneCall).To(Equal(0))
+		})
+
+	})
+
+	Context("with unpack limits", func() {
+		BeforeEach(func() {
+			options.Limits = bundle.UnpackOptions{MaxFileCount: 1}
+		})
+
+		It("should refuse an upload that exceeds the unpack limits", func() {
+			client := &upload.Client{Endpoint: server.URL, Token: token}
+
+			_, err := client.UploadDirectory(context.Background(), source)
+			Expect(statusCodeOf(err)).To(Equal(http.StatusBadRequest))
+			Expect(doneCall).To(Equal(0))
+		})
+	})
+
+	It("should refuse another upload after a successful upload", func() {
+		client := &upload.Client{Endpoint: server.URL, Token: token}
+
+		_, err := client.UploadDirectory(context.Background(), source)
+		Expect(err).ToNot(HaveOccurred())
+
+		_, err = client.UploadDirectory(context.Background(), source)
+		Expect(statusCodeOf(err)).To(Equal(http.StatusConflict))
+		Expect(doneCall).To(Equal(1))
+	})
+})
@@ -0,0 +1,140 @@
+// Copyright The Shipwright Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package validate
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"regexp"
+	"strings"
+
+	"k8s.io/utils/pointer"
+
+	build "github.com/shipwright-io/build/pkg/apis/build/v1alpha1"
+)
+
+// cloneFilterRegEx matches the supported partial clone filters
+var cloneFilterRegEx = regexp.MustCompile(`^(blob:none|blob:limit=[0-9]+[kmg]?|tree:[0-9]+)$`)
+
+// GitSourceRef implements the BuildPath interface to validate the clone options
+// of the Git source
+type GitSourceRef struct {
+	Build *build.Build
+}
+
+// NewGitSourceRef instantiates a new GitSourceRef passing the build object pointer along.
+func NewGitSourceRef(b *build.Build) *GitSourceRef {
+	return &GitSourceRef{Build: b}
+}
+
+// ValidatePath implements BuildPath interface and validates the clone options
+// of the Git source
+func (g *GitSourceRef) ValidatePath(_ context.Context) error {
+	if g.Build.Spec.Source.URL == nil {
+		return nil
+	}
+
+	if err := validateGitOptions(g.Build.Spec.Source); err != nil {
+		return g.invalid("%v", err)
+	}
+
+	return nil
+}
+
+// validateGitOptions validates the sparse checkout paths, the partial clone
+// filter, the depth, the merge target, the LFS patterns, the signature
+// verification, and the cache of a Git source
+func validateGitOptions(source build.Source) error {
+	if source.SparseCheckout != nil {
+		if len(source.SparseCheckout.Paths) == 0 && (source.ContextDir == nil || *source.ContextDir == "") {
+			return fmt.Errorf("sparse checkout requires paths or a context directory")
+		}
+
+		for _, sparsePath := range source.SparseCheckout.Paths {
+			if err := validateSparseCheckoutPath(sparsePath); err != nil {
+				return fmt.Errorf("sparse checkout path %q is invalid: %v", sparsePath, err)
+			}
+		}
+	}
+
+	if source.CloneFilter != nil && !cloneFilterRegEx.MatchString(*source.CloneFilter) {
+		return fmt.Errorf("clone filter %q is not supported, supported filters are blob:none, blob:limit=<size>, and tree:<depth>", *source.CloneFilter)
+	}
+
+	if source.Depth != nil && *source.Depth < 0 {
+		return fmt.Errorf("depth must not be negative")
+	}
+
+	if source.MergeTarget != nil {
+		switch {
+		case strings.TrimSpace(*source.MergeTarget) == "" || strings.HasPrefix(*source.MergeTarget, "-"):
+			return fmt.Errorf("merge target %q is not a valid branch name", *source.MergeTarget)
+
+		case source.Revision == nil || *source.Revision == "":
+			return fmt.Errorf("merge target requires a revision to merge")
+
+		case source.Depth != nil && *source.Depth != 0:
+			return fmt.Errorf("merge target requires the full history, the depth must be 0 or undefined")
+		}
+	}
+
+	if source.LFS != nil {
+		if source.LFS.Enabled != nil && !*source.LFS.Enabled && (len(source.LFS.Include) > 0 || len(source.LFS.Exclude) > 0) {
+			return fmt.Errorf("LFS include and exclude patterns require LFS to be enabled")
+		}
+
+		for _, pattern := range append(append([]string{}, source.LFS.Include...), source.LFS.Exclude...) {
+			if strings.TrimSpace(pattern) == "" || strings.Contains(pattern, ",") {
+				return fmt.Errorf("LFS pattern %q is invalid, it must not be empty or contain a comma", pattern)
+			}
+		}
+	}
+
+	if source.VerifySignature != nil {
+		hasSecret := source.VerifySignature.SecretRef != nil && source.VerifySignature.SecretRef.Name != ""
+		hasConfigMap := source.VerifySignature.ConfigMapRef != nil && source.VerifySignature.ConfigMapRef.Name != ""
+		if hasSecret == hasConfigMap {
+			return fmt.Errorf("signature verification requires either a secret or a config map with the trusted keys")
+		}
+	}
+
+	if source.Cache != nil {
+		if source.Cache.PersistentVolumeClaimRef.Name == "" {
+			return fmt.Errorf("cache requires a persistent volume claim")
+		}
+
+		if source.Cache.MaxSize != nil && source.Cache.MaxSize.Sign() < 0 {
+			return fmt.Errorf("cache max size must not be negative")
+		}
+	}
+
+	return nil
+}
+
+func (g *GitSourceRef) invalid(format string, args ...interface{}) error {
+	g.Build.Status.Reason = build.BuildReasonPtr(build.SpecSourceGitOptionsInvalid)
+	g.Build.Status.Message = pointer.String(fmt.Sprintf(format, args...))
+	return fmt.Errorf("%s", *g.Build.Status.Message)
+}
+
+// validateSparseCheckoutPath checks that the path is a directory inside of the repository
+func validateSparseCheckoutPath(sparsePath string) error {
+	switch {
+	case strings.TrimSpace(sparsePath) == "":
+		return fmt.Errorf("must not be empty")
+
+	case strings.HasPrefix(sparsePath, "/"):
+		return fmt.Errorf("must be relative to the repository root")
+
+	case strings.HasPrefix(sparsePath, "-"):
+		return fmt.Errorf("must not start with a dash")
+
+	case path.Clean(sparsePath) == ".." || strings.HasPrefix(path.Clean(sparsePath), "../"):
+		return fmt.Errorf("must not point outside of the repository")
+	}
+
+	return nil
+}
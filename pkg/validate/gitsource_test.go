@@ -0,0 +1,160 @@
+// Copyright The Shipwright Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package validate_test
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/utils/pointer"
+
+	build "github.com/shipwright-io/build/pkg/apis/build/v1alpha1"
+	"github.com/shipwright-io/build/pkg/validate"
+)
+
+var _ = Describe("GitSourceRef", func() {
+	var gitBuild = func(source build.Source) *build.Build {
+		source.URL = pointer.String("https://github.com/shipwright-io/sample-go")
+		return &build.Build{Spec: build.BuildSpec{Source: source}}
+	}
+
+	Context("ValidatePath", func() {
+		It("should successfully validate a Git source without clone options", func() {
+			b := gitBuild(build.Source{})
+			Expect(validate.NewGitSourceRef(b).ValidatePath(context.TODO())).To(Succeed())
+			Expect(b.Status.Reason).To(BeNil())
+		})
+
+		It("should successfully validate a sparse checkout of the context directory", func() {
+			b := gitBuild(build.Source{
+				ContextDir:     pointer.String("docker-build"),
+				SparseCheckout: &build.SparseCheckout{},
+				CloneFilter:    pointer.String("blob:none"),
+			})
+			Expect(validate.NewGitSourceRef(b).ValidatePath(context.TODO())).To(Succeed())
+		})
+
+		It("should successfully validate sparse checkout paths and a blob size filter", func() {
+			b := gitBuild(build.Source{
+				SparseCheckout: &build.SparseCheckout{Paths: []string{"docker-build", "libs/common"}},
+				CloneFilter:    pointer.String("blob:limit=1m"),
+			})
+			Expect(validate.NewGitSourceRef(b).ValidatePath(context.TODO())).To(Succeed())
+		})
+
+		It("should fail a sparse checkout without paths and context directory", func() {
+			b := gitBuild(build.Source{SparseCheckout: &build.SparseCheckout{}})
+			Expect(validate.NewGitSourceRef(b).ValidatePath(context.TODO())).To(HaveOccurred())
+			Expect(*b.Status.Reason).To(Equal(build.SpecSourceGitOptionsInvalid))
+		})
+
+		It("should fail sparse checkout paths outside of the repository", func() {
+			for _, sparsePath := range []string{"", "/docker-build", "../other", "docker-build/../..", "--cone"} {
+				b := gitBuild(build.Source{SparseCheckout: &build.SparseCheckout{Paths: []string{sparsePath}}})
+				Expect(validate.NewGitSourceRef(b).ValidatePath(context.TODO())).To(HaveOccurred(), sparsePath)
+				Expect(*b.Status.Reason).To(Equal(build.SpecSourceGitOptionsInvalid))
+			}
+		})
+
+		It("should fail an unsupported clone filter", func() {
+			b := gitBuild(build.Source{CloneFilter: pointer.String("sparse:oid=main:.sparse")})
+			Expect(validate.NewGitSourceRef(b).ValidatePath(context.TODO())).To(HaveOccurred())
+			Expect(*b.Status.Reason).To(Equal(build.SpecSourceGitOptionsInvalid))
+			Expect(*b.Status.Message).To(ContainSubstring("sparse:oid=main:.sparse"))
+		})
+
+		It("should successfully validate the full history with tags and LFS patterns", func() {
+			b := gitBuild(build.Source{
+				Depth:     pointer.Int32(0),
+				FetchTags: pointer.Bool(true),
+				LFS:       &build.GitLFS{Include: []string{"assets/**"}, Exclude: []string{"*.mp4"}},
+			})
+			Expect(validate.NewGitSourceRef(b).ValidatePath(context.TODO())).To(Succeed())
+		})
+
+		It("should fail a negative depth", func() {
+			b := gitBuild(build.Source{Depth: pointer.Int32(-1)})
+			Expect(validate.NewGitSourceRef(b).ValidatePath(context.TODO())).To(HaveOccurred())
+			Expect(*b.Status.Reason).To(Equal(build.SpecSourceGitOptionsInvalid))
+		})
+
+		It("should fail LFS patterns when LFS is disabled", func() {
+			b := gitBuild(build.Source{LFS: &build.GitLFS{Enabled: pointer.Bool(false), Include: []string{"assets/**"}}})
+			Expect(validate.NewGitSourceRef(b).ValidatePath(context.TODO())).To(HaveOccurred())
+			Expect(*b.Status.Reason).To(Equal(build.SpecSourceGitOptionsInvalid))
+		})
+
+		It("should fail LFS patterns containing a comma", func() {
+			b := gitBuild(build.Source{LFS: &build.GitLFS{Exclude: []string{"*.mp4,*.mov"}}})
+			Expect(validate.NewGitSourceRef(b).ValidatePath(context.TODO())).To(HaveOccurred())
+			Expect(*b.Status.Message).To(ContainSubstring("*.mp4,*.mov"))
+		})
+
+		It("should successfully validate a pull request reference with a merge target", func() {
+			b := gitBuild(build.Source{
+				Revision:    pointer.String("refs/pull/123/head"),
+				MergeTarget: pointer.String("main"),
+			})
+			Expect(validate.NewGitSourceRef(b).ValidatePath(context.TODO())).To(Succeed())
+		})
+
+		It("should fail a merge target without revision", func() {
+			b := gitBuild(build.Source{MergeTarget: pointer.String("main")})
+			Expect(validate.NewGitSourceRef(b).ValidatePath(context.TODO())).To(HaveOccurred())
+			Expect(*b.Status.Reason).To(Equal(build.SpecSourceGitOptionsInvalid))
+		})
+
+		It("should fail a merge target with a shallow clone", func() {
+			b := gitBuild(build.Source{
+				Revision:    pointer.String("refs/pull/123/head"),
+				MergeTarget: pointer.String("main"),
+				Depth:       pointer.Int32(1),
+			})
+			Expect(validate.NewGitSourceRef(b).ValidatePath(context.TODO())).To(HaveOccurred())
+			Expect(*b.Status.Reason).To(Equal(build.SpecSourceGitOptionsInvalid))
+		})
+
+		It("should successfully validate a signature verification using a secret", func() {
+			b := gitBuild(build.Source{VerifySignature: &build.GitSignatureVerification{
+				SecretRef: &corev1.LocalObjectReference{Name: "trusted-keys"},
+			}})
+			Expect(validate.NewGitSourceRef(b).ValidatePath(context.TODO())).To(Succeed())
+		})
+
+		It("should fail a signature verification without trusted keys", func() {
+			b := gitBuild(build.Source{VerifySignature: &build.GitSignatureVerification{}})
+			Expect(validate.NewGitSourceRef(b).ValidatePath(context.TODO())).To(HaveOccurred())
+			Expect(*b.Status.Reason).To(Equal(build.SpecSourceGitOptionsInvalid))
+		})
+
+		It("should fail a signature verification using both a secret and a config map", func() {
+			b := gitBuild(build.Source{VerifySignature: &build.GitSignatureVerification{
+				SecretRef:    &corev1.LocalObjectReference{Name: "trusted-keys"},
+				ConfigMapRef: &corev1.LocalObjectReference{Name: "trusted-keys"},
+			}})
+			Expect(validate.NewGitSourceRef(b).ValidatePath(context.TODO())).To(HaveOccurred())
+			Expect(*b.Status.Reason).To(Equal(build.SpecSourceGitOptionsInvalid))
+		})
+
+		It("should successfully validate a cache", func() {
+			maxSize := resource.MustParse("10Gi")
+			b := gitBuild(build.Source{Cache: &build.GitCache{
+				PersistentVolumeClaimRef: corev1.LocalObjectReference{Name: "git-cache"},
+				MaxSize:                  &maxSize,
+			}})
+			Expect(validate.NewGitSourceRef(b).ValidatePath(context.TODO())).To(Succeed())
+		})
+
+		It("should fail a cache without a persistent volume claim", func() {
+			b := gitBuild(build.Source{Cache: &build.GitCache{}})
+			Expect(validate.NewGitSourceRef(b).ValidatePath(context.TODO())).To(HaveOccurred())
+			Expect(*b.Status.Reason).To(Equal(build.SpecSourceGitOptionsInvalid))
+		})
+	})
+})
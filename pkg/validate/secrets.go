@@ -64,9 +64,37 @@ func (s Credentials) buildCredentialserences() map[string]build.BuildReason {
 	if s.Build.Spec.Output.Credentials != nil && s.Build.Spec.Output.Credentials.Name != "" {
 		secretRefMap[s.Build.Spec.Output.Credentials.Name] = build.SpecOutputSecretRefNotFound
 	}
+	if s.Build.Spec.Output.Signing != nil && s.Build.Spec.Output.Signing.SecretRef.Name != "" {
+		secretRefMap[s.Build.Spec.Output.Signing.SecretRef.Name] = build.SpecOutputSecretRefNotFound
+	}
+	for _, mirror := range s.Build.Spec.Output.Mirrors {
+		if mirror.Credentials != nil && mirror.Credentials.Name != "" {
+			secretRefMap[mirror.Credentials.Name] = build.SpecOutputSecretRefNotFound
+		}
+	}
 	if s.Build.Spec.Source.Credentials != nil && s.Build.Spec.Source.Credentials.Name != "" {
 		secretRefMap[s.Build.Spec.Source.Credentials.Name] = build.SpecSourceSecretRefNotFound
 	}
+	if s.Build.Spec.Source.VerifySignature != nil && s.Build.Spec.Source.VerifySignature.SecretRef != nil && s.Build.Spec.Source.VerifySignature.SecretRef.Name != "" {
+		secretRefMap[s.Build.Spec.Source.VerifySignature.SecretRef.Name] = build.SpecSourceSecretRefNotFound
+	}
+	if s.Build.Spec.Source.BundleContainer != nil && s.Build.Spec.Source.BundleContainer.VerifySignature != nil && s.Build.Spec.Source.BundleContainer.VerifySignature.SecretRef.Name != "" {
+		secretRefMap[s.Build.Spec.Source.BundleContainer.VerifySignature.SecretRef.Name] = build.SpecSourceSecretRefNotFound
+	}
+	for _, source := range s.Build.Spec.Sources {
+		if source.Type == build.HTTP && source.AuthSecretRef != nil && source.AuthSecretRef.Name != "" {
+			secretRefMap[source.AuthSecretRef.Name] = build.SpecSourceSecretRefNotFound
+		}
+		if source.Type == build.LocalCopy && source.UploadSecretRef != nil && source.UploadSecretRef.Name != "" {
+			secretRefMap[source.UploadSecretRef.Name] = build.SpecSourceSecretRefNotFound
+		}
+		if source.Source != nil && source.Source.Credentials != nil && source.Source.Credentials.Name != "" {
+			secretRefMap[source.Source.Credentials.Name] = build.SpecSourceSecretRefNotFound
+		}
+		if source.Source != nil && source.Source.BundleContainer != nil && source.Source.BundleContainer.VerifySignature != nil && source.Source.BundleContainer.VerifySignature.SecretRef.Name != "" {
+			secretRefMap[source.Source.BundleContainer.VerifySignature.SecretRef.Name] = build.SpecSourceSecretRefNotFound
+		}
+	}
 	if s.Build.Spec.Builder != nil && s.Build.Spec.Builder.Credentials != nil && s.Build.Spec.Builder.Credentials.Name != "" {
 		secretRefMap[s.Build.Spec.Builder.Credentials.Name] = build.SpecBuilderSecretRefNotFound
 	}
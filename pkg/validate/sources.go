@@ -8,10 +8,17 @@ import (
 	"context"
 	"fmt"
 	"net/url"
+	"regexp"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/utils/pointer"
 
 	build "github.com/shipwright-io/build/pkg/apis/build/v1alpha1"
 )
 
+var sha256RegEx = regexp.MustCompile(`^(sha256:)?[0-9a-fA-F]{64}$`)
+
 // SourcesRef implements RuntimeRef interface to add validations for `build.spec.sources` slice.
 type SourcesRef struct {
 	Build *build.Build // build instance for analysis
@@ -20,10 +27,18 @@ type SourcesRef struct {
 // ValidatePath executes the validation routine, inspecting the `build.spec.sources` path, which
 // contains a slice of BuildSource.
 func (s *SourcesRef) ValidatePath(_ context.Context) error {
+	names := map[string]bool{}
 	for _, source := range s.Build.Spec.Sources {
 		if err := s.validateSourceEntry(source); err != nil {
 			return err
 		}
+
+		if source.Name != "" {
+			if names[source.Name] {
+				return s.invalid(build.SpecSourcesInvalid, "source name %q is used more than once", source.Name)
+			}
+			names[source.Name] = true
+		}
 	}
 	return nil
 }
@@ -33,15 +48,104 @@ func (s *SourcesRef) validateSourceEntry(source build.BuildSource) error {
 	if source.Name == "" {
 		return fmt.Errorf("name must be informed")
 	}
+
+	switch source.Type {
+	case build.Git, build.Bundle:
+		return s.validateNamedSource(source)
+	}
+
+	// the name of HTTP sources is part of the names of their step and result
+	if errs := validation.IsDNS1123Label(source.Name); len(errs) > 0 {
+		return s.invalid(build.SpecSourcesInvalid, "source name %q is invalid: %s", source.Name, strings.Join(errs, ", "))
+	}
+
 	if source.URL == "" {
 		return fmt.Errorf("URL must be informed")
 	}
 	if _, err := url.ParseRequestURI(source.URL); err != nil {
 		return err
 	}
+	return s.validateHTTPOptions(source)
+}
+
+// validateNamedSource inspects a Git or Bundle source, which is fetched into its own
+// subdirectory of the source directory
+func (s *SourcesRef) validateNamedSource(source build.BuildSource) error {
+	if errs := validation.IsDNS1123Label(source.Name); len(errs) > 0 {
+		return s.invalid(build.SpecSourcesInvalid, "source name %q is invalid: %s", source.Name, strings.Join(errs, ", "))
+	}
+
+	if source.Name == build.DefaultSourceName {
+		return s.invalid(build.SpecSourcesInvalid, "source name %q is reserved for spec.source", source.Name)
+	}
+
+	if source.URL != "" {
+		return s.invalid(build.SpecSourcesInvalid, "source %q of type %s must define its location in the source attribute", source.Name, source.Type)
+	}
+
+	switch {
+	case source.Source == nil:
+		return s.invalid(build.SpecSourcesInvalid, "source %q of type %s must define the source attribute", source.Name, source.Type)
+
+	case source.Type == build.Git && (source.Source.URL == nil || *source.Source.URL == ""):
+		return s.invalid(build.SpecSourcesInvalid, "source %q of type Git must define a URL", source.Name)
+
+	case source.Type == build.Bundle && (source.Source.BundleContainer == nil || source.Source.BundleContainer.Image == ""):
+		return s.invalid(build.SpecSourcesInvalid, "source %q of type Bundle must define a bundle container image", source.Name)
+	}
+
+	if source.Type == build.Git {
+		if err := validateGitOptions(*source.Source); err != nil {
+			return s.invalid(build.SpecSourceGitOptionsInvalid, "source %q: %v", source.Name, err)
+		}
+	}
+
+	return s.validateHTTPOptions(source)
+}
+
+// validateHTTPOptions inspects the checksum, extraction, and retry options, which
+// are only supported for HTTP sources
+func (s *SourcesRef) validateHTTPOptions(source build.BuildSource) error {
+	hasOptions := source.SHA256 != nil || source.AuthSecretRef != nil || source.Extract != nil || source.StripComponents != nil || source.Retries != nil
+	if !hasOptions {
+		return nil
+	}
+
+	if source.Type != build.HTTP {
+		return s.invalid(build.SpecSourceHTTPOptionsInvalid, "source %q defines options that are only supported for the HTTP type", source.Name)
+	}
+
+	if source.SHA256 != nil && !sha256RegEx.MatchString(*source.SHA256) {
+		return s.invalid(build.SpecSourceHTTPOptionsInvalid, "source %q has an invalid SHA-256 digest, it must consist of 64 hexadecimal characters", source.Name)
+	}
+
+	if source.AuthSecretRef != nil && strings.TrimSpace(source.AuthSecretRef.Name) == "" {
+		return s.invalid(build.SpecSourceHTTPOptionsInvalid, "source %q references an authentication secret without a name", source.Name)
+	}
+
+	if source.StripComponents != nil {
+		if *source.StripComponents < 0 {
+			return s.invalid(build.SpecSourceHTTPOptionsInvalid, "source %q must not define a negative number of path elements to strip", source.Name)
+		}
+
+		if *source.StripComponents > 0 && (source.Extract == nil || !*source.Extract) {
+			return s.invalid(build.SpecSourceHTTPOptionsInvalid, "source %q requires extract to strip path elements", source.Name)
+		}
+	}
+
+	if source.Retries != nil && *source.Retries < 0 {
+		return s.invalid(build.SpecSourceHTTPOptionsInvalid, "source %q must not define a negative number of retries", source.Name)
+	}
+
 	return nil
 }
 
+func (s *SourcesRef) invalid(reason build.BuildReason, format string, args ...interface{}) error {
+	s.Build.Status.Reason = build.BuildReasonPtr(reason)
+	s.Build.Status.Message = pointer.String(fmt.Sprintf(format, args...))
+	return fmt.Errorf("%s", *s.Build.Status.Message)
+}
+
 // NewSourcesRef instantiate a new SourcesRef passing the build object pointer along.
 func NewSourcesRef(b *build.Build) *SourcesRef {
 	return &SourcesRef{Build: b}
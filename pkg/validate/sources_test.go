@@ -12,6 +12,9 @@ import (
 
 	build "github.com/shipwright-io/build/pkg/apis/build/v1alpha1"
 	"github.com/shipwright-io/build/pkg/validate"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/utils/pointer"
 )
 
 var _ = Describe("SourcesRef", func() {
@@ -69,5 +72,206 @@ var _ = Describe("SourcesRef", func() {
 
 			Expect(srcRef.ValidatePath(context.TODO())).To(HaveOccurred())
 		})
+
+		Context("HTTP options", func() {
+			var validateSource = func(source build.BuildSource) (*build.Build, error) {
+				b := &build.Build{
+					Spec: build.BuildSpec{
+						Sources: []build.BuildSource{source},
+					},
+				}
+
+				return b, validate.NewSourcesRef(b).ValidatePath(context.TODO())
+			}
+
+			It("should successfully validate a HTTP source with all options", func() {
+				_, err := validateSource(build.BuildSource{
+					Name:            "archive",
+					Type:            build.HTTP,
+					URL:             "https://example.com/archive.tar.gz",
+					SHA256:          pointer.String("sha256:8d3c0d1f4a2b4a4be0a51dc2c2e7ff0d86b6c9d2a7c19f0c7c4d7e3f8b9a6c1d"),
+					AuthSecretRef:   &corev1.LocalObjectReference{Name: "download-token"},
+					Extract:         pointer.Bool(true),
+					StripComponents: pointer.Int32(1),
+					Retries:         pointer.Int32(0),
+				})
+				Expect(err).ToNot(HaveOccurred())
+			})
+
+			It("should fail to validate an invalid SHA-256 digest", func() {
+				b, err := validateSource(build.BuildSource{
+					Name:   "archive",
+					Type:   build.HTTP,
+					URL:    "https://example.com/archive.tar.gz",
+					SHA256: pointer.String("md5:d41d8cd98f00b204e9800998ecf8427e"),
+				})
+				Expect(err).To(HaveOccurred())
+				Expect(*b.Status.Reason).To(Equal(build.SpecSourceHTTPOptionsInvalid))
+			})
+
+			It("should fail to validate stripping path elements without extraction", func() {
+				b, err := validateSource(build.BuildSource{
+					Name:            "archive",
+					Type:            build.HTTP,
+					URL:             "https://example.com/archive.tar.gz",
+					StripComponents: pointer.Int32(1),
+				})
+				Expect(err).To(HaveOccurred())
+				Expect(*b.Status.Reason).To(Equal(build.SpecSourceHTTPOptionsInvalid))
+			})
+
+			It("should fail to validate a negative number of retries", func() {
+				b, err := validateSource(build.BuildSource{
+					Name:    "archive",
+					Type:    build.HTTP,
+					URL:     "https://example.com/archive.tar.gz",
+					Retries: pointer.Int32(-1),
+				})
+				Expect(err).To(HaveOccurred())
+				Expect(*b.Status.Reason).To(Equal(build.SpecSourceHTTPOptionsInvalid))
+			})
+
+			It("should fail to validate HTTP options on a source of another type", func() {
+				b, err := validateSource(build.BuildSource{
+					Name:    "local",
+					Type:    build.LocalCopy,
+					URL:     "https://example.com/archive.tar.gz",
+					Extract: pointer.Bool(true),
+				})
+				Expect(err).To(HaveOccurred())
+				Expect(*b.Status.Reason).To(Equal(build.SpecSourceHTTPOptionsInvalid))
+			})
+		})
+
+		Context("named sources", func() {
+			var validateSources = func(sources ...build.BuildSource) (*build.Build, error) {
+				b := &build.Build{
+					Spec: build.BuildSpec{
+						Sources: sources,
+					},
+				}
+
+				return b, validate.NewSourcesRef(b).ValidatePath(context.TODO())
+			}
+
+			It("should successfully validate named Git and Bundle sources", func() {
+				_, err := validateSources(
+					build.BuildSource{
+						Name: "config",
+						Type: build.Git,
+						Source: &build.Source{
+							URL: pointer.String("https://github.com/shipwright-io/sample-go"),
+						},
+					},
+					build.BuildSource{
+						Name: "vendor",
+						Type: build.Bundle,
+						Source: &build.Source{
+							BundleContainer: &build.BundleContainer{Image: "ghcr.io/shipwright-io/sample-go/vendor:latest"},
+						},
+					},
+				)
+				Expect(err).ToNot(HaveOccurred())
+			})
+
+			It("should fail to validate a Git source without a URL", func() {
+				b, err := validateSources(build.BuildSource{
+					Name:   "config",
+					Type:   build.Git,
+					Source: &build.Source{},
+				})
+				Expect(err).To(HaveOccurred())
+				Expect(*b.Status.Reason).To(Equal(build.SpecSourcesInvalid))
+			})
+
+			It("should fail to validate a Bundle source without an image", func() {
+				b, err := validateSources(build.BuildSource{
+					Name: "vendor",
+					Type: build.Bundle,
+				})
+				Expect(err).To(HaveOccurred())
+				Expect(*b.Status.Reason).To(Equal(build.SpecSourcesInvalid))
+			})
+
+			It("should fail to validate a source name that is not a DNS label", func() {
+				b, err := validateSources(build.BuildSource{
+					Name: "My_Config",
+					Type: build.Git,
+					Source: &build.Source{
+						URL: pointer.String("https://github.com/shipwright-io/sample-go"),
+					},
+				})
+				Expect(err).To(HaveOccurred())
+				Expect(*b.Status.Reason).To(Equal(build.SpecSourcesInvalid))
+			})
+
+			It("should fail to validate a HTTP source name that is not a DNS label", func() {
+				b, err := validateSources(build.BuildSource{
+					Name: "My_Logo",
+					Type: build.HTTP,
+					URL:  "https://shipwright.io/icons/logo.svg",
+				})
+				Expect(err).To(HaveOccurred())
+				Expect(*b.Status.Reason).To(Equal(build.SpecSourcesInvalid))
+			})
+
+			It("should fail to validate a Git source with a merge target that is an option", func() {
+				b, err := validateSources(build.BuildSource{
+					Name: "config",
+					Type: build.Git,
+					Source: &build.Source{
+						URL:         pointer.String("https://github.com/shipwright-io/sample-go"),
+						Revision:    pointer.String("feature"),
+						MergeTarget: pointer.String("--upload-pack=touch /tmp/pwned"),
+					},
+				})
+				Expect(err).To(HaveOccurred())
+				Expect(*b.Status.Reason).To(Equal(build.SpecSourceGitOptionsInvalid))
+			})
+
+			It("should fail to validate a Git source with a negative depth", func() {
+				b, err := validateSources(build.BuildSource{
+					Name: "config",
+					Type: build.Git,
+					Source: &build.Source{
+						URL:   pointer.String("https://github.com/shipwright-io/sample-go"),
+						Depth: pointer.Int32(-1),
+					},
+				})
+				Expect(err).To(HaveOccurred())
+				Expect(*b.Status.Reason).To(Equal(build.SpecSourceGitOptionsInvalid))
+			})
+
+			It("should fail to validate the reserved default name", func() {
+				b, err := validateSources(build.BuildSource{
+					Name: "default",
+					Type: build.Git,
+					Source: &build.Source{
+						URL: pointer.String("https://github.com/shipwright-io/sample-go"),
+					},
+				})
+				Expect(err).To(HaveOccurred())
+				Expect(*b.Status.Reason).To(Equal(build.SpecSourcesInvalid))
+			})
+
+			It("should fail to validate duplicate source names", func() {
+				b, err := validateSources(
+					build.BuildSource{
+						Name: "config",
+						Type: build.Git,
+						Source: &build.Source{
+							URL: pointer.String("https://github.com/shipwright-io/sample-go"),
+						},
+					},
+					build.BuildSource{
+						Name: "config",
+						Type: build.HTTP,
+						URL:  "https://example.com/config.tar.gz",
+					},
+				)
+				Expect(err).To(HaveOccurred())
+				Expect(*b.Status.Reason).To(Equal(build.SpecSourcesInvalid))
+			})
+		})
 	})
 })
@@ -7,7 +7,11 @@ package validate
 import (
 	"context"
 	"fmt"
+	"net/url"
 
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/utils/pointer"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
@@ -29,8 +33,17 @@ func NewSourceURL(client client.Client, build *build.Build) *SourceURLRef {
 
 // ValidatePath implements BuildPath interface and validates
 // that the spec.source.url exists. This validation only applies
-// to endpoints that do not require authentication.
+// to endpoints that do not require authentication, or that are
+// accessed using the credentials of a GitHub App.
 func (s SourceURLRef) ValidatePath(ctx context.Context) error {
+	if s.Build.Spec.Source.Credentials != nil && s.Build.Spec.Source.URL != nil &&
+		s.Build.GetAnnotations()[build.AnnotationBuildVerifyRepository] == "true" {
+		if err := s.validateWithGitHubApp(ctx); err != nil {
+			s.MarkBuildStatus(s.Build, build.RemoteRepositoryUnreachable, err.Error())
+			return err
+		}
+	}
+
 	if s.Build.Spec.Source.Credentials == nil && s.Build.Spec.Source.URL != nil {
 		switch s.Build.GetAnnotations()[build.AnnotationBuildVerifyRepository] {
 		case "true":
@@ -53,6 +66,40 @@ func (s SourceURLRef) ValidatePath(ctx context.Context) error {
 	return nil
 }
 
+// validateWithGitHubApp validates that the spec.source.url exists using an
+// installation token, if the source secret contains GitHub App credentials
+func (s SourceURLRef) validateWithGitHubApp(ctx context.Context) error {
+	secret := &corev1.Secret{}
+	if err := s.Client.Get(ctx, types.NamespacedName{Namespace: s.Build.Namespace, Name: s.Build.Spec.Source.Credentials.Name}, secret); err != nil {
+		if apierrors.IsNotFound(err) {
+			// the secrets validation reports missing secrets
+			return nil
+		}
+		return err
+	}
+
+	if !git.HasGitHubAppCredentials(secret.Data) {
+		return nil
+	}
+
+	repoURL, err := url.Parse(*s.Build.Spec.Source.URL)
+	if err != nil {
+		return err
+	}
+
+	app, err := git.NewGitHubApp(secret.Data, git.GitHubAPIURL(repoURL.Host))
+	if err != nil {
+		return err
+	}
+
+	token, err := app.InstallationToken(ctx)
+	if err != nil {
+		return err
+	}
+
+	return git.ValidateGitURLExistsWithBasicAuth(ctx, *s.Build.Spec.Source.URL, git.GitHubAppTokenUsername, token)
+}
+
 // MarkBuildStatus updates a Build Status fields
 func (s SourceURLRef) MarkBuildStatus(b *build.Build, reason build.BuildReason, msg string) {
 	b.Status.Reason = build.BuildReasonPtr(reason)
@@ -8,6 +8,7 @@ import (
 	"fmt"
 
 	build "github.com/shipwright-io/build/pkg/apis/build/v1alpha1"
+	"github.com/shipwright-io/build/pkg/trigger"
 	kerrors "k8s.io/apimachinery/pkg/util/errors"
 	"k8s.io/utils/pointer"
 )
@@ -21,6 +22,7 @@ type Trigger struct {
 // validate goes through the trigger "when" conditions to validate each entry.
 func (t *Trigger) validate(triggerWhen []build.TriggerWhen) []error {
 	var allErrs []error
+	schedules := map[string]bool{}
 	for _, when := range triggerWhen {
 		if when.Name == "" {
 			t.build.Status.Reason = build.BuildReasonPtr(build.TriggerNameCanNotBeBlank)
@@ -44,6 +46,69 @@ func (t *Trigger) validate(triggerWhen []build.TriggerWhen) []error {
 					))
 					allErrs = append(allErrs, fmt.Errorf("%s", *t.build.Status.Message))
 				}
+				allErrs = append(allErrs, t.validatePaths(build.TriggerInvalidGitHubWebHook, when.Name, ".github.paths", when.GitHub.Paths)...)
+			}
+		case build.GitLabWebHookTrigger:
+			if when.GitLab == nil {
+				allErrs = append(allErrs, t.invalid(build.TriggerInvalidGitLabWebHook,
+					"%q is missing required attribute `.gitlab`", when.Name))
+			} else {
+				events := make([]string, 0, len(when.GitLab.Events))
+				for _, e := range when.GitLab.Events {
+					events = append(events, string(e))
+				}
+				allErrs = append(allErrs, t.validateEvents(build.TriggerInvalidGitLabWebHook, when.Name, ".gitlab.events", events,
+					string(build.GitLabPushEvent), string(build.GitLabMergeRequestEvent), string(build.GitLabTagEvent))...)
+				allErrs = append(allErrs, t.validatePaths(build.TriggerInvalidGitLabWebHook, when.Name, ".gitlab.paths", when.GitLab.Paths)...)
+			}
+		case build.GiteaWebHookTrigger:
+			if when.Gitea == nil {
+				allErrs = append(allErrs, t.invalid(build.TriggerInvalidGiteaWebHook,
+					"%q is missing required attribute `.gitea`", when.Name))
+			} else {
+				events := make([]string, 0, len(when.Gitea.Events))
+				for _, e := range when.Gitea.Events {
+					events = append(events, string(e))
+				}
+				allErrs = append(allErrs, t.validateEvents(build.TriggerInvalidGiteaWebHook, when.Name, ".gitea.events", events,
+					string(build.GiteaPushEvent), string(build.GiteaPullRequestEvent), string(build.GiteaTagEvent))...)
+				allErrs = append(allErrs, t.validatePaths(build.TriggerInvalidGiteaWebHook, when.Name, ".gitea.paths", when.Gitea.Paths)...)
+			}
+		case build.BitbucketWebHookTrigger:
+			if when.Bitbucket == nil {
+				allErrs = append(allErrs, t.invalid(build.TriggerInvalidBitbucketWebHook,
+					"%q is missing required attribute `.bitbucket`", when.Name))
+			} else {
+				events := make([]string, 0, len(when.Bitbucket.Events))
+				for _, e := range when.Bitbucket.Events {
+					events = append(events, string(e))
+				}
+				allErrs = append(allErrs, t.validateEvents(build.TriggerInvalidBitbucketWebHook, when.Name, ".bitbucket.events", events,
+					string(build.BitbucketPushEvent), string(build.BitbucketPullRequestEvent), string(build.BitbucketTagEvent))...)
+				allErrs = append(allErrs, t.validatePaths(build.TriggerInvalidBitbucketWebHook, when.Name, ".bitbucket.paths", when.Bitbucket.Paths)...)
+			}
+		case build.ScheduleTrigger:
+			switch {
+			case when.Schedule == nil:
+				allErrs = append(allErrs, t.invalid(build.TriggerInvalidSchedule,
+					"%q is missing required attribute `.schedule`", when.Name))
+			case schedules[when.Name]:
+				// the status of Schedule triggers is tracked by name
+				allErrs = append(allErrs, t.invalid(build.TriggerInvalidSchedule,
+					"%q is used by multiple Schedule triggers, the names must be unique", when.Name))
+			default:
+				schedules[when.Name] = true
+				if _, err := trigger.ParseSchedule(when.Schedule); err != nil {
+					allErrs = append(allErrs, t.invalid(build.TriggerInvalidSchedule,
+						"%q contains an invalid schedule: %v", when.Name, err))
+				}
+				switch when.Schedule.ConcurrencyPolicy {
+				case "", build.SkipConcurrencyPolicy, build.ReplaceConcurrencyPolicy:
+				default:
+					allErrs = append(allErrs, t.invalid(build.TriggerInvalidSchedule,
+						"%q contains an invalid concurrency policy %q, must be one of %v", when.Name, when.Schedule.ConcurrencyPolicy,
+						[]build.ScheduleConcurrencyPolicy{build.SkipConcurrencyPolicy, build.ReplaceConcurrencyPolicy}))
+				}
 			}
 		case build.ImageTrigger:
 			if when.Image == nil {
@@ -103,6 +168,53 @@ func (t *Trigger) validate(triggerWhen []build.TriggerWhen) []error {
 	return allErrs
 }
 
+// validateEvents checks the event names of a webhook trigger condition are set and known.
+func (t *Trigger) validateEvents(reason build.BuildReason, whenName string, attribute string, events []string, valid ...string) []error {
+	if len(events) == 0 {
+		return []error{t.invalid(reason, "%q is missing required attribute `%s`", whenName, attribute)}
+	}
+
+	var allErrs []error
+	for _, event := range events {
+		known := false
+		for _, v := range valid {
+			if event == v {
+				known = true
+				break
+			}
+		}
+
+		if !known {
+			allErrs = append(allErrs, t.invalid(reason, "%q contains an invalid event %q in `%s`, must be one of %v",
+				whenName, event, attribute, valid))
+		}
+	}
+	return allErrs
+}
+
+// validatePaths checks the syntax of the changed-path globs of a webhook trigger condition.
+func (t *Trigger) validatePaths(reason build.BuildReason, whenName string, attribute string, paths *build.WhenPaths) []error {
+	if paths == nil {
+		return nil
+	}
+
+	var allErrs []error
+	for _, glob := range append(append([]string{}, paths.Include...), paths.Exclude...) {
+		if err := trigger.ValidateGlob(glob); err != nil {
+			allErrs = append(allErrs, t.invalid(reason, "%q contains an invalid glob %q in `%s`: %v",
+				whenName, glob, attribute, err))
+		}
+	}
+	return allErrs
+}
+
+// invalid records the reason and message in the build status and returns the message as error.
+func (t *Trigger) invalid(reason build.BuildReason, format string, args ...interface{}) error {
+	t.build.Status.Reason = build.BuildReasonPtr(reason)
+	t.build.Status.Message = pointer.String(fmt.Sprintf(format, args...))
+	return fmt.Errorf("%s", *t.build.Status.Message)
+}
+
 // ValidatePath validates the `.spec.trigger` path.
 func (t *Trigger) ValidatePath(_ context.Context) error {
 	if t.build.Spec.Trigger == nil || len(t.build.Spec.Trigger.When) == 0 {
@@ -10,6 +10,7 @@ import (
 	. "github.com/onsi/gomega"
 	build "github.com/shipwright-io/build/pkg/apis/build/v1alpha1"
 	"github.com/shipwright-io/build/pkg/validate"
+	"k8s.io/utils/pointer"
 )
 
 var _ = Describe("ValidateBuildTriggers", func() {
@@ -88,6 +89,221 @@ var _ = Describe("ValidateBuildTriggers", func() {
 		})
 	})
 
+	Context("trigger type gitlab", func() {
+		It("should error when gitlab attribute is not set", func() {
+			b := &build.Build{
+				Spec: build.BuildSpec{
+					Trigger: &build.Trigger{
+						When: []build.TriggerWhen{{
+							Name: "gitlab",
+							Type: build.GitLabWebHookTrigger,
+						}},
+					},
+				},
+			}
+
+			err := validate.NewTrigger(b).ValidatePath(context.TODO())
+			Expect(err.Error()).To(ContainSubstring("missing required attribute `.gitlab`"))
+			Expect(*b.Status.Reason).To(Equal(build.TriggerInvalidGitLabWebHook))
+		})
+
+		It("should error when gitlab events attribute is empty", func() {
+			b := &build.Build{
+				Spec: build.BuildSpec{
+					Trigger: &build.Trigger{
+						When: []build.TriggerWhen{{
+							Name:   "gitlab",
+							Type:   build.GitLabWebHookTrigger,
+							GitLab: &build.WhenGitLab{},
+						}},
+					},
+				},
+			}
+
+			err := validate.NewTrigger(b).ValidatePath(context.TODO())
+			Expect(err.Error()).To(ContainSubstring("missing required attribute `.gitlab.events`"))
+		})
+
+		It("should error when gitlab events contain an unknown event", func() {
+			b := &build.Build{
+				Spec: build.BuildSpec{
+					Trigger: &build.Trigger{
+						When: []build.TriggerWhen{{
+							Name: "gitlab",
+							Type: build.GitLabWebHookTrigger,
+							GitLab: &build.WhenGitLab{
+								Events: []build.GitLabEventName{"PullRequest"},
+							},
+						}},
+					},
+				},
+			}
+
+			err := validate.NewTrigger(b).ValidatePath(context.TODO())
+			Expect(err.Error()).To(ContainSubstring("invalid event \"PullRequest\""))
+		})
+
+		It("should pass when gitlab type is complete", func() {
+			b := &build.Build{
+				Spec: build.BuildSpec{
+					Trigger: &build.Trigger{
+						When: []build.TriggerWhen{{
+							Name: "gitlab",
+							Type: build.GitLabWebHookTrigger,
+							GitLab: &build.WhenGitLab{
+								Events: []build.GitLabEventName{
+									build.GitLabPushEvent,
+									build.GitLabMergeRequestEvent,
+									build.GitLabTagEvent,
+								},
+							},
+						}},
+					},
+				},
+			}
+
+			err := validate.NewTrigger(b).ValidatePath(context.TODO())
+			Expect(err).ToNot(HaveOccurred())
+		})
+	})
+
+	Context("trigger type gitea", func() {
+		It("should error when gitea attribute is not set", func() {
+			b := &build.Build{
+				Spec: build.BuildSpec{
+					Trigger: &build.Trigger{
+						When: []build.TriggerWhen{{
+							Name: "gitea",
+							Type: build.GiteaWebHookTrigger,
+						}},
+					},
+				},
+			}
+
+			err := validate.NewTrigger(b).ValidatePath(context.TODO())
+			Expect(err.Error()).To(ContainSubstring("missing required attribute `.gitea`"))
+		})
+
+		It("should pass when gitea type is complete", func() {
+			b := &build.Build{
+				Spec: build.BuildSpec{
+					Trigger: &build.Trigger{
+						When: []build.TriggerWhen{{
+							Name: "gitea",
+							Type: build.GiteaWebHookTrigger,
+							Gitea: &build.WhenGitea{
+								Events: []build.GiteaEventName{build.GiteaPullRequestEvent},
+							},
+						}},
+					},
+				},
+			}
+
+			err := validate.NewTrigger(b).ValidatePath(context.TODO())
+			Expect(err).ToNot(HaveOccurred())
+		})
+	})
+
+	Context("trigger type bitbucket", func() {
+		It("should error when bitbucket attribute is not set", func() {
+			b := &build.Build{
+				Spec: build.BuildSpec{
+					Trigger: &build.Trigger{
+						When: []build.TriggerWhen{{
+							Name: "bitbucket",
+							Type: build.BitbucketWebHookTrigger,
+						}},
+					},
+				},
+			}
+
+			err := validate.NewTrigger(b).ValidatePath(context.TODO())
+			Expect(err.Error()).To(ContainSubstring("missing required attribute `.bitbucket`"))
+		})
+
+		It("should pass when bitbucket type is complete", func() {
+			b := &build.Build{
+				Spec: build.BuildSpec{
+					Trigger: &build.Trigger{
+						When: []build.TriggerWhen{{
+							Name: "bitbucket",
+							Type: build.BitbucketWebHookTrigger,
+							Bitbucket: &build.WhenBitbucket{
+								Events: []build.BitbucketEventName{build.BitbucketTagEvent},
+							},
+						}},
+					},
+				},
+			}
+
+			err := validate.NewTrigger(b).ValidatePath(context.TODO())
+			Expect(err).ToNot(HaveOccurred())
+		})
+	})
+
+	Context("changed-path filters", func() {
+		It("should error when a glob is malformed", func() {
+			b := &build.Build{
+				Spec: build.BuildSpec{
+					Trigger: &build.Trigger{
+						When: []build.TriggerWhen{{
+							Name: "monorepo",
+							Type: build.GitLabWebHookTrigger,
+							GitLab: &build.WhenGitLab{
+								Events: []build.GitLabEventName{build.GitLabPushEvent},
+								Paths:  &build.WhenPaths{Include: []string{"services/**"}, Exclude: []string{"docs/[a-"}},
+							},
+						}},
+					},
+				},
+			}
+
+			err := validate.NewTrigger(b).ValidatePath(context.TODO())
+			Expect(err.Error()).To(ContainSubstring("invalid glob \"docs/[a-\" in `.gitlab.paths`"))
+			Expect(*b.Status.Reason).To(Equal(build.TriggerInvalidGitLabWebHook))
+		})
+
+		It("should pass with valid globs", func() {
+			b := &build.Build{
+				Spec: build.BuildSpec{
+					Trigger: &build.Trigger{
+						When: []build.TriggerWhen{{
+							Name: "monorepo",
+							Type: build.GitHubWebHookTrigger,
+							GitHub: &build.WhenGitHub{
+								Events: []build.GitHubEventName{build.GitHubPushEvent},
+								Paths:  &build.WhenPaths{Include: []string{"services/api", "libs/**/*.go"}, Exclude: []string{"**/*.md"}},
+							},
+						}},
+					},
+				},
+			}
+
+			err := validate.NewTrigger(b).ValidatePath(context.TODO())
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("should pass when combined with merge request events", func() {
+			b := &build.Build{
+				Spec: build.BuildSpec{
+					Trigger: &build.Trigger{
+						When: []build.TriggerWhen{{
+							Name: "monorepo",
+							Type: build.GitLabWebHookTrigger,
+							GitLab: &build.WhenGitLab{
+								Events: []build.GitLabEventName{build.GitLabPushEvent, build.GitLabMergeRequestEvent},
+								Paths:  &build.WhenPaths{Include: []string{"services/api"}},
+							},
+						}},
+					},
+				},
+			}
+
+			err := validate.NewTrigger(b).ValidatePath(context.TODO())
+			Expect(err).ToNot(HaveOccurred())
+		})
+	})
+
 	Context("trigger type image", func() {
 		It("should error when image attribute is not set", func() {
 			b := &build.Build{
@@ -249,6 +465,64 @@ var _ = Describe("ValidateBuildTriggers", func() {
 		})
 	})
 
+	Context("trigger type schedule", func() {
+		newBuild := func(whens ...build.TriggerWhen) *build.Build {
+			return &build.Build{Spec: build.BuildSpec{Trigger: &build.Trigger{When: whens}}}
+		}
+
+		It("should error when schedule attribute is not set", func() {
+			b := newBuild(build.TriggerWhen{Name: "nightly", Type: build.ScheduleTrigger})
+
+			err := validate.NewTrigger(b).ValidatePath(context.TODO())
+			Expect(err.Error()).To(ContainSubstring("missing required attribute `.schedule`"))
+			Expect(*b.Status.Reason).To(Equal(build.TriggerInvalidSchedule))
+		})
+
+		It("should error when the cron expression is invalid", func() {
+			b := newBuild(build.TriggerWhen{Name: "nightly", Type: build.ScheduleTrigger, Schedule: &build.WhenSchedule{Cron: "0 25 * * *"}})
+
+			err := validate.NewTrigger(b).ValidatePath(context.TODO())
+			Expect(err.Error()).To(ContainSubstring("invalid value \"25\" in hour field"))
+		})
+
+		It("should error when the time zone is unknown", func() {
+			b := newBuild(build.TriggerWhen{Name: "nightly", Type: build.ScheduleTrigger, Schedule: &build.WhenSchedule{Cron: "0 2 * * *", TimeZone: pointer.String("Mars/Olympus_Mons")}})
+
+			err := validate.NewTrigger(b).ValidatePath(context.TODO())
+			Expect(err.Error()).To(ContainSubstring("unknown time zone"))
+		})
+
+		It("should error when the concurrency policy is unknown", func() {
+			b := newBuild(build.TriggerWhen{Name: "nightly", Type: build.ScheduleTrigger, Schedule: &build.WhenSchedule{Cron: "0 2 * * *", ConcurrencyPolicy: "Allow"}})
+
+			err := validate.NewTrigger(b).ValidatePath(context.TODO())
+			Expect(err.Error()).To(ContainSubstring("invalid concurrency policy"))
+		})
+
+		It("should error when schedule names are not unique", func() {
+			when := build.TriggerWhen{Name: "nightly", Type: build.ScheduleTrigger, Schedule: &build.WhenSchedule{Cron: "@daily"}}
+			b := newBuild(when, when)
+
+			err := validate.NewTrigger(b).ValidatePath(context.TODO())
+			Expect(err.Error()).To(ContainSubstring("names must be unique"))
+		})
+
+		It("should pass when schedule type is complete", func() {
+			b := newBuild(build.TriggerWhen{
+				Name: "nightly",
+				Type: build.ScheduleTrigger,
+				Schedule: &build.WhenSchedule{
+					Cron:              "30 2 * * MON-FRI",
+					TimeZone:          pointer.String("Europe/Berlin"),
+					ConcurrencyPolicy: build.ReplaceConcurrencyPolicy,
+				},
+			})
+
+			err := validate.NewTrigger(b).ValidatePath(context.TODO())
+			Expect(err).ToNot(HaveOccurred())
+		})
+	})
+
 	Context("invalid trigger type", func() {
 		It("should error when declaring a invalid trigger type", func() {
 			b := &build.Build{
@@ -33,6 +33,8 @@ const (
 	OwnerReferences = "ownerreferences"
 	// Triggers for validating the `.spec.triggers` entries
 	Triggers = "triggers"
+	// GitSource for validating the clone options of the Git source
+	GitSource = "gitsource"
 )
 
 const (
@@ -71,6 +73,8 @@ func NewValidation(
 		return &Env{Build: build}, nil
 	case Triggers:
 		return &Trigger{build: build}, nil
+	case GitSource:
+		return &GitSourceRef{Build: build}, nil
 	default:
 		return nil, fmt.Errorf("unknown validation type")
 	}
@@ -121,6 +125,11 @@ func BuildRunFields(buildRun *build.BuildRun) (string, string) {
 				"cannot use 'timeout' override and 'buildSpec' simultaneously"
 		}
 
+		if buildRun.Spec.Revision != nil {
+			return resources.BuildRunBuildFieldOverrideForbidden,
+				"cannot use 'revision' override and 'buildSpec' simultaneously"
+		}
+
 		if buildRun.Spec.BuildSpec.Trigger != nil {
 			return resources.BuildRunBuildFieldOverrideForbidden,
 				"cannot use 'triggers' override in the 'BuildRun', only allowed in the 'Build'"
@@ -79,6 +79,7 @@ request:
           local:
             timeout: 1m
             name: foobar_local
+            uploadSecret: upload-token
         strategy:
           name: %s
           kind: %s
@@ -115,6 +116,172 @@ request:
 							Timeout: &v1.Duration{
 								Duration: 1 * time.Minute,
 							},
+							UploadSecretRef: &corev1.LocalObjectReference{
+								Name: "upload-token",
+							},
+						},
+					},
+					Strategy: v1alpha1.Strategy{
+						Name: strategyName,
+						Kind: (*v1alpha1.BuildStrategyKind)(&strategyKind),
+					},
+				},
+			}
+
+			// Use ComparableTo and assert the whole object
+			Expect(build).To(BeComparableTo(desiredBuild))
+		})
+		It("converts for spec source HTTP type", func() {
+			// Create the yaml in v1beta1
+			buildTemplate := `kind: ConversionReview
+apiVersion: %s
+request:
+  uid: 0000-0000-0000-0000
+  desiredAPIVersion: %s
+  objects:
+    - apiVersion: shipwright.io/v1beta1
+      kind: Build
+      metadata:
+        name: buildkit-build
+      spec:
+        source:
+          type: HTTP
+          http:
+            url: https://example.com/archive.tar.gz
+            sha256: 8d3c0d1f4a2b4a4be0a51dc2c2e7ff0d86b6c9d2a7c19f0c7c4d7e3f8b9a6c1d
+            authSecret: %s
+            extract: true
+            stripComponents: 1
+          contextDir: %s
+        strategy:
+          name: %s
+          kind: %s
+`
+			o := fmt.Sprintf(buildTemplate, apiVersion,
+				desiredAPIVersion, secretName, ctxDir, strategyName, strategyKind)
+
+			// Invoke the /convert webhook endpoint
+			conversionReview, err := getConversionReview(o)
+			Expect(err).To(BeNil())
+			Expect(conversionReview.Response.Result.Status).To(Equal(v1.StatusSuccess))
+
+			convertedObj, err := ToUnstructured(conversionReview)
+			Expect(err).To(BeNil())
+
+			build, err := toV1Alpha1BuildObject(convertedObj)
+			Expect(err).To(BeNil())
+
+			// Prepare our desired v1alpha1 Build
+			desiredBuild := v1alpha1.Build{
+				TypeMeta: v1.TypeMeta{
+					APIVersion: "shipwright.io/v1alpha1",
+					Kind:       "Build",
+				},
+				ObjectMeta: v1.ObjectMeta{
+					Name: "buildkit-build",
+				},
+				Spec: v1alpha1.BuildSpec{
+					Source: v1alpha1.Source{
+						ContextDir: &ctxDir,
+					},
+					Sources: []v1alpha1.BuildSource{
+						{
+							Name:   "default",
+							Type:   v1alpha1.HTTP,
+							URL:    "https://example.com/archive.tar.gz",
+							SHA256: pointer.String("8d3c0d1f4a2b4a4be0a51dc2c2e7ff0d86b6c9d2a7c19f0c7c4d7e3f8b9a6c1d"),
+							AuthSecretRef: &corev1.LocalObjectReference{
+								Name: secretName,
+							},
+							Extract:         pointer.Bool(true),
+							StripComponents: pointer.Int32(1),
+						},
+					},
+					Strategy: v1alpha1.Strategy{
+						Name: strategyName,
+						Kind: (*v1alpha1.BuildStrategyKind)(&strategyKind),
+					},
+				},
+			}
+
+			// Use ComparableTo and assert the whole object
+			Expect(build).To(BeComparableTo(desiredBuild))
+		})
+		It("converts for spec named sources", func() {
+			// Create the yaml in v1beta1
+			buildTemplate := `kind: ConversionReview
+apiVersion: %s
+request:
+  uid: 0000-0000-0000-0000
+  desiredAPIVersion: %s
+  objects:
+    - apiVersion: shipwright.io/v1beta1
+      kind: Build
+      metadata:
+        name: buildkit-build
+      spec:
+        source:
+          type: Git
+          git:
+            url: https://github.com/shipwright-io/sample-go
+        sources:
+          - name: config
+            type: Git
+            git:
+              url: https://github.com/shipwright-io/sample-config
+              revision: main
+          - name: vendor
+            type: OCI
+            ociArtifact:
+              image: ghcr.io/shipwright-io/sample-go/vendor:latest
+        strategy:
+          name: %s
+          kind: %s
+`
+			o := fmt.Sprintf(buildTemplate, apiVersion,
+				desiredAPIVersion, strategyName, strategyKind)
+
+			// Invoke the /convert webhook endpoint
+			conversionReview, err := getConversionReview(o)
+			Expect(err).To(BeNil())
+			Expect(conversionReview.Response.Result.Status).To(Equal(v1.StatusSuccess))
+
+			convertedObj, err := ToUnstructured(conversionReview)
+			Expect(err).To(BeNil())
+
+			build, err := toV1Alpha1BuildObject(convertedObj)
+			Expect(err).To(BeNil())
+
+			// Prepare our desired v1alpha1 Build
+			desiredBuild := v1alpha1.Build{
+				TypeMeta: v1.TypeMeta{
+					APIVersion: "shipwright.io/v1alpha1",
+					Kind:       "Build",
+				},
+				ObjectMeta: v1.ObjectMeta{
+					Name: "buildkit-build",
+				},
+				Spec: v1alpha1.BuildSpec{
+					Source: v1alpha1.Source{
+						URL: pointer.String("https://github.com/shipwright-io/sample-go"),
+					},
+					Sources: []v1alpha1.BuildSource{
+						{
+							Name: "config",
+							Type: v1alpha1.Git,
+							Source: &v1alpha1.Source{
+								URL:      pointer.String("https://github.com/shipwright-io/sample-config"),
+								Revision: pointer.String("main"),
+							},
+						},
+						{
+							Name: "vendor",
+							Type: v1alpha1.Bundle,
+							Source: &v1alpha1.Source{
+								BundleContainer: &v1alpha1.BundleContainer{
+									Image: "ghcr.io/shipwright-io/sample-go/vendor:latest",
+								},
+							},
 						},
 					},
 					Strategy: v1alpha1.Strategy{
@@ -150,6 +317,8 @@ request:
             image: %s
             prune: AfterPull
             pullSecret: %s
+            verifySignature:
+              secret: %s
         strategy:
           name: %s
           kind: %s
@@ -167,7 +336,7 @@ request:
 `
 			o := fmt.Sprintf(buildTemplate, apiVersion,
 				desiredAPIVersion, ctxDir,
-				image, secretName,
+				image, secretName, secretName,
 				strategyName, strategyKind,
 				branchMain, branchDev, secretName)
 
@@ -197,6 +366,9 @@ request:
 						BundleContainer: &v1alpha1.BundleContainer{
 							Image: image,
 							Prune: &s,
+							VerifySignature: &v1alpha1.BundleSignatureVerification{
+								SecretRef: corev1.LocalObjectReference{Name: secretName},
+							},
 						},
 						Credentials: &corev1.LocalObjectReference{
 							Name: secretName,
@@ -271,6 +443,18 @@ request:
         output:
           image: %s
           pushSecret: %s
+          signing:
+            secret: signing-key
+            provenance: true
+          sbom:
+            format: spdx
+          additionalTags:
+          - latest
+          - $(commit-sha-short)
+          mirrors:
+          - image: dr-registry.example.com/sample-go
+            insecure: true
+            pushSecret: dr-registry-secret
         retention:
           atBuildDeletion: true
 `
@@ -351,6 +535,19 @@ request:
 						Credentials: &corev1.LocalObjectReference{
 							Name: secretName,
 						},
+						Signing: &v1alpha1.ImageSigning{
+							SecretRef:  corev1.LocalObjectReference{Name: "signing-key"},
+							Provenance: true,
+						},
+						SBOM: &v1alpha1.ImageSBOM{
+							Format: v1alpha1.SBOMFormatSPDX,
+						},
+						AdditionalTags: []string{"latest", "$(commit-sha-short)"},
+						Mirrors: []v1alpha1.ImageMirror{{
+							Image:       "dr-registry.example.com/sample-go",
+							Insecure:    pointer.Bool(true),
+							Credentials: &corev1.LocalObjectReference{Name: "dr-registry-secret"},
+						}},
 					},
 				},
 			}
@@ -580,6 +777,17 @@ request:
             foo: bar
           annotations:
             foo: bar
+          signing:
+            secretRef:
+              name: signing-key
+          sbom:
+            format: cyclonedx
+          additionalTags:
+          - $(buildrun-name)
+          mirrors:
+          - image: dr-registry.example.com/sample-go
+            credentials:
+              name: dr-registry-secret
 `
 			o := fmt.Sprintf(buildTemplate, apiVersion,
 				desiredAPIVersion, ctxDir,
@@ -661,6 +869,17 @@ request:
 						Annotations: map[string]string{
 							"foo": "bar",
 						},
+						Signing: &v1beta1.ImageSigning{
+							Secret: "signing-key",
+						},
+						SBOM: &v1beta1.ImageSBOM{
+							Format: v1beta1.SBOMFormatCycloneDX,
+						},
+						AdditionalTags: []string{"$(buildrun-name)"},
+						Mirrors: []v1beta1.ImageMirror{{
+							Image:      "dr-registry.example.com/sample-go",
+							PushSecret: pointer.String("dr-registry-secret"),
+						}},
 					},
 				},
 			}
@@ -1052,6 +1271,8 @@ request:
         - name: foobar_local
           type: LocalCopy
           timeout: 1m
+          uploadSecretRef:
+            name: upload-token
 `
 			o := fmt.Sprintf(buildTemplate, apiVersion,
 				desiredAPIVersion)
@@ -1087,6 +1308,7 @@ request:
 							Timeout: &v1.Duration{
 								Duration: 1 * time.Minute,
 							},
+							UploadSecret: pointer.String("upload-token"),
 						},
 					},
 				},
@@ -1214,6 +1436,58 @@ request:
 			// Use ComparableTo and assert the whole object
 			Expect(buildRun).To(BeComparableTo(desiredBuildRun))
 		})
+
+		It("converts for status output", func() {
+			// Create the yaml in v1alpha1
+			buildTemplate := `kind: ConversionReview
+apiVersion: %s
+request:
+  uid: 0000-0000-0000-0000
+  desiredAPIVersion: %s
+  objects:
+    - apiVersion: shipwright.io/v1alpha1
+      kind: BuildRun
+      metadata:
+        name: buildkit-run
+      spec:
+        buildRef:
+          name: a_build
+      status:
+        output:
+          digest: sha256:fe1b73cd25ac3f11dec752755e2
+          size: 12345
+          tags:
+          - latest
+          mirrors:
+          - image: dr-registry.example.com/sample-go
+            digest: sha256:fe1b73cd25ac3f11dec752755e2
+          - image: other-registry.example.com/sample-go
+            error: unauthorized
+`
+			o := fmt.Sprintf(buildTemplate, apiVersion,
+				desiredAPIVersion)
+
+			// Invoke the /convert webhook endpoint
+			conversionReview, err := getConversionReview(o)
+			Expect(err).To(BeNil())
+			Expect(conversionReview.Response.Result.Status).To(Equal(v1.StatusSuccess))
+
+			convertedObj, err := ToUnstructured(conversionReview)
+			Expect(err).To(BeNil())
+
+			buildRun, err := toV1Beta1BuildRunObject(convertedObj)
+			Expect(err).To(BeNil())
+
+			Expect(buildRun.Status.Output).To(BeComparableTo(&v1beta1.Output{
+				Digest: "sha256:fe1b73cd25ac3f11dec752755e2",
+				Size:   12345,
+				Tags:   []string{"latest"},
+				Mirrors: []v1beta1.MirrorResult{
+					{Image: "dr-registry.example.com/sample-go", Digest: "sha256:fe1b73cd25ac3f11dec752755e2"},
+					{Image: "other-registry.example.com/sample-go", Error: "unauthorized"},
+				},
+			}))
+		})
 	})
 	Context("for a BuildStrategy spec from v1beta1 to v1alpha1", func() {
 		var desiredAPIVersion = "shipwright.io/v1alpha1"